@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/setup/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// moneyFields são os campos monetários gravados em cada coleção
+var moneyFields = []struct {
+	collection string
+	fields     []string
+}{
+	{"transaction", []string{"balance.value", "balance.discount", "balance.interest", "transfer.source_amount", "transfer.destination_amount"}},
+	{"edit_transaction", []string{"balance.value", "balance.discount", "balance.interest"}},
+	{"account", []string{"balance"}},
+	{"credit_card", []string{"limit", "balance"}},
+	{"credit_card_invoice_payment", []string{"amount", "invoice_total", "remainder_amount"}},
+	{"budget", []string{"amount"}},
+	{"approval_settings", []string{"threshold"}},
+	{"transaction_rule", []string{"conditions.min_amount", "conditions.max_amount"}},
+}
+
+const batchSize = 1000
+
+// migrate-money converte os valores monetários gravados como double para Decimal128, arredondados ao centavo.
+// Os snapshots de saldo, calculados com float, são descartados e refeitos na próxima listagem de contas ou pelo
+// rebuild-ledger. Pode ser executado mais de uma vez: só os campos que ainda não são Decimal128 são convertidos.
+func main() {
+	config.LoadEnvFile(".env")
+	db := helpers.MongoHelper(os.Getenv("MONGO_URL"), "finance")
+	defer helpers.DisconnectMongo()
+
+	for _, money := range moneyFields {
+		converted, err := migrateCollection(db.Collection(money.collection), money.fields)
+		if err != nil {
+			log.Fatalf("%s: %v", money.collection, err)
+		}
+		log.Printf("%s: converted %d documents", money.collection, converted)
+	}
+
+	for _, collection := range []string{"account_balance_snapshot", "account_balance_version"} {
+		if _, err := db.Collection(collection).DeleteMany(context.Background(), bson.M{}); err != nil {
+			log.Fatalf("%s: error clearing snapshots: %v", collection, err)
+		}
+	}
+	log.Print("account balance snapshots cleared")
+}
+
+func migrateCollection(collection *mongo.Collection, fields []string) (int, error) {
+	ctx := context.Background()
+
+	numeric := bson.A{"double", "int", "long"}
+	conditions := make(bson.A, 0, len(fields))
+	for _, field := range fields {
+		conditions = append(conditions, bson.M{field: bson.M{"$type": numeric}})
+	}
+
+	cursor, err := collection.Find(ctx, bson.M{"$or": conditions})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	converted := 0
+	var writes []mongo.WriteModel
+	flush := func() error {
+		if len(writes) == 0 {
+			return nil
+		}
+		result, err := collection.BulkWrite(ctx, writes)
+		if err != nil {
+			return err
+		}
+		converted += int(result.ModifiedCount)
+		writes = nil
+		return nil
+	}
+
+	for cursor.Next(ctx) {
+		set := bson.M{}
+		for _, field := range fields {
+			value, err := cursor.Current.LookupErr(strings.Split(field, ".")...)
+			if err != nil {
+				continue
+			}
+
+			switch value.Type {
+			case bson.TypeDouble, bson.TypeInt32, bson.TypeInt64:
+				var amount models.Money
+				if err := amount.UnmarshalBSONValue(value.Type, value.Value); err != nil {
+					return converted, err
+				}
+				set[field] = amount
+			}
+		}
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": cursor.Current.Lookup("_id")}).
+			SetUpdate(bson.M{"$set": set}))
+		if len(writes) == batchSize {
+			if err := flush(); err != nil {
+				return converted, err
+			}
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return converted, err
+	}
+
+	return converted, flush()
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	presentationHelpers "github.com/anuntech/finance-backend/internal/presentation/helpers"
+	"github.com/anuntech/finance-backend/internal/setup/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// rebuild-ledger descarta e recalcula os snapshots mensais de saldo das contas.
+// Sem -workspace, reconstrói todos os workspaces que possuem contas.
+func main() {
+	workspace := flag.String("workspace", "", "workspace id (default: all workspaces)")
+	from := flag.String("from", "", "first month to rebuild, YYYY-MM (default: month of the oldest transaction)")
+	to := flag.String("to", "", "last month to rebuild, YYYY-MM (default: current month)")
+	flag.Parse()
+
+	config.LoadEnvFile(".env")
+	db := helpers.MongoHelper(os.Getenv("MONGO_URL"), "finance")
+	defer helpers.DisconnectMongo()
+
+	workspaceIds, err := findWorkspaceIds(db, *workspace)
+	if err != nil {
+		log.Fatalf("error finding workspaces: %v", err)
+	}
+
+	findAccounts := account_repository.NewFindAccountsRepository(db)
+
+	for _, workspaceId := range workspaceIds {
+		firstMonth, lastMonth, err := monthRange(db, workspaceId, *from, *to)
+		if err != nil {
+			log.Fatalf("workspace %s: %v", workspaceId.Hex(), err)
+		}
+
+		if err := helpers.DeleteAccountBalanceSnapshots(db, workspaceId, nil); err != nil {
+			log.Fatalf("workspace %s: error clearing snapshots: %v", workspaceId.Hex(), err)
+		}
+
+		months := 0
+		for month := firstMonth; !month.After(lastMonth); month = month.AddDate(0, 1, 0) {
+			// o Find grava os snapshots que estão faltando
+			_, err := findAccounts.Find(&presentationHelpers.GlobalFilterParams{
+				Month:       int(month.Month()),
+				Year:        month.Year(),
+				WorkspaceId: workspaceId,
+			})
+			if err != nil {
+				log.Fatalf("workspace %s: error rebuilding %s: %v", workspaceId.Hex(), month.Format("2006-01"), err)
+			}
+			months++
+		}
+
+		log.Printf("workspace %s: rebuilt %d months (%s to %s)", workspaceId.Hex(), months, firstMonth.Format("2006-01"), lastMonth.Format("2006-01"))
+	}
+}
+
+func findWorkspaceIds(db *mongo.Database, workspace string) ([]primitive.ObjectID, error) {
+	if workspace != "" {
+		workspaceId, err := primitive.ObjectIDFromHex(workspace)
+		if err != nil {
+			return nil, err
+		}
+		return []primitive.ObjectID{workspaceId}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	values, err := db.Collection("account").Distinct(ctx, "workspace_id", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	workspaceIds := make([]primitive.ObjectID, 0, len(values))
+	for _, value := range values {
+		if workspaceId, ok := value.(primitive.ObjectID); ok {
+			workspaceIds = append(workspaceIds, workspaceId)
+		}
+	}
+
+	return workspaceIds, nil
+}
+
+func monthRange(db *mongo.Database, workspaceId primitive.ObjectID, from, to string) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	lastMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if to != "" {
+		parsed, err := time.Parse("2006-01", to)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		lastMonth = parsed
+	}
+
+	if from != "" {
+		firstMonth, err := time.Parse("2006-01", from)
+		return firstMonth, lastMonth, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var oldest struct {
+		DueDate time.Time `bson:"due_date"`
+	}
+	err := db.Collection("transaction").FindOne(ctx,
+		bson.M{"workspace_id": workspaceId, "account_id": bson.M{"$ne": nil}},
+		options.FindOne().SetSort(bson.M{"due_date": 1}).SetProjection(bson.M{"due_date": 1}),
+	).Decode(&oldest)
+	if err == mongo.ErrNoDocuments {
+		return lastMonth, lastMonth, nil
+	}
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	firstMonth := time.Date(oldest.DueDate.Year(), oldest.DueDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return firstMonth, lastMonth, nil
+}
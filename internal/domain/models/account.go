@@ -7,12 +7,15 @@ import (
 )
 
 type Account struct {
-	Id             primitive.ObjectID `bson:"_id" json:"id"`
-	CreatedAt      time.Time          `bson:"created_at" json:"createdAt"`
-	UpdatedAt      time.Time          `bson:"updated_at" json:"updatedAt"`
-	Name           string             `bson:"name" json:"name"`
-	Balance        float64            `bson:"balance" json:"balance"`
-	CurrentBalance float64            `bson:"-" json:"currentBalance"`
-	BankId         primitive.ObjectID `bson:"bank_id" json:"bankId"`
-	WorkspaceId    primitive.ObjectID `bson:"workspace_id" json:"workspaceId"`
+	Id                 primitive.ObjectID `bson:"_id" json:"id"`
+	CreatedAt          time.Time          `bson:"created_at" json:"createdAt"`
+	UpdatedAt          time.Time          `bson:"updated_at" json:"updatedAt"`
+	Name               string             `bson:"name" json:"name"`
+	Currency           string             `bson:"currency" json:"currency"` // ISO 4217, empty is the base currency of the workspace
+	Balance            Money              `bson:"balance" json:"balance"`
+	CurrentBalance     Money              `bson:"-" json:"currentBalance"`
+	BaseBalance        *Money             `bson:"-" json:"baseBalance,omitempty"` // balances converted to the base currency at the end of the month
+	BaseCurrentBalance *Money             `bson:"-" json:"baseCurrentBalance,omitempty"`
+	BankId             primitive.ObjectID `bson:"bank_id" json:"bankId"`
+	WorkspaceId        primitive.ObjectID `bson:"workspace_id" json:"workspaceId"`
 }
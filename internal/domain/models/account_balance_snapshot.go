@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AccountBalanceSnapshot guarda o saldo de uma conta no fim de um mês, já com todas as
+// transações (avulsas, parceladas e recorrentes) e edições aplicadas
+type AccountBalanceSnapshot struct {
+	Id             primitive.ObjectID `bson:"_id" json:"id"`
+	WorkspaceId    primitive.ObjectID `bson:"workspace_id" json:"workspaceId"`
+	AccountId      primitive.ObjectID `bson:"account_id" json:"accountId"`
+	Year           int                `bson:"year" json:"year"`
+	Month          int                `bson:"month" json:"month"`
+	Balance        Money              `bson:"balance" json:"balance"`
+	CurrentBalance Money              `bson:"current_balance" json:"currentBalance"`
+	Version        int64              `bson:"version" json:"version"`
+	UpdatedAt      time.Time          `bson:"updated_at" json:"updatedAt"`
+}
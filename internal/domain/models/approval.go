@@ -0,0 +1,51 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ApprovalPending  = "PENDING_APPROVAL"
+	ApprovalApproved = "APPROVED"
+	ApprovalRejected = "REJECTED"
+)
+
+// ApprovalSettings define which expenses of a workspace must be approved before they can be confirmed,
+// stored in the "approval_settings" collection. A zero threshold disables the amount rule.
+type ApprovalSettings struct {
+	Id          primitive.ObjectID   `json:"id" bson:"_id"`
+	WorkspaceId primitive.ObjectID   `json:"workspaceId" bson:"workspace_id"`
+	Threshold   Money                `json:"threshold" bson:"threshold"`
+	CategoryIds []primitive.ObjectID `json:"categoryIds" bson:"category_ids"`
+	UpdatedAt   time.Time            `json:"updatedAt" bson:"updated_at"`
+}
+
+// TransactionApproval is the review of an expense that required approval
+type TransactionApproval struct {
+	ReviewedBy primitive.ObjectID `json:"reviewedBy" bson:"reviewed_by"`
+	ReviewedAt time.Time          `json:"reviewedAt" bson:"reviewed_at"`
+	Comment    string             `json:"comment,omitempty" bson:"comment,omitempty"`
+}
+
+// RequiresApproval reports whether the expense is above the threshold or in one of the chosen
+// categories. The amount is the net value of the expense, with discounts and interest, as listed.
+// Credit card purchases are settled by the invoice payment and are never held.
+func RequiresApproval(settings *ApprovalSettings, transaction *Transaction, amount Money) bool {
+	if settings == nil || transaction.Type != "EXPENSE" || transaction.CreditCardId != nil {
+		return false
+	}
+
+	if settings.Threshold > 0 && amount > settings.Threshold {
+		return true
+	}
+
+	return transaction.CategoryId != nil && slices.Contains(settings.CategoryIds, *transaction.CategoryId)
+}
+
+// CanBeConfirmed reports whether the transaction is not waiting for approval nor was rejected
+func CanBeConfirmed(transaction *Transaction) bool {
+	return transaction.ApprovalStatus != ApprovalPending && transaction.ApprovalStatus != ApprovalRejected
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Attachment is a file (receipt, invoice, boleto, NF-e XML) attached to a transaction, or to a single
+// installment of it when MainCount is set. The content is kept in the file storage under StorageKey.
+type Attachment struct {
+	Id            primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId   primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	TransactionId primitive.ObjectID `json:"transactionId" bson:"transaction_id"`
+	MainCount     *int               `json:"mainCount,omitempty" bson:"main_count,omitempty"` // installment of a repeated transaction
+	FileName      string             `json:"fileName" bson:"file_name"`
+	ContentType   string             `json:"contentType" bson:"content_type"`
+	Size          int64              `json:"size" bson:"size"`
+	StorageKey    string             `json:"-" bson:"storage_key"`
+	CreatedBy     primitive.ObjectID `json:"createdBy" bson:"created_by"`
+	CreatedAt     time.Time          `json:"createdAt" bson:"created_at"`
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditLogChange is a field changed by an operation. Field uses the name stored in the database;
+// Before is empty on creation and After is empty on removal.
+type AuditLogChange struct {
+	Field  string `json:"field" bson:"field"`
+	Before any    `json:"before,omitempty" bson:"before,omitempty"`
+	After  any    `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+// AuditLog is an append-only record of a change made by a user, stored in the "audit_log" collection
+type AuditLog struct {
+	Id          primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	UserId      primitive.ObjectID `json:"userId" bson:"user_id"`
+	Entity      string             `json:"entity" bson:"entity"` // TRANSACTION | ACCOUNT | CATEGORY | TRANSFER
+	EntityId    primitive.ObjectID `json:"entityId" bson:"entity_id"`
+	MainCount   *int               `json:"mainCount,omitempty" bson:"main_count,omitempty"` // installment of a repeated transaction
+	Action      string             `json:"action" bson:"action"`                            // CREATE | UPDATE | DELETE | RESTORE
+	Changes     []AuditLogChange   `json:"changes" bson:"changes"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+}
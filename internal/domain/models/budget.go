@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Budget struct {
+	Id            primitive.ObjectID  `json:"id" bson:"_id"`
+	WorkspaceId   primitive.ObjectID  `json:"workspaceId" bson:"workspace_id"`
+	CategoryId    primitive.ObjectID  `json:"categoryId" bson:"category_id"`
+	SubCategoryId *primitive.ObjectID `json:"subCategoryId,omitempty" bson:"sub_category_id"` // nil for a budget of the whole category
+	Interval      string              `json:"interval" bson:"interval"`                       // MONTHLY | YEARLY
+	Amount        Money               `json:"amount" bson:"amount"`
+	CarryOver     bool                `json:"carryOver" bson:"carry_over"` // unspent amount of the previous period is added to the current one
+	CreatedBy     primitive.ObjectID  `json:"createdBy" bson:"created_by"`
+	CreatedAt     time.Time           `json:"createdAt" bson:"created_at"`
+	UpdatedAt     time.Time           `json:"updatedAt" bson:"updated_at"`
+}
+
+type BudgetTracking struct {
+	Budget             Budget    `json:"budget"`
+	PeriodStart        time.Time `json:"periodStart"`
+	PeriodEnd          time.Time `json:"periodEnd"`
+	CarriedOver        Money     `json:"carriedOver"`
+	Planned            Money     `json:"planned"`  // amount plus what was carried over
+	Forecast           Money     `json:"forecast"` // all transactions of the period
+	Realized           Money     `json:"realized"` // confirmed transactions of the period
+	PercentageConsumed float64   `json:"percentageConsumed"`
+	ForecastPercentage float64   `json:"forecastPercentage"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CashFlowTotals struct {
+	Income  Money `json:"income"`
+	Expense Money `json:"expense"`
+	Net     Money `json:"net"`
+}
+
+type CashFlowAccount struct {
+	AccountId primitive.ObjectID `json:"accountId"`
+	Forecast  CashFlowTotals     `json:"forecast"`  // all transactions by due date
+	Confirmed CashFlowTotals     `json:"confirmed"` // confirmed transactions by confirmation date
+}
+
+type CashFlowPeriod struct {
+	Period    string            `json:"period"`
+	StartDate time.Time         `json:"startDate"`
+	EndDate   time.Time         `json:"endDate"`
+	Forecast  CashFlowTotals    `json:"forecast"`
+	Confirmed CashFlowTotals    `json:"confirmed"`
+	Accounts  []CashFlowAccount `json:"accounts"`
+}
+
+type CashFlowReport struct {
+	InitialDate time.Time        `json:"initialDate"`
+	FinalDate   time.Time        `json:"finalDate"`
+	GroupBy     string           `json:"groupBy"`  // MONTH | WEEK | DAY
+	Currency    string           `json:"currency"` // base currency of the workspace, all amounts are converted to it
+	Forecast    CashFlowTotals   `json:"forecast"`
+	Confirmed   CashFlowTotals   `json:"confirmed"`
+	Periods     []CashFlowPeriod `json:"periods"`
+}
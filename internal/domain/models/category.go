@@ -10,15 +10,15 @@ type SubCategoryCategory struct {
 	Id            primitive.ObjectID `json:"id" bson:"_id"`
 	Name          string             `json:"name" bson:"name"`
 	Icon          string             `json:"icon" bson:"icon"`
-	Amount        float64            `json:"amount" bson:"-"`
-	CurrentAmount float64            `json:"currentAmount" bson:"-"`
+	Amount        Money              `json:"amount" bson:"-"`
+	CurrentAmount Money              `json:"currentAmount" bson:"-"`
 }
 
 type Category struct {
 	Id            primitive.ObjectID `json:"id" bson:"_id"`
 	Name          string             `json:"name" bson:"name"`
-	Amount        float64            `json:"amount" bson:"-"`
-	CurrentAmount float64            `json:"currentAmount" bson:"-"`
+	Amount        Money              `json:"amount" bson:"-"`
+	CurrentAmount Money              `json:"currentAmount" bson:"-"`
 	Type          string             `json:"type" bson:"type"` // EXPENSE, RECIPE, TAG, PERSONALIZED
 	Icon          string             `json:"icon" bson:"icon"`
 	// PersonalizedType string              `json:"personalizedType" bson:"personalized_type"` // NUMBER | TEXT | PHONE_NUMBER
@@ -29,7 +29,7 @@ type Category struct {
 }
 
 func (r *Category) CalculateTotalAmount() {
-	total := 0.0
+	var total Money
 	for _, subCategory := range r.SubCategories {
 		total += subCategory.Amount
 	}
@@ -37,7 +37,7 @@ func (r *Category) CalculateTotalAmount() {
 }
 
 func (r *Category) CalculateTotalCurrentAmount() {
-	total := 0.0
+	var total Money
 	for _, subCategory := range r.SubCategories {
 		total += subCategory.CurrentAmount
 	}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CreditCardInvoice struct {
+	CreditCardId primitive.ObjectID         `json:"creditCardId"`
+	Period       string                     `json:"period"` // YYYY-MM of the closing month
+	Year         int                        `json:"year"`
+	Month        int                        `json:"month"`
+	OpenDate     time.Time                  `json:"openDate"`
+	CloseDate    time.Time                  `json:"closeDate"`
+	DueDate      time.Time                  `json:"dueDate"`
+	Status       string                     `json:"status"` // OPEN | CLOSED | PAID
+	Total        Money                      `json:"total"`
+	PaidAmount   Money                      `json:"paidAmount"`
+	Payments     []CreditCardInvoicePayment `json:"payments"`
+	Transactions []Transaction              `json:"transactions"`
+}
+
+type CreditCardInvoicePayment struct {
+	Id                     primitive.ObjectID  `json:"id" bson:"_id"`
+	WorkspaceId            primitive.ObjectID  `json:"workspaceId" bson:"workspace_id"`
+	CreditCardId           primitive.ObjectID  `json:"creditCardId" bson:"credit_card_id"`
+	Period                 string              `json:"period" bson:"period"`
+	AccountId              primitive.ObjectID  `json:"accountId" bson:"account_id"`
+	TransactionId          primitive.ObjectID  `json:"transactionId" bson:"transaction_id"` // EXPENSE created on the account
+	Amount                 Money               `json:"amount" bson:"amount"`
+	InvoiceTotal           Money               `json:"invoiceTotal" bson:"invoice_total"`
+	RemainderAmount        Money               `json:"remainderAmount" bson:"remainder_amount"`
+	RemainderTransactionId *primitive.ObjectID `json:"remainderTransactionId,omitempty" bson:"remainder_transaction_id"` // card transaction carried to the next statement
+	PaymentDate            time.Time           `json:"paymentDate" bson:"payment_date"`
+	CreatedBy              primitive.ObjectID  `json:"createdBy" bson:"created_by"`
+	CreatedAt              time.Time           `json:"createdAt" bson:"created_at"`
+}
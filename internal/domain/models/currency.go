@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DefaultCurrency is the base currency of a workspace that never chose one, and the currency of the
+// accounts and transactions created before currencies existed
+const DefaultCurrency = "BRL"
+
+// CurrencySettings hold the base currency of a workspace, stored in the "currency_settings" collection.
+// Reports and category amounts are converted to it.
+type CurrencySettings struct {
+	Id           primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId  primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	BaseCurrency string             `json:"baseCurrency" bson:"base_currency"`
+	UpdatedAt    time.Time          `json:"updatedAt" bson:"updated_at"`
+}
+
+// ExchangeRate is the value of one unit of From in To on a date, stored in the "exchange_rate" collection.
+// There is at most one rate per pair and day.
+type ExchangeRate struct {
+	Id          primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	From        string             `json:"from" bson:"from"`
+	To          string             `json:"to" bson:"to"`
+	Rate        float64            `json:"rate" bson:"rate"`
+	Date        time.Time          `json:"date" bson:"date"`
+	CreatedBy   primitive.ObjectID `json:"createdBy" bson:"created_by"`
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updated_at"`
+}
+
+// ExchangeRateNotFoundError is returned when there is no rate at all between two currencies
+type ExchangeRateNotFoundError struct {
+	From string
+	To   string
+}
+
+func (e *ExchangeRateNotFoundError) Error() string {
+	return fmt.Sprintf("exchange rate from %s to %s not found", e.From, e.To)
+}
+
+// ExchangeRates converts amounts between the currencies of a workspace. An empty currency is the base currency.
+type ExchangeRates struct {
+	BaseCurrency string
+	pairs        map[[2]string][]ExchangeRate
+}
+
+// NewExchangeRates indexes the rates of a workspace by pair, sorted by date
+func NewExchangeRates(baseCurrency string, rates []ExchangeRate) *ExchangeRates {
+	if baseCurrency == "" {
+		baseCurrency = DefaultCurrency
+	}
+
+	exchangeRates := &ExchangeRates{BaseCurrency: baseCurrency, pairs: make(map[[2]string][]ExchangeRate)}
+	for _, rate := range rates {
+		key := [2]string{rate.From, rate.To}
+		exchangeRates.pairs[key] = append(exchangeRates.pairs[key], rate)
+	}
+	for key := range exchangeRates.pairs {
+		sort.Slice(exchangeRates.pairs[key], func(i, j int) bool {
+			return exchangeRates.pairs[key][i].Date.Before(exchangeRates.pairs[key][j].Date)
+		})
+	}
+
+	return exchangeRates
+}
+
+// Currency returns the currency code, or the base currency when it is empty
+func (e *ExchangeRates) Currency(currency string) string {
+	if currency == "" {
+		return e.BaseCurrency
+	}
+	return currency
+}
+
+// Rate returns how much one unit of from is worth in to on the date. The rate used is the last one
+// entered up to the date, or the first one after it when the date is older than all of them. Without a
+// rate for the pair, the inverse pair is used, and then a conversion through the base currency.
+func (e *ExchangeRates) Rate(from, to string, date time.Time) (float64, error) {
+	from, to = e.Currency(from), e.Currency(to)
+	if from == to {
+		return 1, nil
+	}
+
+	if rate, exists := e.pairRate(from, to, date); exists {
+		return rate, nil
+	}
+
+	if from != e.BaseCurrency && to != e.BaseCurrency {
+		toBase, fromErr := e.Rate(from, e.BaseCurrency, date)
+		fromBase, toErr := e.Rate(e.BaseCurrency, to, date)
+		if fromErr == nil && toErr == nil {
+			return toBase * fromBase, nil
+		}
+	}
+
+	return 0, &ExchangeRateNotFoundError{From: from, To: to}
+}
+
+// Convert returns the amount in from converted to to on the date, rounded to the cent
+func (e *ExchangeRates) Convert(amount Money, from, to string, date time.Time) (Money, error) {
+	rate, err := e.Rate(from, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return amount.Mul(rate), nil
+}
+
+func (e *ExchangeRates) pairRate(from, to string, date time.Time) (float64, bool) {
+	if rate, exists := rateAt(e.pairs[[2]string{from, to}], date); exists {
+		return rate, true
+	}
+	if rate, exists := rateAt(e.pairs[[2]string{to, from}], date); exists && rate != 0 {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+func rateAt(rates []ExchangeRate, date time.Time) (float64, bool) {
+	if len(rates) == 0 {
+		return 0, false
+	}
+
+	index := sort.Search(len(rates), func(i int) bool {
+		return rates[i].Date.After(date)
+	})
+	if index == 0 {
+		return rates[0].Rate, true
+	}
+	return rates[index-1].Rate, true
+}
+
+// TransactionReferenceDate is the date whose rate converts the transaction: the confirmation date once it
+// is confirmed, the due date before that
+func TransactionReferenceDate(transaction *Transaction) time.Time {
+	if transaction.IsConfirmed && transaction.ConfirmationDate != nil {
+		return *transaction.ConfirmationDate
+	}
+	return transaction.DueDate
+}
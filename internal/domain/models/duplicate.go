@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+)
+
+// DuplicateTransactionGroup is a set of transactions of a workspace with the same fingerprint
+type DuplicateTransactionGroup struct {
+	Fingerprint  string        `json:"fingerprint"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// TransactionFingerprint identifies the same bill entered twice: type, amount, due date, account or
+// credit card, invoice, supplier and name. Texts are compared ignoring case and surrounding spaces.
+func TransactionFingerprint(transaction *Transaction) string {
+	var walletId string
+	if transaction.AccountId != nil {
+		walletId = transaction.AccountId.Hex()
+	} else if transaction.CreditCardId != nil {
+		walletId = transaction.CreditCardId.Hex()
+	}
+
+	normalize := func(value string) string {
+		return strings.ToLower(strings.TrimSpace(value))
+	}
+
+	return strings.Join([]string{
+		transaction.Type,
+		transaction.Balance.Value.String(),
+		transaction.DueDate.UTC().Format("2006-01-02"),
+		walletId,
+		normalize(transaction.Invoice),
+		normalize(transaction.Supplier),
+		normalize(transaction.Name),
+	}, "|")
+}
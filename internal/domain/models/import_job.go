@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ImportJobPending   = "PENDING"
+	ImportJobRunning   = "RUNNING"
+	ImportJobCompleted = "COMPLETED"
+	ImportJobFailed    = "FAILED"
+	ImportJobCanceled  = "CANCELED"
+)
+
+// ImportJobError is the error of one line of the imported file, numbered as in the spreadsheet
+type ImportJobError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportJob is an import processed in the background, kept in Redis with its progress. The valid
+// lines are queued in batches; Offset is the position in that queue where the processing resumes.
+// Lines repeating saved transactions are reported as errors unless AllowDuplicates is set.
+type ImportJob struct {
+	Id              string             `json:"id"`
+	WorkspaceId     primitive.ObjectID `json:"workspaceId"`
+	CreatedBy       primitive.ObjectID `json:"createdBy"`
+	Status          string             `json:"status"`
+	AllowDuplicates bool               `json:"allowDuplicates"`
+	Total           int                `json:"total"`
+	Processed       int                `json:"processed"`
+	Created         int                `json:"created"`
+	Failed          int                `json:"failed"`
+	Offset          int                `json:"offset"`
+	Errors          []ImportJobError   `json:"errors"`
+	Error           string             `json:"error,omitempty"`
+	CreatedAt       time.Time          `json:"createdAt"`
+	UpdatedAt       time.Time          `json:"updatedAt"`
+	FinishedAt      *time.Time         `json:"finishedAt,omitempty"`
+}
+
+// Finished reports whether the job reached a final status and will not be processed again
+func (j *ImportJob) Finished() bool {
+	return j.Status == ImportJobCompleted || j.Status == ImportJobFailed || j.Status == ImportJobCanceled
+}
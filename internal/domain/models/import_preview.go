@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ImportPreviewSubCategory is a sub category that the import adds to an existing category or tag
+type ImportPreviewSubCategory struct {
+	CategoryId   primitive.ObjectID  `json:"categoryId"`
+	CategoryName string              `json:"categoryName"`
+	CategoryType string              `json:"categoryType"`
+	SubCategory  SubCategoryCategory `json:"subCategory"`
+}
+
+// ImportPreview is the result of a dry-run import, kept in Redis until it is committed or expires.
+// Ids of accounts, categories and sub categories are provisional and replaced on commit.
+type ImportPreview struct {
+	Token         string                     `json:"token"`
+	WorkspaceId   primitive.ObjectID         `json:"workspaceId"`
+	CreatedBy     primitive.ObjectID         `json:"createdBy"`
+	Transactions  []Transaction              `json:"transactions"`
+	Accounts      []Account                  `json:"accounts"`
+	Categories    []Category                 `json:"categories"`
+	SubCategories []ImportPreviewSubCategory `json:"subCategories"`
+	CreatedAt     time.Time                  `json:"createdAt"`
+	ExpiresAt     time.Time                  `json:"expiresAt"`
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RoleViewer     = "VIEWER"
+	RoleBookkeeper = "BOOKKEEPER"
+	RoleApprover   = "APPROVER"
+	RoleAdmin      = "ADMIN"
+)
+
+// DefaultMemberRole is the role of a member allowed in the application who was not given a finance role
+const DefaultMemberRole = RoleBookkeeper
+
+const (
+	PermissionRead        = "READ"
+	PermissionWrite       = "WRITE"
+	PermissionDelete      = "DELETE"
+	PermissionApprove     = "APPROVE"
+	PermissionManageRoles = "MANAGE_ROLES"
+	PermissionSettings    = "SETTINGS"
+)
+
+// RolePermissions lists what each finance role is allowed to do
+var RolePermissions = map[string][]string{
+	RoleViewer:     {PermissionRead},
+	RoleBookkeeper: {PermissionRead, PermissionWrite},
+	RoleApprover:   {PermissionRead, PermissionWrite, PermissionApprove},
+	RoleAdmin:      {PermissionRead, PermissionWrite, PermissionDelete, PermissionApprove, PermissionManageRoles, PermissionSettings},
+}
+
+// HasPermission reports whether the role grants the permission
+func HasPermission(role string, permission string) bool {
+	return slices.Contains(RolePermissions[role], permission)
+}
+
+// MemberRole is the finance role of a workspace member, stored in the "member_role" collection. The
+// workspace owner and its admins are always ADMIN and have no mapping.
+type MemberRole struct {
+	Id          primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	MemberId    primitive.ObjectID `json:"memberId" bson:"member_id"`
+	Role        string             `json:"role" bson:"role"` // VIEWER | BOOKKEEPER | APPROVER | ADMIN
+	CreatedAt   time.Time          `json:"createdAt" bson:"created_at"`
+	UpdatedAt   time.Time          `json:"updatedAt" bson:"updated_at"`
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Money is an amount in cents, so sums and installments add up exactly. It is stored as Decimal128 and
+// written to JSON as a number with two decimal places; documents saved before it, with doubles, still decode.
+type Money int64
+
+// NewMoney rounds a float amount to the nearest cent, using its shortest decimal representation so 1.005
+// becomes 1.01 instead of suffering from the binary error of the float
+func NewMoney(value float64) Money {
+	money, _ := ParseMoney(strconv.FormatFloat(value, 'f', -1, 64))
+	return money
+}
+
+// ParseMoney reads a decimal amount such as "-10.5" or "1e3" exactly, rounding half away from zero to cents
+func ParseMoney(value string) (Money, error) {
+	rat, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("invalid amount: %q", value)
+	}
+	return roundCents(rat.Mul(rat, big.NewRat(100, 1)))
+}
+
+// Float64 returns the amount in units of the currency, for ratios and exports
+func (m Money) Float64() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimal places, e.g. "-10.50"
+func (m Money) String() string {
+	sign := ""
+	cents := int64(m)
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+// Abs returns the amount without its sign
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// Percentage returns percentage percent of the amount, rounded to the cent
+func (m Money) Percentage(percentage float64) Money {
+	return m.Mul(percentage / 100)
+}
+
+// Mul returns the amount multiplied by factor, such as an exchange rate, rounded to the cent. The factor is
+// taken by its shortest decimal representation.
+func (m Money) Mul(factor float64) Money {
+	rat, ok := new(big.Rat).SetString(strconv.FormatFloat(factor, 'g', -1, 64))
+	if !ok {
+		return 0
+	}
+	result, _ := roundCents(rat.Mul(rat, big.NewRat(int64(m), 1)))
+	return result
+}
+
+// Installment returns the value of installment number (1 to count) when the amount is split in count
+// installments: every installment gets the amount divided by count, truncated to the cent, and the last
+// one absorbs the remainder.
+func (m Money) Installment(number, count int) Money {
+	if count <= 1 {
+		return m
+	}
+	part := m / Money(count)
+	if number >= count {
+		return m - part*Money(count-1)
+	}
+	return part
+}
+
+// Installments returns the sum of the first installments when the amount is split in count installments.
+// Once every installment is included, the result is exactly the amount.
+func (m Money) Installments(installments, count int) Money {
+	if installments <= 0 {
+		return 0
+	}
+	if count <= 1 || installments >= count {
+		return m
+	}
+	return m / Money(count) * Money(installments)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON accepts a number or a string with a decimal number; null keeps the amount
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value := string(data)
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+
+	money, err := ParseMoney(value)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+func (m Money) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	decimal, err := primitive.ParseDecimal128(m.String())
+	if err != nil {
+		return 0, nil, err
+	}
+	return bson.MarshalValue(decimal)
+}
+
+// UnmarshalBSONValue reads Decimal128 and, for documents not yet migrated, doubles and integers
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+
+	switch t {
+	case bson.TypeDecimal128:
+		money, err := ParseMoney(raw.Decimal128().String())
+		if err != nil {
+			return err
+		}
+		*m = money
+	case bson.TypeDouble:
+		*m = NewMoney(raw.Double())
+	case bson.TypeInt32:
+		*m = Money(raw.Int32()) * 100
+	case bson.TypeInt64:
+		*m = Money(raw.Int64()) * 100
+	case bson.TypeNull, bson.TypeUndefined:
+		*m = 0
+	default:
+		return fmt.Errorf("cannot decode %s into an amount", t)
+	}
+	return nil
+}
+
+func roundCents(cents *big.Rat) (Money, error) {
+	quotient, remainder := new(big.Int).QuoRem(new(big.Int).Abs(cents.Num()), cents.Denom(), new(big.Int))
+	if remainder.Lsh(remainder, 1).Cmp(cents.Denom()) >= 0 {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	if cents.Sign() < 0 {
+		quotient.Neg(quotient)
+	}
+
+	if !quotient.IsInt64() {
+		return 0, fmt.Errorf("amount out of range: %s", cents.FloatString(0))
+	}
+	return Money(quotient.Int64()), nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// NfeImportSettings hold how the expenses imported from NF-e XML files are categorized, stored in the
+// "nfe_import_settings" collection
+type NfeImportSettings struct {
+	Id                 primitive.ObjectID `json:"id" bson:"_id"`
+	WorkspaceId        primitive.ObjectID `json:"workspaceId" bson:"workspace_id"`
+	CategoryRules      []NfeCategoryRule  `json:"categoryRules" bson:"category_rules"`
+	DefaultCategory    string             `json:"defaultCategory,omitempty" bson:"default_category"`
+	DefaultSubCategory string             `json:"defaultSubCategory,omitempty" bson:"default_sub_category"`
+	UpdatedAt          time.Time          `json:"updatedAt" bson:"updated_at"`
+}
+
+// NfeCategoryRule maps an NF-e to an expense category by name. Every condition that is filled must
+// match: the issuer CNPJ/CPF exactly, the CFOP and NCM as prefixes of any item and the keyword as part
+// of the issuer name or of any item description. Rules are evaluated in order and the first match wins.
+type NfeCategoryRule struct {
+	IssuerDocument string `json:"issuerDocument,omitempty" bson:"issuer_document,omitempty"`
+	Cfop           string `json:"cfop,omitempty" bson:"cfop,omitempty"`
+	Ncm            string `json:"ncm,omitempty" bson:"ncm,omitempty"`
+	Keyword        string `json:"keyword,omitempty" bson:"keyword,omitempty"`
+	Category       string `json:"category" bson:"category"`
+	SubCategory    string `json:"subCategory,omitempty" bson:"sub_category,omitempty"`
+}
@@ -7,19 +7,19 @@ import (
 )
 
 type TransactionBalance struct {
-	Value              float64 `bson:"value" json:"value"`
-	Discount           float64 `bson:"discount" json:"discount"`                      // decrease
-	Interest           float64 `bson:"interest" json:"interest"`                      // increase
+	Value              Money   `bson:"value" json:"value"`
+	Discount           Money   `bson:"discount" json:"discount"`                      // decrease
+	Interest           Money   `bson:"interest" json:"interest"`                      // increase
 	DiscountPercentage float64 `bson:"discount_percentage" json:"discountPercentage"` // decrease
 	InterestPercentage float64 `bson:"interest_percentage" json:"interestPercentage"` // increase
-	NetBalance         float64 `bson:"-" json:"netBalance,omitempty"`
+	NetBalance         Money   `bson:"-" json:"netBalance,omitempty"`
 }
 
 type TransactionRepeatSettings struct {
 	InitialInstallment time.Month `bson:"initial_installment" json:"initialInstallment,omitempty"`
 	Count              int        `bson:"count" json:"count,omitempty"`
 	CurrentCount       int        `bson:"-" json:"currentCount,omitempty"`
-	Interval           string     `bson:"interval" json:"interval,omitempty"` // MONTHLY | DAILY | WEEKLY | QUARTERLY | YEARLY | CUSTOM
+	Interval           string     `bson:"interval" json:"interval,omitempty"` // MONTHLY | DAILY | WEEKLY | BIWEEKLY | QUARTERLY | YEARLY | CUSTOM
 	CustomDay          int        `bson:"custom_day" json:"customDay,omitempty"`
 }
 
@@ -28,6 +28,22 @@ type TransactionTags struct {
 	SubTagId primitive.ObjectID `bson:"sub_tag_id" json:"subTagId"`
 }
 
+// TransactionTransfer records both sides of a transfer between accounts, on each of its transactions.
+// Rate is the value of one unit of the source currency in the destination currency. Id and the ids of
+// the two legs are empty on transfers made before they were kept in the "transfer" collection.
+type TransactionTransfer struct {
+	Id                   primitive.ObjectID `bson:"id,omitempty" json:"id"`
+	ExpenseTransactionId primitive.ObjectID `bson:"expense_transaction_id,omitempty" json:"expenseTransactionId"`
+	ReceiptTransactionId primitive.ObjectID `bson:"receipt_transaction_id,omitempty" json:"receiptTransactionId"`
+	SourceAccountId      primitive.ObjectID `bson:"source_account_id" json:"sourceAccountId"`
+	SourceAmount         Money              `bson:"source_amount" json:"sourceAmount"`
+	SourceCurrency       string             `bson:"source_currency" json:"sourceCurrency"`
+	DestinationAccountId primitive.ObjectID `bson:"destination_account_id" json:"destinationAccountId"`
+	DestinationAmount    Money              `bson:"destination_amount" json:"destinationAmount"`
+	DestinationCurrency  string             `bson:"destination_currency" json:"destinationCurrency"`
+	Rate                 float64            `bson:"rate" json:"rate"`
+}
+
 type TransactionCustomField struct {
 	CustomFieldId primitive.ObjectID `bson:"custom_field_id" json:"id"`
 	Value         string             `bson:"value" json:"value"`
@@ -47,7 +63,9 @@ type Transaction struct {
 	Supplier                 string                     `bson:"supplier" json:"supplier"`
 	AssignedTo               primitive.ObjectID         `bson:"assigned_to" json:"assignedTo"`
 	Balance                  TransactionBalance         `bson:"balance" json:"balance"`
-	TotalBalance             float64                    `bson:"-" json:"totalBalance,omitempty"`
+	Currency                 string                     `bson:"currency" json:"currency,omitempty"` // ISO 4217, empty is the base currency of the workspace
+	Transfer                 *TransactionTransfer       `bson:"transfer,omitempty" json:"transfer,omitempty"`
+	TotalBalance             Money                      `bson:"-" json:"totalBalance,omitempty"`
 	Frequency                string                     `bson:"frequency" json:"frequency"` // DO_NOT_REPEAT | RECURRING | REPEAT
 	RepeatSettings           *TransactionRepeatSettings `bson:"repeat_settings" json:"repeatSettings,omitempty"`
 	DueDate                  time.Time                  `bson:"due_date" json:"dueDate"`
@@ -56,6 +74,7 @@ type Transaction struct {
 	SubCategoryId            *primitive.ObjectID        `bson:"sub_category_id" json:"subCategoryId"`
 	Tags                     []TransactionTags          `bson:"tags" json:"tags"`
 	AccountId                *primitive.ObjectID        `bson:"account_id" json:"accountId"`
+	CreditCardId             *primitive.ObjectID        `bson:"credit_card_id" json:"creditCardId,omitempty"`
 	RegistrationDate         time.Time                  `bson:"registration_date" json:"registrationDate"`
 	ConfirmationDate         *time.Time                 `bson:"confirmation_date" json:"confirmationDate,omitempty"`
 	IsOverdue                bool                       `bson:"-" json:"isOverdue"`
@@ -64,4 +83,6 @@ type Transaction struct {
 	WorkspaceId              primitive.ObjectID         `bson:"workspace_id" json:"workspaceId"`
 	CustomFields             []TransactionCustomField   `bson:"custom_fields" json:"customFields"`
 	ExcludeInstallmentsUntil *time.Time                 `bson:"exclude_installments_until" json:"excludeInstallmentsUntil,omitempty"`
+	ApprovalStatus           string                     `bson:"approval_status" json:"approvalStatus,omitempty"` // PENDING_APPROVAL | APPROVED | REJECTED, empty when no approval is required
+	Approval                 *TransactionApproval       `bson:"approval" json:"approval,omitempty"`
 }
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TransactionRuleConditions are the criteria of a rule; only the ones filled in are checked and all of
+// them must match. Texts match when they are contained in the transaction field, ignoring case.
+type TransactionRuleConditions struct {
+	Name        string               `json:"name,omitempty" bson:"name,omitempty"`
+	Supplier    string               `json:"supplier,omitempty" bson:"supplier,omitempty"`
+	Description string               `json:"description,omitempty" bson:"description,omitempty"`
+	MinAmount   *Money               `json:"minAmount,omitempty" bson:"min_amount,omitempty"`
+	MaxAmount   *Money               `json:"maxAmount,omitempty" bson:"max_amount,omitempty"`
+	AccountIds  []primitive.ObjectID `json:"accountIds,omitempty" bson:"account_ids,omitempty"`
+	Type        string               `json:"type,omitempty" bson:"type,omitempty"` // EXPENSE | RECIPE
+}
+
+// TransactionRuleActions are the classification a rule gives to the transactions it matches
+type TransactionRuleActions struct {
+	CategoryId    *primitive.ObjectID      `json:"categoryId,omitempty" bson:"category_id,omitempty"`
+	SubCategoryId *primitive.ObjectID      `json:"subCategoryId,omitempty" bson:"sub_category_id,omitempty"`
+	Tags          []TransactionTags        `json:"tags,omitempty" bson:"tags,omitempty"`
+	AssignedTo    *primitive.ObjectID      `json:"assignedTo,omitempty" bson:"assigned_to,omitempty"`
+	CustomFields  []TransactionCustomField `json:"customFields,omitempty" bson:"custom_fields,omitempty"`
+}
+
+// TransactionRule categorizes the transactions of a workspace automatically, stored in the
+// "transaction_rule" collection. Rules are evaluated by ascending priority.
+type TransactionRule struct {
+	Id          primitive.ObjectID        `json:"id" bson:"_id"`
+	WorkspaceId primitive.ObjectID        `json:"workspaceId" bson:"workspace_id"`
+	Name        string                    `json:"name" bson:"name"`
+	Priority    int                       `json:"priority" bson:"priority"`
+	IsActive    bool                      `json:"isActive" bson:"is_active"`
+	Conditions  TransactionRuleConditions `json:"conditions" bson:"conditions"`
+	Actions     TransactionRuleActions    `json:"actions" bson:"actions"`
+	CreatedBy   primitive.ObjectID        `json:"createdBy" bson:"created_by"`
+	CreatedAt   time.Time                 `json:"createdAt" bson:"created_at"`
+	UpdatedAt   time.Time                 `json:"updatedAt" bson:"updated_at"`
+}
+
+// Matches reports whether the rule is active and all of its conditions match the transaction
+func (r *TransactionRule) Matches(transaction *Transaction) bool {
+	if !r.IsActive {
+		return false
+	}
+
+	conditions := r.Conditions
+	contains := func(value, search string) bool {
+		return search == "" || strings.Contains(strings.ToLower(value), strings.ToLower(search))
+	}
+
+	if conditions.Type != "" && conditions.Type != transaction.Type {
+		return false
+	}
+	if !contains(transaction.Name, conditions.Name) ||
+		!contains(transaction.Supplier, conditions.Supplier) ||
+		!contains(transaction.Description, conditions.Description) {
+		return false
+	}
+	if conditions.MinAmount != nil && transaction.Balance.Value < *conditions.MinAmount {
+		return false
+	}
+	if conditions.MaxAmount != nil && transaction.Balance.Value > *conditions.MaxAmount {
+		return false
+	}
+	if len(conditions.AccountIds) > 0 &&
+		(transaction.AccountId == nil || !slices.Contains(conditions.AccountIds, *transaction.AccountId)) {
+		return false
+	}
+
+	return true
+}
+
+// MatchTransactionRules combines the actions of the rules matching the transaction. The rules must be
+// sorted by priority: a field set by a rule is kept over the following ones, while tags and custom
+// fields are added up. Returns nil when no rule matches.
+func MatchTransactionRules(rules []TransactionRule, transaction *Transaction) *TransactionRuleActions {
+	var actions *TransactionRuleActions
+	for i := range rules {
+		if !rules[i].Matches(transaction) {
+			continue
+		}
+
+		ruleActions := rules[i].Actions
+		if actions == nil {
+			actions = &TransactionRuleActions{}
+		}
+
+		if actions.CategoryId == nil && ruleActions.CategoryId != nil {
+			actions.CategoryId = ruleActions.CategoryId
+			actions.SubCategoryId = ruleActions.SubCategoryId
+		}
+		if actions.AssignedTo == nil {
+			actions.AssignedTo = ruleActions.AssignedTo
+		}
+		actions.Tags = mergeTags(actions.Tags, ruleActions.Tags)
+		actions.CustomFields = mergeCustomFields(actions.CustomFields, ruleActions.CustomFields, false)
+	}
+
+	return actions
+}
+
+// Apply classifies the transaction with the actions. Unless overwrite is set, only what the transaction
+// leaves empty is filled in; tags are always added to the existing ones. Returns whether it changed.
+func (a *TransactionRuleActions) Apply(transaction *Transaction, overwrite bool) bool {
+	changed := false
+
+	if a.CategoryId != nil {
+		switch {
+		case transaction.CategoryId == nil || (overwrite && *transaction.CategoryId != *a.CategoryId):
+			transaction.CategoryId = a.CategoryId
+			transaction.SubCategoryId = a.SubCategoryId
+			changed = true
+		case *transaction.CategoryId == *a.CategoryId && a.SubCategoryId != nil &&
+			(transaction.SubCategoryId == nil || (overwrite && *transaction.SubCategoryId != *a.SubCategoryId)):
+			// the sub-category of the rule is only taken when it belongs to the category of the transaction
+			transaction.SubCategoryId = a.SubCategoryId
+			changed = true
+		}
+	}
+
+	if a.AssignedTo != nil && (transaction.AssignedTo.IsZero() || (overwrite && transaction.AssignedTo != *a.AssignedTo)) {
+		transaction.AssignedTo = *a.AssignedTo
+		changed = true
+	}
+
+	if tags := mergeTags(transaction.Tags, a.Tags); len(tags) != len(transaction.Tags) {
+		transaction.Tags = tags
+		changed = true
+	}
+
+	customFields := mergeCustomFields(transaction.CustomFields, a.CustomFields, overwrite)
+	if !slices.Equal(customFields, transaction.CustomFields) {
+		transaction.CustomFields = customFields
+		changed = true
+	}
+
+	return changed
+}
+
+func mergeTags(tags []TransactionTags, added []TransactionTags) []TransactionTags {
+	for _, tag := range added {
+		if !slices.Contains(tags, tag) {
+			tags = append(slices.Clip(tags), tag)
+		}
+	}
+	return tags
+}
+
+// mergeCustomFields adds the fields that are missing; the values already filled in are replaced only with overwrite
+func mergeCustomFields(customFields []TransactionCustomField, added []TransactionCustomField, overwrite bool) []TransactionCustomField {
+	merged := slices.Clone(customFields)
+	for _, field := range added {
+		index := slices.IndexFunc(merged, func(existing TransactionCustomField) bool {
+			return existing.CustomFieldId == field.CustomFieldId
+		})
+
+		switch {
+		case index == -1:
+			merged = append(merged, field)
+		case overwrite || merged[index].Value == "":
+			merged[index].Value = field.Value
+		}
+	}
+	return merged
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Transfer moves money between two accounts of the workspace, stored in the "transfer" collection. Its legs,
+// an EXPENSE on the source account and a RECIPE on the destination, are written, edited and deleted together
+// with it. The legs count in the account balances but not as income or expense in the reports.
+// Scheduled and repeating transfers keep Date as the due date of the first occurrence and share their frequency
+// with the legs, so every occurrence is projected into both accounts; a single occurrence is changed with an
+// edit_transaction of each leg.
+type Transfer struct {
+	Id                   primitive.ObjectID         `json:"id" bson:"_id"`
+	WorkspaceId          primitive.ObjectID         `json:"workspaceId" bson:"workspace_id"`
+	Description          string                     `json:"description" bson:"description"`
+	Date                 time.Time                  `json:"date" bson:"date"` // due date
+	IsConfirmed          bool                       `json:"isConfirmed" bson:"is_confirmed"`
+	ConfirmationDate     *time.Time                 `json:"confirmationDate,omitempty" bson:"confirmation_date,omitempty"`
+	Frequency            string                     `json:"frequency" bson:"frequency"` // DO_NOT_REPEAT | RECURRING | REPEAT
+	RepeatSettings       *TransactionRepeatSettings `json:"repeatSettings,omitempty" bson:"repeat_settings,omitempty"`
+	SourceAccountId      primitive.ObjectID         `json:"sourceAccountId" bson:"source_account_id"`
+	SourceAmount         Money                      `json:"sourceAmount" bson:"source_amount"`
+	SourceCurrency       string                     `json:"sourceCurrency" bson:"source_currency"`
+	DestinationAccountId primitive.ObjectID         `json:"destinationAccountId" bson:"destination_account_id"`
+	DestinationAmount    Money                      `json:"destinationAmount" bson:"destination_amount"`
+	DestinationCurrency  string                     `json:"destinationCurrency" bson:"destination_currency"`
+	Rate                 float64                    `json:"rate" bson:"rate"` // value of one unit of the source currency in the destination currency
+	ExpenseTransactionId primitive.ObjectID         `json:"expenseTransactionId" bson:"expense_transaction_id"`
+	ReceiptTransactionId primitive.ObjectID         `json:"receiptTransactionId" bson:"receipt_transaction_id"`
+	IsDeleted            bool                       `json:"isDeleted" bson:"is_deleted"`
+	CreatedBy            primitive.ObjectID         `json:"createdBy" bson:"created_by"`
+	CreatedAt            time.Time                  `json:"createdAt" bson:"created_at"`
+	UpdatedAt            time.Time                  `json:"updatedAt" bson:"updated_at"`
+}
+
+// Leg returns the copy of the transfer kept on each of its transactions
+func (t *Transfer) Leg() *TransactionTransfer {
+	return &TransactionTransfer{
+		Id:                   t.Id,
+		ExpenseTransactionId: t.ExpenseTransactionId,
+		ReceiptTransactionId: t.ReceiptTransactionId,
+		SourceAccountId:      t.SourceAccountId,
+		SourceAmount:         t.SourceAmount,
+		SourceCurrency:       t.SourceCurrency,
+		DestinationAccountId: t.DestinationAccountId,
+		DestinationAmount:    t.DestinationAmount,
+		DestinationCurrency:  t.DestinationCurrency,
+		Rate:                 t.Rate,
+	}
+}
+
+// IsTransferLeg reports whether the transaction is one of the legs of a transfer between accounts
+func IsTransferLeg(transaction *Transaction) bool {
+	return transaction.Transfer != nil
+}
+
+// TransactionTransferId returns the transfer the transaction is a leg of. Legs of transfers made before they
+// were kept in the "transfer" collection have none and are handled as ordinary transactions.
+func TransactionTransferId(transaction *Transaction) (primitive.ObjectID, bool) {
+	if transaction.Transfer == nil || transaction.Transfer.Id.IsZero() {
+		return primitive.NilObjectID, false
+	}
+	return transaction.Transfer.Id, true
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TrashReference is a link to the deleted entity that was removed from another document, kept so the
+// restore can put it back. Field is empty when the whole document was removed and Value holds it.
+type TrashReference struct {
+	Collection string             `json:"collection" bson:"collection"`
+	DocumentId primitive.ObjectID `json:"documentId" bson:"document_id"`
+	Field      string             `json:"field,omitempty" bson:"field,omitempty"`
+	Value      any                `json:"value,omitempty" bson:"value,omitempty"`
+}
+
+// TrashItem is an entity removed by a user, stored in the "trash" collection until it is restored or
+// purged. The entity itself stays in its collection flagged as deleted.
+type TrashItem struct {
+	Id           primitive.ObjectID  `json:"id" bson:"_id"`
+	WorkspaceId  primitive.ObjectID  `json:"workspaceId" bson:"workspace_id"`
+	Entity       string              `json:"entity" bson:"entity"` // TRANSACTION | TRANSFER | ACCOUNT | CATEGORY | CREDIT_CARD | CUSTOM_FIELD
+	EntityId     primitive.ObjectID  `json:"entityId" bson:"entity_id"`
+	Name         string              `json:"name" bson:"name"`
+	MainId       *primitive.ObjectID `json:"mainId,omitempty" bson:"main_id,omitempty"`       // installment of a repeated transaction
+	MainCount    *int                `json:"mainCount,omitempty" bson:"main_count,omitempty"` // installment of a repeated transaction
+	DeletionOnly bool                `json:"-" bson:"deletion_only,omitempty"`                // installment without an edit, the deleted edit was created only to hide it
+	References   []TrashReference    `json:"-" bson:"references,omitempty"`
+	DeletedAt    time.Time           `json:"deletedAt" bson:"deleted_at"`
+	PurgeAt      time.Time           `json:"purgeAt" bson:"-"`
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindApprovalSettingsRepository defines the interface for finding the approval settings of a workspace,
+// nil when approval was never configured
+type FindApprovalSettingsRepository interface {
+	Find(workspaceId primitive.ObjectID) (*models.ApprovalSettings, error)
+}
+
+// SaveApprovalSettingsRepository defines the interface for saving the approval settings of a workspace
+type SaveApprovalSettingsRepository interface {
+	Save(settings *models.ApprovalSettings) (*models.ApprovalSettings, error)
+}
+
+// FindPendingApprovalRepository defines the interface for listing the expenses waiting for approval
+type FindPendingApprovalRepository interface {
+	Find(workspaceId primitive.ObjectID) ([]models.Transaction, error)
+}
+
+// ReviewTransactionRepository defines the interface for approving or rejecting a pending expense. It
+// returns nil when the transaction is no longer pending.
+type ReviewTransactionRepository interface {
+	Review(transactionId primitive.ObjectID, workspaceId primitive.ObjectID, status string, approval *models.TransactionApproval) (*models.Transaction, error)
+}
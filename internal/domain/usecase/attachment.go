@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"io"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FileStorage defines the interface for storing the content of files by key
+type FileStorage interface {
+	Save(key string, content io.Reader) error
+	Open(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// CreateAttachmentRepository defines the interface for registering an attachment
+type CreateAttachmentRepository interface {
+	Create(attachment *models.Attachment) (*models.Attachment, error)
+}
+
+// FindAttachmentsRepository defines the interface for listing the attachments of a transaction. A nil
+// mainCount lists the attachments of the transaction itself, otherwise the ones of the installment.
+type FindAttachmentsRepository interface {
+	Find(transactionId primitive.ObjectID, mainCount *int, workspaceId primitive.ObjectID) ([]models.Attachment, error)
+}
+
+// FindAttachmentByIdRepository defines the interface for finding an attachment, nil when not found
+type FindAttachmentByIdRepository interface {
+	Find(attachmentId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Attachment, error)
+}
+
+// DeleteAttachmentRepository defines the interface for removing an attachment and its content
+type DeleteAttachmentRepository interface {
+	Delete(attachmentId primitive.ObjectID, workspaceId primitive.ObjectID) error
+}
+
+// DeleteTransactionAttachmentsRepository defines the interface for removing the attachments of a
+// transaction that is being deleted for good. A nil mainCount removes all of them, installments included.
+type DeleteTransactionAttachmentsRepository interface {
+	DeleteByTransaction(transactionId primitive.ObjectID, mainCount *int, workspaceId primitive.ObjectID) error
+}
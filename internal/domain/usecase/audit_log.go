@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateAuditLogRepository defines the interface for appending audit logs
+type CreateAuditLogRepository interface {
+	Create(logs ...models.AuditLog) error
+}
+
+// FindAuditLogsInputRepository holds the filters of the audit log listing; zero values are ignored
+type FindAuditLogsInputRepository struct {
+	WorkspaceId primitive.ObjectID
+	Entity      string
+	EntityId    *primitive.ObjectID
+	From        time.Time
+	To          time.Time
+	Limit       int
+	Offset      int
+}
+
+// FindAuditLogsRepository defines the interface for listing audit logs, most recent first
+type FindAuditLogsRepository interface {
+	Find(filters *FindAuditLogsInputRepository) ([]models.AuditLog, int64, error)
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateBudgetRepository defines the interface for creating budgets
+type CreateBudgetRepository interface {
+	Create(budget *models.Budget) (*models.Budget, error)
+}
+
+// FindBudgetsRepository defines the interface for listing the budgets of a workspace
+type FindBudgetsRepository interface {
+	Find(workspaceId primitive.ObjectID) ([]models.Budget, error)
+}
+
+// FindBudgetByIdRepository defines the interface for finding a budget by ID
+type FindBudgetByIdRepository interface {
+	Find(budgetId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Budget, error)
+}
+
+// FindBudgetByCategoryRepository defines the interface for finding the budget of a category or sub-category
+type FindBudgetByCategoryRepository interface {
+	Find(categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Budget, error)
+}
+
+// UpdateBudgetRepository defines the interface for updating budgets
+type UpdateBudgetRepository interface {
+	Update(budgetId primitive.ObjectID, budget *models.Budget) (*models.Budget, error)
+}
+
+// DeleteBudgetRepository defines the interface for deleting budgets
+type DeleteBudgetRepository interface {
+	Delete(budgetIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
+}
+
+// FindBudgetTrackingRepository defines the interface for comparing budgets against the actual amounts of a month
+type FindBudgetTrackingRepository interface {
+	Find(workspaceId primitive.ObjectID, year int, month int) ([]models.BudgetTracking, error)
+}
@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"errors"
+	"time"
+
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	presentationHelpers "github.com/anuntech/finance-backend/internal/presentation/helpers"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -35,3 +38,22 @@ type UpdateCreditCardRepository interface {
 type DeleteCreditCardRepository interface {
 	Delete(creditCardIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
 }
+
+// FindCreditCardInvoiceRepository defines the interface for computing the invoice of a credit card statement
+type FindCreditCardInvoiceRepository interface {
+	Find(creditCard *models.CreditCard, year int, month int) (*models.CreditCardInvoice, error)
+}
+
+// ErrInvoiceAlreadyPaid is returned by Pay when a purchase of the invoice was confirmed in the meantime,
+// by a concurrent or repeated payment
+var ErrInvoiceAlreadyPaid = errors.New("invoice is already paid")
+
+// PayCreditCardInvoiceRepository defines the interface for settling credit card invoices atomically
+type PayCreditCardInvoiceRepository interface {
+	Pay(pending []models.Transaction, paymentDate time.Time, paymentTransaction *models.Transaction, remainderTransaction *models.Transaction, payment *models.CreditCardInvoicePayment) (*models.CreditCardInvoicePayment, error)
+}
+
+// FindCreditCardInvoicePaymentsRepository defines the interface for retrieving the payments of a credit card statement
+type FindCreditCardInvoicePaymentsRepository interface {
+	Find(creditCardId primitive.ObjectID, period string, workspaceId primitive.ObjectID) ([]models.CreditCardInvoicePayment, error)
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindCurrencySettingsRepository defines the interface for finding the currency settings of a workspace,
+// nil when the base currency was never chosen
+type FindCurrencySettingsRepository interface {
+	Find(workspaceId primitive.ObjectID) (*models.CurrencySettings, error)
+}
+
+// SaveCurrencySettingsRepository defines the interface for saving the currency settings of a workspace
+type SaveCurrencySettingsRepository interface {
+	Save(settings *models.CurrencySettings) (*models.CurrencySettings, error)
+}
+
+// SaveExchangeRatesRepository defines the interface for saving exchange rates, replacing the rate
+// already entered for the same pair and day
+type SaveExchangeRatesRepository interface {
+	Save(rates []models.ExchangeRate) ([]models.ExchangeRate, error)
+}
+
+// FindExchangeRatesFilter narrows the exchange rates listed; empty fields match every rate
+type FindExchangeRatesFilter struct {
+	WorkspaceId primitive.ObjectID
+	From        string
+	To          string
+	InitialDate *time.Time
+	FinalDate   *time.Time
+}
+
+// FindExchangeRatesRepository defines the interface for listing the exchange rates of a workspace
+type FindExchangeRatesRepository interface {
+	Find(filter *FindExchangeRatesFilter) ([]models.ExchangeRate, error)
+}
+
+// DeleteExchangeRatesRepository defines the interface for deleting exchange rates
+type DeleteExchangeRatesRepository interface {
+	Delete(rateIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
+}
+
+// FindExchangeRateTableRepository defines the interface for loading the base currency and all the
+// exchange rates of a workspace to convert amounts
+type FindExchangeRateTableRepository interface {
+	Find(workspaceId primitive.ObjectID) (*models.ExchangeRates, error)
+}
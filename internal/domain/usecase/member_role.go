@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindMemberRolesRepository defines the interface for listing the finance roles given in a workspace
+type FindMemberRolesRepository interface {
+	Find(workspaceId primitive.ObjectID) ([]models.MemberRole, error)
+}
+
+// FindMemberRoleRepository defines the interface for finding the finance role of a member, nil when
+// none was given
+type FindMemberRoleRepository interface {
+	Find(workspaceId primitive.ObjectID, memberId primitive.ObjectID) (*models.MemberRole, error)
+}
+
+// SaveMemberRoleRepository defines the interface for giving a finance role to a member
+type SaveMemberRoleRepository interface {
+	Save(memberRole *models.MemberRole) (*models.MemberRole, error)
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindNfeImportSettingsRepository defines the interface for finding the NF-e import settings of a
+// workspace, nil when they were never configured
+type FindNfeImportSettingsRepository interface {
+	Find(workspaceId primitive.ObjectID) (*models.NfeImportSettings, error)
+}
+
+// SaveNfeImportSettingsRepository defines the interface for saving the NF-e import settings of a workspace
+type SaveNfeImportSettingsRepository interface {
+	Save(settings *models.NfeImportSettings) (*models.NfeImportSettings, error)
+}
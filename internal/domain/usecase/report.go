@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FindCashFlowReportInputRepository struct {
+	WorkspaceId primitive.ObjectID
+	InitialDate time.Time
+	FinalDate   time.Time
+	GroupBy     string // MONTH | WEEK | DAY
+}
+
+type FindCashFlowReportRepository interface {
+	Find(data *FindCashFlowReportInputRepository) (*models.CashFlowReport, error)
+}
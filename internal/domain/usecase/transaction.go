@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"time"
+
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -11,22 +13,61 @@ type CreateTransactionRepository interface {
 }
 
 type FindTransactionsByWorkspaceIdInputRepository struct {
-	Month       int
-	Year        int
-	Type        string
-	InitialDate string
-	FinalDate   string
-	WorkspaceId primitive.ObjectID
-	AccountIds  []primitive.ObjectID
-	Limit       int
-	Offset      int
-	IsSearching bool
+	Month          int
+	Year           int
+	Type           string
+	InitialDate    string
+	FinalDate      string
+	DateType       string // CONFIRMATION | DUE | REGISTRATION | DUE_OR_CONFIRMATION
+	WorkspaceId    primitive.ObjectID
+	AccountIds     []primitive.ObjectID
+	CreditCardIds  []primitive.ObjectID
+	Search         string
+	CategoryIds    []primitive.ObjectID
+	SubCategoryIds []primitive.ObjectID
+	TagIds         []primitive.ObjectID
+	AssignedTo     []primitive.ObjectID
+	CustomFields   []models.TransactionCustomField
+	MinAmount      *models.Money
+	MaxAmount      *models.Money
+	Status         string // CONFIRMED | PENDING | OVERDUE
 }
 
 type FindTransactionsByWorkspaceIdRepository interface {
 	Find(data *FindTransactionsByWorkspaceIdInputRepository) ([]models.Transaction, error)
 }
 
+// TransactionCursor identifies the last item of a page. Expanded installments share the id of the
+// main transaction, so the installment number is part of the key.
+type TransactionCursor struct {
+	Date      time.Time `json:"d"`
+	MainId    string    `json:"i"`
+	MainCount int       `json:"c"`
+}
+
+// FindTransactionsPageInputRepository selects one page of the transactions matched by the filters.
+// With Sort and a DateType the page is ordered by that date, newest first unless Sort is DESC, the
+// same order used with a cursor; otherwise the newest transactions come first.
+type FindTransactionsPageInputRepository struct {
+	FindTransactionsByWorkspaceIdInputRepository
+	Sort   string // ASC | DESC
+	Limit  int
+	Offset int
+	// UseCursor pages with After, the last item of the previous page, instead of Offset
+	UseCursor bool
+	After     *TransactionCursor
+}
+
+type TransactionsPage struct {
+	Transactions []models.Transaction
+	TotalCount   int
+	NextCursor   *TransactionCursor
+}
+
+type FindTransactionsPageRepository interface {
+	FindPage(data *FindTransactionsPageInputRepository) (*TransactionsPage, error)
+}
+
 type FindTransactionByIdRepository interface {
 	Find(transactionId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Transaction, error)
 }
@@ -35,6 +76,10 @@ type UpdateTransactionRepository interface {
 	Update(transactionId primitive.ObjectID, transaction *models.Transaction) (*models.Transaction, error)
 }
 
+type ConfirmTransactionRepository interface {
+	Confirm(transactions []models.Transaction, confirmationDate time.Time) error
+}
+
 type DeleteTransactionRepository interface {
 	Delete(transactionIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
 	DeleteEditTransactions(editTransactionParams []struct {
@@ -43,3 +88,35 @@ type DeleteTransactionRepository interface {
 		WorkspaceId primitive.ObjectID
 	}, findTransactionById FindTransactionByIdRepository) error
 }
+
+type ImportPreviewRepository interface {
+	Save(preview *models.ImportPreview) error
+	Find(workspaceId primitive.ObjectID, token string) (*models.ImportPreview, error)
+	Delete(workspaceId primitive.ObjectID, token string) (bool, error)
+}
+
+// FindDuplicateTransactionsRepository finds the transactions of a workspace with the same
+// models.TransactionFingerprint
+type FindDuplicateTransactionsRepository interface {
+	// FindByFingerprint returns, by fingerprint, the saved transactions that duplicate the given ones
+	FindByFingerprint(workspaceId primitive.ObjectID, transactions []*models.Transaction) (map[string][]models.Transaction, error)
+	FindGroups(workspaceId primitive.ObjectID) ([]models.DuplicateTransactionGroup, error)
+}
+
+// ImportJobRepository keeps the background imports, the lines they process and the queue the
+// workers read from. Lock guarantees a single worker per job, also across server instances.
+type ImportJobRepository interface {
+	Save(job *models.ImportJob) error
+	Find(id string) (*models.ImportJob, error)
+	SaveItems(id string, items []byte) error
+	FindItems(id string) ([]byte, error)
+	Enqueue(id string) error
+	Dequeue(timeout time.Duration) (string, error)
+	FindActive() ([]string, error)
+	Finish(id string) error
+	Cancel(id string) error
+	IsCanceled(id string) (bool, error)
+	Lock(id string, ttl time.Duration) (bool, error)
+	ExtendLock(id string, ttl time.Duration) error
+	Unlock(id string) error
+}
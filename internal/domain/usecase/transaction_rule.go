@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateTransactionRuleRepository defines the interface for creating categorization rules
+type CreateTransactionRuleRepository interface {
+	Create(rule *models.TransactionRule) (*models.TransactionRule, error)
+}
+
+// FindTransactionRulesRepository defines the interface for listing the categorization rules of a workspace by priority
+type FindTransactionRulesRepository interface {
+	Find(workspaceId primitive.ObjectID) ([]models.TransactionRule, error)
+}
+
+// FindTransactionRuleByIdRepository defines the interface for finding a categorization rule by ID
+type FindTransactionRuleByIdRepository interface {
+	Find(ruleId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.TransactionRule, error)
+}
+
+// UpdateTransactionRuleRepository defines the interface for updating categorization rules
+type UpdateTransactionRuleRepository interface {
+	Update(ruleId primitive.ObjectID, rule *models.TransactionRule) (*models.TransactionRule, error)
+}
+
+// DeleteTransactionRuleRepository defines the interface for deleting categorization rules
+type DeleteTransactionRuleRepository interface {
+	Delete(ruleIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
+}
+
+// CategorizeTransactionsFilter restricts the transactions the rules are applied to again. Empty fields are not filtered.
+type CategorizeTransactionsFilter struct {
+	TransactionIds []primitive.ObjectID
+	InitialDate    *time.Time // due date
+	FinalDate      *time.Time
+}
+
+// CategorizeTransactionsRepository defines the interface for reading and saving the classification of existing transactions
+type CategorizeTransactionsRepository interface {
+	Find(workspaceId primitive.ObjectID, filter *CategorizeTransactionsFilter) ([]models.Transaction, error)
+	Update(transactions []models.Transaction) error
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateTransferRepository defines the interface for creating a transfer and its two transactions atomically
+type CreateTransferRepository interface {
+	Create(transfer *models.Transfer, expense *models.Transaction, receipt *models.Transaction) (*models.Transfer, error)
+}
+
+// FindTransfersFilter narrows the transfers listed; empty fields match every transfer
+type FindTransfersFilter struct {
+	WorkspaceId primitive.ObjectID
+	AccountId   *primitive.ObjectID // source or destination account
+	InitialDate *time.Time
+	FinalDate   *time.Time
+}
+
+// FindTransfersRepository defines the interface for listing the transfers of a workspace
+type FindTransfersRepository interface {
+	Find(filter *FindTransfersFilter) ([]models.Transfer, error)
+}
+
+// FindTransferByIdRepository defines the interface for finding a transfer by ID
+type FindTransferByIdRepository interface {
+	Find(transferId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Transfer, error)
+}
+
+// UpdateTransferRepository defines the interface for updating a transfer and its two transactions atomically
+type UpdateTransferRepository interface {
+	Update(transfer *models.Transfer, expense *models.Transaction, receipt *models.Transaction) (*models.Transfer, error)
+}
+
+// DeleteTransfersRepository defines the interface for moving transfers and their transactions to the trash
+type DeleteTransfersRepository interface {
+	Delete(transferIds []primitive.ObjectID, workspaceId primitive.ObjectID) error
+}
+
+// EditTransferRepository defines the interface for editing one occurrence of a repeating transfer, saving the
+// edit_transaction of each of its two transactions atomically
+type EditTransferRepository interface {
+	Edit(expense *models.Transaction, receipt *models.Transaction) error
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindTrashRepository defines the interface for listing the trash of a workspace, most recent first
+type FindTrashRepository interface {
+	Find(workspaceId primitive.ObjectID, entity string) ([]models.TrashItem, error)
+}
+
+// RestoreTrashOutputRepository holds the result of a restore. Items whose name is now taken by another
+// record are left in the trash as conflicts.
+type RestoreTrashOutputRepository struct {
+	Restored  []models.TrashItem
+	Conflicts []models.TrashItem
+}
+
+// RestoreTrashRepository defines the interface for restoring trash items and re-linking their references
+type RestoreTrashRepository interface {
+	Restore(trashIds []primitive.ObjectID, workspaceId primitive.ObjectID) (*RestoreTrashOutputRepository, error)
+}
+
+// PurgeTrashRepository defines the interface for permanently removing the items deleted before the given time
+type PurgeTrashRepository interface {
+	Purge(deletedBefore time.Time) (int, error)
+}
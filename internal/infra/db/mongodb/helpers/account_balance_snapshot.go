@@ -0,0 +1,248 @@
+package helpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Os snapshots guardam apenas a movimentação das transações até o fim do mês, sem o saldo inicial da conta,
+// assim editar a conta não invalida o ledger.
+// A versão da conta é incrementada a cada invalidação e um snapshot só é válido se foi calculado com a versão atual,
+// o que evita gravar um saldo calculado antes de uma escrita concorrente.
+
+// AccountBalanceVersions retorna a versão atual do saldo de cada conta
+func AccountBalanceVersions(db *mongo.Database, workspaceId primitive.ObjectID, accountIds []primitive.ObjectID) (map[primitive.ObjectID]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cursor, err := db.Collection("account_balance_version").Find(ctx, bson.M{
+		"workspace_id": workspaceId,
+		"account_id":   bson.M{"$in": accountIds},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var versions []struct {
+		AccountId primitive.ObjectID `bson:"account_id"`
+		Version   int64              `bson:"version"`
+	}
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	result := make(map[primitive.ObjectID]int64, len(accountIds))
+	for _, version := range versions {
+		result[version.AccountId] = version.Version
+	}
+
+	return result, nil
+}
+
+// FindAccountBalanceSnapshots retorna os snapshots válidos (na versão atual) das contas no mês informado
+func FindAccountBalanceSnapshots(db *mongo.Database, workspaceId primitive.ObjectID, accountIds []primitive.ObjectID, year, month int, versions map[primitive.ObjectID]int64) (map[primitive.ObjectID]models.AccountBalanceSnapshot, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cursor, err := db.Collection("account_balance_snapshot").Find(ctx, bson.M{
+		"workspace_id": workspaceId,
+		"account_id":   bson.M{"$in": accountIds},
+		"year":         year,
+		"month":        month,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var snapshots []models.AccountBalanceSnapshot
+	if err := cursor.All(ctx, &snapshots); err != nil {
+		return nil, err
+	}
+
+	result := make(map[primitive.ObjectID]models.AccountBalanceSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Version != versions[snapshot.AccountId] {
+			continue
+		}
+		result[snapshot.AccountId] = snapshot
+	}
+
+	return result, nil
+}
+
+// SaveAccountBalanceSnapshots grava (ou substitui) os snapshots do mês
+func SaveAccountBalanceSnapshots(db *mongo.Database, snapshots []models.AccountBalanceSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	writes := make([]mongo.WriteModel, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{
+				"workspace_id": snapshot.WorkspaceId,
+				"account_id":   snapshot.AccountId,
+				"year":         snapshot.Year,
+				"month":        snapshot.Month,
+			}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"balance":         snapshot.Balance,
+					"current_balance": snapshot.CurrentBalance,
+					"version":         snapshot.Version,
+					"updated_at":      snapshot.UpdatedAt,
+				},
+				"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+			}).
+			SetUpsert(true))
+	}
+
+	_, err := db.Collection("account_balance_snapshot").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// DeleteAccountBalanceSnapshots remove todos os snapshots das contas. Sem contas, remove os do workspace inteiro.
+func DeleteAccountBalanceSnapshots(db *mongo.Database, workspaceId primitive.ObjectID, accountIds []primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	filter := bson.M{"workspace_id": workspaceId}
+	if len(accountIds) > 0 {
+		filter["account_id"] = bson.M{"$in": accountIds}
+	}
+
+	if _, err := db.Collection("account_balance_snapshot").DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("account_balance_version").DeleteMany(ctx, filter)
+	return err
+}
+
+// InvalidateAccountBalanceSnapshots descarta os snapshots de saldo das contas a partir do mês da data informada
+func InvalidateAccountBalanceSnapshots(db *mongo.Database, workspaceId primitive.ObjectID, accountIds []primitive.ObjectID, from time.Time) error {
+	if len(accountIds) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	from = from.UTC()
+	affectedMonths := bson.M{"$or": []bson.M{
+		{"year": bson.M{"$gt": from.Year()}},
+		{"year": from.Year(), "month": bson.M{"$gte": int(from.Month())}},
+	}}
+
+	for _, accountId := range accountIds {
+		filter := bson.M{"workspace_id": workspaceId, "account_id": accountId}
+
+		var version struct {
+			Version int64 `bson:"version"`
+		}
+		err := db.Collection("account_balance_version").FindOneAndUpdate(ctx, filter,
+			bson.M{"$inc": bson.M{"version": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&version)
+		if err != nil {
+			return err
+		}
+
+		snapshotCollection := db.Collection("account_balance_snapshot")
+		if _, err := snapshotCollection.DeleteMany(ctx, bson.M{"$and": []bson.M{filter, affectedMonths}}); err != nil {
+			return err
+		}
+
+		// os meses anteriores à alteração continuam válidos e passam para a nova versão
+		unaffectedMonths := bson.M{"$and": []bson.M{filter, {"$nor": []bson.M{affectedMonths}}}}
+		if _, err := snapshotCollection.UpdateMany(ctx, unaffectedMonths, bson.M{"$set": bson.M{"version": version.Version}}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InvalidateTransactionsBalanceSnapshots descarta os snapshots das contas afetadas pelas transações,
+// a partir da data mais antiga (vencimento ou confirmação) entre elas
+func InvalidateTransactionsBalanceSnapshots(db *mongo.Database, transactions ...*models.Transaction) error {
+	type invalidation struct {
+		accountIds map[primitive.ObjectID]bool
+		from       time.Time
+	}
+
+	byWorkspace := make(map[primitive.ObjectID]*invalidation)
+
+	for _, tx := range transactions {
+		if tx == nil || tx.AccountId == nil {
+			continue
+		}
+
+		item, exists := byWorkspace[tx.WorkspaceId]
+		if !exists {
+			item = &invalidation{accountIds: make(map[primitive.ObjectID]bool), from: tx.DueDate}
+			byWorkspace[tx.WorkspaceId] = item
+		}
+		item.accountIds[*tx.AccountId] = true
+
+		if tx.DueDate.Before(item.from) {
+			item.from = tx.DueDate
+		}
+		if tx.ConfirmationDate != nil && tx.ConfirmationDate.Before(item.from) {
+			item.from = *tx.ConfirmationDate
+		}
+	}
+
+	for workspaceId, item := range byWorkspace {
+		accountIds := make([]primitive.ObjectID, 0, len(item.accountIds))
+		for accountId := range item.accountIds {
+			accountIds = append(accountIds, accountId)
+		}
+
+		if err := InvalidateAccountBalanceSnapshots(db, workspaceId, accountIds, item.from); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InvalidateMainTransactionsBalanceSnapshots invalida os snapshots a partir das transações principais de parcelas
+// editadas, já que a data de vencimento da principal é a da primeira ocorrência da série
+func InvalidateMainTransactionsBalanceSnapshots(db *mongo.Database, workspaceId primitive.ObjectID, mainIds []primitive.ObjectID, edits ...*models.Transaction) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	cursor, err := db.Collection("transaction").Find(ctx, bson.M{
+		"_id":          bson.M{"$in": mainIds},
+		"workspace_id": workspaceId,
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var mainTransactions []models.Transaction
+	if err := cursor.All(ctx, &mainTransactions); err != nil {
+		return err
+	}
+
+	transactions := make([]*models.Transaction, 0, len(mainTransactions)+len(edits))
+	for i := range mainTransactions {
+		transactions = append(transactions, &mainTransactions[i])
+	}
+	transactions = append(transactions, edits...)
+
+	return InvalidateTransactionsBalanceSnapshots(db, transactions...)
+}
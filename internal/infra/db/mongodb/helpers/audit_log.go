@@ -0,0 +1,152 @@
+package helpers
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// campos que mudam em toda gravação e não dizem nada sobre a alteração
+var auditIgnoredFields = map[string]bool{
+	"_id":        true,
+	"updated_at": true,
+}
+
+// NewAuditLog monta o registro de auditoria de uma operação. before é nil na criação e after é nil
+// na remoção; na atualização apenas os campos alterados são registrados.
+func NewAuditLog(workspaceId, userId primitive.ObjectID, entity string, entityId primitive.ObjectID, action string, before, after any) models.AuditLog {
+	return models.AuditLog{
+		Id:          primitive.NewObjectID(),
+		WorkspaceId: workspaceId,
+		UserId:      userId,
+		Entity:      entity,
+		EntityId:    entityId,
+		Action:      action,
+		Changes:     AuditChanges(before, after),
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+// NewTransactionAuditLog registra a alteração de uma transação. Quando a transação é uma parcela
+// editada (edit_transaction), o registro fica na transação principal com o número da parcela.
+func NewTransactionAuditLog(userId primitive.ObjectID, action string, before, after *models.Transaction) models.AuditLog {
+	transaction := after
+	if transaction == nil {
+		transaction = before
+	}
+
+	entityId := transaction.Id
+	if transaction.MainId != nil {
+		entityId = *transaction.MainId
+	}
+
+	var beforeValue, afterValue any
+	if before != nil {
+		beforeValue = before
+	}
+	if after != nil {
+		afterValue = after
+	}
+
+	log := NewAuditLog(transaction.WorkspaceId, userId, "TRANSACTION", entityId, action, beforeValue, afterValue)
+	log.MainCount = transaction.MainCount
+	return log
+}
+
+type auditSubCategory struct {
+	CategoryId                 primitive.ObjectID `bson:"category_id"`
+	models.SubCategoryCategory `bson:",inline"`
+}
+
+// NewSubCategoryAuditLog registra a alteração de uma subcategoria como uma entidade própria, junto com
+// o id da categoria a que pertence
+func NewSubCategoryAuditLog(workspaceId, userId, categoryId primitive.ObjectID, action string, before, after *models.SubCategoryCategory) models.AuditLog {
+	subCategory := after
+	if subCategory == nil {
+		subCategory = before
+	}
+
+	var beforeValue, afterValue any
+	if before != nil {
+		beforeValue = auditSubCategory{CategoryId: categoryId, SubCategoryCategory: *before}
+	}
+	if after != nil {
+		afterValue = auditSubCategory{CategoryId: categoryId, SubCategoryCategory: *after}
+	}
+
+	return NewAuditLog(workspaceId, userId, "SUB_CATEGORY", subCategory.Id, action, beforeValue, afterValue)
+}
+
+// AuditChanges compara os documentos como são gravados no banco, campo a campo no primeiro nível
+func AuditChanges(before, after any) []models.AuditLogChange {
+	beforeDocument := auditDocument(before)
+	afterDocument := auditDocument(after)
+
+	fields := map[string]bool{}
+	for field := range beforeDocument {
+		fields[field] = true
+	}
+	for field := range afterDocument {
+		fields[field] = true
+	}
+
+	changes := []models.AuditLogChange{}
+	for field := range fields {
+		if auditIgnoredFields[field] {
+			continue
+		}
+		// na atualização a data de criação não muda; na criação e na remoção ela faz parte do registro
+		if field == "created_at" && beforeDocument != nil && afterDocument != nil {
+			continue
+		}
+
+		beforeValue, afterValue := beforeDocument[field], afterDocument[field]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		changes = append(changes, models.AuditLogChange{
+			Field:  field,
+			Before: beforeValue,
+			After:  afterValue,
+		})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func auditDocument(value any) bson.M {
+	if value == nil {
+		return nil
+	}
+	if reflected := reflect.ValueOf(value); reflected.Kind() == reflect.Pointer && reflected.IsNil() {
+		return nil
+	}
+
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	decoder, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(data))
+	if err != nil {
+		return nil
+	}
+	decoder.DefaultDocumentM()
+
+	var document bson.M
+	if err := decoder.Decode(&document); err != nil {
+		return nil
+	}
+
+	return document
+}
@@ -0,0 +1,111 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CategoryAmounts guarda os valores acumulados (Amount e CurrentAmount) de categorias e subcategorias até o fim de um mês.
+// O total da categoria inclui as transações sem subcategoria.
+type CategoryAmounts struct {
+	Types   map[primitive.ObjectID]string
+	Amounts map[string]categoryAmount
+}
+
+type categoryAmount struct {
+	Amount        models.Money
+	CurrentAmount models.Money
+}
+
+func budgetKey(categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID) string {
+	if subCategoryId == nil {
+		return categoryId.Hex()
+	}
+	return categoryId.Hex() + "_" + subCategoryId.Hex()
+}
+
+// NewCategoryAmounts indexa o tipo das categorias, com os valores zerados até serem somados por Add
+func NewCategoryAmounts(categories []models.Category) *CategoryAmounts {
+	amounts := &CategoryAmounts{
+		Types:   make(map[primitive.ObjectID]string, len(categories)),
+		Amounts: make(map[string]categoryAmount),
+	}
+
+	for _, category := range categories {
+		amounts.Types[category.Id] = category.Type
+	}
+
+	return amounts
+}
+
+// Add soma os valores ao total da categoria e, quando informada, ao da subcategoria
+func (a *CategoryAmounts) Add(categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID, amount, currentAmount models.Money) {
+	keys := []string{budgetKey(categoryId, nil)}
+	if subCategoryId != nil {
+		keys = append(keys, budgetKey(categoryId, subCategoryId))
+	}
+
+	for _, key := range keys {
+		total := a.Amounts[key]
+		total.Amount += amount
+		total.CurrentAmount += currentAmount
+		a.Amounts[key] = total
+	}
+}
+
+// BudgetPeriod retorna o início e o fim do período do orçamento que contém o mês informado
+func BudgetPeriod(interval string, year int, month time.Month) (time.Time, time.Time) {
+	if interval == "YEARLY" {
+		start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(1, 0, 0).Add(-time.Second)
+	}
+
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0).Add(-time.Second)
+}
+
+// BudgetPeriodSpending retorna quanto foi previsto e realizado no período a partir dos acumulados do fim do período e do fim do período anterior.
+// Despesas são acumuladas com sinal negativo, então o valor é invertido para que o consumo seja positivo; em categorias de receita o orçamento é uma meta.
+func BudgetPeriodSpending(budget *models.Budget, categoryType string, end, previousEnd *CategoryAmounts) (forecast models.Money, realized models.Money) {
+	key := budgetKey(budget.CategoryId, budget.SubCategoryId)
+	current := end.Amounts[key]
+	previous := previousEnd.Amounts[key]
+
+	forecast = current.Amount - previous.Amount
+	realized = current.CurrentAmount - previous.CurrentAmount
+
+	if categoryType != "RECIPE" {
+		forecast, realized = -forecast, -realized
+	}
+
+	return forecast, realized
+}
+
+// BuildBudgetTracking compara o orçamento com os valores do período. O saldo não utilizado do período anterior
+// é somado apenas um nível, sem acumular os períodos anteriores a ele.
+func BuildBudgetTracking(budget *models.Budget, year int, month time.Month, forecast, realized, previousRealized models.Money) models.BudgetTracking {
+	periodStart, periodEnd := BudgetPeriod(budget.Interval, year, month)
+
+	tracking := models.BudgetTracking{
+		Budget:      *budget,
+		PeriodStart: periodStart,
+		PeriodEnd:   periodEnd,
+		Planned:     budget.Amount,
+		Forecast:    forecast,
+		Realized:    realized,
+	}
+
+	if budget.CarryOver {
+		tracking.CarriedOver = max(budget.Amount-previousRealized, 0)
+		tracking.Planned += tracking.CarriedOver
+	}
+
+	if tracking.Planned > 0 {
+		tracking.PercentageConsumed = float64(realized) / float64(tracking.Planned) * 100
+		tracking.ForecastPercentage = float64(forecast) / float64(tracking.Planned) * 100
+	}
+
+	return tracking
+}
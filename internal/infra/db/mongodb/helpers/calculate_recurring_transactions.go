@@ -10,23 +10,32 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func CalculateRecurringTransactionsBalance(transactions []models.Transaction, year int, month int, db *mongo.Database, isConfirmed bool) float64 {
-	var balance float64
+func CalculateRecurringTransactionsBalance(transactions []models.Transaction, year int, month int, db *mongo.Database, isConfirmed bool) models.Money {
+	var balance models.Money
 	editCollection := db.Collection("edit_transaction")
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	for _, t := range transactions {
 		wg.Add(1)
 		go func(t models.Transaction) {
 			defer wg.Done()
+
+			var transactionBalance models.Money
+			defer func() {
+				mu.Lock()
+				balance += transactionBalance
+				mu.Unlock()
+			}()
+
 			var refDate time.Time
 			if !t.IsConfirmed {
 				refDate = t.DueDate
 			} else {
 				refDate = *t.ConfirmationDate
 			}
-			currentCount := MonthsBetween(refDate, year, month) + 1
+			currentCount := RecurringOccurrencesUntil(&t, refDate, year, month)
 
 			// Find all edits for this transaction with main_count <= currentCount
 			cursor, err := editCollection.Find(context.Background(), bson.M{
@@ -41,19 +50,7 @@ func CalculateRecurringTransactionsBalance(transactions []models.Transaction, ye
 				if err := cursor.All(context.Background(), &editTransactions); err == nil && len(editTransactions) > 0 {
 					// Apply the balance adjustments for each edit
 					for _, editTransaction := range editTransactions {
-						oneRecurringValue := CalculateOneTransactionBalance(&t)
-
-						if !isConfirmed {
-							balance += CalculateOneTransactionBalance(&editTransaction) - oneRecurringValue
-							continue
-						}
-
-						if editTransaction.IsConfirmed {
-							balance += CalculateOneTransactionBalance(&editTransaction) - oneRecurringValue
-							continue
-						}
-						balance -= oneRecurringValue
-
+						transactionBalance += editBalanceAdjustment(&t, &editTransaction, CalculateOneTransactionBalance(&t), isConfirmed)
 					}
 
 				}
@@ -63,8 +60,7 @@ func CalculateRecurringTransactionsBalance(transactions []models.Transaction, ye
 				return
 			}
 
-			months := MonthsBetween(refDate, year, month)
-			balance += (float64(months) + 1) * CalculateOneTransactionBalance(&t)
+			transactionBalance += models.Money(currentCount) * CalculateOneTransactionBalance(&t)
 		}(t)
 	}
 
@@ -72,3 +68,15 @@ func CalculateRecurringTransactionsBalance(transactions []models.Transaction, ye
 
 	return balance
 }
+
+// RecurringOccurrencesUntil retorna quantas ocorrências da transação recorrente existem até o fim do mês filtrado.
+// Intervalos diário, semanal e quinzenal podem ter mais de uma ocorrência no mês; os demais contam uma por mês.
+func RecurringOccurrencesUntil(t *models.Transaction, refDate time.Time, year int, month int) int {
+	if t.RepeatSettings != nil {
+		if step := IntervalDays(t.RepeatSettings.Interval, t.RepeatSettings.CustomDay); step > 0 {
+			return StepsBetween(refDate, year, month, step) + 1
+		}
+	}
+
+	return MonthsBetween(refDate, year, month) + 1
+}
@@ -0,0 +1,98 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCalculateRecurringTransactionsBalance(t *testing.T) {
+	// 20.00 every month from January 5th
+	monthly := func(workspaceId primitive.ObjectID) models.Transaction {
+		return expense(workspaceId, 2000, "RECURRING", "MONTHLY", 0, date(2025, time.January, 5))
+	}
+	weekly := func(workspaceId primitive.ObjectID) models.Transaction {
+		return expense(workspaceId, 2000, "RECURRING", "WEEKLY", 0, date(2025, time.January, 1))
+	}
+
+	tests := []struct {
+		name        string
+		transaction func(primitive.ObjectID) models.Transaction
+		confirmed   bool
+		edits       []occurrenceEdit
+		month       int
+		isConfirmed bool
+		want        models.Money
+	}{
+		{name: "first occurrence", transaction: monthly, month: 1, want: -2000},
+		{name: "occurrences until the month", transaction: monthly, month: 3, want: -6000},
+		{name: "weekly occurrences in the month", transaction: weekly, month: 1, want: -10000},
+		{
+			name:        "edited occurrence",
+			transaction: monthly,
+			edits:       []occurrenceEdit{{mainCount: 2, value: 3500}},
+			month:       3,
+			want:        -2000 - 3500 - 2000,
+		},
+		{
+			name:        "edit of a later occurrence",
+			transaction: monthly,
+			edits:       []occurrenceEdit{{mainCount: 4, value: 3500}},
+			month:       3,
+			want:        -6000,
+		},
+		{
+			name:        "deleted occurrence",
+			transaction: monthly,
+			edits:       []occurrenceEdit{{mainCount: 2, isDeleted: true}},
+			month:       3,
+			want:        -4000,
+		},
+		{
+			name:        "confirmed edit of an unconfirmed occurrence in the current balance",
+			transaction: monthly,
+			edits:       []occurrenceEdit{{mainCount: 2, value: 3500, isConfirmed: true}},
+			month:       3,
+			isConfirmed: true,
+			want:        -3500,
+		},
+		{
+			name:        "unconfirmed edit of an unconfirmed occurrence in the current balance",
+			transaction: monthly,
+			edits:       []occurrenceEdit{{mainCount: 2, value: 3500}},
+			month:       3,
+			isConfirmed: true,
+			want:        0,
+		},
+		{
+			name:        "unconfirmed edit of a confirmed occurrence in the current balance",
+			transaction: monthly,
+			confirmed:   true,
+			edits:       []occurrenceEdit{{mainCount: 2, value: 3500}},
+			month:       3,
+			isConfirmed: true,
+			want:        -4000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mongotest.Database(t)
+			workspaceId := primitive.NewObjectID()
+
+			main := tt.transaction(workspaceId)
+			if tt.confirmed {
+				main = confirmed(main)
+			}
+			mongotest.Insert(t, db, "edit_transaction", editDocs(main, tt.edits)...)
+
+			got := CalculateRecurringTransactionsBalance([]models.Transaction{main}, 2025, tt.month, db, tt.isConfirmed)
+			if got != tt.want {
+				t.Errorf("CalculateRecurringTransactionsBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,16 +10,25 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func CalculateRepeatTransactionsBalance(transactions []models.Transaction, year int, month int, db *mongo.Database, isConfirmed bool) float64 {
-	var balance float64
+func CalculateRepeatTransactionsBalance(transactions []models.Transaction, year int, month int, db *mongo.Database, isConfirmed bool) models.Money {
+	var balance models.Money
 	editCollection := db.Collection("edit_transaction")
 
 	var wg sync.WaitGroup
+	var mu sync.Mutex
 
 	for _, t := range transactions {
 		wg.Add(1)
 		go func(t models.Transaction) {
 			defer wg.Done()
+
+			var transactionBalance models.Money
+			defer func() {
+				mu.Lock()
+				balance += transactionBalance
+				mu.Unlock()
+			}()
+
 			// Get the current count for this transaction in the specified month
 			currentCount := CalculateCurrentCount(&t, year, month)
 
@@ -36,18 +45,7 @@ func CalculateRepeatTransactionsBalance(transactions []models.Transaction, year
 				if err := cursor.All(context.Background(), &editTransactions); err == nil && len(editTransactions) > 0 {
 					// Apply the balance adjustments for each edit
 					for _, editTransaction := range editTransactions {
-						oneRecurringValue := CalculateOneTransactionBalance(&t) / float64(t.RepeatSettings.Count)
-						if !isConfirmed {
-							balance += CalculateOneTransactionBalance(&editTransaction) - oneRecurringValue
-							continue
-						}
-
-						if editTransaction.IsConfirmed {
-							balance += CalculateOneTransactionBalance(&editTransaction)
-							continue
-						}
-						balance -= oneRecurringValue
-
+						transactionBalance += editBalanceAdjustment(&t, &editTransaction, installmentBalance(&t, editTransaction.MainCount), isConfirmed)
 					}
 				}
 			}
@@ -66,11 +64,19 @@ func CalculateRepeatTransactionsBalance(transactions []models.Transaction, year
 
 			switch t.RepeatSettings.Interval {
 			case "MONTHLY":
-				balance += repeatMonthlyTransaction(&t, refDate, year, month)
+				transactionBalance += repeatMonthlyTransaction(&t, refDate, year, month)
 			case "YEARLY":
-				balance += repeatYearlyTransaction(&t, refDate, year)
+				transactionBalance += repeatYearlyTransaction(&t, refDate, year)
 			case "QUARTERLY":
-				balance += repeatQuarterlyTransaction(&t, refDate, year, month)
+				transactionBalance += repeatQuarterlyTransaction(&t, refDate, year, month)
+			case "DAILY", "WEEKLY", "BIWEEKLY":
+				transactionBalance += repeatDaysTransaction(&t, refDate, year, month)
+			case "CUSTOM":
+				if IntervalDays(t.RepeatSettings.Interval, t.RepeatSettings.CustomDay) > 0 {
+					transactionBalance += repeatDaysTransaction(&t, refDate, year, month)
+				} else {
+					transactionBalance += repeatMonthlyTransaction(&t, refDate, year, month)
+				}
 			}
 		}(t)
 	}
@@ -80,41 +86,58 @@ func CalculateRepeatTransactionsBalance(transactions []models.Transaction, year
 	return balance
 }
 
-func repeatMonthlyTransaction(t *models.Transaction, refDate time.Time, year int, month int) float64 {
+func repeatMonthlyTransaction(t *models.Transaction, refDate time.Time, year int, month int) models.Money {
 	monthsBetween := MonthsBetween(refDate, year, month)
 
 	effectiveInstallment := int(t.RepeatSettings.InitialInstallment) + monthsBetween
 
-	if effectiveInstallment >= t.RepeatSettings.Count {
-		return CalculateOneTransactionBalance(t)
-	}
-
-	installmentValue := CalculateOneTransactionBalance(t) / float64(t.RepeatSettings.Count)
-	return installmentValue * float64(effectiveInstallment)
+	return CalculateOneTransactionBalance(t).Installments(effectiveInstallment, t.RepeatSettings.Count)
 }
 
-func repeatYearlyTransaction(t *models.Transaction, refDate time.Time, year int) float64 {
+func repeatYearlyTransaction(t *models.Transaction, refDate time.Time, year int) models.Money {
 	yearsBetween := YearsBetween(refDate, year)
 
 	effectiveInstallment := int(t.RepeatSettings.InitialInstallment) + yearsBetween
 
-	if effectiveInstallment >= t.RepeatSettings.Count {
-		return CalculateOneTransactionBalance(t)
-	}
-
-	installmentValue := CalculateOneTransactionBalance(t) / float64(t.RepeatSettings.Count)
-	return installmentValue * float64(effectiveInstallment)
+	return CalculateOneTransactionBalance(t).Installments(effectiveInstallment, t.RepeatSettings.Count)
 }
 
-func repeatQuarterlyTransaction(t *models.Transaction, refDate time.Time, year int, month int) float64 {
+func repeatQuarterlyTransaction(t *models.Transaction, refDate time.Time, year int, month int) models.Money {
 	quartersBetween := QuartersBetween(refDate, year, month)
 
 	effectiveInstallment := int(t.RepeatSettings.InitialInstallment) + quartersBetween
 
-	if effectiveInstallment >= t.RepeatSettings.Count {
-		return CalculateOneTransactionBalance(t)
+	return CalculateOneTransactionBalance(t).Installments(effectiveInstallment, t.RepeatSettings.Count)
+}
+
+func repeatDaysTransaction(t *models.Transaction, refDate time.Time, year int, month int) models.Money {
+	step := IntervalDays(t.RepeatSettings.Interval, t.RepeatSettings.CustomDay)
+	stepsBetween := StepsBetween(refDate, year, month, step)
+
+	effectiveInstallment := int(t.RepeatSettings.InitialInstallment) + stepsBetween
+
+	return CalculateOneTransactionBalance(t).Installments(effectiveInstallment, t.RepeatSettings.Count)
+}
+
+// editBalanceAdjustment retorna quanto a edição de uma ocorrência muda o saldo calculado pela transação principal:
+// a ocorrência deixa de valer o valor original e passa a valer o da edição. No saldo atual cada lado só conta
+// quando está confirmado, e a ocorrência excluída não vale nada.
+func editBalanceAdjustment(t *models.Transaction, editTransaction *models.Transaction, occurrenceValue models.Money, isConfirmed bool) models.Money {
+	var adjustment models.Money
+	if !editTransaction.IsDeleted && (!isConfirmed || editTransaction.IsConfirmed) {
+		adjustment += CalculateOneTransactionBalance(editTransaction)
+	}
+	if !isConfirmed || t.IsConfirmed {
+		adjustment -= occurrenceValue
 	}
+	return adjustment
+}
 
-	installmentValue := CalculateOneTransactionBalance(t) / float64(t.RepeatSettings.Count)
-	return installmentValue * float64(effectiveInstallment)
+// installmentBalance retorna o valor da parcela informada; a última parcela absorve a sobra da divisão
+func installmentBalance(t *models.Transaction, mainCount *int) models.Money {
+	number := 1
+	if mainCount != nil {
+		number = *mainCount
+	}
+	return CalculateOneTransactionBalance(t).Installment(number, t.RepeatSettings.Count)
 }
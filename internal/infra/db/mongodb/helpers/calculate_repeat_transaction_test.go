@@ -0,0 +1,231 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func expense(workspaceId primitive.ObjectID, value models.Money, frequency string, interval string, count int, dueDate time.Time) models.Transaction {
+	return models.Transaction{
+		Id:          primitive.NewObjectID(),
+		WorkspaceId: workspaceId,
+		Name:        "Aluguel",
+		Type:        "EXPENSE",
+		Frequency:   frequency,
+		RepeatSettings: &models.TransactionRepeatSettings{
+			InitialInstallment: 1,
+			Count:              count,
+			Interval:           interval,
+		},
+		Balance: models.TransactionBalance{Value: value},
+		DueDate: dueDate,
+	}
+}
+
+func confirmed(t models.Transaction) models.Transaction {
+	confirmationDate := t.DueDate
+	t.IsConfirmed = true
+	t.ConfirmationDate = &confirmationDate
+	return t
+}
+
+// occurrenceEdit is the edit_transaction of occurrence mainCount, like the edit and delete controllers save it
+type occurrenceEdit struct {
+	mainCount   int
+	value       models.Money
+	isConfirmed bool
+	isDeleted   bool
+}
+
+func (e occurrenceEdit) transaction(main models.Transaction) models.Transaction {
+	edit := main
+	edit.Id = primitive.NewObjectID()
+	edit.MainId = &main.Id
+	edit.MainCount = &e.mainCount
+	edit.Balance = models.TransactionBalance{Value: e.value}
+	edit.IsConfirmed = e.isConfirmed
+	edit.ConfirmationDate = nil
+	if e.isConfirmed {
+		edit.ConfirmationDate = &main.DueDate
+	}
+	edit.IsDeleted = e.isDeleted
+	return edit
+}
+
+func editDocs(main models.Transaction, edits []occurrenceEdit) []any {
+	docs := make([]any, len(edits))
+	for i, edit := range edits {
+		docs[i] = edit.transaction(main)
+	}
+	return docs
+}
+
+func TestCalculateRepeatTransactionsBalance(t *testing.T) {
+	// 100.00 in 3 monthly installments of 33.33, 33.33 and 33.34, the first due on January 10th
+	installments := func(workspaceId primitive.ObjectID) models.Transaction {
+		return expense(workspaceId, 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+	}
+
+	tests := []struct {
+		name        string
+		confirmed   bool
+		edits       []occurrenceEdit
+		month       int
+		isConfirmed bool
+		want        models.Money
+	}{
+		{name: "first installment", month: 1, want: -3333},
+		{name: "second installment", month: 2, want: -6666},
+		{name: "last installment absorbs the remainder", month: 3, want: -10000},
+		{name: "after the last installment", month: 6, want: -10000},
+		{
+			name:  "edited installment",
+			edits: []occurrenceEdit{{mainCount: 2, value: 5000}},
+			month: 2,
+			want:  -3333 - 5000,
+		},
+		{
+			name:  "edit of a later installment",
+			edits: []occurrenceEdit{{mainCount: 3, value: 5000}},
+			month: 2,
+			want:  -6666,
+		},
+		{
+			name:  "deleted installment",
+			edits: []occurrenceEdit{{mainCount: 2, isDeleted: true}},
+			month: 3,
+			want:  -3333 - 3334,
+		},
+		{
+			name:  "edited and deleted installments",
+			edits: []occurrenceEdit{{mainCount: 1, value: 1000}, {mainCount: 3, isDeleted: true}},
+			month: 4,
+			want:  -1000 - 3333,
+		},
+		{name: "unconfirmed installments in the current balance", month: 3, isConfirmed: true, want: 0},
+		{name: "confirmed installments in the current balance", confirmed: true, month: 2, isConfirmed: true, want: -6666},
+		{
+			name:        "confirmed edit of an unconfirmed installment in the current balance",
+			edits:       []occurrenceEdit{{mainCount: 1, value: 4000, isConfirmed: true}},
+			month:       2,
+			isConfirmed: true,
+			want:        -4000,
+		},
+		{
+			name:        "unconfirmed edit of an unconfirmed installment in the current balance",
+			edits:       []occurrenceEdit{{mainCount: 1, value: 4000}},
+			month:       2,
+			isConfirmed: true,
+			want:        0,
+		},
+		{
+			name:        "confirmed edit of a confirmed installment in the current balance",
+			confirmed:   true,
+			edits:       []occurrenceEdit{{mainCount: 2, value: 5000, isConfirmed: true}},
+			month:       2,
+			isConfirmed: true,
+			want:        -3333 - 5000,
+		},
+		{
+			name:        "deleted installment in the current balance",
+			confirmed:   true,
+			edits:       []occurrenceEdit{{mainCount: 1, isDeleted: true}},
+			month:       2,
+			isConfirmed: true,
+			want:        -3333,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mongotest.Database(t)
+			workspaceId := primitive.NewObjectID()
+
+			main := installments(workspaceId)
+			if tt.confirmed {
+				main = confirmed(main)
+			}
+			mongotest.Insert(t, db, "edit_transaction", editDocs(main, tt.edits)...)
+
+			got := CalculateRepeatTransactionsBalance([]models.Transaction{main}, 2025, tt.month, db, tt.isConfirmed)
+			if got != tt.want {
+				t.Errorf("CalculateRepeatTransactionsBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRepeatTransactionsBalanceIntervals(t *testing.T) {
+	tests := []struct {
+		name        string
+		transaction models.Transaction
+		year, month int
+		want        models.Money
+	}{
+		{
+			name:        "weekly installments due in the month",
+			transaction: expense(primitive.NilObjectID, 1000, "REPEAT", "WEEKLY", 10, date(2025, time.January, 1)),
+			year:        2025, month: 1,
+			want: -500,
+		},
+		{
+			name:        "custom interval in days",
+			transaction: customInterval(expense(primitive.NilObjectID, 1000, "REPEAT", "CUSTOM", 4, date(2025, time.January, 1)), 10),
+			year:        2025, month: 1,
+			want: -1000,
+		},
+		{
+			name:        "yearly installments",
+			transaction: expense(primitive.NilObjectID, 9000, "REPEAT", "YEARLY", 3, date(2024, time.March, 1)),
+			year:        2025, month: 1,
+			want: -6000,
+		},
+		{
+			name:        "quarterly installments",
+			transaction: expense(primitive.NilObjectID, 4000, "REPEAT", "QUARTERLY", 4, date(2025, time.January, 1)),
+			year:        2025, month: 7,
+			want: -3000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mongotest.Database(t)
+
+			got := CalculateRepeatTransactionsBalance([]models.Transaction{tt.transaction}, tt.year, tt.month, db, false)
+			if got != tt.want {
+				t.Errorf("CalculateRepeatTransactionsBalance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRepeatTransactionsBalanceSumsTransactions(t *testing.T) {
+	db := mongotest.Database(t)
+	workspaceId := primitive.NewObjectID()
+
+	var transactions []models.Transaction
+	for i := 0; i < 20; i++ {
+		transactions = append(transactions, expense(workspaceId, 3000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10)))
+	}
+	edited := transactions[0]
+	mongotest.Insert(t, db, "edit_transaction", occurrenceEdit{mainCount: 1, value: 500}.transaction(edited))
+
+	got := CalculateRepeatTransactionsBalance(transactions, 2025, 1, db, false)
+	if want := models.Money(-1000*19 - 500); got != want {
+		t.Errorf("CalculateRepeatTransactionsBalance() = %v, want %v", got, want)
+	}
+}
+
+func customInterval(t models.Transaction, days int) models.Transaction {
+	t.RepeatSettings.CustomDay = days
+	return t
+}
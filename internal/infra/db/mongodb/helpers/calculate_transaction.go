@@ -5,8 +5,8 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func CalculateTransactionBalanceWithEdits(transactions []models.Transaction, db *mongo.Database, isConfirmed bool) float64 {
-	var balance float64
+func CalculateTransactionBalanceWithEdits(transactions []models.Transaction, db *mongo.Database, isConfirmed bool) models.Money {
+	var balance models.Money
 
 	for _, t := range transactions {
 		if isConfirmed && !t.IsConfirmed {
@@ -19,9 +19,11 @@ func CalculateTransactionBalanceWithEdits(transactions []models.Transaction, db
 	return balance
 }
 
-func CalculateOneTransactionBalance(transaction *models.Transaction) float64 {
-	var balance float64
-	var multiplier float64
+// CalculateOneTransactionBalance calcula o valor da transação em centavos; os percentuais de desconto e juros são
+// arredondados para o centavo a cada etapa, então o mesmo valor sempre produz o mesmo resultado
+func CalculateOneTransactionBalance(transaction *models.Transaction) models.Money {
+	var balance models.Money
+	var multiplier models.Money
 
 	switch transaction.Type {
 	case "EXPENSE":
@@ -35,14 +37,14 @@ func CalculateOneTransactionBalance(transaction *models.Transaction) float64 {
 	balance -= transaction.Balance.Discount * multiplier
 
 	if transaction.Balance.DiscountPercentage > 0 {
-		discountAmount := balance * (transaction.Balance.DiscountPercentage / 100)
+		discountAmount := balance.Percentage(transaction.Balance.DiscountPercentage)
 		balance -= discountAmount
 	}
 
 	balance += transaction.Balance.Interest * multiplier
 
 	if transaction.Balance.InterestPercentage > 0 {
-		interestAmount := balance * (transaction.Balance.InterestPercentage / 100)
+		interestAmount := balance.Percentage(transaction.Balance.InterestPercentage)
 		balance += interestAmount * multiplier
 	}
 
@@ -0,0 +1,145 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CashFlowPeriodStart retorna o início do período (mês, semana começando na segunda-feira ou dia) que contém a data
+func CashFlowPeriodStart(date time.Time, groupBy string) time.Time {
+	date = date.UTC()
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch groupBy {
+	case "DAY":
+		return day
+	case "WEEK":
+		weekday := (int(day.Weekday()) + 6) % 7 // segunda-feira = 0
+		return day.AddDate(0, 0, -weekday)
+	default:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func nextCashFlowPeriod(start time.Time, groupBy string) time.Time {
+	switch groupBy {
+	case "DAY":
+		return start.AddDate(0, 0, 1)
+	case "WEEK":
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 1, 0)
+	}
+}
+
+func cashFlowPeriodName(start time.Time, groupBy string) string {
+	if groupBy == "MONTH" {
+		return start.Format("2006-01")
+	}
+	return start.Format("2006-01-02")
+}
+
+// CountCashFlowPeriods retorna quantos períodos existem entre as datas
+func CountCashFlowPeriods(initialDate, finalDate time.Time, groupBy string) int {
+	count := 0
+	for start := CashFlowPeriodStart(initialDate, groupBy); !start.After(finalDate); start = nextCashFlowPeriod(start, groupBy) {
+		count++
+	}
+	return count
+}
+
+func addToCashFlowTotals(totals *models.CashFlowTotals, tx *models.Transaction, balance models.Money) {
+	if tx.Type == "RECIPE" {
+		totals.Income += balance
+	} else {
+		totals.Expense -= balance
+	}
+	totals.Net += balance
+}
+
+// BuildCashFlowReport agrupa as transações já expandidas (parcelas e recorrências) por período.
+// A previsão considera todas as transações pelo vencimento e o realizado apenas as confirmadas pela data de confirmação.
+// Compras no cartão ficam de fora: o dinheiro só sai da conta no pagamento da fatura. Transferências entre contas
+// também, pois não são receita nem despesa.
+// Os valores são convertidos para a moeda base na cotação da data em que entram no relatório.
+func BuildCashFlowReport(initialDate, finalDate time.Time, groupBy string, transactions []models.Transaction, rates *models.ExchangeRates) (*models.CashFlowReport, error) {
+	report := &models.CashFlowReport{
+		InitialDate: initialDate,
+		FinalDate:   finalDate,
+		GroupBy:     groupBy,
+		Currency:    rates.BaseCurrency,
+		Periods:     []models.CashFlowPeriod{},
+	}
+
+	periodIndex := make(map[string]int)
+	accountIndex := make([]map[primitive.ObjectID]int, 0)
+
+	for start := CashFlowPeriodStart(initialDate, groupBy); !start.After(finalDate); start = nextCashFlowPeriod(start, groupBy) {
+		name := cashFlowPeriodName(start, groupBy)
+		periodIndex[name] = len(report.Periods)
+		accountIndex = append(accountIndex, make(map[primitive.ObjectID]int))
+		report.Periods = append(report.Periods, models.CashFlowPeriod{
+			Period:    name,
+			StartDate: start,
+			EndDate:   nextCashFlowPeriod(start, groupBy).Add(-time.Second),
+			Accounts:  []models.CashFlowAccount{},
+		})
+	}
+
+	accountFor := func(i int, tx *models.Transaction) *models.CashFlowAccount {
+		if tx.AccountId == nil {
+			return nil
+		}
+
+		idx, exists := accountIndex[i][*tx.AccountId]
+		if !exists {
+			idx = len(report.Periods[i].Accounts)
+			accountIndex[i][*tx.AccountId] = idx
+			report.Periods[i].Accounts = append(report.Periods[i].Accounts, models.CashFlowAccount{AccountId: *tx.AccountId})
+		}
+
+		return &report.Periods[i].Accounts[idx]
+	}
+
+	inRange := func(date time.Time) bool {
+		return !date.Before(initialDate) && !date.After(finalDate)
+	}
+
+	for _, tx := range transactions {
+		if tx.CreditCardId != nil || models.IsTransferLeg(&tx) {
+			continue
+		}
+
+		if inRange(tx.DueDate) {
+			balance, err := rates.Convert(CalculateOneTransactionBalance(&tx), tx.Currency, rates.BaseCurrency, tx.DueDate)
+			if err != nil {
+				return nil, err
+			}
+
+			i := periodIndex[cashFlowPeriodName(CashFlowPeriodStart(tx.DueDate, groupBy), groupBy)]
+			addToCashFlowTotals(&report.Forecast, &tx, balance)
+			addToCashFlowTotals(&report.Periods[i].Forecast, &tx, balance)
+			if account := accountFor(i, &tx); account != nil {
+				addToCashFlowTotals(&account.Forecast, &tx, balance)
+			}
+		}
+
+		if tx.IsConfirmed && tx.ConfirmationDate != nil && inRange(*tx.ConfirmationDate) {
+			balance, err := rates.Convert(CalculateOneTransactionBalance(&tx), tx.Currency, rates.BaseCurrency, *tx.ConfirmationDate)
+			if err != nil {
+				return nil, err
+			}
+
+			i := periodIndex[cashFlowPeriodName(CashFlowPeriodStart(*tx.ConfirmationDate, groupBy), groupBy)]
+			addToCashFlowTotals(&report.Confirmed, &tx, balance)
+			addToCashFlowTotals(&report.Periods[i].Confirmed, &tx, balance)
+			if account := accountFor(i, &tx); account != nil {
+				addToCashFlowTotals(&account.Confirmed, &tx, balance)
+			}
+		}
+	}
+
+	return report, nil
+}
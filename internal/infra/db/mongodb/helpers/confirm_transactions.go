@@ -0,0 +1,107 @@
+package helpers
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ConfirmTransactionsWrites monta as escritas que confirmam transações já expandidas pelo TransactionRepository.Find.
+// Transações avulsas são atualizadas diretamente (transactionWrites); parcelas e recorrências são confirmadas
+// através da edição (edit_transaction) da parcela correspondente (editWrites). Retorna também as transações
+// cujos snapshots de saldo devem ser invalidados.
+// As transações avulsas só são atualizadas se ainda não estiverem confirmadas; para as edições, quem precisa
+// garantir isso usa ConfirmedEditsFilter antes das escritas.
+func ConfirmTransactionsWrites(transactions []models.Transaction, confirmationDate time.Time) (transactionWrites []mongo.WriteModel, editWrites []mongo.WriteModel, confirmed []*models.Transaction, err error) {
+	now := time.Now().UTC()
+
+	for _, tx := range transactions {
+		if tx.IsConfirmed {
+			continue
+		}
+
+		// o saldo muda tanto no vencimento quanto na data de confirmação
+		invalidated := tx
+		invalidated.ConfirmationDate = &confirmationDate
+		confirmed = append(confirmed, &invalidated)
+
+		if tx.Frequency == "DO_NOT_REPEAT" || tx.RepeatSettings == nil || tx.RepeatSettings.CurrentCount == 0 {
+			transactionWrites = append(transactionWrites, mongo.NewUpdateOneModel().
+				SetFilter(bson.M{"_id": tx.Id, "workspace_id": tx.WorkspaceId, "is_confirmed": bson.M{"$ne": true}}).
+				SetUpdate(bson.M{"$set": bson.M{
+					"is_confirmed":      true,
+					"confirmation_date": confirmationDate,
+					"updated_at":        now,
+				}}))
+			continue
+		}
+
+		mainId := tx.Id
+		mainCount := tx.RepeatSettings.CurrentCount
+
+		editTransaction := tx
+		editTransaction.Id = primitive.NewObjectID()
+		editTransaction.MainId = &mainId
+		editTransaction.MainCount = &mainCount
+		editTransaction.RepeatSettings = nil
+		editTransaction.CreatedAt = now
+
+		document, err := toDocument(&editTransaction)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		// estes campos são definidos pelo $set e não podem se repetir no $setOnInsert
+		delete(document, "is_confirmed")
+		delete(document, "confirmation_date")
+		delete(document, "updated_at")
+
+		editWrites = append(editWrites, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"main_id": mainId, "main_count": mainCount, "workspace_id": tx.WorkspaceId}).
+			SetUpdate(bson.M{
+				"$set": bson.M{
+					"is_confirmed":      true,
+					"confirmation_date": confirmationDate,
+					"updated_at":        now,
+				},
+				"$setOnInsert": document,
+			}).
+			SetUpsert(true))
+	}
+
+	return transactionWrites, editWrites, confirmed, nil
+}
+
+// ConfirmedEditsFilter seleciona as edições já confirmadas das parcelas e recorrências que ConfirmTransactionsWrites
+// confirmaria. Retorna nil quando não há nenhuma parcela ou recorrência entre as transações.
+func ConfirmedEditsFilter(transactions []models.Transaction) bson.M {
+	var occurrences []bson.M
+	for _, tx := range transactions {
+		if tx.IsConfirmed || tx.Frequency == "DO_NOT_REPEAT" || tx.RepeatSettings == nil || tx.RepeatSettings.CurrentCount == 0 {
+			continue
+		}
+		occurrences = append(occurrences, bson.M{"main_id": tx.Id, "main_count": tx.RepeatSettings.CurrentCount, "workspace_id": tx.WorkspaceId})
+	}
+
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	return bson.M{"$or": occurrences, "is_confirmed": true}
+}
+
+func toDocument(value any) (bson.M, error) {
+	data, err := bson.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var document bson.M
+	if err := bson.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+
+	return document, nil
+}
@@ -0,0 +1,157 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+// InvoiceCloseDate retorna o último instante da fatura que fecha no mês informado.
+// Se o dia de fechamento não existir no mês (ex.: 31 em fevereiro), usa o último dia do mês.
+func InvoiceCloseDate(creditCard *models.CreditCard, year int, month time.Month) time.Time {
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	day := min(max(creditCard.CloseDate, 1), daysInMonth(firstDay))
+
+	return time.Date(firstDay.Year(), firstDay.Month(), day, 23, 59, 59, 0, time.UTC)
+}
+
+// InvoiceCycle retorna a data de abertura, fechamento e vencimento da fatura que fecha no mês informado.
+// A fatura abre no dia seguinte ao fechamento anterior e vence no mesmo mês do fechamento
+// quando o dia de vencimento é posterior ao de fechamento, ou no mês seguinte caso contrário.
+func InvoiceCycle(creditCard *models.CreditCard, year int, month time.Month) (openDate, closeDate, dueDate time.Time) {
+	firstDay := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	previousMonth := firstDay.AddDate(0, -1, 0)
+
+	closeDate = InvoiceCloseDate(creditCard, firstDay.Year(), firstDay.Month())
+	previousCloseDate := InvoiceCloseDate(creditCard, previousMonth.Year(), previousMonth.Month())
+	openDate = time.Date(previousCloseDate.Year(), previousCloseDate.Month(), previousCloseDate.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	dueMonth := firstDay
+	if creditCard.DueDate <= creditCard.CloseDate {
+		dueMonth = firstDay.AddDate(0, 1, 0)
+	}
+	dueDay := min(max(creditCard.DueDate, 1), daysInMonth(dueMonth))
+	dueDate = time.Date(dueMonth.Year(), dueMonth.Month(), dueDay, 0, 0, 0, 0, time.UTC)
+
+	return openDate, closeDate, dueDate
+}
+
+// InvoiceReferenceForDate retorna o ano e o mês de fechamento da fatura em que uma compra feita na data informada entra
+func InvoiceReferenceForDate(creditCard *models.CreditCard, date time.Time) (int, time.Month) {
+	date = date.UTC()
+	closeDate := InvoiceCloseDate(creditCard, date.Year(), date.Month())
+	if date.After(closeDate) {
+		next := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0)
+		return next.Year(), next.Month()
+	}
+
+	return date.Year(), date.Month()
+}
+
+// InvoicePeriod formata o período da fatura como YYYY-MM
+func InvoicePeriod(year int, month time.Month) string {
+	return fmt.Sprintf("%04d-%02d", year, int(month))
+}
+
+// BuildCreditCardInvoice monta a fatura que fecha no mês informado a partir das transações já expandidas
+// (parcelas e recorrências). Uma compra entra na fatura cujo ciclo contém a sua data de vencimento.
+func BuildCreditCardInvoice(creditCard *models.CreditCard, year int, month time.Month, transactions []models.Transaction, now time.Time) *models.CreditCardInvoice {
+	openDate, closeDate, dueDate := InvoiceCycle(creditCard, year, month)
+
+	invoice := &models.CreditCardInvoice{
+		CreditCardId: creditCard.Id,
+		Period:       InvoicePeriod(year, month),
+		Year:         year,
+		Month:        int(month),
+		OpenDate:     openDate,
+		CloseDate:    closeDate,
+		DueDate:      dueDate,
+		Payments:     []models.CreditCardInvoicePayment{},
+		Transactions: []models.Transaction{},
+	}
+
+	allConfirmed := true
+	for _, tx := range transactions {
+		if tx.CreditCardId == nil || *tx.CreditCardId != creditCard.Id {
+			continue
+		}
+
+		if tx.DueDate.Before(openDate) || tx.DueDate.After(closeDate) {
+			continue
+		}
+
+		// compras (EXPENSE) aumentam a fatura e estornos (RECIPE) a reduzem
+		invoice.Total -= CalculateOneTransactionBalance(&tx)
+		if !tx.IsConfirmed {
+			allConfirmed = false
+		}
+
+		invoice.Transactions = append(invoice.Transactions, tx)
+	}
+
+	SortTransactionsByDueDate(invoice.Transactions)
+
+	switch {
+	case len(invoice.Transactions) > 0 && allConfirmed:
+		invoice.Status = "PAID"
+	case now.After(closeDate):
+		invoice.Status = "CLOSED"
+	default:
+		invoice.Status = "OPEN"
+	}
+
+	return invoice
+}
+
+// CreditCardBalancePeriod retorna o período que contém tudo o que CalculateCreditCardBalance soma, a partir
+// das transações dos cartões ainda não expandidas: da primeira compra ao fechamento da fatura atual ou,
+// quando uma compra parcelada termina depois dele, até a última parcela. Assim as recorrências não são
+// expandidas além da fatura atual.
+func CreditCardBalancePeriod(transactions []models.Transaction, currentCloseDate time.Time) (time.Time, time.Time) {
+	start, end := currentCloseDate, currentCloseDate
+
+	for _, tx := range transactions {
+		dateRef := tx.DueDate
+		if tx.IsConfirmed && tx.ConfirmationDate != nil {
+			dateRef = *tx.ConfirmationDate
+		}
+		if dateRef.Before(start) {
+			start = dateRef
+		}
+
+		if tx.Frequency != "REPEAT" || tx.RepeatSettings == nil || tx.RepeatSettings.Count <= 1 {
+			continue
+		}
+
+		lastInstallment := computeInstallmentDueDate(dateRef, tx.RepeatSettings.Interval, tx.RepeatSettings.Count-1)
+		if step := IntervalDays(tx.RepeatSettings.Interval, tx.RepeatSettings.CustomDay); step > 0 {
+			lastInstallment = dateRef.AddDate(0, 0, step*(tx.RepeatSettings.Count-1))
+		}
+		if lastInstallment.After(end) {
+			end = lastInstallment
+		}
+	}
+
+	return start, end
+}
+
+// CalculateCreditCardBalance soma o que ainda não foi pago no cartão: todas as parcelas em aberto
+// de compras parceladas e avulsas, e as recorrências até o fechamento da fatura atual
+func CalculateCreditCardBalance(creditCard *models.CreditCard, transactions []models.Transaction, currentCloseDate time.Time) models.Money {
+	var balance models.Money
+
+	for _, tx := range transactions {
+		if tx.CreditCardId == nil || *tx.CreditCardId != creditCard.Id || tx.IsConfirmed {
+			continue
+		}
+
+		if tx.Frequency == "RECURRING" && tx.DueDate.After(currentCloseDate) {
+			continue
+		}
+
+		balance -= CalculateOneTransactionBalance(&tx)
+	}
+
+	return balance
+}
@@ -30,3 +30,38 @@ func QuartersBetween(date time.Time, filterYear int, filterMonth int) int {
 
 	return totalMonths / 3
 }
+
+// IntervalDays retorna o passo em dias dos intervalos contados em dias (diário, semanal, quinzenal e
+// personalizado). Para os intervalos baseados em meses retorna 0.
+func IntervalDays(interval string, customDay int) int {
+	switch interval {
+	case "DAILY":
+		return 1
+	case "WEEKLY":
+		return 7
+	case "BIWEEKLY":
+		return 14
+	case "CUSTOM":
+		if customDay > 0 {
+			return customDay
+		}
+	}
+
+	return 0
+}
+
+// StepsBetween retorna quantos passos de `step` dias cabem entre a data e o último dia do mês filtrado
+func StepsBetween(date time.Time, filterYear int, filterMonth int, step int) int {
+	if step <= 0 {
+		return 0
+	}
+
+	startDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	lastDay := time.Date(filterYear, time.Month(filterMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+	if lastDay.Before(startDay) {
+		return 0
+	}
+
+	days := int(lastDay.Sub(startDay).Hours() / 24)
+	return days / step
+}
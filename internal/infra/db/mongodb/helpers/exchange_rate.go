@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindExchangeRates carrega a moeda base e todas as cotações do workspace
+func FindExchangeRates(db *mongo.Database, workspaceId primitive.ObjectID) (*models.ExchangeRates, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	var settings models.CurrencySettings
+	err := db.Collection("currency_settings").FindOne(ctx, bson.M{"workspace_id": workspaceId}).Decode(&settings)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	cursor, err := db.Collection("exchange_rate").Find(ctx, bson.M{"workspace_id": workspaceId})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rates []models.ExchangeRate
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+
+	return models.NewExchangeRates(settings.BaseCurrency, rates), nil
+}
+
+// SplitForeignTransactions separa as transações na moeda informada das que estão em outra moeda e precisam de conversão
+func SplitForeignTransactions(transactions []models.Transaction, currency string, rates *models.ExchangeRates) ([]models.Transaction, []models.Transaction) {
+	var same, foreign []models.Transaction
+	for _, tx := range transactions {
+		if rates.Currency(tx.Currency) == rates.Currency(currency) {
+			same = append(same, tx)
+			continue
+		}
+		foreign = append(foreign, tx)
+	}
+	return same, foreign
+}
+
+// CalculateConvertedTransactionsBalance calcula o saldo de cada transação na sua própria moeda e soma o valor convertido
+// para a moeda informada. Transações únicas usam a cotação da data de referência; parcelas e recorrências, que somam
+// várias ocorrências, usam a cotação do fim do mês calculado.
+func CalculateConvertedTransactionsBalance(transactions []models.Transaction, currency string, rates *models.ExchangeRates, year, month int, db *mongo.Database, isConfirmed bool) (models.Money, error) {
+	endOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0).Add(-time.Second)
+
+	var balance models.Money
+	for _, tx := range transactions {
+		single := []models.Transaction{tx}
+		date := endOfMonth
+
+		var txBalance models.Money
+		switch tx.Frequency {
+		case "RECURRING":
+			txBalance = CalculateRecurringTransactionsBalance(single, year, month, db, isConfirmed)
+		case "REPEAT":
+			txBalance = CalculateRepeatTransactionsBalance(single, year, month, db, isConfirmed)
+		default:
+			txBalance = CalculateTransactionBalanceWithEdits(single, db, isConfirmed)
+			date = models.TransactionReferenceDate(&tx)
+		}
+
+		if txBalance == 0 {
+			continue
+		}
+
+		converted, err := rates.Convert(txBalance, tx.Currency, currency, date)
+		if err != nil {
+			return 0, err
+		}
+		balance += converted
+	}
+
+	return balance, nil
+}
@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// WithTransaction executa as escritas dentro de uma transação do MongoDB: ou todas são gravadas, ou nenhuma.
+// Transações exigem que o MongoDB rode como replica set.
+func WithTransaction(db *mongo.Database, fn func(ctx mongo.SessionContext) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), Timeout)
+	defer cancel()
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionContext)
+	})
+	return err
+}
@@ -23,7 +23,15 @@ func ReplaceEditTransactions(transactions []models.Transaction, db *mongo.Databa
 			transactions[i].RepeatSettings.CurrentCount = transaction.RepeatSettings.CurrentCount
 		}
 
-		result := editCollection.FindOne(context.Background(), bson.M{"main_id": transaction.Id, "workspace_id": transaction.WorkspaceId})
+		if transaction.RepeatSettings == nil {
+			continue
+		}
+
+		result := editCollection.FindOne(context.Background(), bson.M{
+			"main_id":      transaction.Id,
+			"workspace_id": transaction.WorkspaceId,
+			"main_count":   transaction.RepeatSettings.CurrentCount,
+		})
 
 		if result.Err() == mongo.ErrNoDocuments {
 			continue
@@ -38,12 +46,7 @@ func ReplaceEditTransactions(transactions []models.Transaction, db *mongo.Databa
 			return nil, err
 		}
 
-		if transaction.RepeatSettings != nil {
-
-			if editTransaction.MainCount != nil && transaction.RepeatSettings.CurrentCount == *editTransaction.MainCount {
-				transactions[i].Balance = editTransaction.Balance
-			}
-		}
+		transactions[i].Balance = editTransaction.Balance
 	}
 
 	return transactions, nil
@@ -67,8 +70,8 @@ func CalculateCurrentCount(transaction *models.Transaction, year int, month int)
 
 	switch transaction.Frequency {
 	case "REPEAT":
-		// Determinar qual parcela vem antes do mês alvo para encontrar a base
-		baseInstallment := int(transaction.RepeatSettings.InitialInstallment)
+		// Determinar qual parcela vem antes do mês alvo para encontrar a base; antes da primeira, a próxima é a inicial
+		baseInstallment := int(transaction.RepeatSettings.InitialInstallment) - 1
 
 		for i := int(transaction.RepeatSettings.InitialInstallment); i <= transaction.RepeatSettings.Count; i++ {
 			var installmentDueDate time.Time
@@ -100,6 +103,15 @@ func CalculateCurrentCount(transaction *models.Transaction, year int, month int)
 		return baseInstallment
 
 	case "RECURRING":
+		// Intervalos em dias: retornamos a última ocorrência até o fim do mês alvo
+		if step := IntervalDays(transaction.RepeatSettings.Interval, transaction.RepeatSettings.CustomDay); step > 0 {
+			if endOfMonth.Before(dateRef) {
+				return 0
+			}
+
+			return StepsBetween(dateRef, year, month, step) + 1
+		}
+
 		// Para transações recorrentes, calculamos o número de meses entre a data de referência e a data alvo
 		refYear, refMonth, _ := dateRef.Date()
 		months := (year-refYear)*12 + (month - int(refMonth))
@@ -123,6 +135,8 @@ func computeInstallmentDueDate(initial time.Time, interval string, offset int) t
 		return initial.AddDate(0, 0, offset)
 	case "WEEKLY":
 		return initial.AddDate(0, 0, 7*offset)
+	case "BIWEEKLY":
+		return initial.AddDate(0, 0, 14*offset)
 	case "MONTHLY":
 		return initial.AddDate(0, offset, 0)
 	case "QUARTERLY":
@@ -0,0 +1,118 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	presentationHelpers "github.com/anuntech/finance-backend/internal/presentation/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCalculateCurrentCount(t *testing.T) {
+	installments := expense(primitive.NilObjectID, 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+	recurring := expense(primitive.NilObjectID, 2000, "RECURRING", "MONTHLY", 0, date(2025, time.January, 15))
+
+	confirmedLater := recurring
+	confirmationDate := date(2025, time.February, 2)
+	confirmedLater.IsConfirmed = true
+	confirmedLater.ConfirmationDate = &confirmationDate
+
+	noSettings := installments
+	noSettings.RepeatSettings = nil
+
+	tests := []struct {
+		name        string
+		transaction models.Transaction
+		year, month int
+		want        int
+	}{
+		{name: "before the first installment", transaction: installments, year: 2024, month: 12, want: 1},
+		{name: "first installment", transaction: installments, year: 2025, month: 1, want: 1},
+		{name: "second installment", transaction: installments, year: 2025, month: 2, want: 2},
+		{name: "last installment", transaction: installments, year: 2025, month: 3, want: 3},
+		{name: "after the last installment", transaction: installments, year: 2025, month: 6, want: 3},
+		{
+			name:        "weekly installments",
+			transaction: expense(primitive.NilObjectID, 1000, "REPEAT", "WEEKLY", 10, date(2025, time.January, 1)),
+			year:        2025, month: 2,
+			want: 6,
+		},
+		{
+			name:        "custom interval in days",
+			transaction: customInterval(expense(primitive.NilObjectID, 1000, "REPEAT", "CUSTOM", 5, date(2025, time.January, 1)), 10),
+			year:        2025, month: 2,
+			want: 5,
+		},
+		{name: "recurring before the first occurrence", transaction: recurring, year: 2024, month: 12, want: 0},
+		{name: "recurring first occurrence", transaction: recurring, year: 2025, month: 1, want: 1},
+		{name: "recurring later occurrence", transaction: recurring, year: 2025, month: 4, want: 4},
+		{
+			name:        "recurring weekly occurrences",
+			transaction: expense(primitive.NilObjectID, 2000, "RECURRING", "WEEKLY", 0, date(2025, time.January, 1)),
+			year:        2025, month: 1,
+			want: 5,
+		},
+		{name: "recurring counted from the confirmation", transaction: confirmedLater, year: 2025, month: 2, want: 1},
+		{
+			name:        "not repeated",
+			transaction: expense(primitive.NilObjectID, 1000, "DO_NOT_REPEAT", "", 0, date(2025, time.January, 1)),
+			year:        2025, month: 1,
+			want: 0,
+		},
+		{name: "without repeat settings", transaction: noSettings, year: 2025, month: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transaction := tt.transaction
+			if got := CalculateCurrentCount(&transaction, tt.year, tt.month); got != tt.want {
+				t.Errorf("CalculateCurrentCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplaceEditTransactions(t *testing.T) {
+	filters := &presentationHelpers.GlobalFilterParams{Year: 2025, Month: 2}
+
+	t.Run("without database", func(t *testing.T) {
+		transactions := []models.Transaction{expense(primitive.NewObjectID(), 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))}
+
+		got, err := ReplaceEditTransactions(transactions, nil, filters)
+		if err != nil {
+			t.Fatalf("ReplaceEditTransactions() error = %v", err)
+		}
+		if got[0].Balance.Value != 10000 {
+			t.Errorf("Balance.Value = %v, want 10000", got[0].Balance.Value)
+		}
+	})
+
+	t.Run("edit of the month installment", func(t *testing.T) {
+		db := mongotest.Database(t)
+		workspaceId := primitive.NewObjectID()
+
+		main := expense(workspaceId, 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+		single := expense(workspaceId, 1000, "DO_NOT_REPEAT", "", 0, date(2025, time.February, 1))
+		single.RepeatSettings = nil
+		mongotest.Insert(t, db, "edit_transaction", editDocs(main, []occurrenceEdit{
+			{mainCount: 1, value: 1111},
+			{mainCount: 2, value: 2222},
+		})...)
+
+		got, err := ReplaceEditTransactions([]models.Transaction{main, single}, db, filters)
+		if err != nil {
+			t.Fatalf("ReplaceEditTransactions() error = %v", err)
+		}
+		if got[0].RepeatSettings.CurrentCount != 2 {
+			t.Errorf("RepeatSettings.CurrentCount = %v, want 2", got[0].RepeatSettings.CurrentCount)
+		}
+		if got[0].Balance.Value != 2222 {
+			t.Errorf("Balance.Value = %v, want 2222", got[0].Balance.Value)
+		}
+		if got[1].Balance.Value != 1000 {
+			t.Errorf("Balance.Value of the single transaction = %v, want 1000", got[1].Balance.Value)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package helpers
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultTrashRetentionDays = 30
+
+// TrashRetention retorna por quanto tempo os itens ficam na lixeira antes de serem removidos de vez,
+// configurado em TRASH_RETENTION_DAYS (30 dias por padrão)
+func TrashRetention() time.Duration {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days <= 0 {
+		days = defaultTrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// MoveToTrash registra na lixeira as entidades removidas
+func MoveToTrash(ctx context.Context, db *mongo.Database, items ...models.TrashItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(items))
+	for i := range items {
+		if items[i].Id.IsZero() {
+			items[i].Id = primitive.NewObjectID()
+		}
+		documents[i] = items[i]
+	}
+
+	_, err := db.Collection("trash").InsertMany(ctx, documents)
+	return err
+}
+
+// FindTrashReferences busca os documentos da coleção que apontam, no campo informado, para as entidades
+// que estão sendo removidas, agrupados pela entidade
+func FindTrashReferences(ctx context.Context, db *mongo.Database, collection, field string, workspaceId primitive.ObjectID, ids []primitive.ObjectID) (map[primitive.ObjectID][]models.TrashReference, error) {
+	cursor, err := db.Collection(collection).Find(ctx,
+		bson.M{field: bson.M{"$in": ids}, "workspace_id": workspaceId},
+		options.Find().SetProjection(bson.M{"_id": 1, field: 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	references := make(map[primitive.ObjectID][]models.TrashReference)
+	for cursor.Next(ctx) {
+		var document bson.M
+		if err := cursor.Decode(&document); err != nil {
+			return nil, err
+		}
+
+		documentId, _ := document["_id"].(primitive.ObjectID)
+		entityId, ok := document[field].(primitive.ObjectID)
+		if !ok {
+			continue
+		}
+
+		references[entityId] = append(references[entityId], models.TrashReference{
+			Collection: collection,
+			DocumentId: documentId,
+			Field:      field,
+		})
+	}
+
+	return references, cursor.Err()
+}
@@ -0,0 +1,265 @@
+package mongotest
+
+import (
+	"math/big"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// aggregate runs the pipeline stages the repositories use: $match, $group, $sort, $skip, $limit, $project,
+// $count and $unwind
+func (c *collection) aggregate(pipeline bson.A) []bson.D {
+	var docs []bson.D
+	if c != nil {
+		for _, doc := range c.docs {
+			docs = append(docs, copyDoc(doc))
+		}
+	}
+
+	for _, raw := range pipeline {
+		stage, ok := raw.(bson.D)
+		if !ok || len(stage) != 1 {
+			fail(40323, "Location40323", "a pipeline stage specification object must contain exactly one field")
+		}
+
+		switch spec := stage[0].Value; stage[0].Key {
+		case "$match":
+			filter, _ := spec.(bson.D)
+			var matched []bson.D
+			for _, doc := range docs {
+				if c.matches(doc, filter) {
+					matched = append(matched, doc)
+				}
+			}
+			docs = matched
+		case "$group":
+			group, _ := spec.(bson.D)
+			docs = groupDocs(docs, group)
+		case "$sort":
+			order, _ := spec.(bson.D)
+			sortDocs(docs, order)
+		case "$skip":
+			n, _ := intValue(spec)
+			docs = docs[min(int(n), len(docs)):]
+		case "$limit":
+			n, _ := intValue(spec)
+			docs = docs[:min(int(n), len(docs))]
+		case "$project":
+			projection, _ := spec.(bson.D)
+			for i := range docs {
+				docs[i] = project(docs[i], projection)
+			}
+		case "$count":
+			field, _ := spec.(string)
+			docs = []bson.D{{{Key: field, Value: int32(len(docs))}}}
+		case "$unwind":
+			path, _ := spec.(string)
+			if d, ok := spec.(bson.D); ok {
+				path = stringKey(d, "path")
+			}
+			docs = unwindDocs(docs, strings.TrimPrefix(path, "$"))
+		default:
+			fail(40324, "Location40324", "unrecognized pipeline stage name: "+stage[0].Key)
+		}
+	}
+	return docs
+}
+
+func groupDocs(docs []bson.D, spec bson.D) []bson.D {
+	idExpression, ok := lookupKey(spec, "_id")
+	if !ok {
+		fail(15955, "Location15955", "a group specification must include an _id")
+	}
+
+	var keys []any
+	groups := map[int][]bson.D{}
+	for _, doc := range docs {
+		key := evaluate(doc, idExpression)
+		position := -1
+		for i, existing := range keys {
+			if valuesEqual(existing, key) {
+				position = i
+				break
+			}
+		}
+		if position < 0 {
+			keys = append(keys, key)
+			position = len(keys) - 1
+		}
+		groups[position] = append(groups[position], doc)
+	}
+
+	out := make([]bson.D, len(keys))
+	for i, key := range keys {
+		result := bson.D{{Key: "_id", Value: key}}
+		for _, field := range spec {
+			if field.Key == "_id" {
+				continue
+			}
+			accumulator, ok := field.Value.(bson.D)
+			if !ok || len(accumulator) != 1 {
+				fail(40234, "Location40234", "the field '"+field.Key+"' must be an accumulator object")
+			}
+			result = append(result, bson.E{Key: field.Key, Value: accumulate(groups[i], accumulator[0])})
+		}
+		out[i] = result
+	}
+	return out
+}
+
+func accumulate(docs []bson.D, accumulator bson.E) any {
+	switch accumulator.Key {
+	case "$sum", "$avg":
+		var sum any = int32(0)
+		count := 0
+		for _, doc := range docs {
+			v := evaluate(doc, accumulator.Value)
+			if _, isNumber := toRat(v); isNumber {
+				sum = addNumbers(sum, v)
+				count++
+			}
+		}
+		if accumulator.Key == "$sum" {
+			return sum
+		}
+		if count == 0 {
+			return nil
+		}
+		r, _ := toRat(sum)
+		f, _ := new(big.Rat).Quo(r, big.NewRat(int64(count), 1)).Float64()
+		return f
+	case "$push", "$addToSet":
+		values := bson.A{}
+		for _, doc := range docs {
+			v := evaluate(doc, accumulator.Value)
+			if accumulator.Key == "$addToSet" && matchesEqual([]any{values}, v) {
+				continue
+			}
+			values = append(values, v)
+		}
+		return values
+	case "$first", "$last":
+		if len(docs) == 0 {
+			return nil
+		}
+		if accumulator.Key == "$first" {
+			return evaluate(docs[0], accumulator.Value)
+		}
+		return evaluate(docs[len(docs)-1], accumulator.Value)
+	case "$min", "$max":
+		var best any
+		for _, doc := range docs {
+			v := evaluate(doc, accumulator.Value)
+			if v == nil {
+				continue
+			}
+			c := compareValues(v, best)
+			if best == nil || (accumulator.Key == "$min" && c < 0) || (accumulator.Key == "$max" && c > 0) {
+				best = v
+			}
+		}
+		return best
+	}
+
+	fail(15952, "Location15952", "unknown group operator: "+accumulator.Key)
+	return nil
+}
+
+// evaluate resolves an aggregation expression: field paths, $$ROOT, documents of expressions and literals
+func evaluate(doc bson.D, expression any) any {
+	switch x := expression.(type) {
+	case string:
+		switch {
+		case x == "$$ROOT":
+			return doc
+		case strings.HasPrefix(x, "$$"):
+			fail(17276, "Location17276", "use of undefined variable: "+x)
+		case strings.HasPrefix(x, "$"):
+			values := lookupPath(doc, x[1:])
+			if len(values) == 0 {
+				return nil
+			}
+			return values[0]
+		}
+		return x
+	case bson.D:
+		if len(x) == 1 && isOperator(x[0].Key) {
+			return evaluateOperator(doc, x[0])
+		}
+		out := bson.D{}
+		for _, e := range x {
+			out = append(out, bson.E{Key: e.Key, Value: evaluate(doc, e.Value)})
+		}
+		return out
+	case bson.A:
+		out := bson.A{}
+		for _, e := range x {
+			out = append(out, evaluate(doc, e))
+		}
+		return out
+	}
+	return expression
+}
+
+func evaluateOperator(doc bson.D, operator bson.E) any {
+	switch operator.Key {
+	case "$literal":
+		return operator.Value
+	case "$dateToString":
+		args, _ := operator.Value.(bson.D)
+		date, ok := evaluate(doc, docArgument(args, "date")).(primitive.DateTime)
+		if !ok {
+			return nil
+		}
+		format := stringKey(args, "format")
+		if format == "" {
+			format = "%Y-%m-%dT%H:%M:%S.%LZ"
+		}
+		return formatDate(date, format)
+	}
+
+	fail(31325, "InvalidPipelineOperator", "unrecognized expression '"+operator.Key+"'")
+	return nil
+}
+
+func docArgument(args bson.D, key string) any {
+	v, _ := lookupKey(args, key)
+	return v
+}
+
+// formatDate supports the specifiers of $dateToString used for calendar days and times, always in UTC
+func formatDate(date primitive.DateTime, format string) string {
+	t := date.Time().UTC()
+	replacer := strings.NewReplacer(
+		"%Y", t.Format("2006"),
+		"%m", t.Format("01"),
+		"%d", t.Format("02"),
+		"%H", t.Format("15"),
+		"%M", t.Format("04"),
+		"%S", t.Format("05"),
+		"%L", t.Format(".000")[1:],
+		"%%", "%",
+	)
+	return replacer.Replace(format)
+}
+
+func unwindDocs(docs []bson.D, path string) []bson.D {
+	var out []bson.D
+	for _, doc := range docs {
+		values := lookupPath(doc, path)
+		if len(values) == 0 {
+			continue
+		}
+		array, ok := values[0].(bson.A)
+		if !ok {
+			out = append(out, doc)
+			continue
+		}
+		for _, element := range array {
+			out = append(out, setPath(copyDoc(doc), strings.Split(path, "."), element).(bson.D))
+		}
+	}
+	return out
+}
@@ -0,0 +1,535 @@
+package mongotest
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const replicaSetName = "rs0"
+
+func cutNamespace(ns string) (db, coll string, ok bool) {
+	return strings.Cut(ns, ".")
+}
+
+func okReply(fields ...bson.E) bson.D {
+	return append(bson.D(fields), bson.E{Key: "ok", Value: 1.0})
+}
+
+func errorReply(err *commandError) bson.D {
+	return bson.D{
+		{Key: "ok", Value: 0.0},
+		{Key: "errmsg", Value: err.Message},
+		{Key: "code", Value: err.Code},
+		{Key: "codeName", Value: err.CodeName},
+	}
+}
+
+func cursorReply(ns string, docs []bson.D) bson.D {
+	batch := make(bson.A, len(docs))
+	for i, doc := range docs {
+		batch[i] = doc
+	}
+	return okReply(bson.E{Key: "cursor", Value: bson.D{
+		{Key: "firstBatch", Value: batch},
+		{Key: "id", Value: int64(0)},
+		{Key: "ns", Value: ns},
+	}})
+}
+
+// run executes one command. Every command holds the store lock, so the server applies them one at a time.
+func (s *Server) run(command bson.D) (reply bson.D) {
+	if len(command) == 0 {
+		return errorReply(&commandError{Code: 59, CodeName: "CommandNotFound", Message: "empty command"})
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err, ok := r.(*commandError)
+			if !ok {
+				panic(r)
+			}
+			reply = errorReply(err)
+		}
+	}()
+
+	s.store.mu.Lock()
+	defer s.store.mu.Unlock()
+
+	db := stringKey(command, "$db")
+	name := command[0].Key
+	coll, _ := command[0].Value.(string)
+	ns := db + "." + coll
+	txn := s.store.session(command)
+
+	switch name {
+	case "hello", "isMaster", "ismaster":
+		return s.hello()
+	case "ping", "endSessions", "killCursors", "saslContinue", "getLastError":
+		return okReply()
+	case "buildInfo", "buildinfo":
+		return okReply(
+			bson.E{Key: "version", Value: "7.0.0"},
+			bson.E{Key: "versionArray", Value: bson.A{int32(7), int32(0), int32(0), int32(0)}},
+		)
+	case "commitTransaction":
+		s.store.endTransaction(command, false)
+		return okReply()
+	case "abortTransaction":
+		s.store.endTransaction(command, true)
+		return okReply()
+	case "find":
+		return cursorReply(ns, s.store.find(ns, command))
+	case "aggregate":
+		pipeline, _ := lookupKey(command, "pipeline")
+		stages, _ := pipeline.(bson.A)
+		return cursorReply(ns, s.store.collection(ns).aggregate(stages))
+	case "count":
+		query := docValue(command, "query")
+		count := 0
+		if c := s.store.collection(ns); c != nil {
+			count = len(c.find(query))
+		}
+		return okReply(bson.E{Key: "n", Value: int32(count)})
+	case "distinct":
+		return okReply(bson.E{Key: "values", Value: s.store.distinct(ns, command)})
+	case "insert":
+		return s.store.insert(ns, command, txn)
+	case "update":
+		return s.store.update(ns, command, txn)
+	case "delete":
+		return s.store.delete(ns, command, txn)
+	case "findAndModify", "findandmodify":
+		return s.store.findAndModify(ns, command, txn)
+	case "create":
+		s.store.writable(ns, txn)
+		return okReply()
+	case "createIndexes":
+		return s.store.createIndexes(ns, command, txn)
+	case "listIndexes":
+		indexes := []bson.D{{{Key: "v", Value: int32(2)}, {Key: "key", Value: bson.D{{Key: "_id", Value: int32(1)}}}, {Key: "name", Value: "_id_"}}}
+		if c := s.store.collection(ns); c != nil {
+			indexes = append(indexes, c.indexes...)
+		}
+		return cursorReply(ns, indexes)
+	case "dropIndexes":
+		if c := s.store.writable(ns, txn); c != nil {
+			c.indexes = nil
+		}
+		return okReply()
+	case "listCollections":
+		var collections []bson.D
+		for _, name := range s.store.collectionNames(db) {
+			collections = append(collections, bson.D{{Key: "name", Value: name}, {Key: "type", Value: "collection"}})
+		}
+		return cursorReply(db+".$cmd.listCollections", collections)
+	case "drop":
+		s.store.writable(ns, txn)
+		delete(s.store.collections, ns)
+		return okReply()
+	case "dropDatabase":
+		s.store.dropDatabase(db)
+		return okReply()
+	}
+
+	fail(59, "CommandNotFound", "no such command: '"+name+"'")
+	return nil
+}
+
+// hello describes the server as the primary of a single-node replica set, which lets the driver use
+// sessions and transactions. It has no topologyVersion, so the driver polls it instead of streaming.
+func (s *Server) hello() bson.D {
+	address := s.listener.Addr().String()
+	return okReply(
+		bson.E{Key: "helloOk", Value: true},
+		bson.E{Key: "isWritablePrimary", Value: true},
+		bson.E{Key: "ismaster", Value: true},
+		bson.E{Key: "setName", Value: replicaSetName},
+		bson.E{Key: "setVersion", Value: int32(1)},
+		bson.E{Key: "hosts", Value: bson.A{address}},
+		bson.E{Key: "primary", Value: address},
+		bson.E{Key: "me", Value: address},
+		bson.E{Key: "maxBsonObjectSize", Value: int32(16 * 1024 * 1024)},
+		bson.E{Key: "maxMessageSizeBytes", Value: int32(maxMessageSize)},
+		bson.E{Key: "maxWriteBatchSize", Value: int32(100000)},
+		bson.E{Key: "localTime", Value: primitive.NewDateTimeFromTime(time.Now())},
+		bson.E{Key: "logicalSessionTimeoutMinutes", Value: int32(30)},
+		bson.E{Key: "minWireVersion", Value: int32(0)},
+		bson.E{Key: "maxWireVersion", Value: int32(21)},
+		bson.E{Key: "readOnly", Value: false},
+	)
+}
+
+// session returns the transaction the command belongs to, starting it on startTransaction
+func (s *store) session(command bson.D) *transaction {
+	if autocommit, ok := lookupKey(command, "autocommit"); !ok || autocommit != false {
+		return nil
+	}
+
+	key := sessionKey(command)
+	number := intKey(command, "txnNumber")
+	txn := s.transactions[key]
+
+	if boolKey(command, "startTransaction") {
+		if txn != nil {
+			s.abort(txn)
+		}
+		txn = &transaction{number: number, saved: map[string]*collection{}}
+		s.transactions[key] = txn
+	}
+
+	if txn == nil || txn.number != number {
+		fail(251, "NoSuchTransaction", "transaction is not in progress")
+	}
+	return txn
+}
+
+func (s *store) endTransaction(command bson.D, abort bool) {
+	key := sessionKey(command)
+	txn := s.transactions[key]
+	if txn == nil || txn.number != intKey(command, "txnNumber") {
+		if abort {
+			return
+		}
+		fail(251, "NoSuchTransaction", "transaction is not in progress")
+	}
+
+	if abort {
+		s.abort(txn)
+	}
+	delete(s.transactions, key)
+}
+
+func sessionKey(command bson.D) string {
+	id, _ := lookupKey(docValue(command, "lsid"), "id")
+	if binary, ok := id.(primitive.Binary); ok {
+		return string(binary.Data)
+	}
+	return ""
+}
+
+func (s *store) find(ns string, command bson.D) []bson.D {
+	c := s.collection(ns)
+	if c == nil {
+		return nil
+	}
+
+	var docs []bson.D
+	for _, i := range c.find(docValue(command, "filter")) {
+		docs = append(docs, copyDoc(c.docs[i]))
+	}
+	sortDocs(docs, docValue(command, "sort"))
+
+	skip := intKey(command, "skip")
+	docs = docs[min(int(skip), len(docs)):]
+	if limit := intKey(command, "limit"); limit != 0 {
+		if limit < 0 {
+			limit = -limit
+		}
+		docs = docs[:min(int(limit), len(docs))]
+	}
+
+	projection := docValue(command, "projection")
+	for i := range docs {
+		docs[i] = project(docs[i], projection)
+	}
+	return docs
+}
+
+func (s *store) distinct(ns string, command bson.D) bson.A {
+	values := bson.A{}
+	c := s.collection(ns)
+	if c == nil {
+		return values
+	}
+
+	key := stringKey(command, "key")
+	for _, i := range c.find(docValue(command, "query")) {
+		for _, v := range lookupPath(c.docs[i], key) {
+			candidates := []any{v}
+			if array, ok := v.(bson.A); ok {
+				candidates = array
+			}
+			for _, candidate := range candidates {
+				if !matchesEqual([]any{values}, candidate) {
+					values = append(values, candidate)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// writeError is reported in the writeErrors of a write command, the rest of an unordered batch goes on
+func writeError(index int, err *commandError) bson.D {
+	return bson.D{
+		{Key: "index", Value: int32(index)},
+		{Key: "code", Value: err.Code},
+		{Key: "errmsg", Value: err.Message},
+	}
+}
+
+// batch runs each operation of a write command, stopping at the first error when the batch is ordered
+func batch(command bson.D, key string, each func(op bson.D)) bson.A {
+	ordered := true
+	if v, ok := lookupKey(command, "ordered"); ok {
+		ordered = v == true
+	}
+
+	writeErrors := bson.A{}
+	ops, _ := lookupKey(command, key)
+	for i, raw := range ops.(bson.A) {
+		op, _ := raw.(bson.D)
+		err := func() (err *commandError) {
+			defer func() {
+				if r := recover(); r != nil {
+					commandErr, ok := r.(*commandError)
+					if !ok {
+						panic(r)
+					}
+					err = commandErr
+				}
+			}()
+			each(op)
+			return nil
+		}()
+
+		if err != nil {
+			writeErrors = append(writeErrors, writeError(i, err))
+			if ordered {
+				break
+			}
+		}
+	}
+	return writeErrors
+}
+
+func writeReply(n int, writeErrors bson.A, fields ...bson.E) bson.D {
+	reply := bson.D{{Key: "n", Value: int32(n)}}
+	reply = append(reply, fields...)
+	if len(writeErrors) > 0 {
+		reply = append(reply, bson.E{Key: "writeErrors", Value: writeErrors})
+	}
+	return okReply(reply...)
+}
+
+func duplicateKey(id any) {
+	fail(11000, "DuplicateKey", "E11000 duplicate key error dup key: { _id: "+formatId(id)+" }")
+}
+
+func formatId(id any) string {
+	if oid, ok := id.(primitive.ObjectID); ok {
+		return "ObjectId('" + oid.Hex() + "')"
+	}
+	raw, err := bson.MarshalExtJSON(bson.D{{Key: "v", Value: id}}, false, false)
+	if err != nil {
+		return "?"
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(string(raw), `{"v":`), "}")
+}
+
+func (s *store) insert(ns string, command bson.D, txn *transaction) bson.D {
+	c := s.writable(ns, txn)
+	n := 0
+	writeErrors := batch(command, "documents", func(doc bson.D) {
+		id, ok := lookupKey(doc, "_id")
+		if !ok {
+			id = newId()
+			doc = withId(doc, id)
+		}
+		if c.hasId(id, -1) {
+			duplicateKey(id)
+		}
+		c.docs = append(c.docs, copyDoc(doc))
+		n++
+	})
+	return writeReply(n, writeErrors)
+}
+
+func (s *store) update(ns string, command bson.D, txn *transaction) bson.D {
+	c := s.writable(ns, txn)
+	matched, modified := 0, 0
+	upserted := bson.A{}
+	index := 0
+
+	writeErrors := batch(command, "updates", func(op bson.D) {
+		defer func() { index++ }()
+
+		filter := docValue(op, "q")
+		update := docValue(op, "u")
+		if update == nil {
+			if _, isPipeline := lookupKey(op, "u"); isPipeline {
+				fail(2, "BadValue", "update pipelines are not supported by the stand-in")
+			}
+		}
+
+		positions := c.find(filter)
+		if len(positions) > 0 && !boolKey(op, "multi") {
+			positions = positions[:1]
+		}
+		if isReplacement(update) && len(positions) > 1 {
+			badValue("replacement document cannot be applied to multiple documents")
+		}
+
+		for _, i := range positions {
+			updated := applyUpdate(c.docs[i], update, false)
+			id, _ := lookupKey(updated, "_id")
+			if c.hasId(id, i) {
+				duplicateKey(id)
+			}
+			matched++
+			if compareValues(c.docs[i], updated) != 0 || len(c.docs[i]) != len(updated) {
+				modified++
+			}
+			c.docs[i] = updated
+		}
+
+		if len(positions) == 0 && boolKey(op, "upsert") {
+			doc := upsertSeed(filter)
+			if isReplacement(update) {
+				doc = applyUpdate(bson.D{}, update, true)
+				if id, ok := lookupKey(upsertSeed(filter), "_id"); ok {
+					doc = withId(doc, id)
+				}
+			} else {
+				doc = applyUpdate(doc, update, true)
+			}
+
+			id, ok := lookupKey(doc, "_id")
+			if !ok {
+				id = newId()
+				doc = withId(doc, id)
+			}
+			if c.hasId(id, -1) {
+				duplicateKey(id)
+			}
+			c.docs = append(c.docs, doc)
+			upserted = append(upserted, bson.D{{Key: "index", Value: int32(index)}, {Key: "_id", Value: id}})
+		}
+	})
+
+	fields := []bson.E{{Key: "nModified", Value: int32(modified)}}
+	if len(upserted) > 0 {
+		fields = append(fields, bson.E{Key: "upserted", Value: upserted})
+	}
+	return writeReply(matched+len(upserted), writeErrors, fields...)
+}
+
+func (s *store) delete(ns string, command bson.D, txn *transaction) bson.D {
+	c := s.writable(ns, txn)
+	n := 0
+	writeErrors := batch(command, "deletes", func(op bson.D) {
+		positions := c.find(docValue(op, "q"))
+		if limit := intKey(op, "limit"); limit == 1 && len(positions) > 1 {
+			positions = positions[:1]
+		}
+
+		removed := map[int]bool{}
+		for _, i := range positions {
+			removed[i] = true
+		}
+
+		docs := c.docs[:0:0]
+		for i, doc := range c.docs {
+			if !removed[i] {
+				docs = append(docs, doc)
+			}
+		}
+		c.docs = docs
+		n += len(positions)
+	})
+	return writeReply(n, writeErrors)
+}
+
+func (s *store) findAndModify(ns string, command bson.D, txn *transaction) bson.D {
+	c := s.writable(ns, txn)
+	filter := docValue(command, "query")
+
+	position := -1
+	if positions := c.sorted(c.find(filter), docValue(command, "sort")); len(positions) > 0 {
+		position = positions[0]
+	}
+
+	returnNew := boolKey(command, "new")
+	fields := docValue(command, "fields")
+
+	if boolKey(command, "remove") {
+		if position < 0 {
+			return okReply(bson.E{Key: "lastErrorObject", Value: bson.D{{Key: "n", Value: int32(0)}}}, bson.E{Key: "value", Value: nil})
+		}
+		removed := c.docs[position]
+		c.docs = append(c.docs[:position:position], c.docs[position+1:]...)
+		return okReply(
+			bson.E{Key: "lastErrorObject", Value: bson.D{{Key: "n", Value: int32(1)}}},
+			bson.E{Key: "value", Value: project(copyDoc(removed), fields)},
+		)
+	}
+
+	update := docValue(command, "update")
+	if position >= 0 {
+		before := c.docs[position]
+		updated := applyUpdate(before, update, false)
+		c.docs[position] = updated
+
+		value := before
+		if returnNew {
+			value = updated
+		}
+		return okReply(
+			bson.E{Key: "lastErrorObject", Value: bson.D{{Key: "n", Value: int32(1)}, {Key: "updatedExisting", Value: true}}},
+			bson.E{Key: "value", Value: project(copyDoc(value), fields)},
+		)
+	}
+
+	if !boolKey(command, "upsert") {
+		return okReply(
+			bson.E{Key: "lastErrorObject", Value: bson.D{{Key: "n", Value: int32(0)}, {Key: "updatedExisting", Value: false}}},
+			bson.E{Key: "value", Value: nil},
+		)
+	}
+
+	doc := applyUpdate(upsertSeed(filter), update, true)
+	id, ok := lookupKey(doc, "_id")
+	if !ok {
+		id = newId()
+		doc = withId(doc, id)
+	}
+	c.docs = append(c.docs, doc)
+
+	var value any
+	if returnNew {
+		value = project(copyDoc(doc), fields)
+	}
+	return okReply(
+		bson.E{Key: "lastErrorObject", Value: bson.D{{Key: "n", Value: int32(1)}, {Key: "updatedExisting", Value: false}, {Key: "upserted", Value: id}}},
+		bson.E{Key: "value", Value: value},
+	)
+}
+
+func (s *store) createIndexes(ns string, command bson.D, txn *transaction) bson.D {
+	c := s.writable(ns, txn)
+	before := len(c.indexes) + 1
+	indexes, _ := lookupKey(command, "indexes")
+	for _, raw := range indexes.(bson.A) {
+		index, _ := raw.(bson.D)
+		name := stringKey(index, "name")
+
+		exists := false
+		for _, existing := range c.indexes {
+			if stringKey(existing, "name") == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			c.indexes = append(c.indexes, index)
+		}
+	}
+
+	return okReply(
+		bson.E{Key: "numIndexesBefore", Value: int32(before)},
+		bson.E{Key: "numIndexesAfter", Value: int32(len(c.indexes) + 1)},
+	)
+}
@@ -0,0 +1,71 @@
+// Package mongotest gives the tests a MongoDB to run the repositories and controllers against.
+//
+// Database connects to the replica set in MONGO_TEST_URL when it is set, and otherwise to an in-process Server
+// started once for the test binary, so the tests need no mongod or container. Each call returns a database of
+// its own, dropped at the end of the test.
+package mongotest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	connectOnce sync.Once
+	client      *mongo.Client
+	connectErr  error
+	databases   atomic.Int64
+)
+
+func connect() (*mongo.Client, error) {
+	connectOnce.Do(func() {
+		uri := os.Getenv("MONGO_TEST_URL")
+		if uri == "" {
+			server, err := NewServer()
+			if err != nil {
+				connectErr = err
+				return
+			}
+			uri = server.URI()
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		client, connectErr = mongo.Connect(ctx, options.Client().ApplyURI(uri))
+		if connectErr != nil {
+			return
+		}
+		connectErr = client.Ping(ctx, nil)
+	})
+	return client, connectErr
+}
+
+// Database returns an empty database for the test. Transactions need a replica set, MONGO_TEST_URL must point
+// to one.
+func Database(t testing.TB) *mongo.Database {
+	t.Helper()
+
+	c, err := connect()
+	if err != nil {
+		t.Fatalf("mongotest: connecting to MongoDB: %v", err)
+	}
+
+	name := fmt.Sprintf("test_%d_%d", os.Getpid(), databases.Add(1))
+	db := c.Database(name)
+	t.Cleanup(func() {
+		if err := db.Drop(context.Background()); err != nil {
+			t.Logf("mongotest: dropping %s: %v", name, err)
+		}
+	})
+
+	return db
+}
@@ -0,0 +1,362 @@
+package mongotest
+
+import (
+	"regexp"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// lookup returns every value the dotted path reaches, arrays on the way are traversed element by element
+func lookup(v any, parts []string) []any {
+	if len(parts) == 0 {
+		return []any{v}
+	}
+
+	switch x := v.(type) {
+	case bson.D:
+		child, ok := lookupKey(x, parts[0])
+		if !ok {
+			return nil
+		}
+		return lookup(child, parts[1:])
+	case bson.A:
+		if i, ok := arrayIndex(parts[0]); ok {
+			if i >= len(x) {
+				return nil
+			}
+			return lookup(x[i], parts[1:])
+		}
+
+		var values []any
+		for _, element := range x {
+			if _, isDoc := element.(bson.D); isDoc {
+				values = append(values, lookup(element, parts)...)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func lookupPath(doc bson.D, path string) []any {
+	return lookup(doc, strings.Split(path, "."))
+}
+
+// expand adds the elements of the arrays found, a condition on an array field matches any of its elements
+func expand(values []any) []any {
+	var out []any
+	for _, v := range values {
+		out = append(out, v)
+		if a, ok := v.(bson.A); ok {
+			out = append(out, a...)
+		}
+	}
+	return out
+}
+
+// matches reports whether the document satisfies a query filter
+func (c *collection) matches(doc bson.D, filter bson.D) bool {
+	for _, e := range filter {
+		switch e.Key {
+		case "$and", "$or", "$nor":
+			clauses, ok := e.Value.(bson.A)
+			if !ok || len(clauses) == 0 {
+				badValue(e.Key + " must be a nonempty array")
+			}
+
+			matched := 0
+			for _, clause := range clauses {
+				clauseFilter, ok := clause.(bson.D)
+				if !ok {
+					badValue(e.Key + " argument's entries must be objects")
+				}
+				if c.matches(doc, clauseFilter) {
+					matched++
+				}
+			}
+
+			switch {
+			case e.Key == "$and" && matched != len(clauses),
+				e.Key == "$or" && matched == 0,
+				e.Key == "$nor" && matched > 0:
+				return false
+			}
+		case "$text":
+			if !c.matchesText(doc, docValue(filter, "$text")) {
+				return false
+			}
+		case "$comment":
+		default:
+			if isOperator(e.Key) {
+				badValue("unknown top level operator: " + e.Key)
+			}
+			if !matchesCondition(lookupPath(doc, e.Key), e.Value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesCondition applies the condition of a single field to the values found at its path
+func matchesCondition(values []any, condition any) bool {
+	if regex, ok := condition.(primitive.Regex); ok {
+		return matchesRegex(values, regex)
+	}
+	if !isOperatorDoc(condition) {
+		return matchesEqual(values, condition)
+	}
+
+	operators := condition.(bson.D)
+	for _, op := range operators {
+		if !matchesOperator(values, op, operators) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesEqual(values []any, want any) bool {
+	if want == nil {
+		if len(values) == 0 {
+			return true
+		}
+	}
+	for _, v := range expand(values) {
+		if valuesEqual(v, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesOperator(values []any, op bson.E, operators bson.D) bool {
+	switch op.Key {
+	case "$eq":
+		return matchesEqual(values, op.Value)
+	case "$ne":
+		return !matchesEqual(values, op.Value)
+	case "$gt", "$gte", "$lt", "$lte":
+		for _, v := range expand(values) {
+			if typeOrder(v) != typeOrder(op.Value) {
+				continue
+			}
+			c := compareValues(v, op.Value)
+			if (op.Key == "$gt" && c > 0) || (op.Key == "$gte" && c >= 0) ||
+				(op.Key == "$lt" && c < 0) || (op.Key == "$lte" && c <= 0) {
+				return true
+			}
+		}
+		return false
+	case "$in", "$nin":
+		list, ok := op.Value.(bson.A)
+		if !ok {
+			badValue(op.Key + " needs an array")
+		}
+		found := false
+		for _, want := range list {
+			if regex, isRegex := want.(primitive.Regex); isRegex {
+				found = matchesRegex(values, regex)
+			} else {
+				found = matchesEqual(values, want)
+			}
+			if found {
+				break
+			}
+		}
+		return found == (op.Key == "$in")
+	case "$exists":
+		return (len(values) > 0) == boolKey(bson.D{op}, "$exists")
+	case "$type":
+		aliases, ok := op.Value.(bson.A)
+		if !ok {
+			aliases = bson.A{op.Value}
+		}
+		for _, v := range expand(values) {
+			for _, alias := range aliases {
+				if hasType(v, alias) {
+					return true
+				}
+			}
+		}
+		return false
+	case "$elemMatch":
+		condition, ok := op.Value.(bson.D)
+		if !ok {
+			badValue("$elemMatch needs an Object")
+		}
+		for _, v := range values {
+			array, ok := v.(bson.A)
+			if !ok {
+				continue
+			}
+			for _, element := range array {
+				if matchesElement(element, condition) {
+					return true
+				}
+			}
+		}
+		return false
+	case "$size":
+		size, _ := intValue(op.Value)
+		for _, v := range values {
+			if array, ok := v.(bson.A); ok && int64(len(array)) == size {
+				return true
+			}
+		}
+		return false
+	case "$all":
+		list, _ := op.Value.(bson.A)
+		for _, want := range list {
+			if !matchesEqual(values, want) {
+				return false
+			}
+		}
+		return len(list) > 0
+	case "$not":
+		return !matchesCondition(values, op.Value)
+	case "$regex":
+		var regex primitive.Regex
+		switch pattern := op.Value.(type) {
+		case primitive.Regex:
+			regex = pattern
+		case string:
+			regex = primitive.Regex{Pattern: pattern}
+		}
+		if options, ok := lookupKey(operators, "$options"); ok {
+			regex.Options, _ = options.(string)
+		}
+		return matchesRegex(values, regex)
+	case "$options":
+		return true
+	}
+
+	badValue("unknown operator: " + op.Key)
+	return false
+}
+
+// matchesElement tells whether an array element satisfies $elemMatch: documents are matched as a query,
+// other values take the operators directly
+func matchesElement(element any, condition bson.D) bool {
+	if isOperatorDoc(condition) && condition[0].Key != "$and" && condition[0].Key != "$or" && condition[0].Key != "$nor" {
+		return matchesCondition([]any{element}, condition)
+	}
+
+	doc, ok := element.(bson.D)
+	if !ok {
+		return false
+	}
+	return (&collection{}).matches(doc, condition)
+}
+
+func matchesRegex(values []any, regex primitive.Regex) bool {
+	pattern := regex.Pattern
+	if regex.Options != "" {
+		flags := ""
+		for _, option := range regex.Options {
+			if strings.ContainsRune("ims", option) {
+				flags += string(option)
+			}
+		}
+		if flags != "" {
+			pattern = "(?" + flags + ")" + pattern
+		}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		badValue("invalid regular expression: " + err.Error())
+	}
+
+	for _, v := range expand(values) {
+		if s, ok := v.(string); ok && re.MatchString(s) {
+			return true
+		}
+	}
+	return false
+}
+
+var typeAliases = map[string]int32{
+	"double": 1, "string": 2, "object": 3, "array": 4, "binData": 5, "undefined": 6, "objectId": 7, "bool": 8,
+	"date": 9, "null": 10, "regex": 11, "int": 16, "timestamp": 17, "long": 18, "decimal": 19,
+}
+
+func typeNumber(v any) int32 {
+	switch v.(type) {
+	case float64:
+		return 1
+	case string:
+		return 2
+	case bson.D:
+		return 3
+	case bson.A:
+		return 4
+	case primitive.Binary:
+		return 5
+	case primitive.Undefined:
+		return 6
+	case primitive.ObjectID:
+		return 7
+	case bool:
+		return 8
+	case primitive.DateTime:
+		return 9
+	case nil, primitive.Null:
+		return 10
+	case primitive.Regex:
+		return 11
+	case int32:
+		return 16
+	case primitive.Timestamp:
+		return 17
+	case int64:
+		return 18
+	case primitive.Decimal128:
+		return 19
+	}
+	return 0
+}
+
+func hasType(v any, alias any) bool {
+	if name, ok := alias.(string); ok {
+		if name == "number" {
+			return typeOrder(v) == 3
+		}
+		number, known := typeAliases[name]
+		if !known {
+			badValue("unknown type name alias: " + name)
+		}
+		return typeNumber(v) == number
+	}
+
+	number, ok := intValue(alias)
+	return ok && int64(typeNumber(v)) == number
+}
+
+// matchesText is a plain stand-in for $text: any of the words searched must appear in one of the fields of
+// the text index, ignoring case
+func (c *collection) matchesText(doc bson.D, text bson.D) bool {
+	fields := c.textFields()
+	if fields == nil {
+		fail(27, "IndexNotFound", "text index required for $text query")
+	}
+
+	words := strings.Fields(strings.ToLower(stringKey(text, "$search")))
+	for _, field := range fields {
+		for _, v := range expand(lookupPath(doc, field)) {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			s = strings.ToLower(s)
+			for _, word := range words {
+				if strings.Contains(s, strings.Trim(word, `"`)) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package mongotest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Workspace is a workspace seeded in the workspaces database with access to the finance application
+type Workspace struct {
+	models.Workspace
+	ApplicationId primitive.ObjectID
+}
+
+// SeedWorkspace creates a workspace owned by a new user and allows it in a new application, whose id is set
+// in APPLICATION_ID for the test like the middlewares expect
+func SeedWorkspace(t testing.TB, workspaceDb *mongo.Database) *Workspace {
+	t.Helper()
+
+	w := &Workspace{
+		Workspace: models.Workspace{
+			ID:      primitive.NewObjectID(),
+			Owner:   primitive.NewObjectID(),
+			Members: []models.Member{},
+			Rules: models.Rules{
+				AllowedMemberApps: []models.AllowedMemberApps{},
+			},
+		},
+		ApplicationId: primitive.NewObjectID(),
+	}
+	t.Setenv("APPLICATION_ID", w.ApplicationId.Hex())
+
+	Insert(t, workspaceDb, "workspaces", w.Workspace)
+	Insert(t, workspaceDb, "myapplications", models.MyApplication{
+		Id:                    primitive.NewObjectID(),
+		WorkspaceId:           w.ID,
+		AllowedApplicationsId: []string{w.ApplicationId.Hex()},
+	})
+
+	return w
+}
+
+// AddMember adds a new user to the workspace. Admins reach every application of the workspace, the other
+// members are allowed in the finance application, where their access comes from the finance role, if any.
+func (w *Workspace) AddMember(t testing.TB, workspaceDb *mongo.Database, db *mongo.Database, workspaceRole string, financeRole string) primitive.ObjectID {
+	t.Helper()
+
+	memberId := primitive.NewObjectID()
+	w.Members = append(w.Members, models.Member{ID: primitive.NewObjectID(), Role: workspaceRole, MemberId: memberId})
+
+	if workspaceRole != "admin" {
+		allowed := models.Members{MemberId: memberId, Id: primitive.NewObjectID()}
+		if len(w.Rules.AllowedMemberApps) == 0 {
+			w.Rules.AllowedMemberApps = append(w.Rules.AllowedMemberApps, models.AllowedMemberApps{AppId: w.ApplicationId})
+		}
+		w.Rules.AllowedMemberApps[0].Members = append(w.Rules.AllowedMemberApps[0].Members, allowed)
+	}
+
+	if _, err := workspaceDb.Collection("workspaces").ReplaceOne(context.Background(), bson.M{"_id": w.ID}, w.Workspace); err != nil {
+		t.Fatalf("mongotest: updating workspace: %v", err)
+	}
+
+	if financeRole != "" {
+		Insert(t, db, "member_role", models.MemberRole{
+			Id:          primitive.NewObjectID(),
+			WorkspaceId: w.ID,
+			MemberId:    memberId,
+			Role:        financeRole,
+		})
+	}
+
+	return memberId
+}
+
+// Insert writes the documents to the collection, failing the test on error
+func Insert(t testing.TB, db *mongo.Database, collection string, docs ...any) {
+	t.Helper()
+
+	if len(docs) == 0 {
+		return
+	}
+	if _, err := db.Collection(collection).InsertMany(context.Background(), docs, options.InsertMany().SetOrdered(true)); err != nil {
+		t.Fatalf("mongotest: inserting in %s: %v", collection, err)
+	}
+}
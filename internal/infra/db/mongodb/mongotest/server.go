@@ -0,0 +1,316 @@
+package mongotest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	opReply = 1
+	opQuery = 2004
+	opMsg   = 2013
+
+	maxMessageSize = 48000000
+)
+
+// Server is an in-process stand-in for a single-node MongoDB replica set. It speaks the wire protocol over
+// TCP, keeps the data in memory and implements the commands and query operators the repositories use.
+// Transactions are rolled back on abort but are not isolated from the other sessions.
+type Server struct {
+	listener    net.Listener
+	store       *store
+	connections sync.WaitGroup
+	lastId      atomic.Int32
+
+	mu    sync.Mutex
+	conns map[net.Conn]bool
+}
+
+// NewServer starts listening on a free local port
+func NewServer() (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{
+		listener: listener,
+		store:    newStore(),
+		conns:    map[net.Conn]bool{},
+	}
+	go s.serve()
+
+	return s, nil
+}
+
+// URI is the connection string of the server. The driver must connect to it directly, there is no other
+// member to discover.
+func (s *Server) URI() string {
+	return "mongodb://" + s.listener.Addr().String() + "/?directConnection=true"
+}
+
+// Close stops accepting connections and closes the open ones
+func (s *Server) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.connections.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = true
+		s.mu.Unlock()
+
+		s.connections.Add(1)
+		go func() {
+			defer s.connections.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+type header struct {
+	length     int32
+	requestId  int32
+	responseTo int32
+	opCode     int32
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		h, body, err := readMessage(reader)
+		if err != nil {
+			return
+		}
+
+		var reply []byte
+		switch h.opCode {
+		case opMsg:
+			command, err := parseMsg(body)
+			if err != nil {
+				return
+			}
+			reply = s.msgReply(h.requestId, s.run(command))
+		case opQuery:
+			command, err := parseQuery(body)
+			if err != nil {
+				return
+			}
+			reply = s.queryReply(h.requestId, s.run(command))
+		default:
+			// compression is never negotiated, any other opcode is a client this stand-in does not speak to
+			return
+		}
+
+		if _, err := conn.Write(reply); err != nil {
+			return
+		}
+	}
+}
+
+func readMessage(r io.Reader) (header, []byte, error) {
+	var raw [16]byte
+	if _, err := io.ReadFull(r, raw[:]); err != nil {
+		return header{}, nil, err
+	}
+
+	h := header{
+		length:     int32(binary.LittleEndian.Uint32(raw[0:])),
+		requestId:  int32(binary.LittleEndian.Uint32(raw[4:])),
+		responseTo: int32(binary.LittleEndian.Uint32(raw[8:])),
+		opCode:     int32(binary.LittleEndian.Uint32(raw[12:])),
+	}
+	if h.length < 16 || h.length > maxMessageSize {
+		return header{}, nil, fmt.Errorf("invalid message length %d", h.length)
+	}
+
+	body := make([]byte, h.length-16)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return header{}, nil, err
+	}
+	return h, body, nil
+}
+
+// readDocument splits the BSON document at the start of b from the rest
+func readDocument(b []byte) (bson.Raw, []byte, error) {
+	if len(b) < 4 {
+		return nil, nil, errors.New("document too short")
+	}
+	length := int(binary.LittleEndian.Uint32(b))
+	if length < 5 || length > len(b) {
+		return nil, nil, errors.New("invalid document length")
+	}
+	return bson.Raw(b[:length]), b[length:], nil
+}
+
+func readCString(b []byte) (string, []byte, error) {
+	for i, c := range b {
+		if c == 0 {
+			return string(b[:i]), b[i+1:], nil
+		}
+	}
+	return "", nil, errors.New("unterminated string")
+}
+
+func decode(raw bson.Raw) (bson.D, error) {
+	var doc bson.D
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+	return normalize(doc).(bson.D), nil
+}
+
+// parseMsg reads an OP_MSG: the body section is the command and each document sequence becomes one of its arrays
+func parseMsg(b []byte) (bson.D, error) {
+	if len(b) < 4 {
+		return nil, errors.New("message too short")
+	}
+	flags := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+	if flags&1 != 0 {
+		b = b[:len(b)-4] // checksum
+	}
+
+	var command bson.D
+	var sequences bson.D
+	for len(b) > 0 {
+		kind := b[0]
+		b = b[1:]
+
+		switch kind {
+		case 0:
+			raw, rest, err := readDocument(b)
+			if err != nil {
+				return nil, err
+			}
+			if command, err = decode(raw); err != nil {
+				return nil, err
+			}
+			b = rest
+		case 1:
+			if len(b) < 4 {
+				return nil, errors.New("sequence too short")
+			}
+			size := int(binary.LittleEndian.Uint32(b))
+			if size < 4 || size > len(b) {
+				return nil, errors.New("invalid sequence size")
+			}
+			section := b[4:size]
+			b = b[size:]
+
+			identifier, section, err := readCString(section)
+			if err != nil {
+				return nil, err
+			}
+			docs := bson.A{}
+			for len(section) > 0 {
+				raw, rest, err := readDocument(section)
+				if err != nil {
+					return nil, err
+				}
+				doc, err := decode(raw)
+				if err != nil {
+					return nil, err
+				}
+				docs = append(docs, doc)
+				section = rest
+			}
+			sequences = append(sequences, bson.E{Key: identifier, Value: docs})
+		default:
+			return nil, fmt.Errorf("unknown section kind %d", kind)
+		}
+	}
+
+	return append(command, sequences...), nil
+}
+
+// parseQuery reads the legacy OP_QUERY the driver still uses for the first handshake
+func parseQuery(b []byte) (bson.D, error) {
+	if len(b) < 4 {
+		return nil, errors.New("message too short")
+	}
+	namespace, rest, err := readCString(b[4:])
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < 8 {
+		return nil, errors.New("message too short")
+	}
+
+	raw, _, err := readDocument(rest[8:])
+	if err != nil {
+		return nil, err
+	}
+	query, err := decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	if wrapped := docValue(query, "$query"); wrapped != nil {
+		query = wrapped
+	}
+
+	db, _, _ := cutNamespace(namespace)
+	return append(query, bson.E{Key: "$db", Value: db}), nil
+}
+
+func (s *Server) msgReply(responseTo int32, reply bson.D) []byte {
+	doc, err := bson.Marshal(reply)
+	if err != nil {
+		doc, _ = bson.Marshal(errorReply(&commandError{Code: 1, CodeName: "InternalError", Message: err.Error()}))
+	}
+
+	b := s.header(opMsg, responseTo, 4+1+len(doc))
+	b = binary.LittleEndian.AppendUint32(b, 0)
+	b = append(b, 0)
+	return append(b, doc...)
+}
+
+func (s *Server) queryReply(responseTo int32, reply bson.D) []byte {
+	doc, err := bson.Marshal(reply)
+	if err != nil {
+		doc, _ = bson.Marshal(errorReply(&commandError{Code: 1, CodeName: "InternalError", Message: err.Error()}))
+	}
+
+	b := s.header(opReply, responseTo, 4+8+4+4+len(doc))
+	b = binary.LittleEndian.AppendUint32(b, 0) // response flags
+	b = binary.LittleEndian.AppendUint64(b, 0) // cursor id
+	b = binary.LittleEndian.AppendUint32(b, 0) // starting from
+	b = binary.LittleEndian.AppendUint32(b, 1) // number returned
+	return append(b, doc...)
+}
+
+func (s *Server) header(opCode int32, responseTo int32, bodyLength int) []byte {
+	b := make([]byte, 0, 16+bodyLength)
+	b = binary.LittleEndian.AppendUint32(b, uint32(16+bodyLength))
+	b = binary.LittleEndian.AppendUint32(b, uint32(s.lastId.Add(1)))
+	b = binary.LittleEndian.AppendUint32(b, uint32(responseTo))
+	return binary.LittleEndian.AppendUint32(b, uint32(opCode))
+}
@@ -0,0 +1,147 @@
+package mongotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestServerFind(t *testing.T) {
+	db := Database(t)
+	value, _ := primitive.ParseDecimal128("20.00")
+	Insert(t, db, "transaction",
+		bson.M{"_id": 1, "name": "Aluguel", "value": value, "tags": bson.A{bson.M{"tag_id": "a"}}, "main_count": 1},
+		bson.M{"_id": 2, "name": "Feira", "value": 15.5, "tags": bson.A{bson.M{"tag_id": "b"}}, "is_deleted": true},
+		bson.M{"_id": 3, "name": "Padaria", "value": int64(8), "tags": bson.A{}, "main_count": 3},
+	)
+
+	tests := []struct {
+		name   string
+		filter bson.M
+		want   []int32
+	}{
+		{name: "equality", filter: bson.M{"name": "Feira"}, want: []int32{2}},
+		{name: "numbers of different types", filter: bson.M{"value": bson.M{"$gt": 10}}, want: []int32{1, 2}},
+		{name: "missing field with $ne", filter: bson.M{"is_deleted": bson.M{"$ne": true}}, want: []int32{1, 3}},
+		{name: "$in", filter: bson.M{"_id": bson.M{"$in": bson.A{1, 3}}}, want: []int32{1, 3}},
+		{name: "$lte", filter: bson.M{"main_count": bson.M{"$lte": 2}}, want: []int32{1}},
+		{name: "$or", filter: bson.M{"$or": bson.A{bson.M{"name": "Aluguel"}, bson.M{"main_count": 3}}}, want: []int32{1, 3}},
+		{name: "$elemMatch", filter: bson.M{"tags": bson.M{"$elemMatch": bson.M{"tag_id": "b"}}}, want: []int32{2}},
+		{name: "array element by path", filter: bson.M{"tags.tag_id": "a"}, want: []int32{1}},
+		{name: "$exists", filter: bson.M{"main_count": bson.M{"$exists": false}}, want: []int32{2}},
+		{name: "$regex", filter: bson.M{"name": bson.M{"$regex": "^p", "$options": "i"}}, want: []int32{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := db.Collection("transaction").Find(context.Background(), tt.filter, options.Find().SetSort(bson.M{"_id": 1}))
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+
+			var docs []struct {
+				Id int32 `bson:"_id"`
+			}
+			if err := cursor.All(context.Background(), &docs); err != nil {
+				t.Fatalf("All() error = %v", err)
+			}
+
+			if len(docs) != len(tt.want) {
+				t.Fatalf("Find() = %v, want ids %v", docs, tt.want)
+			}
+			for i, doc := range docs {
+				if doc.Id != tt.want[i] {
+					t.Errorf("Find()[%d] = %v, want %v", i, doc.Id, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestServerUpdate(t *testing.T) {
+	db := Database(t)
+	collection := db.Collection("account_balance")
+	ctx := context.Background()
+
+	filter := bson.M{"account_id": 1, "month": 3}
+	for i := 0; i < 2; i++ {
+		_, err := collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"version": 1}, "$setOnInsert": bson.M{"created": i}}, options.Update().SetUpsert(true))
+		if err != nil {
+			t.Fatalf("UpdateOne() error = %v", err)
+		}
+	}
+
+	var doc struct {
+		AccountId int32 `bson:"account_id"`
+		Version   int32 `bson:"version"`
+		Created   int32 `bson:"created"`
+	}
+	if err := collection.FindOne(ctx, filter).Decode(&doc); err != nil {
+		t.Fatalf("FindOne() error = %v", err)
+	}
+	if doc.AccountId != 1 || doc.Version != 2 || doc.Created != 0 {
+		t.Errorf("upserted document = %+v, want account 1 with version 2 created by the first update", doc)
+	}
+
+	_, err := collection.InsertOne(ctx, bson.M{"_id": "same"})
+	if err == nil {
+		_, err = collection.InsertOne(ctx, bson.M{"_id": "same"})
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		t.Errorf("second InsertOne() error = %v, want a duplicate key error", err)
+	}
+}
+
+func TestServerTransactionAbort(t *testing.T) {
+	db := Database(t)
+	ctx := context.Background()
+	Insert(t, db, "transaction", bson.M{"_id": 1, "name": "Aluguel"})
+
+	session, err := db.Client().StartSession()
+	if err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	defer session.EndSession(ctx)
+
+	failure := errors.New("second leg failed")
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		if _, err := db.Collection("transaction").InsertOne(sc, bson.M{"_id": 2, "name": "Transferência"}); err != nil {
+			return nil, err
+		}
+		if _, err := db.Collection("transaction").UpdateOne(sc, bson.M{"_id": 1}, bson.M{"$set": bson.M{"name": "Alterado"}}); err != nil {
+			return nil, err
+		}
+		if _, err := db.Collection("transfer").InsertOne(sc, bson.M{"_id": 1}); err != nil {
+			return nil, err
+		}
+		return nil, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("WithTransaction() error = %v, want %v", err, failure)
+	}
+
+	count, err := db.Collection("transaction").CountDocuments(ctx, bson.M{})
+	if err != nil || count != 1 {
+		t.Errorf("CountDocuments() = %d, %v, want only the document inserted before the transaction", count, err)
+	}
+
+	var doc bson.M
+	if err := db.Collection("transaction").FindOne(ctx, bson.M{"_id": 1}).Decode(&doc); err != nil || doc["name"] != "Aluguel" {
+		t.Errorf("FindOne() = %v, %v, want the update rolled back", doc, err)
+	}
+
+	names, err := db.ListCollectionNames(ctx, bson.M{})
+	if err != nil {
+		t.Fatalf("ListCollectionNames() error = %v", err)
+	}
+	for _, name := range names {
+		if name == "transfer" {
+			t.Errorf("collection created in the aborted transaction still exists")
+		}
+	}
+}
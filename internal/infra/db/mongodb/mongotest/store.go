@@ -0,0 +1,244 @@
+package mongotest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// collection keeps the documents in insertion order, the natural order of a find without sort
+type collection struct {
+	docs    []bson.D
+	indexes []bson.D
+}
+
+// textFields returns the fields of the text index, nil when the collection has none
+func (c *collection) textFields() []string {
+	if c == nil {
+		return nil
+	}
+	for _, index := range c.indexes {
+		var fields []string
+		for _, key := range docValue(index, "key") {
+			if key.Value == "text" {
+				fields = append(fields, key.Key)
+			}
+		}
+		if fields != nil {
+			return fields
+		}
+	}
+	return nil
+}
+
+func (c *collection) find(filter bson.D) []int {
+	var positions []int
+	for i, doc := range c.docs {
+		if c.matches(doc, filter) {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+func (c *collection) hasId(id any, except int) bool {
+	for i, doc := range c.docs {
+		if i == except {
+			continue
+		}
+		if existing, ok := lookupKey(doc, "_id"); ok && valuesEqual(existing, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// transaction remembers the collections as they were before its first write to each of them,
+// an abort puts them back. Writes are not isolated from the other sessions while it runs.
+type transaction struct {
+	number int64
+	saved  map[string]*collection
+}
+
+// store is the whole server state, every command runs holding its lock
+type store struct {
+	mu           sync.Mutex
+	collections  map[string]*collection // keyed by namespace, "db.collection"
+	transactions map[string]*transaction
+}
+
+func newStore() *store {
+	return &store{
+		collections:  map[string]*collection{},
+		transactions: map[string]*transaction{},
+	}
+}
+
+func (s *store) collection(ns string) *collection {
+	return s.collections[ns]
+}
+
+// writable returns the collection to write to, creating it, and saves it first for an open transaction
+func (s *store) writable(ns string, txn *transaction) *collection {
+	c := s.collections[ns]
+	if txn != nil {
+		if _, saved := txn.saved[ns]; !saved {
+			var before *collection
+			if c != nil {
+				before = &collection{
+					docs:    append([]bson.D(nil), c.docs...),
+					indexes: append([]bson.D(nil), c.indexes...),
+				}
+			}
+			txn.saved[ns] = before
+		}
+	}
+
+	if c == nil {
+		c = &collection{}
+		s.collections[ns] = c
+	}
+	return c
+}
+
+func (s *store) abort(txn *transaction) {
+	for ns, before := range txn.saved {
+		if before == nil {
+			delete(s.collections, ns)
+			continue
+		}
+		s.collections[ns] = before
+	}
+}
+
+func (s *store) dropDatabase(db string) {
+	for ns := range s.collections {
+		if strings.HasPrefix(ns, db+".") {
+			delete(s.collections, ns)
+		}
+	}
+}
+
+func (s *store) collectionNames(db string) []string {
+	var names []string
+	for ns := range s.collections {
+		if name, ok := strings.CutPrefix(ns, db+"."); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sorted orders the positions of the documents by the sort specification
+func (c *collection) sorted(positions []int, spec bson.D) []int {
+	docs := make([]bson.D, len(positions))
+	for i, position := range positions {
+		docs[i] = append(bson.D{{Key: "\x00position", Value: int32(position)}}, c.docs[position]...)
+	}
+	sortDocs(docs, spec)
+
+	out := make([]int, len(docs))
+	for i, doc := range docs {
+		out[i] = int(doc[0].Value.(int32))
+	}
+	return out
+}
+
+// sortDocs orders the documents by the sort specification, keeping the natural order between equals
+func sortDocs(docs []bson.D, spec bson.D) {
+	if len(spec) == 0 {
+		return
+	}
+
+	sort.SliceStable(docs, func(i, j int) bool {
+		for _, key := range spec {
+			direction, _ := intValue(key.Value)
+			a, b := sortValue(docs[i], key.Key, direction), sortValue(docs[j], key.Key, direction)
+			c := compareValues(a, b)
+			if direction < 0 {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+}
+
+// sortValue is the value a document sorts by: the smallest element of an array ascending, the largest descending
+func sortValue(doc bson.D, path string, direction int64) any {
+	values := lookupPath(doc, path)
+	if len(values) == 0 {
+		return nil
+	}
+
+	var candidates []any
+	for _, v := range values {
+		if array, ok := v.(bson.A); ok && len(array) > 0 {
+			candidates = append(candidates, array...)
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+
+	best := candidates[0]
+	for _, v := range candidates[1:] {
+		c := compareValues(v, best)
+		if (direction >= 0 && c < 0) || (direction < 0 && c > 0) {
+			best = v
+		}
+	}
+	return best
+}
+
+// project applies an inclusion or an exclusion projection, _id is kept unless excluded
+func project(doc bson.D, projection bson.D) bson.D {
+	if len(projection) == 0 {
+		return doc
+	}
+
+	inclusion := false
+	keepId := true
+	for _, field := range projection {
+		include := boolKey(bson.D{field}, field.Key)
+		if field.Key == "_id" {
+			keepId = include
+			continue
+		}
+		inclusion = include
+	}
+
+	if !inclusion {
+		out := doc
+		for _, field := range projection {
+			if field.Key != "_id" || !keepId {
+				out = unsetPath(out, strings.Split(field.Key, ".")).(bson.D)
+			}
+		}
+		return out
+	}
+
+	out := bson.D{}
+	if id, ok := lookupKey(doc, "_id"); ok && keepId {
+		out = append(out, bson.E{Key: "_id", Value: id})
+	}
+	for _, field := range projection {
+		if field.Key == "_id" {
+			continue
+		}
+		path := strings.Split(field.Key, ".")
+		if values := lookup(doc, path); len(values) > 0 {
+			out = setPath(out, path, values[0]).(bson.D)
+		}
+	}
+	return out
+}
+
+func newId() primitive.ObjectID {
+	return primitive.NewObjectID()
+}
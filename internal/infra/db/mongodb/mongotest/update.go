@@ -0,0 +1,285 @@
+package mongotest
+
+import (
+	"math/big"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func isReplacement(update bson.D) bool {
+	return len(update) == 0 || !isOperator(update[0].Key)
+}
+
+// applyUpdate returns a copy of the document with the update operators or the replacement applied.
+// $setOnInsert only counts when the document is being inserted by an upsert.
+func applyUpdate(doc bson.D, update bson.D, inserting bool) bson.D {
+	if isReplacement(update) {
+		replacement := copyDoc(update)
+		if id, ok := lookupKey(doc, "_id"); ok {
+			if newId, hasId := lookupKey(replacement, "_id"); hasId && !valuesEqual(id, newId) {
+				fail(66, "ImmutableField", "the (immutable) field '_id' was found to have been altered")
+			}
+			replacement = withId(replacement, id)
+		}
+		return replacement
+	}
+
+	out := copyDoc(doc)
+	for _, op := range update {
+		fields, ok := op.Value.(bson.D)
+		if !ok {
+			badValue("modifier " + op.Key + " needs an Object")
+		}
+
+		for _, field := range fields {
+			path := strings.Split(field.Key, ".")
+			switch op.Key {
+			case "$set":
+				out = setPath(out, path, deepCopy(field.Value)).(bson.D)
+			case "$setOnInsert":
+				if inserting {
+					out = setPath(out, path, deepCopy(field.Value)).(bson.D)
+				}
+			case "$unset":
+				out = unsetPath(out, path).(bson.D)
+			case "$inc":
+				current := lookup(out, path)
+				var value any = int32(0)
+				if len(current) > 0 {
+					value = current[0]
+				}
+				out = setPath(out, path, addNumbers(value, field.Value)).(bson.D)
+			case "$min", "$max":
+				current := lookup(out, path)
+				c := 0
+				if len(current) > 0 {
+					c = compareValues(field.Value, current[0])
+				}
+				if len(current) == 0 || (op.Key == "$min" && c < 0) || (op.Key == "$max" && c > 0) {
+					out = setPath(out, path, deepCopy(field.Value)).(bson.D)
+				}
+			case "$currentDate":
+				out = setPath(out, path, primitive.NewDateTimeFromTime(time.Now())).(bson.D)
+			case "$push", "$addToSet":
+				out = setPath(out, path, pushValues(lookup(out, path), field.Value, op.Key == "$addToSet")).(bson.D)
+			case "$pull":
+				current := lookup(out, path)
+				if len(current) == 0 {
+					continue
+				}
+				array, ok := current[0].(bson.A)
+				if !ok {
+					badValue("cannot apply $pull to a non-array value")
+				}
+				out = setPath(out, path, pullValues(array, field.Value)).(bson.D)
+			default:
+				fail(9, "FailedToParse", "unknown modifier: "+op.Key)
+			}
+		}
+	}
+	return out
+}
+
+func withId(doc bson.D, id any) bson.D {
+	out := bson.D{{Key: "_id", Value: id}}
+	for _, e := range doc {
+		if e.Key != "_id" {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// setPath sets the value at the dotted path, creating the documents missing on the way
+func setPath(v any, path []string, value any) any {
+	switch x := v.(type) {
+	case bson.D:
+		for i, e := range x {
+			if e.Key == path[0] {
+				if len(path) == 1 {
+					x[i].Value = value
+				} else {
+					x[i].Value = setPath(e.Value, path[1:], value)
+				}
+				return x
+			}
+		}
+		if len(path) == 1 {
+			return append(x, bson.E{Key: path[0], Value: value})
+		}
+		return append(x, bson.E{Key: path[0], Value: setPath(bson.D{}, path[1:], value)})
+	case bson.A:
+		i, ok := arrayIndex(path[0])
+		if !ok {
+			fail(28, "PathNotViable", "cannot create field '"+path[0]+"' in an array")
+		}
+		for len(x) <= i {
+			x = append(x, nil)
+		}
+		if len(path) == 1 {
+			x[i] = value
+		} else {
+			if x[i] == nil {
+				x[i] = bson.D{}
+			}
+			x[i] = setPath(x[i], path[1:], value)
+		}
+		return x
+	case nil:
+		return setPath(bson.D{}, path, value)
+	}
+
+	fail(28, "PathNotViable", "cannot create field '"+path[0]+"' in a non-document value")
+	return nil
+}
+
+func unsetPath(v any, path []string) any {
+	switch x := v.(type) {
+	case bson.D:
+		for i, e := range x {
+			if e.Key != path[0] {
+				continue
+			}
+			if len(path) == 1 {
+				return append(x[:i:i], x[i+1:]...)
+			}
+			x[i].Value = unsetPath(e.Value, path[1:])
+			return x
+		}
+	case bson.A:
+		if i, ok := arrayIndex(path[0]); ok && i < len(x) {
+			if len(path) == 1 {
+				x[i] = nil
+			} else {
+				x[i] = unsetPath(x[i], path[1:])
+			}
+		}
+	}
+	return v
+}
+
+func pushValues(current []any, value any, unique bool) bson.A {
+	var array bson.A
+	if len(current) > 0 {
+		existing, ok := current[0].(bson.A)
+		if !ok && current[0] != nil {
+			badValue("the field to push to must be an array")
+		}
+		array = existing
+	}
+	if array == nil {
+		array = bson.A{}
+	}
+
+	values := bson.A{value}
+	if d, ok := value.(bson.D); ok && len(d) > 0 && d[0].Key == "$each" {
+		values, _ = d[0].Value.(bson.A)
+	}
+
+	for _, v := range values {
+		if unique && matchesEqual([]any{array}, v) {
+			continue
+		}
+		array = append(array, deepCopy(v))
+	}
+	return array
+}
+
+func pullValues(array bson.A, condition any) bson.A {
+	out := bson.A{}
+	for _, element := range array {
+		var remove bool
+		if d, isDoc := condition.(bson.D); isDoc && !isOperatorDoc(condition) {
+			doc, elementIsDoc := element.(bson.D)
+			remove = elementIsDoc && (*collection)(nil).matches(doc, d)
+		} else {
+			remove = matchesCondition([]any{element}, condition)
+		}
+		if !remove {
+			out = append(out, element)
+		}
+	}
+	return out
+}
+
+// addNumbers adds keeping the widest type of the two operands, like $inc and $sum
+func addNumbers(a, b any) any {
+	if a == nil {
+		a = int32(0)
+	}
+	ra, okA := toRat(a)
+	rb, okB := toRat(b)
+	if !okA || !okB {
+		fail(14, "TypeMismatch", "cannot add a non-numeric value")
+	}
+	sum := new(big.Rat).Add(ra, rb)
+
+	_, decA := a.(primitive.Decimal128)
+	_, decB := b.(primitive.Decimal128)
+	_, floatA := a.(float64)
+	_, floatB := b.(float64)
+	_, longA := a.(int64)
+	_, longB := b.(int64)
+
+	switch {
+	case decA || decB:
+		d, _ := primitive.ParseDecimal128(ratString(sum))
+		return d
+	case floatA || floatB:
+		f, _ := sum.Float64()
+		return f
+	case longA || longB:
+		return sum.Num().Int64()
+	}
+
+	n := sum.Num().Int64()
+	if n > 1<<31-1 || n < -1<<31 {
+		return n
+	}
+	return int32(n)
+}
+
+// ratString writes the rational as a decimal, with up to 34 digits after the point for the inexact ones
+func ratString(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	for digits := 1; digits <= 34; digits++ {
+		s := r.FloatString(digits)
+		if back, ok := new(big.Rat).SetString(s); ok && back.Cmp(r) == 0 {
+			return s
+		}
+	}
+	return r.FloatString(34)
+}
+
+// upsertSeed builds the document inserted by an upsert from the equality conditions of the filter
+func upsertSeed(filter bson.D) bson.D {
+	doc := bson.D{}
+	for _, e := range filter {
+		switch {
+		case e.Key == "$and":
+			clauses, _ := e.Value.(bson.A)
+			for _, clause := range clauses {
+				if d, ok := clause.(bson.D); ok {
+					for _, seeded := range upsertSeed(d) {
+						doc = setPath(doc, strings.Split(seeded.Key, "."), seeded.Value).(bson.D)
+					}
+				}
+			}
+		case isOperator(e.Key):
+		case isOperatorDoc(e.Value):
+			if eq, ok := lookupKey(e.Value.(bson.D), "$eq"); ok {
+				doc = setPath(doc, strings.Split(e.Key, "."), deepCopy(eq)).(bson.D)
+			}
+		default:
+			if _, isRegex := e.Value.(primitive.Regex); !isRegex {
+				doc = setPath(doc, strings.Split(e.Key, "."), deepCopy(e.Value)).(bson.D)
+			}
+		}
+	}
+	return doc
+}
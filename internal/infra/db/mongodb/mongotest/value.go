@@ -0,0 +1,306 @@
+package mongotest
+
+import (
+	"bytes"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// commandError is returned to the driver as a failed command. The stand-in panics with it deep inside a
+// filter or an update and the command dispatcher turns it into the reply.
+type commandError struct {
+	Code     int32
+	CodeName string
+	Message  string
+}
+
+func (e *commandError) Error() string {
+	return e.Message
+}
+
+func fail(code int32, codeName, message string) {
+	panic(&commandError{Code: code, CodeName: codeName, Message: message})
+}
+
+func badValue(message string) {
+	fail(2, "BadValue", message)
+}
+
+// typeOrder is the position of the value in the order MongoDB uses to compare values of different types
+func typeOrder(v any) int {
+	switch v.(type) {
+	case primitive.MinKey:
+		return 1
+	case nil, primitive.Null, primitive.Undefined:
+		return 2
+	case int32, int64, float64, primitive.Decimal128:
+		return 3
+	case string, primitive.Symbol:
+		return 4
+	case bson.D:
+		return 5
+	case bson.A:
+		return 6
+	case primitive.Binary:
+		return 7
+	case primitive.ObjectID:
+		return 8
+	case bool:
+		return 9
+	case primitive.DateTime:
+		return 10
+	case primitive.Timestamp:
+		return 11
+	case primitive.Regex:
+		return 12
+	case primitive.MaxKey:
+		return 13
+	}
+	return 14
+}
+
+// toRat converts any numeric BSON value to an exact rational, so Decimal128 cents compare and add without
+// the rounding of float64
+func toRat(v any) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case int32:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, false
+		}
+		return r, true
+	case primitive.Decimal128:
+		r, ok := new(big.Rat).SetString(n.String())
+		return r, ok
+	}
+	return nil, false
+}
+
+// compareValues orders two values the way MongoDB sorts them
+func compareValues(a, b any) int {
+	ta, tb := typeOrder(a), typeOrder(b)
+	if ta != tb {
+		return cmpInt(ta, tb)
+	}
+
+	switch x := a.(type) {
+	case int32, int64, float64, primitive.Decimal128:
+		ra, okA := toRat(x)
+		rb, okB := toRat(b)
+		if !okA || !okB {
+			return cmpInt(boolInt(okA), boolInt(okB))
+		}
+		return ra.Cmp(rb)
+	case string:
+		return strings.Compare(x, stringValue(b))
+	case primitive.Symbol:
+		return strings.Compare(string(x), stringValue(b))
+	case bson.D:
+		y := b.(bson.D)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := compareValues(x[i].Value, y[i].Value); c != 0 {
+				return c
+			}
+			if c := strings.Compare(x[i].Key, y[i].Key); c != 0 {
+				return c
+			}
+		}
+		return cmpInt(len(x), len(y))
+	case bson.A:
+		y := b.(bson.A)
+		for i := 0; i < len(x) && i < len(y); i++ {
+			if c := compareValues(x[i], y[i]); c != 0 {
+				return c
+			}
+		}
+		return cmpInt(len(x), len(y))
+	case primitive.Binary:
+		y := b.(primitive.Binary)
+		if len(x.Data) != len(y.Data) {
+			return cmpInt(len(x.Data), len(y.Data))
+		}
+		if x.Subtype != y.Subtype {
+			return cmpInt(int(x.Subtype), int(y.Subtype))
+		}
+		return bytes.Compare(x.Data, y.Data)
+	case primitive.ObjectID:
+		y := b.(primitive.ObjectID)
+		return bytes.Compare(x[:], y[:])
+	case bool:
+		return cmpInt(boolInt(x), boolInt(b.(bool)))
+	case primitive.DateTime:
+		return cmpInt64(int64(x), int64(b.(primitive.DateTime)))
+	case primitive.Timestamp:
+		return primitive.CompareTimestamp(x, b.(primitive.Timestamp))
+	case primitive.Regex:
+		y := b.(primitive.Regex)
+		if c := strings.Compare(x.Pattern, y.Pattern); c != 0 {
+			return c
+		}
+		return strings.Compare(x.Options, y.Options)
+	}
+	return 0
+}
+
+func stringValue(v any) string {
+	if s, ok := v.(primitive.Symbol); ok {
+		return string(s)
+	}
+	s, _ := v.(string)
+	return s
+}
+
+func cmpInt(a, b int) int {
+	return cmpInt64(int64(a), int64(b))
+}
+
+func cmpInt64(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func valuesEqual(a, b any) bool {
+	return typeOrder(a) == typeOrder(b) && compareValues(a, b) == 0
+}
+
+// deepCopy copies documents and arrays, the stored documents are never shared with a command
+func deepCopy(v any) any {
+	switch x := v.(type) {
+	case bson.D:
+		out := make(bson.D, len(x))
+		for i, e := range x {
+			out[i] = bson.E{Key: e.Key, Value: deepCopy(e.Value)}
+		}
+		return out
+	case bson.A:
+		out := make(bson.A, len(x))
+		for i, e := range x {
+			out[i] = deepCopy(e)
+		}
+		return out
+	}
+	return v
+}
+
+func copyDoc(doc bson.D) bson.D {
+	return deepCopy(doc).(bson.D)
+}
+
+// normalize turns the maps and typed slices of a decoded value into bson.D and bson.A
+func normalize(v any) any {
+	switch x := v.(type) {
+	case bson.D:
+		out := make(bson.D, len(x))
+		for i, e := range x {
+			out[i] = bson.E{Key: e.Key, Value: normalize(e.Value)}
+		}
+		return out
+	case bson.M:
+		out := make(bson.D, 0, len(x))
+		for k, e := range x {
+			out = append(out, bson.E{Key: k, Value: normalize(e)})
+		}
+		return out
+	case bson.A:
+		out := make(bson.A, len(x))
+		for i, e := range x {
+			out[i] = normalize(e)
+		}
+		return out
+	case []any:
+		return normalize(bson.A(x))
+	case int:
+		return int64(x)
+	}
+	return v
+}
+
+func lookupKey(doc bson.D, key string) (any, bool) {
+	for _, e := range doc {
+		if e.Key == key {
+			return e.Value, true
+		}
+	}
+	return nil, false
+}
+
+func docValue(doc bson.D, key string) bson.D {
+	v, _ := lookupKey(doc, key)
+	d, _ := v.(bson.D)
+	return d
+}
+
+func arrayValue(doc bson.D, key string) bson.A {
+	v, _ := lookupKey(doc, key)
+	a, _ := v.(bson.A)
+	return a
+}
+
+func stringKey(doc bson.D, key string) string {
+	v, _ := lookupKey(doc, key)
+	s, _ := v.(string)
+	return s
+}
+
+func boolKey(doc bson.D, key string) bool {
+	v, ok := lookupKey(doc, key)
+	if !ok {
+		return false
+	}
+	if b, isBool := v.(bool); isBool {
+		return b
+	}
+	if r, isNumber := toRat(v); isNumber {
+		return r.Sign() != 0
+	}
+	return v != nil
+}
+
+func intValue(v any) (int64, bool) {
+	r, ok := toRat(v)
+	if !ok || !r.IsInt() {
+		return 0, false
+	}
+	return r.Num().Int64(), true
+}
+
+func intKey(doc bson.D, key string) int64 {
+	v, _ := lookupKey(doc, key)
+	n, _ := intValue(v)
+	return n
+}
+
+func isOperator(key string) bool {
+	return strings.HasPrefix(key, "$")
+}
+
+// isOperatorDoc reports whether a filter value is a set of operators ({"$gt": 1}) rather than a document to compare
+func isOperatorDoc(v any) bool {
+	d, ok := v.(bson.D)
+	return ok && len(d) > 0 && isOperator(d[0].Key)
+}
+
+func arrayIndex(part string) (int, bool) {
+	i, err := strconv.Atoi(part)
+	return i, err == nil && i >= 0
+}
@@ -31,6 +31,7 @@ func (c *CreateAccountMongoRepository) Create(account *models.Account) (*models.
 		WorkspaceId: account.WorkspaceId,
 		BankId:      account.BankId,
 		Balance:     account.Balance,
+		Currency:    account.Currency,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
@@ -49,5 +50,6 @@ func (c *CreateAccountMongoRepository) Create(account *models.Account) (*models.
 		WorkspaceId: accountToSave.WorkspaceId,
 		BankId:      accountToSave.BankId,
 		Balance:     accountToSave.Balance,
+		Currency:    accountToSave.Currency,
 	}, nil
 }
@@ -2,7 +2,9 @@ package account_repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,11 +24,37 @@ func NewDeleteAccountMongoRepository(db *mongo.Database) *DeleteAccountMongoRepo
 func (d *DeleteAccountMongoRepository) Delete(accountIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
 	collection := d.Db.Collection("account")
 
-	filter := bson.M{"_id": bson.M{"$in": accountIds}, "workspace_id": workspaceId}
+	filter := bson.M{"_id": bson.M{"$in": accountIds}, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, filter)
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var accounts []models.Account
+	if err := cursor.All(ctx, &accounts); err != nil {
+		return err
+	}
+
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	deletedIds := make([]primitive.ObjectID, len(accounts))
+	for i, account := range accounts {
+		deletedIds[i] = account.Id
+	}
+
+	// os vínculos com as transações são desfeitos, mas ficam guardados na lixeira para a restauração
+	references, err := helpers.FindTrashReferences(ctx, d.Db, "transaction", "account_id", workspaceId, deletedIds)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err = collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}})
 	if err != nil {
 		return err
 	}
@@ -35,9 +63,27 @@ func (d *DeleteAccountMongoRepository) Delete(accountIds []primitive.ObjectID, w
 	transactionCollection := d.Db.Collection("transaction")
 	_, err = transactionCollection.UpdateMany(
 		ctx,
-		bson.M{"account_id": bson.M{"$in": accountIds}, "workspace_id": workspaceId},
+		bson.M{"account_id": bson.M{"$in": deletedIds}, "workspace_id": workspaceId},
 		bson.M{"$unset": bson.M{"account_id": ""}},
 	)
+	if err != nil {
+		return err
+	}
+
+	trash := make([]models.TrashItem, 0, len(accounts))
+	for _, account := range accounts {
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "ACCOUNT",
+			EntityId:    account.Id,
+			Name:        account.Name,
+			References:  references[account.Id],
+			DeletedAt:   now,
+		})
+	}
+	if err := helpers.MoveToTrash(ctx, d.Db, trash...); err != nil {
+		return err
+	}
 
-	return err
+	return helpers.DeleteAccountBalanceSnapshots(d.Db, workspaceId, deletedIds)
 }
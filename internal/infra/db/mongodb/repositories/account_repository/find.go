@@ -26,7 +26,7 @@ func NewFindAccountsRepository(db *mongo.Database) *FindAccountsRepository {
 func (c *FindAccountsRepository) Find(globalFilters *presentationHelpers.GlobalFilterParams) ([]models.Account, error) {
 	collection := c.Db.Collection("account")
 
-	filter := bson.M{"workspace_id": globalFilters.WorkspaceId}
+	filter := bson.M{"workspace_id": globalFilters.WorkspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
@@ -44,16 +44,113 @@ func (c *FindAccountsRepository) Find(globalFilters *presentationHelpers.GlobalF
 		return accounts, nil
 	}
 
-	// Optimize by fetching all transactions for all accounts in batch
-	if err := c.calculateAccountBalances(accounts, globalFilters); err != nil {
+	rates, err := helpers.FindExchangeRates(c.Db, globalFilters.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.applyBalanceSnapshots(accounts, globalFilters, rates); err != nil {
 		return nil, err
 	}
 
+	applyBaseBalances(accounts, globalFilters, rates)
+
 	return accounts, nil
 }
 
+// applyBaseBalances converts the balances of the accounts in another currency to the base currency of the workspace,
+// at the rate of the end of the month. They are left empty when there is no rate to convert them.
+func applyBaseBalances(accounts []models.Account, globalFilters *presentationHelpers.GlobalFilterParams, rates *models.ExchangeRates) {
+	endOfMonth := time.Date(globalFilters.Year, time.Month(globalFilters.Month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0).Add(-time.Second)
+
+	for i := range accounts {
+		if rates.Currency(accounts[i].Currency) == rates.BaseCurrency {
+			continue
+		}
+
+		rate, err := rates.Rate(accounts[i].Currency, rates.BaseCurrency, endOfMonth)
+		if err != nil {
+			continue
+		}
+
+		baseBalance := accounts[i].Balance.Mul(rate)
+		baseCurrentBalance := accounts[i].CurrentBalance.Mul(rate)
+		accounts[i].BaseBalance = &baseBalance
+		accounts[i].BaseCurrentBalance = &baseCurrentBalance
+	}
+}
+
+// applyBalanceSnapshots reads the month balances from the ledger and only recalculates
+// the accounts whose snapshot is missing or was invalidated by a transaction write
+func (c *FindAccountsRepository) applyBalanceSnapshots(accounts []models.Account, globalFilters *presentationHelpers.GlobalFilterParams, rates *models.ExchangeRates) error {
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	accountIDs := make([]primitive.ObjectID, len(accounts))
+	for i, account := range accounts {
+		accountIDs[i] = account.Id
+	}
+
+	// the versions must be read before calculating, so a write that happens meanwhile makes the new snapshot stale
+	versions, err := helpers.AccountBalanceVersions(c.Db, globalFilters.WorkspaceId, accountIDs)
+	if err != nil {
+		return err
+	}
+
+	snapshots, err := helpers.FindAccountBalanceSnapshots(c.Db, globalFilters.WorkspaceId, accountIDs, globalFilters.Year, globalFilters.Month, versions)
+	if err != nil {
+		return err
+	}
+
+	var missing []models.Account
+	var missingIndexes []int
+	for i := range accounts {
+		snapshot, exists := snapshots[accounts[i].Id]
+		if !exists {
+			missing = append(missing, accounts[i])
+			missingIndexes = append(missingIndexes, i)
+			continue
+		}
+
+		accounts[i].CurrentBalance = accounts[i].Balance + snapshot.CurrentBalance
+		accounts[i].Balance += snapshot.Balance
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	// Optimize by fetching all transactions for all accounts in batch
+	if err := c.calculateAccountBalances(missing, globalFilters, rates); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	newSnapshots := make([]models.AccountBalanceSnapshot, len(missing))
+	for i, account := range missing {
+		initialBalance := accounts[missingIndexes[i]].Balance
+
+		// the snapshot keeps only the transactions movement, the initial balance comes from the account
+		newSnapshots[i] = models.AccountBalanceSnapshot{
+			WorkspaceId:    globalFilters.WorkspaceId,
+			AccountId:      account.Id,
+			Year:           globalFilters.Year,
+			Month:          globalFilters.Month,
+			Balance:        account.Balance - initialBalance,
+			CurrentBalance: account.CurrentBalance - initialBalance,
+			Version:        versions[account.Id],
+			UpdatedAt:      now,
+		}
+
+		accounts[missingIndexes[i]] = account
+	}
+
+	return helpers.SaveAccountBalanceSnapshots(c.Db, newSnapshots)
+}
+
 // New optimized method that maintains the same calculation logic but with batch processing
-func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Account, globalFilters *presentationHelpers.GlobalFilterParams) error {
+func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Account, globalFilters *presentationHelpers.GlobalFilterParams, rates *models.ExchangeRates) error {
 	if len(accounts) == 0 {
 		return nil
 	}
@@ -74,9 +171,14 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Second)
 
 	// Get all transactions in one query (for total balance)
+	// Credit card purchases only leave the account when the invoice is paid, through the payment EXPENSE
+	// Expenses waiting for approval or rejected are not part of the forecast
 	balanceFilter := bson.M{
-		"workspace_id": globalFilters.WorkspaceId,
-		"account_id":   bson.M{"$in": accountIDs},
+		"workspace_id":    globalFilters.WorkspaceId,
+		"account_id":      bson.M{"$in": accountIDs},
+		"credit_card_id":  nil,
+		"is_deleted":      bson.M{"$ne": true},
+		"approval_status": bson.M{"$nin": []string{models.ApprovalPending, models.ApprovalRejected}},
 		"$or": []bson.M{
 			{
 				"due_date":     bson.M{"$lt": endOfMonth},
@@ -111,6 +213,18 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 		currentBalanceByAccountAndFrequency[accountID]["REPEAT"] = []models.Transaction{}
 	}
 
+	// Transactions in another currency than the account's are converted one by one
+	foreignByAccount := make(map[primitive.ObjectID][]models.Transaction)
+	sameCurrencyTransactions := balanceTransactions[:0]
+	for _, tx := range balanceTransactions {
+		if account, exists := accountMap[*tx.AccountId]; exists && rates.Currency(account.Currency) != rates.Currency(tx.Currency) {
+			foreignByAccount[account.Id] = append(foreignByAccount[account.Id], tx)
+			continue
+		}
+		sameCurrencyTransactions = append(sameCurrencyTransactions, tx)
+	}
+	balanceTransactions = sameCurrencyTransactions
+
 	// Group balance transactions
 	for _, tx := range balanceTransactions {
 		if tx.AccountId != nil {
@@ -135,6 +249,8 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 
 	// Use a WaitGroup to wait for all goroutines to finish
 	var wg sync.WaitGroup
+	// The goroutines of an account add to the same balances
+	var mu sync.Mutex
 
 	// Calculate balances for each account concurrently
 	for accountID, account := range accountMap {
@@ -146,7 +262,9 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			doNotRepeatBalance := helpers.CalculateTransactionBalanceWithEdits(
 				balanceByAccountAndFrequency[accID]["DO_NOT_REPEAT"], c.Db, false)
 			// The total balance includes both confirmed and unconfirmed transactions
+			mu.Lock()
 			acc.Balance += doNotRepeatBalance
+			mu.Unlock()
 		}(accountID, account)
 
 		wg.Add(1)
@@ -156,7 +274,9 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			recurringBalance := helpers.CalculateRecurringTransactionsBalance(
 				balanceByAccountAndFrequency[accID]["RECURRING"], globalFilters.Year, globalFilters.Month, c.Db, false)
 
+			mu.Lock()
 			acc.Balance += recurringBalance
+			mu.Unlock()
 		}(accountID, account)
 
 		wg.Add(1)
@@ -166,7 +286,9 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			repeatBalance := helpers.CalculateRepeatTransactionsBalance(
 				balanceByAccountAndFrequency[accID]["REPEAT"], globalFilters.Year, globalFilters.Month, c.Db, false)
 
+			mu.Lock()
 			acc.Balance += repeatBalance
+			mu.Unlock()
 		}(accountID, account)
 
 		wg.Add(1)
@@ -176,7 +298,9 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			doNotRepeatCurrentBalance := helpers.CalculateTransactionBalanceWithEdits(
 				currentBalanceByAccountAndFrequency[accID]["DO_NOT_REPEAT"], c.Db, true)
 
+			mu.Lock()
 			acc.CurrentBalance += doNotRepeatCurrentBalance
+			mu.Unlock()
 		}(accountID, account)
 
 		wg.Add(1)
@@ -186,7 +310,9 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			recurringCurrentBalance := helpers.CalculateRecurringTransactionsBalance(
 				currentBalanceByAccountAndFrequency[accID]["RECURRING"], globalFilters.Year, globalFilters.Month, c.Db, true)
 
+			mu.Lock()
 			acc.CurrentBalance += recurringCurrentBalance
+			mu.Unlock()
 		}(accountID, account)
 
 		wg.Add(1)
@@ -196,12 +322,33 @@ func (c *FindAccountsRepository) calculateAccountBalances(accounts []models.Acco
 			repeatCurrentBalance := helpers.CalculateRepeatTransactionsBalance(
 				currentBalanceByAccountAndFrequency[accID]["REPEAT"], globalFilters.Year, globalFilters.Month, c.Db, true)
 
+			mu.Lock()
 			acc.CurrentBalance += repeatCurrentBalance
+			mu.Unlock()
 		}(accountID, account)
 	}
 
 	wg.Wait()
 
+	for accountID, transactions := range foreignByAccount {
+		account := accountMap[accountID]
+
+		balance, err := helpers.CalculateConvertedTransactionsBalance(
+			transactions, account.Currency, rates, globalFilters.Year, globalFilters.Month, c.Db, false)
+		if err != nil {
+			return err
+		}
+
+		currentBalance, err := helpers.CalculateConvertedTransactionsBalance(
+			transactions, account.Currency, rates, globalFilters.Year, globalFilters.Month, c.Db, true)
+		if err != nil {
+			return err
+		}
+
+		account.Balance += balance
+		account.CurrentBalance += currentBalance
+	}
+
 	return nil
 }
 
@@ -23,7 +23,7 @@ func NewFindByIdMongoRepository(db *mongo.Database) *FindByIdMongoRepository {
 func (f *FindByIdMongoRepository) Find(id primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Account, error) {
 	collection := f.Db.Collection("account")
 
-	filter := bson.M{"_id": id, "workspace_id": workspaceId}
+	filter := bson.M{"_id": id, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
@@ -23,7 +23,7 @@ func NewFindByNameMongoRepository(db *mongo.Database) *FindByNameMongoRepository
 func (f *FindByNameMongoRepository) FindByNameAndWorkspaceId(name string, workspaceId primitive.ObjectID) (*models.Account, error) {
 	collection := f.Db.Collection("account")
 
-	filter := bson.M{"name": name, "workspace_id": workspaceId}
+	filter := bson.M{"name": name, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
@@ -29,6 +29,7 @@ func (u *UpdateAccountMongoRepository) Update(id primitive.ObjectID, account *mo
 			"name":       account.Name,
 			"bank_id":    account.BankId,
 			"balance":    account.Balance,
+			"currency":   account.Currency,
 			"updated_at": time.Now().UTC(),
 		},
 	}
@@ -46,5 +47,12 @@ func (u *UpdateAccountMongoRepository) Update(id primitive.ObjectID, account *mo
 		return nil, err
 	}
 
+	// the snapshots were calculated converting the transactions to the previous currency
+	if updatedAccount.Currency != account.Currency {
+		if err := helpers.DeleteAccountBalanceSnapshots(u.Db, updatedAccount.WorkspaceId, []primitive.ObjectID{id}); err != nil {
+			return nil, err
+		}
+	}
+
 	return &updatedAccount, nil
 }
@@ -0,0 +1,48 @@
+package approval_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindPendingApprovalRepository handles listing the expenses waiting for approval
+type FindPendingApprovalRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindPendingApprovalRepository creates a new FindPendingApprovalRepository
+func NewFindPendingApprovalRepository(db *mongo.Database) *FindPendingApprovalRepository {
+	return &FindPendingApprovalRepository{Db: db}
+}
+
+// Find returns the pending expenses of the workspace, oldest due date first
+func (r *FindPendingApprovalRepository) Find(workspaceId primitive.ObjectID) ([]models.Transaction, error) {
+	collection := r.Db.Collection("transaction")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"workspace_id":    workspaceId,
+		"approval_status": models.ApprovalPending,
+		"is_deleted":      bson.M{"$ne": true},
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
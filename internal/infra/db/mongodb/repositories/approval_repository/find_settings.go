@@ -0,0 +1,40 @@
+package approval_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindApprovalSettingsRepository handles finding the approval settings of a workspace
+type FindApprovalSettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindApprovalSettingsRepository creates a new FindApprovalSettingsRepository
+func NewFindApprovalSettingsRepository(db *mongo.Database) *FindApprovalSettingsRepository {
+	return &FindApprovalSettingsRepository{Db: db}
+}
+
+// Find returns the settings of the workspace, nil when approval was never configured
+func (r *FindApprovalSettingsRepository) Find(workspaceId primitive.ObjectID) (*models.ApprovalSettings, error) {
+	collection := r.Db.Collection("approval_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var settings models.ApprovalSettings
+	err := collection.FindOne(ctx, bson.M{"workspace_id": workspaceId}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
@@ -0,0 +1,81 @@
+package approval_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReviewTransactionRepository handles approving and rejecting pending expenses
+type ReviewTransactionRepository struct {
+	Db *mongo.Database
+}
+
+// NewReviewTransactionRepository creates a new ReviewTransactionRepository
+func NewReviewTransactionRepository(db *mongo.Database) *ReviewTransactionRepository {
+	return &ReviewTransactionRepository{Db: db}
+}
+
+// Review sets the status of a pending expense. Only pending expenses are matched, so two reviewers
+// can not decide on the same expense.
+func (r *ReviewTransactionRepository) Review(transactionId primitive.ObjectID, workspaceId primitive.ObjectID, status string, approval *models.TransactionApproval) (*models.Transaction, error) {
+	collection := r.Db.Collection("transaction")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"_id":             transactionId,
+		"workspace_id":    workspaceId,
+		"approval_status": models.ApprovalPending,
+		"is_deleted":      bson.M{"$ne": true},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"approval_status": status,
+			"approval":        approval,
+			"updated_at":      time.Now().UTC(),
+		},
+	}
+
+	var transaction models.Transaction
+	err := collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&transaction)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the edited installments are copies of the expense and follow its review
+	_, err = r.Db.Collection("edit_transaction").UpdateMany(ctx,
+		bson.M{"main_id": transactionId, "workspace_id": workspaceId},
+		bson.M{"$set": bson.M{"approval_status": status, "approval": approval}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// an approved expense enters the account forecasts again
+	editCursor, err := r.Db.Collection("edit_transaction").Find(ctx, bson.M{"main_id": transactionId, "workspace_id": workspaceId})
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []*models.Transaction
+	if err := editCursor.All(ctx, &edits); err != nil {
+		return nil, err
+	}
+
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, append(edits, &transaction)...); err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
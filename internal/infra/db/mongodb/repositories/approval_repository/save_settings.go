@@ -0,0 +1,52 @@
+package approval_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveApprovalSettingsRepository handles saving the approval settings of a workspace
+type SaveApprovalSettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewSaveApprovalSettingsRepository creates a new SaveApprovalSettingsRepository
+func NewSaveApprovalSettingsRepository(db *mongo.Database) *SaveApprovalSettingsRepository {
+	return &SaveApprovalSettingsRepository{Db: db}
+}
+
+// Save replaces the settings of the workspace. Expenses already created keep their approval status.
+func (r *SaveApprovalSettingsRepository) Save(settings *models.ApprovalSettings) (*models.ApprovalSettings, error) {
+	collection := r.Db.Collection("approval_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"threshold":    settings.Threshold,
+			"category_ids": settings.CategoryIds,
+			"updated_at":   time.Now().UTC(),
+		},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+	}
+
+	var saved models.ApprovalSettings
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"workspace_id": settings.WorkspaceId},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
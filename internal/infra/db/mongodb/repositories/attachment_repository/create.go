@@ -0,0 +1,39 @@
+package attachment_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CreateAttachmentRepository struct {
+	Db *mongo.Database
+}
+
+func NewCreateAttachmentRepository(db *mongo.Database) *CreateAttachmentRepository {
+	return &CreateAttachmentRepository{
+		Db: db,
+	}
+}
+
+func (r *CreateAttachmentRepository) Create(attachment *models.Attachment) (*models.Attachment, error) {
+	collection := r.Db.Collection("attachment")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	if attachment.Id.IsZero() {
+		attachment.Id = primitive.NewObjectID()
+	}
+	attachment.CreatedAt = time.Now().UTC()
+
+	if _, err := collection.InsertOne(ctx, attachment); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,72 @@
+package attachment_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DeleteAttachmentRepository struct {
+	Db      *mongo.Database
+	Storage usecase.FileStorage
+}
+
+func NewDeleteAttachmentRepository(db *mongo.Database, storage usecase.FileStorage) *DeleteAttachmentRepository {
+	return &DeleteAttachmentRepository{
+		Db:      db,
+		Storage: storage,
+	}
+}
+
+func (r *DeleteAttachmentRepository) Delete(attachmentId primitive.ObjectID, workspaceId primitive.ObjectID) error {
+	return r.delete(bson.M{"_id": attachmentId, "workspace_id": workspaceId})
+}
+
+func (r *DeleteAttachmentRepository) DeleteByTransaction(transactionId primitive.ObjectID, mainCount *int, workspaceId primitive.ObjectID) error {
+	filter := bson.M{"transaction_id": transactionId, "workspace_id": workspaceId}
+	if mainCount != nil {
+		filter["main_count"] = *mainCount
+	}
+
+	return r.delete(filter)
+}
+
+// delete removes the registers first, so a file left behind by a storage failure is only an orphan
+// and never an attachment pointing to nothing
+func (r *DeleteAttachmentRepository) delete(filter bson.M) error {
+	collection := r.Db.Collection("attachment")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var attachments []models.Attachment
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return err
+	}
+
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	if _, err := collection.DeleteMany(ctx, filter); err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err := r.Storage.Delete(attachment.StorageKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
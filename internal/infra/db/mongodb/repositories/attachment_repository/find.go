@@ -0,0 +1,46 @@
+package attachment_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FindAttachmentsRepository struct {
+	Db *mongo.Database
+}
+
+func NewFindAttachmentsRepository(db *mongo.Database) *FindAttachmentsRepository {
+	return &FindAttachmentsRepository{
+		Db: db,
+	}
+}
+
+func (r *FindAttachmentsRepository) Find(transactionId primitive.ObjectID, mainCount *int, workspaceId primitive.ObjectID) ([]models.Attachment, error) {
+	collection := r.Db.Collection("attachment")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{"transaction_id": transactionId, "workspace_id": workspaceId, "main_count": bson.M{"$exists": false}}
+	if mainCount != nil {
+		filter["main_count"] = *mainCount
+	}
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []models.Attachment{}
+	if err := cursor.All(ctx, &attachments); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
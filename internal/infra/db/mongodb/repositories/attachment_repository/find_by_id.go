@@ -0,0 +1,39 @@
+package attachment_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FindAttachmentByIdRepository struct {
+	Db *mongo.Database
+}
+
+func NewFindAttachmentByIdRepository(db *mongo.Database) *FindAttachmentByIdRepository {
+	return &FindAttachmentByIdRepository{
+		Db: db,
+	}
+}
+
+func (r *FindAttachmentByIdRepository) Find(attachmentId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Attachment, error) {
+	collection := r.Db.Collection("attachment")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var attachment models.Attachment
+	err := collection.FindOne(ctx, bson.M{"_id": attachmentId, "workspace_id": workspaceId}).Decode(&attachment)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
@@ -0,0 +1,41 @@
+package audit_log_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateAuditLogRepository handles appending audit logs. There is no update or delete counterpart,
+// the collection is append-only.
+type CreateAuditLogRepository struct {
+	Db *mongo.Database
+}
+
+// NewCreateAuditLogRepository creates a new CreateAuditLogRepository
+func NewCreateAuditLogRepository(db *mongo.Database) *CreateAuditLogRepository {
+	return &CreateAuditLogRepository{Db: db}
+}
+
+// Create inserts the given audit logs, skipping the ones without changes
+func (r *CreateAuditLogRepository) Create(logs ...models.AuditLog) error {
+	documents := make([]any, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Changes) == 0 {
+			continue
+		}
+		documents = append(documents, log)
+	}
+
+	if len(documents) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	_, err := r.Db.Collection("audit_log").InsertMany(ctx, documents)
+	return err
+}
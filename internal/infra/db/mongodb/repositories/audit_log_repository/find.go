@@ -0,0 +1,89 @@
+package audit_log_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindAuditLogsRepository handles listing audit logs
+type FindAuditLogsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindAuditLogsRepository creates a new FindAuditLogsRepository
+func NewFindAuditLogsRepository(db *mongo.Database) *FindAuditLogsRepository {
+	return &FindAuditLogsRepository{Db: db}
+}
+
+// Find returns a page of the audit logs matching the filters and the total count of matches
+func (r *FindAuditLogsRepository) Find(filters *usecase.FindAuditLogsInputRepository) ([]models.AuditLog, int64, error) {
+	collection := r.Db.Collection("audit_log")
+
+	filter := bson.M{"workspace_id": filters.WorkspaceId}
+	if filters.Entity != "" {
+		filter["entity"] = filters.Entity
+	}
+	if filters.EntityId != nil {
+		filter["entity_id"] = *filters.EntityId
+	}
+
+	createdAt := bson.M{}
+	if !filters.From.IsZero() {
+		createdAt["$gte"] = filters.From
+	}
+	if !filters.To.IsZero() {
+		createdAt["$lte"] = filters.To
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	total, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(filters.Offset))
+	if filters.Limit > 0 {
+		findOptions.SetLimit(int64(filters.Limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	logs := []models.AuditLog{}
+	for cursor.Next(ctx) {
+		// before and after hold arbitrary documents, decoded as maps so they are serialized as JSON objects
+		decoder, err := bson.NewDecoder(bsonrw.NewBSONDocumentReader(cursor.Current))
+		if err != nil {
+			return nil, 0, err
+		}
+		decoder.DefaultDocumentM()
+
+		var log models.AuditLog
+		if err := decoder.Decode(&log); err != nil {
+			return nil, 0, err
+		}
+		logs = append(logs, log)
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
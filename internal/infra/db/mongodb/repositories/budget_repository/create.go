@@ -0,0 +1,39 @@
+package budget_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateBudgetRepository handles creating budgets
+type CreateBudgetRepository struct {
+	Db *mongo.Database
+}
+
+// NewCreateBudgetRepository creates a new CreateBudgetRepository
+func NewCreateBudgetRepository(db *mongo.Database) *CreateBudgetRepository {
+	return &CreateBudgetRepository{Db: db}
+}
+
+// Create inserts a new budget
+func (r *CreateBudgetRepository) Create(budget *models.Budget) (*models.Budget, error) {
+	collection := r.Db.Collection("budget")
+
+	budget.Id = primitive.NewObjectID()
+	budget.CreatedAt = time.Now().UTC()
+	budget.UpdatedAt = budget.CreatedAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
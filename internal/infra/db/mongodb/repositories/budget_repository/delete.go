@@ -0,0 +1,32 @@
+package budget_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteBudgetRepository handles deleting budgets
+type DeleteBudgetRepository struct {
+	Db *mongo.Database
+}
+
+// NewDeleteBudgetRepository creates a new DeleteBudgetRepository
+func NewDeleteBudgetRepository(db *mongo.Database) *DeleteBudgetRepository {
+	return &DeleteBudgetRepository{Db: db}
+}
+
+// Delete removes budgets matching the given IDs and workspace
+func (r *DeleteBudgetRepository) Delete(budgetIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
+	collection := r.Db.Collection("budget")
+	filter := bson.M{"_id": bson.M{"$in": budgetIds}, "workspace_id": workspaceId}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, filter)
+	return err
+}
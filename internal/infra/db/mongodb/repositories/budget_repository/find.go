@@ -0,0 +1,43 @@
+package budget_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindBudgetsRepository handles listing budgets
+type FindBudgetsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindBudgetsRepository creates a new FindBudgetsRepository
+func NewFindBudgetsRepository(db *mongo.Database) *FindBudgetsRepository {
+	return &FindBudgetsRepository{Db: db}
+}
+
+// Find returns all budgets of a workspace
+func (r *FindBudgetsRepository) Find(workspaceId primitive.ObjectID) ([]models.Budget, error) {
+	collection := r.Db.Collection("budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"workspace_id": workspaceId}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	budgets := []models.Budget{}
+	if err := cursor.All(ctx, &budgets); err != nil {
+		return nil, err
+	}
+
+	return budgets, nil
+}
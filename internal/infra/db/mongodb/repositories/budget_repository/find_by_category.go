@@ -0,0 +1,42 @@
+package budget_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindBudgetByCategoryRepository handles fetching the budget of a category or sub-category
+type FindBudgetByCategoryRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindBudgetByCategoryRepository creates a new FindBudgetByCategoryRepository
+func NewFindBudgetByCategoryRepository(db *mongo.Database) *FindBudgetByCategoryRepository {
+	return &FindBudgetByCategoryRepository{Db: db}
+}
+
+// Find returns the budget of the category, or of the sub-category when one is given
+func (r *FindBudgetByCategoryRepository) Find(categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Budget, error) {
+	collection := r.Db.Collection("budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{"category_id": categoryId, "sub_category_id": subCategoryId, "workspace_id": workspaceId}
+
+	var budget models.Budget
+	err := collection.FindOne(ctx, filter).Decode(&budget)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
@@ -0,0 +1,40 @@
+package budget_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindBudgetByIdRepository handles fetching a budget by its ID
+type FindBudgetByIdRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindBudgetByIdRepository creates a new FindBudgetByIdRepository
+func NewFindBudgetByIdRepository(db *mongo.Database) *FindBudgetByIdRepository {
+	return &FindBudgetByIdRepository{Db: db}
+}
+
+// Find returns a budget by its ID and workspace
+func (r *FindBudgetByIdRepository) Find(budgetId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Budget, error) {
+	collection := r.Db.Collection("budget")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var budget models.Budget
+	err := collection.FindOne(ctx, bson.M{"_id": budgetId, "workspace_id": workspaceId}).Decode(&budget)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &budget, nil
+}
@@ -0,0 +1,222 @@
+package budget_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	presentationHelpers "github.com/anuntech/finance-backend/internal/presentation/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindBudgetTrackingRepository compares budgets against the amounts of their categories in a month
+type FindBudgetTrackingRepository struct {
+	Db                       *mongo.Database
+	FindBudgetsRepository    usecase.FindBudgetsRepository
+	FindCategoriesRepository usecase.FindCategoriesRepository
+}
+
+// NewFindBudgetTrackingRepository creates a new FindBudgetTrackingRepository
+func NewFindBudgetTrackingRepository(db *mongo.Database, findBudgetsRepository usecase.FindBudgetsRepository, findCategoriesRepository usecase.FindCategoriesRepository) *FindBudgetTrackingRepository {
+	return &FindBudgetTrackingRepository{
+		Db:                       db,
+		FindBudgetsRepository:    findBudgetsRepository,
+		FindCategoriesRepository: findCategoriesRepository,
+	}
+}
+
+// Find returns the planned, forecast and realized amounts of every budget for the period containing the month.
+// The category amounts are accumulated until the end of a month, so the amount of a period is the
+// difference between the end of the period and the end of the previous one.
+func (r *FindBudgetTrackingRepository) Find(workspaceId primitive.ObjectID, year int, month int) ([]models.BudgetTracking, error) {
+	budgets, err := r.FindBudgetsRepository.Find(workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	trackings := []models.BudgetTracking{}
+	if len(budgets) == 0 {
+		return trackings, nil
+	}
+
+	// without a month the categories come without amounts, these are summed from the transactions below
+	categories, err := r.FindCategoriesRepository.Find(&presentationHelpers.GlobalFilterParams{WorkspaceId: workspaceId})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := helpers.FindExchangeRates(r.Db, workspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[time.Time]*helpers.CategoryAmounts)
+	amountsAt := func(periodEnd time.Time) (*helpers.CategoryAmounts, error) {
+		key := time.Date(periodEnd.Year(), periodEnd.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if amounts, exists := cache[key]; exists {
+			return amounts, nil
+		}
+
+		amounts, err := r.categoryAmounts(workspaceId, categories, rates, key.Year(), int(key.Month()))
+		if err != nil {
+			return nil, err
+		}
+
+		cache[key] = amounts
+		return amounts, nil
+	}
+
+	for i := range budgets {
+		budget := &budgets[i]
+
+		periodStart, periodEnd := helpers.BudgetPeriod(budget.Interval, year, time.Month(month))
+		previousEnd := periodStart.Add(-time.Second)
+		previousStart, _ := helpers.BudgetPeriod(budget.Interval, previousEnd.Year(), previousEnd.Month())
+
+		end, err := amountsAt(periodEnd)
+		if err != nil {
+			return nil, err
+		}
+		previous, err := amountsAt(previousEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		categoryType, exists := end.Types[budget.CategoryId]
+		if !exists {
+			// the category was deleted, the budget no longer has anything to track
+			continue
+		}
+
+		forecast, realized := helpers.BudgetPeriodSpending(budget, categoryType, end, previous)
+
+		var previousRealized models.Money
+		if budget.CarryOver {
+			beforePrevious, err := amountsAt(previousStart.Add(-time.Second))
+			if err != nil {
+				return nil, err
+			}
+			_, previousRealized = helpers.BudgetPeriodSpending(budget, categoryType, previous, beforePrevious)
+		}
+
+		trackings = append(trackings, helpers.BuildBudgetTracking(budget, year, time.Month(month), forecast, realized, previousRealized))
+	}
+
+	return trackings, nil
+}
+
+// budgetCategory is the category, and the sub-category when there is one, a transaction is counted in
+type budgetCategory struct {
+	categoryId    primitive.ObjectID
+	subCategoryId primitive.ObjectID
+}
+
+// categoryAmounts sums the transactions of each category until the end of the month, with the same
+// balance rules of the category listing. The category balances of FindCategoriesRepository only count
+// transactions with a sub-category, so a category budget would miss the expenses without one.
+func (r *FindBudgetTrackingRepository) categoryAmounts(workspaceId primitive.ObjectID, categories []models.Category, rates *models.ExchangeRates, year int, month int) (*helpers.CategoryAmounts, error) {
+	amounts := helpers.NewCategoryAmounts(categories)
+	if len(categories) == 0 {
+		return amounts, nil
+	}
+
+	categoryIds := make([]primitive.ObjectID, len(categories))
+	for i, category := range categories {
+		categoryIds[i] = category.Id
+	}
+
+	endOfMonth := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 1, 0).Add(-time.Second)
+	filter := bson.M{
+		"workspace_id": workspaceId,
+		"is_deleted":   bson.M{"$ne": true},
+		"$and": []bson.M{
+			{"$or": []bson.M{
+				{"category_id": bson.M{"$in": categoryIds}},
+				{"tags.tag_id": bson.M{"$in": categoryIds}},
+			}},
+			{"$or": []bson.M{
+				{"due_date": bson.M{"$lt": endOfMonth}, "is_confirmed": false},
+				{"confirmation_date": bson.M{"$lt": endOfMonth}, "is_confirmed": true},
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transaction").Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var transactions []models.Transaction
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, err
+	}
+
+	// tags are categories of the TAG type, so a transaction counts in its category and in each of its tags
+	byCategory := make(map[budgetCategory][]models.Transaction)
+	for _, tx := range transactions {
+		if tx.CategoryId != nil {
+			key := budgetCategory{categoryId: *tx.CategoryId}
+			if tx.SubCategoryId != nil {
+				key.subCategoryId = *tx.SubCategoryId
+			}
+			byCategory[key] = append(byCategory[key], tx)
+		}
+		for _, tag := range tx.Tags {
+			key := budgetCategory{categoryId: tag.TagId, subCategoryId: tag.SubTagId}
+			byCategory[key] = append(byCategory[key], tx)
+		}
+	}
+
+	for key, transactions := range byCategory {
+		if _, exists := amounts.Types[key.categoryId]; !exists {
+			continue
+		}
+
+		amount, err := r.transactionsBalance(transactions, rates, year, month, false)
+		if err != nil {
+			return nil, err
+		}
+		currentAmount, err := r.transactionsBalance(transactions, rates, year, month, true)
+		if err != nil {
+			return nil, err
+		}
+
+		var subCategoryId *primitive.ObjectID
+		if !key.subCategoryId.IsZero() {
+			subCategoryId = &key.subCategoryId
+		}
+		amounts.Add(key.categoryId, subCategoryId, amount, currentAmount)
+	}
+
+	return amounts, nil
+}
+
+// transactionsBalance is the balance of the transactions until the end of the month in the base currency
+// of the workspace, only of the confirmed ones when isConfirmed
+func (r *FindBudgetTrackingRepository) transactionsBalance(transactions []models.Transaction, rates *models.ExchangeRates, year int, month int, isConfirmed bool) (models.Money, error) {
+	transactions, foreign := helpers.SplitForeignTransactions(transactions, rates.BaseCurrency, rates)
+
+	byFrequency := make(map[string][]models.Transaction)
+	for _, tx := range transactions {
+		byFrequency[tx.Frequency] = append(byFrequency[tx.Frequency], tx)
+	}
+
+	balance := helpers.CalculateTransactionBalanceWithEdits(byFrequency["DO_NOT_REPEAT"], r.Db, isConfirmed) +
+		helpers.CalculateRecurringTransactionsBalance(byFrequency["RECURRING"], year, month, r.Db, isConfirmed) +
+		helpers.CalculateRepeatTransactionsBalance(byFrequency["REPEAT"], year, month, r.Db, isConfirmed)
+
+	foreignBalance, err := helpers.CalculateConvertedTransactionsBalance(foreign, rates.BaseCurrency, rates, year, month, r.Db, isConfirmed)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance + foreignBalance, nil
+}
@@ -0,0 +1,50 @@
+package budget_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateBudgetRepository handles updating budgets
+type UpdateBudgetRepository struct {
+	Db *mongo.Database
+}
+
+// NewUpdateBudgetRepository creates a new UpdateBudgetRepository
+func NewUpdateBudgetRepository(db *mongo.Database) *UpdateBudgetRepository {
+	return &UpdateBudgetRepository{Db: db}
+}
+
+// Update modifies the limit of an existing budget. The category it belongs to cannot change.
+func (r *UpdateBudgetRepository) Update(budgetId primitive.ObjectID, budget *models.Budget) (*models.Budget, error) {
+	collection := r.Db.Collection("budget")
+
+	filter := bson.M{"_id": budgetId, "workspace_id": budget.WorkspaceId}
+	update := bson.M{"$set": bson.M{
+		"interval":   budget.Interval,
+		"amount":     budget.Amount,
+		"carry_over": budget.CarryOver,
+		"updated_at": time.Now().UTC(),
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var updated models.Budget
+	err := collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
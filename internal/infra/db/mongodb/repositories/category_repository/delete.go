@@ -2,7 +2,9 @@ package category_repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -25,7 +27,57 @@ func (r *DeleteCategoryRepository) Delete(categoryIds []primitive.ObjectID, work
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": categoryIds}, "workspace_id": workspaceId})
+	filter := bson.M{"_id": bson.M{"$in": categoryIds}, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var categories []models.Category
+	if err := cursor.All(ctx, &categories); err != nil {
+		return err
+	}
+
+	if len(categories) == 0 {
+		return nil
+	}
+
+	deletedIds := make([]primitive.ObjectID, len(categories))
+	for i, category := range categories {
+		deletedIds[i] = category.Id
+	}
+
+	// os vínculos com as transações e os orçamentos removidos ficam guardados na lixeira para a restauração
+	references, err := helpers.FindTrashReferences(ctx, r.Db, "transaction", "category_id", workspaceId, deletedIds)
+	if err != nil {
+		return err
+	}
+
+	budgetCollection := r.Db.Collection("budget")
+	budgetFilter := bson.M{"category_id": bson.M{"$in": deletedIds}, "workspace_id": workspaceId}
+
+	budgetCursor, err := budgetCollection.Find(ctx, budgetFilter)
+	if err != nil {
+		return err
+	}
+
+	var budgets []bson.M
+	if err := budgetCursor.All(ctx, &budgets); err != nil {
+		return err
+	}
+	for _, budget := range budgets {
+		budgetId, _ := budget["_id"].(primitive.ObjectID)
+		categoryId, _ := budget["category_id"].(primitive.ObjectID)
+		references[categoryId] = append(references[categoryId], models.TrashReference{
+			Collection: "budget",
+			DocumentId: budgetId,
+			Value:      budget,
+		})
+	}
+
+	now := time.Now().UTC()
+	_, err = collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}})
 	if err != nil {
 		return err
 	}
@@ -33,12 +85,29 @@ func (r *DeleteCategoryRepository) Delete(categoryIds []primitive.ObjectID, work
 	transactionCollection := r.Db.Collection("transaction")
 	_, err = transactionCollection.UpdateMany(
 		ctx,
-		bson.M{"category_id": bson.M{"$in": categoryIds}, "workspace_id": workspaceId},
+		bson.M{"category_id": bson.M{"$in": deletedIds}, "workspace_id": workspaceId},
 		bson.M{"$unset": bson.M{"category_id": ""}},
 	)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	_, err = budgetCollection.DeleteMany(ctx, budgetFilter)
+	if err != nil {
+		return err
+	}
+
+	trash := make([]models.TrashItem, 0, len(categories))
+	for _, category := range categories {
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "CATEGORY",
+			EntityId:    category.Id,
+			Name:        category.Name,
+			References:  references[category.Id],
+			DeletedAt:   now,
+		})
+	}
+
+	return helpers.MoveToTrash(ctx, r.Db, trash...)
 }
@@ -26,7 +26,7 @@ func NewFindCategoriesRepository(db *mongo.Database) *FindCategoriesRepository {
 func (r *FindCategoriesRepository) Find(globalFilters *presentationHelpers.GlobalFilterParams) ([]models.Category, error) {
 	collection := r.Db.Collection("category")
 
-	filter := bson.M{"workspace_id": globalFilters.WorkspaceId}
+	filter := bson.M{"workspace_id": globalFilters.WorkspaceId, "deleted_at": bson.M{"$exists": false}}
 	if globalFilters.Type != "" {
 		filter["type"] = globalFilters.Type
 	}
@@ -85,6 +85,7 @@ func (r *FindCategoriesRepository) calculateCategoryBalances(categories []models
 	// Build filter for balance calculation (includes both confirmed and unconfirmed)
 	balanceFilter := bson.M{
 		"workspace_id": globalFilters.WorkspaceId,
+		"is_deleted":   bson.M{"$ne": true},
 		"$and": []bson.M{
 			{"$or": []bson.M{
 				{"sub_category_id": bson.M{"$in": subCategoryIDs}},
@@ -117,6 +118,28 @@ func (r *FindCategoriesRepository) calculateCategoryBalances(categories []models
 		return err
 	}
 
+	// Amounts are in the base currency of the workspace, the transactions in another currency are converted one by one
+	rates, err := helpers.FindExchangeRates(r.Db, globalFilters.WorkspaceId)
+	if err != nil {
+		return err
+	}
+
+	balanceTransactions, foreignTransactions := helpers.SplitForeignTransactions(balanceTransactions, rates.BaseCurrency, rates)
+	foreignBySubCategory := make(map[primitive.ObjectID][]models.Transaction)
+	for _, tx := range foreignTransactions {
+		if tx.SubCategoryId != nil {
+			if _, exists := subCategoryMap[*tx.SubCategoryId]; exists {
+				foreignBySubCategory[*tx.SubCategoryId] = append(foreignBySubCategory[*tx.SubCategoryId], tx)
+			}
+		}
+
+		for _, tag := range tx.Tags {
+			if _, exists := subCategoryMap[tag.SubTagId]; exists {
+				foreignBySubCategory[tag.SubTagId] = append(foreignBySubCategory[tag.SubTagId], tx)
+			}
+		}
+	}
+
 	// Group transactions by subcategory ID and frequency
 	transactionsBySubCategoryAndFrequency := make(map[primitive.ObjectID]map[string][]models.Transaction)
 	currentTransactionsBySubCategoryAndFrequency := make(map[primitive.ObjectID]map[string][]models.Transaction)
@@ -249,6 +272,25 @@ func (r *FindCategoriesRepository) calculateCategoryBalances(categories []models
 	// Wait for all subcategory calculations to complete
 	wg.Wait()
 
+	for subCategoryID, transactions := range foreignBySubCategory {
+		subCategory := subCategoryMap[subCategoryID]
+
+		amount, err := helpers.CalculateConvertedTransactionsBalance(
+			transactions, rates.BaseCurrency, rates, globalFilters.Year, globalFilters.Month, r.Db, false)
+		if err != nil {
+			return err
+		}
+
+		currentAmount, err := helpers.CalculateConvertedTransactionsBalance(
+			transactions, rates.BaseCurrency, rates, globalFilters.Year, globalFilters.Month, r.Db, true)
+		if err != nil {
+			return err
+		}
+
+		subCategory.Amount += amount
+		subCategory.CurrentAmount += currentAmount
+	}
+
 	// Calculate total amounts for each category based on their subcategories
 	for i := range categories {
 		categories[i].Amount = 0
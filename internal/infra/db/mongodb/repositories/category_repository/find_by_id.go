@@ -27,7 +27,7 @@ func (r *FindCategoryByIdRepository) Find(categoryId primitive.ObjectID, workspa
 	defer cancel()
 
 	var category models.Category
-	err := collection.FindOne(ctx, bson.M{"_id": categoryId, "workspace_id": workspaceId}).Decode(&category)
+	err := collection.FindOne(ctx, bson.M{"_id": categoryId, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}).Decode(&category)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -23,7 +23,7 @@ func NewFindByNameAndTypeMongoRepository(db *mongo.Database) *FindByNameAndTypeM
 func (r *FindByNameAndTypeMongoRepository) Find(name string, typeCategory string, workspaceId primitive.ObjectID) (*models.Category, error) {
 	collection := r.Db.Collection("category")
 
-	filter := bson.M{"name": name, "type": typeCategory, "workspace_id": workspaceId}
+	filter := bson.M{"name": name, "type": typeCategory, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
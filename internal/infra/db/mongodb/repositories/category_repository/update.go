@@ -62,6 +62,11 @@ func (r *UpdateCategoryRepository) DeleteSubCategory(subCategoryIds []primitive.
 	defer cancel()
 
 	_, err := collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Db.Collection("budget").DeleteMany(ctx, bson.M{"sub_category_id": bson.M{"$in": subCategoryIds}, "workspace_id": workspaceId})
 	return err
 }
 
@@ -2,7 +2,9 @@ package credit_card_repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -22,11 +24,42 @@ func NewDeleteCreditCardRepository(db *mongo.Database) *DeleteCreditCardReposito
 // Delete removes credit cards matching the given IDs and workspace
 func (r *DeleteCreditCardRepository) Delete(creditCardIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
 	collection := r.Db.Collection("credit_card")
-	filter := bson.M{"_id": bson.M{"$in": creditCardIds}, "workspace_id": workspaceId}
+	filter := bson.M{"_id": bson.M{"$in": creditCardIds}, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, filter)
-	return err
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var creditCards []models.CreditCard
+	if err := cursor.All(ctx, &creditCards); err != nil {
+		return err
+	}
+
+	if len(creditCards) == 0 {
+		return nil
+	}
+
+	// the purchases keep pointing to the card, so restoring it brings its invoices back as they were
+	now := time.Now().UTC()
+	_, err = collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}})
+	if err != nil {
+		return err
+	}
+
+	trash := make([]models.TrashItem, 0, len(creditCards))
+	for _, creditCard := range creditCards {
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "CREDIT_CARD",
+			EntityId:    creditCard.Id,
+			Name:        creditCard.Name,
+			DeletedAt:   now,
+		})
+	}
+
+	return helpers.MoveToTrash(ctx, r.Db, trash...)
 }
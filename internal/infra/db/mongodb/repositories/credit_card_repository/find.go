@@ -2,30 +2,34 @@ package credit_card_repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	presentationHelpers "github.com/anuntech/finance-backend/internal/presentation/helpers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // FindCreditCardsRepository handles fetching credit cards
 type FindCreditCardsRepository struct {
-	Db *mongo.Database
+	Db                         *mongo.Database
+	FindTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository
 }
 
 // NewFindCreditCardsRepository creates a new FindCreditCardsRepository
-func NewFindCreditCardsRepository(db *mongo.Database) *FindCreditCardsRepository {
-	return &FindCreditCardsRepository{Db: db}
+func NewFindCreditCardsRepository(db *mongo.Database, findTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository) *FindCreditCardsRepository {
+	return &FindCreditCardsRepository{Db: db, FindTransactionsRepository: findTransactionsRepository}
 }
 
-// Find retrieves credit cards by global filters
+// Find retrieves credit cards by global filters, filling the outstanding balance and available limit
 func (r *FindCreditCardsRepository) Find(globalFilters *presentationHelpers.GlobalFilterParams) ([]models.CreditCard, error) {
 	collection := r.Db.Collection("credit_card")
 
-	filter := bson.M{"workspace_id": globalFilters.WorkspaceId}
+	filter := bson.M{"workspace_id": globalFilters.WorkspaceId, "deleted_at": bson.M{"$exists": false}}
 
 	opts := options.Find().SetSort(bson.M{"name": 1})
 
@@ -43,5 +47,93 @@ func (r *FindCreditCardsRepository) Find(globalFilters *presentationHelpers.Glob
 		return nil, err
 	}
 
+	if err := r.calculateCreditCardBalances(creditCards, globalFilters); err != nil {
+		return nil, err
+	}
+
 	return creditCards, nil
 }
+
+// calculateCreditCardBalances fills Balance with the unpaid card transactions and AvailableLimit with what is left of the limit
+func (r *FindCreditCardsRepository) calculateCreditCardBalances(creditCards []models.CreditCard, globalFilters *presentationHelpers.GlobalFilterParams) error {
+	if len(creditCards) == 0 {
+		return nil
+	}
+
+	referenceDate := time.Now().UTC()
+	if globalFilters.Month != 0 && globalFilters.Year != 0 {
+		referenceDate = time.Date(globalFilters.Year, time.Month(globalFilters.Month), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	creditCardIds := make([]primitive.ObjectID, len(creditCards))
+	closeDates := make([]time.Time, len(creditCards))
+	var lastCloseDate time.Time
+	for i := range creditCards {
+		creditCardIds[i] = creditCards[i].Id
+
+		year, month := helpers.InvoiceReferenceForDate(&creditCards[i], referenceDate)
+		closeDates[i] = helpers.InvoiceCloseDate(&creditCards[i], year, month)
+		if closeDates[i].After(lastCloseDate) {
+			lastCloseDate = closeDates[i]
+		}
+	}
+
+	mains, err := r.findCardTransactions(globalFilters.WorkspaceId, creditCardIds)
+	if err != nil {
+		return err
+	}
+	if len(mains) == 0 {
+		for i := range creditCards {
+			creditCards[i].AvailableLimit = creditCards[i].Limit
+		}
+		return nil
+	}
+
+	// the period only goes past the current invoices for installments, so recurrences are not expanded for years
+	start, end := helpers.CreditCardBalancePeriod(mains, lastCloseDate)
+	transactions, err := r.FindTransactionsRepository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{
+		WorkspaceId:   globalFilters.WorkspaceId,
+		CreditCardIds: creditCardIds,
+		InitialDate:   start.Format("2006-01-02"),
+		FinalDate:     end.Format("2006-01-02"),
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range creditCards {
+		creditCards[i].Balance = helpers.CalculateCreditCardBalance(&creditCards[i], transactions, closeDates[i])
+		creditCards[i].AvailableLimit = creditCards[i].Limit - creditCards[i].Balance
+	}
+
+	return nil
+}
+
+// findCardTransactions returns the transactions of the cards as saved, without expanding installments and
+// recurrences, with only the fields needed to know their dates
+func (r *FindCreditCardsRepository) findCardTransactions(workspaceId primitive.ObjectID, creditCardIds []primitive.ObjectID) ([]models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"workspace_id":   workspaceId,
+		"credit_card_id": bson.M{"$in": creditCardIds},
+		"is_deleted":     bson.M{"$ne": true},
+	}
+	opts := options.Find().SetProjection(bson.M{
+		"frequency": 1, "repeat_settings": 1, "due_date": 1, "is_confirmed": 1, "confirmation_date": 1,
+	})
+
+	cursor, err := r.Db.Collection("transaction").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var transactions []models.Transaction
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
@@ -28,7 +28,7 @@ func (r *FindCreditCardByIdRepository) Find(creditCardId primitive.ObjectID, wor
 	defer cancel()
 
 	var creditCard models.CreditCard
-	err := collection.FindOne(ctx, bson.M{"_id": creditCardId, "workspace_id": workspaceId}).Decode(&creditCard)
+	err := collection.FindOne(ctx, bson.M{"_id": creditCardId, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}).Decode(&creditCard)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
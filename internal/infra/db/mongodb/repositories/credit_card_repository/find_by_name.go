@@ -24,7 +24,7 @@ func NewFindByNameMongoRepository(db *mongo.Database) *FindByNameMongoRepository
 func (r *FindByNameMongoRepository) FindByNameAndWorkspaceId(name string, workspaceId primitive.ObjectID) (*models.CreditCard, error) {
 	collection := r.Db.Collection("credit_card")
 
-	filter := bson.M{"name": name, "workspace_id": workspaceId}
+	filter := bson.M{"name": name, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
@@ -0,0 +1,56 @@
+package credit_card_repository
+
+import (
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindCreditCardInvoiceRepository handles computing credit card invoices from the card transactions
+type FindCreditCardInvoiceRepository struct {
+	Db                         *mongo.Database
+	FindTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository
+	FindPaymentsRepository     usecase.FindCreditCardInvoicePaymentsRepository
+}
+
+// NewFindCreditCardInvoiceRepository creates a new FindCreditCardInvoiceRepository
+func NewFindCreditCardInvoiceRepository(db *mongo.Database, findTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository) *FindCreditCardInvoiceRepository {
+	return &FindCreditCardInvoiceRepository{
+		Db:                         db,
+		FindTransactionsRepository: findTransactionsRepository,
+		FindPaymentsRepository:     NewFindCreditCardInvoicePaymentsRepository(db),
+	}
+}
+
+// Find builds the invoice of the statement that closes in the given year and month
+func (r *FindCreditCardInvoiceRepository) Find(creditCard *models.CreditCard, year int, month int) (*models.CreditCardInvoice, error) {
+	openDate, closeDate, _ := helpers.InvoiceCycle(creditCard, year, time.Month(month))
+
+	transactions, err := r.FindTransactionsRepository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{
+		WorkspaceId:   creditCard.WorkspaceId,
+		CreditCardIds: []primitive.ObjectID{creditCard.Id},
+		InitialDate:   openDate.Format("2006-01-02"),
+		FinalDate:     closeDate.Format("2006-01-02"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invoice := helpers.BuildCreditCardInvoice(creditCard, year, time.Month(month), transactions, time.Now().UTC())
+
+	payments, err := r.FindPaymentsRepository.Find(creditCard.Id, invoice.Period, creditCard.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.Payments = payments
+	for _, payment := range payments {
+		invoice.PaidAmount += payment.Amount
+	}
+
+	return invoice, nil
+}
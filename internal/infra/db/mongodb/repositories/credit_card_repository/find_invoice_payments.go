@@ -0,0 +1,49 @@
+package credit_card_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindCreditCardInvoicePaymentsRepository handles fetching the payments of a credit card statement
+type FindCreditCardInvoicePaymentsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindCreditCardInvoicePaymentsRepository creates a new FindCreditCardInvoicePaymentsRepository
+func NewFindCreditCardInvoicePaymentsRepository(db *mongo.Database) *FindCreditCardInvoicePaymentsRepository {
+	return &FindCreditCardInvoicePaymentsRepository{Db: db}
+}
+
+// Find retrieves the payments of a statement ordered by payment date
+func (r *FindCreditCardInvoicePaymentsRepository) Find(creditCardId primitive.ObjectID, period string, workspaceId primitive.ObjectID) ([]models.CreditCardInvoicePayment, error) {
+	collection := r.Db.Collection("credit_card_invoice_payment")
+
+	filter := bson.M{
+		"credit_card_id": creditCardId,
+		"period":         period,
+		"workspace_id":   workspaceId,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, filter, options.Find().SetSort(bson.M{"payment_date": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	payments := []models.CreditCardInvoicePayment{}
+	if err := cursor.All(ctx, &payments); err != nil {
+		return nil, err
+	}
+
+	return payments, nil
+}
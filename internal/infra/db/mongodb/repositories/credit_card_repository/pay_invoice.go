@@ -0,0 +1,95 @@
+package credit_card_repository
+
+import (
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PayCreditCardInvoiceRepository handles settling credit card invoices
+type PayCreditCardInvoiceRepository struct {
+	Db *mongo.Database
+}
+
+// NewPayCreditCardInvoiceRepository creates a new PayCreditCardInvoiceRepository
+func NewPayCreditCardInvoiceRepository(db *mongo.Database) *PayCreditCardInvoiceRepository {
+	return &PayCreditCardInvoiceRepository{Db: db}
+}
+
+// Pay confirms the pending purchases and saves the payment transaction, the carried over remainder, when
+// there is one, and the payment record in a single MongoDB transaction, so a failure leaves the invoice open.
+// When a pending purchase is already confirmed nothing is saved and ErrInvoiceAlreadyPaid is returned.
+func (r *PayCreditCardInvoiceRepository) Pay(pending []models.Transaction, paymentDate time.Time, paymentTransaction *models.Transaction, remainderTransaction *models.Transaction, payment *models.CreditCardInvoicePayment) (*models.CreditCardInvoicePayment, error) {
+	transactionWrites, editWrites, confirmed, err := helpers.ConfirmTransactionsWrites(pending, paymentDate)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	created := []interface{}{paymentTransaction}
+	paymentTransaction.Id = primitive.NewObjectID()
+	paymentTransaction.CreatedAt = now
+	paymentTransaction.UpdatedAt = now
+	payment.TransactionId = paymentTransaction.Id
+
+	if remainderTransaction != nil {
+		remainderTransaction.Id = primitive.NewObjectID()
+		remainderTransaction.CreatedAt = now
+		remainderTransaction.UpdatedAt = now
+		payment.RemainderTransactionId = &remainderTransaction.Id
+		created = append(created, remainderTransaction)
+	}
+
+	payment.Id = primitive.NewObjectID()
+	payment.CreatedAt = now
+
+	confirmedEdits := helpers.ConfirmedEditsFilter(pending)
+
+	// a purchase confirmed since the invoice was read means another payment got there first
+	err = helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		if confirmedEdits != nil {
+			count, err := r.Db.Collection("edit_transaction").CountDocuments(ctx, confirmedEdits)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return usecase.ErrInvoiceAlreadyPaid
+			}
+		}
+		if len(transactionWrites) > 0 {
+			result, err := r.Db.Collection("transaction").BulkWrite(ctx, transactionWrites, options.BulkWrite().SetOrdered(false))
+			if err != nil {
+				return err
+			}
+			if result.MatchedCount < int64(len(transactionWrites)) {
+				return usecase.ErrInvoiceAlreadyPaid
+			}
+		}
+		if len(editWrites) > 0 {
+			if _, err := r.Db.Collection("edit_transaction").BulkWrite(ctx, editWrites, options.BulkWrite().SetOrdered(false)); err != nil {
+				return err
+			}
+		}
+		if _, err := r.Db.Collection("transaction").InsertMany(ctx, created); err != nil {
+			return err
+		}
+		_, err := r.Db.Collection("credit_card_invoice_payment").InsertOne(ctx, payment)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the payment is already saved, a failure here only costs a recalculation of the balances
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, append(confirmed, paymentTransaction, remainderTransaction)...); err != nil {
+		log.Printf("error invalidating balance snapshots after paying invoice %s: %v", payment.Period, err)
+	}
+
+	return payment, nil
+}
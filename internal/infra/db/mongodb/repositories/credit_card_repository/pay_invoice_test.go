@@ -0,0 +1,94 @@
+package credit_card_repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestPayCreditCardInvoiceRepositoryPayTwice(t *testing.T) {
+	workspaceId := primitive.NewObjectID()
+	cardId := primitive.NewObjectID()
+	dueDate := time.Date(2025, time.January, 5, 0, 0, 0, 0, time.UTC)
+	paymentDate := time.Date(2025, time.January, 20, 0, 0, 0, 0, time.UTC)
+
+	purchase := func(frequency string) models.Transaction {
+		tx := models.Transaction{
+			Id:           primitive.NewObjectID(),
+			WorkspaceId:  workspaceId,
+			Name:         "Mercado",
+			Type:         "EXPENSE",
+			Frequency:    frequency,
+			Balance:      models.TransactionBalance{Value: 3000},
+			DueDate:      dueDate,
+			CreditCardId: &cardId,
+		}
+		if frequency == "REPEAT" {
+			tx.RepeatSettings = &models.TransactionRepeatSettings{InitialInstallment: 1, Count: 3, Interval: "MONTHLY"}
+		}
+		return tx
+	}
+
+	tests := []struct {
+		name      string
+		frequency string
+	}{
+		{name: "single purchase", frequency: "DO_NOT_REPEAT"},
+		{name: "installment", frequency: "REPEAT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mongotest.Database(t)
+			tx := purchase(tt.frequency)
+			mongotest.Insert(t, db, "transaction", tx)
+
+			// the pending purchases as read from the invoice, the installment already expanded
+			pending := tx
+			if pending.RepeatSettings != nil {
+				settings := *pending.RepeatSettings
+				settings.CurrentCount = 1
+				pending.RepeatSettings = &settings
+				pending.Balance.Value = 1000
+			}
+
+			repository := NewPayCreditCardInvoiceRepository(db)
+			pay := func() error {
+				paymentTransaction := &models.Transaction{WorkspaceId: workspaceId, Name: "Fatura", Type: "EXPENSE", Frequency: "DO_NOT_REPEAT", IsConfirmed: true, ConfirmationDate: &paymentDate}
+				payment := &models.CreditCardInvoicePayment{WorkspaceId: workspaceId, CreditCardId: cardId, Period: "2025-01", PaymentDate: paymentDate}
+				_, err := repository.Pay([]models.Transaction{pending}, paymentDate, paymentTransaction, nil, payment)
+				return err
+			}
+
+			if err := pay(); err != nil {
+				t.Fatalf("first Pay() error = %v", err)
+			}
+			// a retry with the invoice read before the first payment must not charge the account again
+			if err := pay(); !errors.Is(err, usecase.ErrInvoiceAlreadyPaid) {
+				t.Fatalf("second Pay() error = %v, want %v", err, usecase.ErrInvoiceAlreadyPaid)
+			}
+
+			payments, err := db.Collection("credit_card_invoice_payment").CountDocuments(context.Background(), bson.M{})
+			if err != nil {
+				t.Fatalf("counting payments: %v", err)
+			}
+			if payments != 1 {
+				t.Errorf("saved %d payments, want 1", payments)
+			}
+			charges, err := db.Collection("transaction").CountDocuments(context.Background(), bson.M{"name": "Fatura"})
+			if err != nil {
+				t.Fatalf("counting payment transactions: %v", err)
+			}
+			if charges != 1 {
+				t.Errorf("saved %d payment transactions, want 1", charges)
+			}
+		})
+	}
+}
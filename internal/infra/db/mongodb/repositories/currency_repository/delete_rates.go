@@ -0,0 +1,36 @@
+package currency_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteExchangeRatesRepository handles deleting exchange rates
+type DeleteExchangeRatesRepository struct {
+	Db *mongo.Database
+}
+
+// NewDeleteExchangeRatesRepository creates a new DeleteExchangeRatesRepository
+func NewDeleteExchangeRatesRepository(db *mongo.Database) *DeleteExchangeRatesRepository {
+	return &DeleteExchangeRatesRepository{Db: db}
+}
+
+// Delete removes the rates matching the given IDs and workspace and discards the balance snapshots converted with them
+func (r *DeleteExchangeRatesRepository) Delete(rateIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
+	collection := r.Db.Collection("exchange_rate")
+	filter := bson.M{"_id": bson.M{"$in": rateIds}, "workspace_id": workspaceId}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	result, err := collection.DeleteMany(ctx, filter)
+	if err != nil || result.DeletedCount == 0 {
+		return err
+	}
+
+	return helpers.DeleteAccountBalanceSnapshots(r.Db, workspaceId, nil)
+}
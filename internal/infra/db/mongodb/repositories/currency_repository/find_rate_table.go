@@ -0,0 +1,23 @@
+package currency_repository
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindExchangeRateTableRepository handles loading the exchange rates used to convert amounts
+type FindExchangeRateTableRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindExchangeRateTableRepository creates a new FindExchangeRateTableRepository
+func NewFindExchangeRateTableRepository(db *mongo.Database) *FindExchangeRateTableRepository {
+	return &FindExchangeRateTableRepository{Db: db}
+}
+
+// Find returns the base currency and all the rates of the workspace
+func (r *FindExchangeRateTableRepository) Find(workspaceId primitive.ObjectID) (*models.ExchangeRates, error) {
+	return helpers.FindExchangeRates(r.Db, workspaceId)
+}
@@ -0,0 +1,62 @@
+package currency_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindExchangeRatesRepository handles listing exchange rates
+type FindExchangeRatesRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindExchangeRatesRepository creates a new FindExchangeRatesRepository
+func NewFindExchangeRatesRepository(db *mongo.Database) *FindExchangeRatesRepository {
+	return &FindExchangeRatesRepository{Db: db}
+}
+
+// Find returns the rates of a workspace matching the filter, the most recent first
+func (r *FindExchangeRatesRepository) Find(filter *usecase.FindExchangeRatesFilter) ([]models.ExchangeRate, error) {
+	collection := r.Db.Collection("exchange_rate")
+
+	query := bson.M{"workspace_id": filter.WorkspaceId}
+	if filter.From != "" {
+		query["from"] = filter.From
+	}
+	if filter.To != "" {
+		query["to"] = filter.To
+	}
+
+	date := bson.M{}
+	if filter.InitialDate != nil {
+		date["$gte"] = *filter.InitialDate
+	}
+	if filter.FinalDate != nil {
+		date["$lte"] = *filter.FinalDate
+	}
+	if len(date) > 0 {
+		query["date"] = date
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "from", Value: 1}, {Key: "to", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rates := []models.ExchangeRate{}
+	if err := cursor.All(ctx, &rates); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,40 @@
+package currency_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindCurrencySettingsRepository handles finding the currency settings of a workspace
+type FindCurrencySettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindCurrencySettingsRepository creates a new FindCurrencySettingsRepository
+func NewFindCurrencySettingsRepository(db *mongo.Database) *FindCurrencySettingsRepository {
+	return &FindCurrencySettingsRepository{Db: db}
+}
+
+// Find returns the settings of the workspace, nil when the base currency was never chosen
+func (r *FindCurrencySettingsRepository) Find(workspaceId primitive.ObjectID) (*models.CurrencySettings, error) {
+	collection := r.Db.Collection("currency_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var settings models.CurrencySettings
+	err := collection.FindOne(ctx, bson.M{"workspace_id": workspaceId}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
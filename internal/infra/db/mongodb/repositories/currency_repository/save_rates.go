@@ -0,0 +1,71 @@
+package currency_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveExchangeRatesRepository handles saving exchange rates
+type SaveExchangeRatesRepository struct {
+	Db *mongo.Database
+}
+
+// NewSaveExchangeRatesRepository creates a new SaveExchangeRatesRepository
+func NewSaveExchangeRatesRepository(db *mongo.Database) *SaveExchangeRatesRepository {
+	return &SaveExchangeRatesRepository{Db: db}
+}
+
+// Save inserts the rates or replaces the ones of the same pair and day. A rate can change the converted
+// balances of any month, since older dates use the first rate entered, so the balance snapshots of the
+// workspace are discarded.
+func (r *SaveExchangeRatesRepository) Save(rates []models.ExchangeRate) ([]models.ExchangeRate, error) {
+	if len(rates) == 0 {
+		return []models.ExchangeRate{}, nil
+	}
+
+	collection := r.Db.Collection("exchange_rate")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	saved := make([]models.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		update := bson.M{
+			"$set": bson.M{
+				"rate":       rate.Rate,
+				"updated_at": now,
+			},
+			"$setOnInsert": bson.M{
+				"_id":        primitive.NewObjectID(),
+				"created_by": rate.CreatedBy,
+				"created_at": now,
+			},
+		}
+
+		var savedRate models.ExchangeRate
+		err := collection.FindOneAndUpdate(ctx,
+			bson.M{"workspace_id": rate.WorkspaceId, "from": rate.From, "to": rate.To, "date": rate.Date},
+			update,
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&savedRate)
+		if err != nil {
+			return nil, err
+		}
+
+		saved = append(saved, savedRate)
+	}
+
+	if err := helpers.DeleteAccountBalanceSnapshots(r.Db, rates[0].WorkspaceId, nil); err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
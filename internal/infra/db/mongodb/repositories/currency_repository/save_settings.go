@@ -0,0 +1,56 @@
+package currency_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveCurrencySettingsRepository handles saving the currency settings of a workspace
+type SaveCurrencySettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewSaveCurrencySettingsRepository creates a new SaveCurrencySettingsRepository
+func NewSaveCurrencySettingsRepository(db *mongo.Database) *SaveCurrencySettingsRepository {
+	return &SaveCurrencySettingsRepository{Db: db}
+}
+
+// Save replaces the settings of the workspace. The accounts and transactions without a currency follow the
+// base currency, so the balance snapshots of the whole workspace are discarded.
+func (r *SaveCurrencySettingsRepository) Save(settings *models.CurrencySettings) (*models.CurrencySettings, error) {
+	collection := r.Db.Collection("currency_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"base_currency": settings.BaseCurrency,
+			"updated_at":    time.Now().UTC(),
+		},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+	}
+
+	var saved models.CurrencySettings
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"workspace_id": settings.WorkspaceId},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := helpers.DeleteAccountBalanceSnapshots(r.Db, settings.WorkspaceId, nil); err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
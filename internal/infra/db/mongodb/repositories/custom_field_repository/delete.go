@@ -2,11 +2,14 @@ package custom_field_repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type DeleteCustomFieldRepository struct {
@@ -25,44 +28,120 @@ func (r *DeleteCustomFieldRepository) Delete(customFieldIds []primitive.ObjectID
 	filter := bson.M{
 		"_id":          bson.M{"$in": customFieldIds},
 		"workspace_id": workspaceId,
+		"deleted_at":   bson.M{"$exists": false},
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, filter)
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	transactionCollection := r.Db.Collection("transaction")
-	editTransactionCollection := r.Db.Collection("edit_transaction")
+	var customFields []models.CustomField
+	if err := cursor.All(ctx, &customFields); err != nil {
+		return err
+	}
 
-	update := bson.M{
-		"$pull": bson.M{
-			"custom_fields": bson.M{
-				"custom_field_id": bson.M{"$in": customFieldIds},
-			},
-		},
+	if len(customFields) == 0 {
+		return nil
+	}
+
+	deletedIds := make([]primitive.ObjectID, 0, len(customFields))
+	for _, customField := range customFields {
+		id, err := primitive.ObjectIDFromHex(customField.Id)
+		if err != nil {
+			return err
+		}
+		deletedIds = append(deletedIds, id)
 	}
 
 	transactionFilter := bson.M{
 		"workspace_id": workspaceId,
 		"custom_fields": bson.M{
 			"$elemMatch": bson.M{
-				"custom_field_id": bson.M{"$in": customFieldIds},
+				"custom_field_id": bson.M{"$in": deletedIds},
+			},
+		},
+	}
+
+	// os valores preenchidos nas transações ficam guardados na lixeira para a restauração
+	references := make(map[primitive.ObjectID][]models.TrashReference)
+	for _, collectionName := range []string{"transaction", "edit_transaction"} {
+		if err := findCustomFieldValues(ctx, r.Db.Collection(collectionName), transactionFilter, references); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	_, err = collection.UpdateMany(ctx, filter, bson.M{"$set": bson.M{"deleted_at": now}})
+	if err != nil {
+		return err
+	}
+
+	update := bson.M{
+		"$pull": bson.M{
+			"custom_fields": bson.M{
+				"custom_field_id": bson.M{"$in": deletedIds},
 			},
 		},
 	}
 
-	updateCtx, updateCancel := context.WithTimeout(context.Background(), helpers.Timeout)
-	defer updateCancel()
+	_, err = r.Db.Collection("transaction").UpdateMany(ctx, transactionFilter, update)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.Db.Collection("edit_transaction").UpdateMany(ctx, transactionFilter, update)
+	if err != nil {
+		return err
+	}
+
+	trash := make([]models.TrashItem, 0, len(customFields))
+	for i, customField := range customFields {
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "CUSTOM_FIELD",
+			EntityId:    deletedIds[i],
+			Name:        customField.Name,
+			References:  references[deletedIds[i]],
+			DeletedAt:   now,
+		})
+	}
 
-	_, err = transactionCollection.UpdateMany(updateCtx, transactionFilter, update)
+	return helpers.MoveToTrash(ctx, r.Db, trash...)
+}
+
+func findCustomFieldValues(ctx context.Context, collection *mongo.Collection, filter bson.M, references map[primitive.ObjectID][]models.TrashReference) error {
+	cursor, err := collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1, "custom_fields": 1}))
 	if err != nil {
 		return err
 	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var document struct {
+			Id           primitive.ObjectID `bson:"_id"`
+			CustomFields []bson.M           `bson:"custom_fields"`
+		}
+		if err := cursor.Decode(&document); err != nil {
+			return err
+		}
+
+		for _, value := range document.CustomFields {
+			customFieldId, ok := value["custom_field_id"].(primitive.ObjectID)
+			if !ok {
+				continue
+			}
+			references[customFieldId] = append(references[customFieldId], models.TrashReference{
+				Collection: collection.Name(),
+				DocumentId: document.Id,
+				Field:      "custom_fields",
+				Value:      value,
+			})
+		}
+	}
 
-	_, err = editTransactionCollection.UpdateMany(updateCtx, transactionFilter, update)
-	return err
+	return cursor.Err()
 }
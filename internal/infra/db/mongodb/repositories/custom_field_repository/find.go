@@ -24,7 +24,7 @@ func NewFindCustomFieldsRepository(db *mongo.Database) *FindCustomFieldsReposito
 func (r *FindCustomFieldsRepository) Find(globalFilters *presentationHelpers.GlobalFilterParams) ([]models.CustomField, error) {
 	collection := r.Db.Collection("custom_field")
 
-	filter := bson.M{"workspace_id": globalFilters.WorkspaceId}
+	filter := bson.M{"workspace_id": globalFilters.WorkspaceId, "deleted_at": bson.M{"$exists": false}}
 	if globalFilters.Type != "" {
 		filter["transaction_type"] = globalFilters.Type
 	}
@@ -27,7 +27,7 @@ func (r *FindCustomFieldByIdRepository) Find(customFieldId primitive.ObjectID, w
 	defer cancel()
 
 	var customField models.CustomField
-	err := collection.FindOne(ctx, bson.M{"_id": customFieldId, "workspace_id": workspaceId}).Decode(&customField)
+	err := collection.FindOne(ctx, bson.M{"_id": customFieldId, "workspace_id": workspaceId, "deleted_at": bson.M{"$exists": false}}).Decode(&customField)
 	if err == mongo.ErrNoDocuments {
 		return nil, nil
 	}
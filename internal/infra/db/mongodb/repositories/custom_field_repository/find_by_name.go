@@ -30,6 +30,7 @@ func (r *FindCustomFieldByNameRepository) FindByNameAndWorkspaceId(name string,
 	err := collection.FindOne(ctx, bson.M{
 		"name":         name,
 		"workspace_id": workspaceId,
+		"deleted_at":   bson.M{"$exists": false},
 	}).Decode(&customField)
 
 	if err != nil {
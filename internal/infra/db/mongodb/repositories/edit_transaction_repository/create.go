@@ -34,5 +34,11 @@ func (r *CreateEditTransactionRepository) Create(transaction *models.Transaction
 		return nil, err
 	}
 
+	if transaction.MainId != nil {
+		if err := helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, transaction.WorkspaceId, []primitive.ObjectID{*transaction.MainId}, transaction); err != nil {
+			return nil, err
+		}
+	}
+
 	return transaction, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -58,6 +59,25 @@ func (r *DeleteEditTransactionRepository) Delete(editTransactionParams []struct
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.UpdateMany(ctx, filter, update)
-	return err
+	mainIds := make([]primitive.ObjectID, 0, len(editTransactionParams))
+	for _, param := range editTransactionParams {
+		mainIds = append(mainIds, param.MainId)
+	}
+
+	cursor, err := collection.Find(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	var edits []*models.Transaction
+	if err := cursor.All(ctx, &edits); err != nil {
+		return err
+	}
+
+	_, err = collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	return helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, workspaceId, mainIds, edits...)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -25,10 +26,16 @@ func (r *UpdateEditTransactionRepository) Update(transaction *models.Transaction
 
 	transaction.UpdatedAt = time.Now().UTC()
 
-	filter := bson.M{"main_id": transaction.MainId, "workspace_id": transaction.WorkspaceId}
+	filter := bson.M{"main_id": transaction.MainId, "main_count": transaction.MainCount, "workspace_id": transaction.WorkspaceId}
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
+	// the previous version is needed to invalidate the ledger of the account the installment is leaving
+	var previous models.Transaction
+	if err := collection.FindOne(ctx, filter).Decode(&previous); err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
 	// Use $set to update fields without changing _id
 	update := bson.M{
 		"$set": bson.M{
@@ -65,5 +72,11 @@ func (r *UpdateEditTransactionRepository) Update(transaction *models.Transaction
 		return nil, err
 	}
 
+	if transaction.MainId != nil {
+		if err := helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, transaction.WorkspaceId, []primitive.ObjectID{*transaction.MainId}, &previous, &updatedTransaction); err != nil {
+			return nil, err
+		}
+	}
+
 	return &updatedTransaction, nil
 }
@@ -0,0 +1,42 @@
+package member_role_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindMemberRolesRepository handles listing the finance roles of a workspace
+type FindMemberRolesRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindMemberRolesRepository creates a new FindMemberRolesRepository
+func NewFindMemberRolesRepository(db *mongo.Database) *FindMemberRolesRepository {
+	return &FindMemberRolesRepository{Db: db}
+}
+
+// Find returns the roles given in the workspace
+func (r *FindMemberRolesRepository) Find(workspaceId primitive.ObjectID) ([]models.MemberRole, error) {
+	collection := r.Db.Collection("member_role")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, bson.M{"workspace_id": workspaceId}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	memberRoles := []models.MemberRole{}
+	if err := cursor.All(ctx, &memberRoles); err != nil {
+		return nil, err
+	}
+
+	return memberRoles, nil
+}
@@ -0,0 +1,40 @@
+package member_role_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindMemberRoleRepository handles finding the finance role of a member
+type FindMemberRoleRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindMemberRoleRepository creates a new FindMemberRoleRepository
+func NewFindMemberRoleRepository(db *mongo.Database) *FindMemberRoleRepository {
+	return &FindMemberRoleRepository{Db: db}
+}
+
+// Find returns the role of the member in the workspace, nil when none was given
+func (r *FindMemberRoleRepository) Find(workspaceId primitive.ObjectID, memberId primitive.ObjectID) (*models.MemberRole, error) {
+	collection := r.Db.Collection("member_role")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var memberRole models.MemberRole
+	err := collection.FindOne(ctx, bson.M{"workspace_id": workspaceId, "member_id": memberId}).Decode(&memberRole)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &memberRole, nil
+}
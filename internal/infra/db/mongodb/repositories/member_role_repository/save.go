@@ -0,0 +1,55 @@
+package member_role_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveMemberRoleRepository handles giving finance roles to members
+type SaveMemberRoleRepository struct {
+	Db *mongo.Database
+}
+
+// NewSaveMemberRoleRepository creates a new SaveMemberRoleRepository
+func NewSaveMemberRoleRepository(db *mongo.Database) *SaveMemberRoleRepository {
+	return &SaveMemberRoleRepository{Db: db}
+}
+
+// Save sets the role of the member, replacing the previous one
+func (r *SaveMemberRoleRepository) Save(memberRole *models.MemberRole) (*models.MemberRole, error) {
+	collection := r.Db.Collection("member_role")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	now := time.Now().UTC()
+	update := bson.M{
+		"$set": bson.M{
+			"role":       memberRole.Role,
+			"updated_at": now,
+		},
+		"$setOnInsert": bson.M{
+			"_id":        primitive.NewObjectID(),
+			"created_at": now,
+		},
+	}
+
+	var saved models.MemberRole
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"workspace_id": memberRole.WorkspaceId, "member_id": memberRole.MemberId},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
@@ -0,0 +1,40 @@
+package nfe_import_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindNfeImportSettingsRepository handles finding the NF-e import settings of a workspace
+type FindNfeImportSettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindNfeImportSettingsRepository creates a new FindNfeImportSettingsRepository
+func NewFindNfeImportSettingsRepository(db *mongo.Database) *FindNfeImportSettingsRepository {
+	return &FindNfeImportSettingsRepository{Db: db}
+}
+
+// Find returns the settings of the workspace, nil when they were never configured
+func (r *FindNfeImportSettingsRepository) Find(workspaceId primitive.ObjectID) (*models.NfeImportSettings, error) {
+	collection := r.Db.Collection("nfe_import_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var settings models.NfeImportSettings
+	err := collection.FindOne(ctx, bson.M{"workspace_id": workspaceId}).Decode(&settings)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
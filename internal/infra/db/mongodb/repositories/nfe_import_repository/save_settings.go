@@ -0,0 +1,53 @@
+package nfe_import_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SaveNfeImportSettingsRepository handles saving the NF-e import settings of a workspace
+type SaveNfeImportSettingsRepository struct {
+	Db *mongo.Database
+}
+
+// NewSaveNfeImportSettingsRepository creates a new SaveNfeImportSettingsRepository
+func NewSaveNfeImportSettingsRepository(db *mongo.Database) *SaveNfeImportSettingsRepository {
+	return &SaveNfeImportSettingsRepository{Db: db}
+}
+
+// Save replaces the settings of the workspace. Expenses already imported keep their category.
+func (r *SaveNfeImportSettingsRepository) Save(settings *models.NfeImportSettings) (*models.NfeImportSettings, error) {
+	collection := r.Db.Collection("nfe_import_settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	update := bson.M{
+		"$set": bson.M{
+			"category_rules":       settings.CategoryRules,
+			"default_category":     settings.DefaultCategory,
+			"default_sub_category": settings.DefaultSubCategory,
+			"updated_at":           time.Now().UTC(),
+		},
+		"$setOnInsert": bson.M{"_id": primitive.NewObjectID()},
+	}
+
+	var saved models.NfeImportSettings
+	err := collection.FindOneAndUpdate(ctx,
+		bson.M{"workspace_id": settings.WorkspaceId},
+		update,
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -59,3 +60,21 @@ func SaveExcelToRedis(redisURL string, key string, excelData *excelize.File, exp
 
 	return nil
 }
+
+func SaveJSONToRedis(redisURL string, key string, value any, expiration time.Duration) error {
+	redisClient := helpers.RedisHelper(redisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("erro ao serializar JSON: %w", err)
+	}
+
+	err = redisClient.Set(ctx, key, data, expiration).Err()
+	if err != nil {
+		return fmt.Errorf("erro ao salvar JSON no Redis: %w", err)
+	}
+
+	return nil
+}
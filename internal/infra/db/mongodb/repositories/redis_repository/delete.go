@@ -0,0 +1,21 @@
+package redis_repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+)
+
+func DeleteByKey(redisURL string, key string) (bool, error) {
+	redisClient := helpers.RedisHelper(redisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	deleted, err := redisClient.Del(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("erro ao remover chave %s do Redis: %w", key, err)
+	}
+
+	return deleted > 0, nil
+}
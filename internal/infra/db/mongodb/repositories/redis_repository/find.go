@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -61,6 +62,19 @@ func FindExcelByKey(redisURL string, key string) (*excelize.File, error) {
 	return excelFile, nil
 }
 
+func FindJSONByKey(redisURL string, key string, value any) error {
+	jsonString, err := FindByKey(redisURL, key)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal([]byte(jsonString), value); err != nil {
+		return fmt.Errorf("erro ao converter JSON: %w", err)
+	}
+
+	return nil
+}
+
 func KeyExists(redisURL string, key string) (bool, error) {
 	redisClient := helpers.RedisHelper(redisURL)
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
@@ -0,0 +1,202 @@
+package redis_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/redis/go-redis/v9"
+)
+
+// the jobs and their lines are kept for a week, enough to follow a job resumed after a restart
+const importJobExpiration = 7 * 24 * time.Hour
+
+const (
+	importJobQueueKey  = "import_jobs:queue"
+	importJobActiveKey = "import_jobs:active"
+)
+
+type ImportJobRepository struct {
+	RedisURL string
+}
+
+func NewImportJobRepository(redisURL string) *ImportJobRepository {
+	return &ImportJobRepository{
+		RedisURL: redisURL,
+	}
+}
+
+func importJobKey(id string) string {
+	return "import_job:" + id
+}
+
+func importJobItemsKey(id string) string {
+	return "import_job_items:" + id
+}
+
+func importJobCancelKey(id string) string {
+	return "import_job_cancel:" + id
+}
+
+func importJobLockKey(id string) string {
+	return "import_job_lock:" + id
+}
+
+func (r *ImportJobRepository) Save(job *models.ImportJob) error {
+	return SaveJSONToRedis(r.RedisURL, importJobKey(job.Id), job, importJobExpiration)
+}
+
+func (r *ImportJobRepository) Find(id string) (*models.ImportJob, error) {
+	var job models.ImportJob
+	if err := FindJSONByKey(r.RedisURL, importJobKey(id), &job); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (r *ImportJobRepository) SaveItems(id string, items []byte) error {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	if err := redisClient.Set(ctx, importJobItemsKey(id), items, importJobExpiration).Err(); err != nil {
+		return fmt.Errorf("erro ao salvar as linhas da importação no Redis: %w", err)
+	}
+
+	return nil
+}
+
+// FindItems returns nil when the lines expired or the job already finished
+func (r *ImportJobRepository) FindItems(id string) ([]byte, error) {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	items, err := redisClient.Get(ctx, importJobItemsKey(id)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar as linhas da importação no Redis: %w", err)
+	}
+
+	return items, nil
+}
+
+// Enqueue adds the job to the queue and to the active jobs, which are checked to resume the jobs
+// interrupted by a restart
+func (r *ImportJobRepository) Enqueue(id string) error {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	pipe := redisClient.TxPipeline()
+	pipe.SAdd(ctx, importJobActiveKey, id)
+	pipe.RPush(ctx, importJobQueueKey, id)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("erro ao enfileirar a importação: %w", err)
+	}
+
+	return nil
+}
+
+// Dequeue waits up to timeout for a job, returning an empty id when the queue stays empty
+func (r *ImportJobRepository) Dequeue(timeout time.Duration) (string, error) {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout+helpers.RedisTimeout)
+	defer cancel()
+
+	result, err := redisClient.BLPop(ctx, timeout, importJobQueueKey).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+		return "", fmt.Errorf("erro ao buscar importação na fila: %w", err)
+	}
+
+	return result[1], nil
+}
+
+func (r *ImportJobRepository) FindActive() ([]string, error) {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	ids, err := redisClient.SMembers(ctx, importJobActiveKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar as importações em andamento: %w", err)
+	}
+
+	return ids, nil
+}
+
+// Finish removes the job from the active jobs and drops its lines, the job itself is kept until it
+// expires so its result can still be read
+func (r *ImportJobRepository) Finish(id string) error {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	pipe := redisClient.TxPipeline()
+	pipe.SRem(ctx, importJobActiveKey, id)
+	pipe.Del(ctx, importJobItemsKey(id), importJobCancelKey(id))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("erro ao finalizar a importação: %w", err)
+	}
+
+	return nil
+}
+
+// Cancel flags the job, the worker stops before its next batch
+func (r *ImportJobRepository) Cancel(id string) error {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	if err := redisClient.Set(ctx, importJobCancelKey(id), "1", importJobExpiration).Err(); err != nil {
+		return fmt.Errorf("erro ao cancelar a importação: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) IsCanceled(id string) (bool, error) {
+	return KeyExists(r.RedisURL, importJobCancelKey(id))
+}
+
+func (r *ImportJobRepository) Lock(id string, ttl time.Duration) (bool, error) {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	locked, err := redisClient.SetNX(ctx, importJobLockKey(id), "1", ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("erro ao bloquear a importação: %w", err)
+	}
+
+	return locked, nil
+}
+
+func (r *ImportJobRepository) ExtendLock(id string, ttl time.Duration) error {
+	redisClient := helpers.RedisHelper(r.RedisURL)
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.RedisTimeout)
+	defer cancel()
+
+	if err := redisClient.Expire(ctx, importJobLockKey(id), ttl).Err(); err != nil {
+		return fmt.Errorf("erro ao renovar o bloqueio da importação: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) Unlock(id string) error {
+	_, err := DeleteByKey(r.RedisURL, importJobLockKey(id))
+	return err
+}
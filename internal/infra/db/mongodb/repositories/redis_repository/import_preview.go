@@ -0,0 +1,45 @@
+package redis_repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ImportPreviewRepository struct {
+	RedisURL string
+}
+
+func NewImportPreviewRepository(redisURL string) *ImportPreviewRepository {
+	return &ImportPreviewRepository{
+		RedisURL: redisURL,
+	}
+}
+
+func importPreviewKey(workspaceId primitive.ObjectID, token string) string {
+	return "import_preview:" + workspaceId.Hex() + ":" + token
+}
+
+func (r *ImportPreviewRepository) Save(preview *models.ImportPreview) error {
+	return SaveJSONToRedis(r.RedisURL, importPreviewKey(preview.WorkspaceId, preview.Token), preview, time.Until(preview.ExpiresAt))
+}
+
+func (r *ImportPreviewRepository) Find(workspaceId primitive.ObjectID, token string) (*models.ImportPreview, error) {
+	var preview models.ImportPreview
+	if err := FindJSONByKey(r.RedisURL, importPreviewKey(workspaceId, token), &preview); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &preview, nil
+}
+
+// Delete returns false when the preview no longer exists, e.g. it was committed by another request
+func (r *ImportPreviewRepository) Delete(workspaceId primitive.ObjectID, token string) (bool, error) {
+	return DeleteByKey(r.RedisURL, importPreviewKey(workspaceId, token))
+}
@@ -0,0 +1,41 @@
+package report_repository
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FindCashFlowReportRepository struct {
+	Db                         *mongo.Database
+	FindTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository
+}
+
+func NewFindCashFlowReportRepository(db *mongo.Database, findTransactionsRepository usecase.FindTransactionsByWorkspaceIdRepository) *FindCashFlowReportRepository {
+	return &FindCashFlowReportRepository{
+		Db:                         db,
+		FindTransactionsRepository: findTransactionsRepository,
+	}
+}
+
+func (r *FindCashFlowReportRepository) Find(data *usecase.FindCashFlowReportInputRepository) (*models.CashFlowReport, error) {
+	// the expansion of installments and recurrences is the same used by the transaction listing.
+	// The forecast is by due date and the realized by confirmation date, so both bring a transaction in.
+	transactions, err := r.FindTransactionsRepository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{
+		WorkspaceId: data.WorkspaceId,
+		InitialDate: data.InitialDate.Format("2006-01-02"),
+		FinalDate:   data.FinalDate.Format("2006-01-02"),
+		DateType:    "DUE_OR_CONFIRMATION",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := helpers.FindExchangeRates(r.Db, data.WorkspaceId)
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.BuildCashFlowReport(data.InitialDate, data.FinalDate, data.GroupBy, transactions, rates)
+}
@@ -0,0 +1,91 @@
+package transaction_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CategorizeTransactionsRepository struct {
+	Db *mongo.Database
+}
+
+func NewCategorizeTransactionsRepository(db *mongo.Database) *CategorizeTransactionsRepository {
+	return &CategorizeTransactionsRepository{
+		Db: db,
+	}
+}
+
+// Find retorna as transações como estão gravadas, sem expandir parcelas e recorrências: a
+// classificação é da transação principal e as parcelas editadas mantêm a sua.
+func (r *CategorizeTransactionsRepository) Find(workspaceId primitive.ObjectID, filter *usecase.CategorizeTransactionsFilter) ([]models.Transaction, error) {
+	// transferências entre contas não são receita nem despesa e ficam sem categoria
+	query := bson.M{"workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}, "transfer": bson.M{"$exists": false}}
+	if len(filter.TransactionIds) > 0 {
+		query["_id"] = bson.M{"$in": filter.TransactionIds}
+	}
+
+	dueDate := bson.M{}
+	if filter.InitialDate != nil {
+		dueDate["$gte"] = *filter.InitialDate
+	}
+	if filter.FinalDate != nil {
+		dueDate["$lte"] = *filter.FinalDate
+	}
+	if len(dueDate) > 0 {
+		query["due_date"] = dueDate
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transaction").Find(ctx, query, options.Find().SetSort(bson.M{"due_date": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	transactions := []models.Transaction{}
+	if err := cursor.All(ctx, &transactions); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Update grava apenas a classificação das transações e a aprovação, que depende da categoria
+func (r *CategorizeTransactionsRepository) Update(transactions []models.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(transactions))
+	for _, tx := range transactions {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": tx.Id, "workspace_id": tx.WorkspaceId}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"category_id":     tx.CategoryId,
+				"sub_category_id": tx.SubCategoryId,
+				"tags":            tx.Tags,
+				"assigned_to":     tx.AssignedTo,
+				"custom_fields":   tx.CustomFields,
+				"approval_status": tx.ApprovalStatus,
+				"approval":        tx.Approval,
+				"updated_at":      now,
+			}}))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	_, err := r.Db.Collection("transaction").BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	return err
+}
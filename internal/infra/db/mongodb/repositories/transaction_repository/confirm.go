@@ -0,0 +1,48 @@
+package transaction_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ConfirmTransactionRepository struct {
+	Db *mongo.Database
+}
+
+func NewConfirmTransactionRepository(db *mongo.Database) *ConfirmTransactionRepository {
+	return &ConfirmTransactionRepository{
+		Db: db,
+	}
+}
+
+// Confirm confirma transações já expandidas pelo TransactionRepository.Find.
+// Transações avulsas são atualizadas diretamente; parcelas e recorrências são
+// confirmadas através da edição (edit_transaction) da parcela correspondente.
+func (r *ConfirmTransactionRepository) Confirm(transactions []models.Transaction, confirmationDate time.Time) error {
+	transactionWrites, editWrites, confirmed, err := helpers.ConfirmTransactionsWrites(transactions, confirmationDate)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	if len(transactionWrites) > 0 {
+		if _, err := r.Db.Collection("transaction").BulkWrite(ctx, transactionWrites, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	if len(editWrites) > 0 {
+		if _, err := r.Db.Collection("edit_transaction").BulkWrite(ctx, editWrites, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	return helpers.InvalidateTransactionsBalanceSnapshots(r.Db, confirmed...)
+}
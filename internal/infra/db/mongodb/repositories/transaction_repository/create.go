@@ -34,6 +34,10 @@ func (r *CreateTransactionRepository) Create(transaction *models.Transaction) (*
 		return nil, err
 	}
 
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, transaction); err != nil {
+		return nil, err
+	}
+
 	return transaction, nil
 }
 
@@ -66,5 +70,9 @@ func (r *CreateTransactionRepository) CreateMany(transactions []*models.Transact
 		return nil, err
 	}
 
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, transactions...); err != nil {
+		return nil, err
+	}
+
 	return transactions, nil
 }
@@ -28,18 +28,61 @@ func (r *DeleteTransactionRepository) Delete(transactionIds []primitive.ObjectID
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": transactionIds}, "workspace_id": workspaceId})
+	filter := bson.M{"_id": bson.M{"$in": transactionIds}, "workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}}
+
+	cursor, err := collection.Find(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	editTransactionCollection := r.Db.Collection("edit_transaction")
-	_, err = editTransactionCollection.DeleteMany(ctx, bson.M{"main_id": bson.M{"$in": transactionIds}})
+	var deleted []*models.Transaction
+	if err := cursor.All(ctx, &deleted); err != nil {
+		return err
+	}
+
+	if len(deleted) == 0 {
+		return nil
+	}
+
+	// as edições das parcelas e os anexos ficam intactos, eles voltam a valer se a transação for
+	// restaurada; os anexos só são apagados quando a lixeira é esvaziada
+	editCursor, err := r.Db.Collection("edit_transaction").Find(ctx, bson.M{"main_id": bson.M{"$in": transactionIds}})
 	if err != nil {
 		return err
 	}
 
-	return nil
+	var deletedEdits []*models.Transaction
+	if err := editCursor.All(ctx, &deletedEdits); err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	_, err = collection.UpdateMany(ctx, filter, bson.M{
+		"$set": bson.M{
+			"is_deleted": true,
+			"deleted_at": now,
+			"updated_at": now,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	trash := make([]models.TrashItem, 0, len(deleted))
+	for _, tx := range deleted {
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "TRANSACTION",
+			EntityId:    tx.Id,
+			Name:        tx.Name,
+			DeletedAt:   now,
+		})
+	}
+	if err := helpers.MoveToTrash(ctx, r.Db, trash...); err != nil {
+		return err
+	}
+
+	return helpers.InvalidateTransactionsBalanceSnapshots(r.Db, append(deleted, deletedEdits...)...)
 }
 
 func (r *DeleteTransactionRepository) DeleteEditTransactions(editTransactionParams []struct {
@@ -52,62 +95,104 @@ func (r *DeleteTransactionRepository) DeleteEditTransactions(editTransactionPara
 	}
 
 	collection := r.Db.Collection("edit_transaction")
-	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
-	defer cancel()
 
-	for _, param := range editTransactionParams {
-		// First check if the edit transaction already exists
-		filter := bson.M{
-			"main_id":      param.MainId,
-			"main_count":   param.MainCount,
-			"workspace_id": param.WorkspaceId,
-		}
+	var mainIds []primitive.ObjectID
+	var edits []*models.Transaction
+	now := time.Now().UTC()
 
-		var existingEditTx models.Transaction
-		err := collection.FindOne(ctx, filter).Decode(&existingEditTx)
+	// the occurrences are deleted together: the two legs of a transfer can't be left apart
+	err := helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		mainIds = make([]primitive.ObjectID, 0, len(editTransactionParams))
+		edits = nil
+		var trash []models.TrashItem
 
-		if err != nil {
-			if err == mongo.ErrNoDocuments {
-				// Edit transaction doesn't exist, need to create it
-				// First get the main transaction to copy its data
-				mainTransaction, err := findTransactionById.Find(param.MainId, param.WorkspaceId)
-				if err != nil || mainTransaction == nil {
-					// Skip if main transaction doesn't exist
+		for _, param := range editTransactionParams {
+			mainIds = append(mainIds, param.MainId)
+
+			// First check if the edit transaction already exists
+			filter := bson.M{
+				"main_id":      param.MainId,
+				"main_count":   param.MainCount,
+				"workspace_id": param.WorkspaceId,
+			}
+
+			var existingEditTx models.Transaction
+			err := collection.FindOne(ctx, filter).Decode(&existingEditTx)
+			if err == nil {
+				if existingEditTx.IsDeleted {
 					continue
 				}
+				edits = append(edits, &existingEditTx)
+			}
 
-				// Create a copy of the main transaction
-				newEditTx := *mainTransaction
-				newEditTx.Id = primitive.NewObjectID()
-				newEditTx.MainId = &param.MainId
-				newEditTx.MainCount = &param.MainCount
-				newEditTx.IsDeleted = true
-				newEditTx.CreatedAt = time.Now().UTC()
-				newEditTx.UpdatedAt = time.Now().UTC()
-
-				// Insert the new edit transaction
-				_, err = collection.InsertOne(ctx, newEditTx)
-				if err != nil {
+			if err != nil {
+				if err == mongo.ErrNoDocuments {
+					// Edit transaction doesn't exist, need to create it
+					// First get the main transaction to copy its data
+					mainTransaction, err := findTransactionById.Find(param.MainId, param.WorkspaceId)
+					if err != nil || mainTransaction == nil {
+						// Skip if main transaction doesn't exist
+						continue
+					}
+
+					// Create a copy of the main transaction
+					newEditTx := *mainTransaction
+					newEditTx.Id = primitive.NewObjectID()
+					newEditTx.MainId = &param.MainId
+					newEditTx.MainCount = &param.MainCount
+					newEditTx.IsDeleted = true
+					newEditTx.CreatedAt = now
+					newEditTx.UpdatedAt = now
+
+					// Insert the new edit transaction
+					_, err = collection.InsertOne(ctx, newEditTx)
+					if err != nil {
+						return err
+					}
+
+					trash = append(trash, installmentTrashItem(&newEditTx, param.MainId, param.MainCount, true, now))
+				} else {
 					return err
 				}
 			} else {
-				return err
-			}
-		} else {
-			// Edit transaction exists, update it to mark as deleted
-			update := bson.M{
-				"$set": bson.M{
-					"is_deleted": true,
-					"updated_at": time.Now().UTC(),
-				},
-			}
+				// Edit transaction exists, update it to mark as deleted
+				update := bson.M{
+					"$set": bson.M{
+						"is_deleted": true,
+						"updated_at": now,
+					},
+				}
 
-			_, err = collection.UpdateOne(ctx, filter, update)
-			if err != nil {
-				return err
+				_, err = collection.UpdateOne(ctx, filter, update)
+				if err != nil {
+					return err
+				}
+
+				trash = append(trash, installmentTrashItem(&existingEditTx, param.MainId, param.MainCount, false, now))
 			}
 		}
+
+		return helpers.MoveToTrash(ctx, r.Db, trash...)
+	})
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, editTransactionParams[0].WorkspaceId, mainIds, edits...)
+}
+
+// installmentTrashItem registra a exclusão de uma parcela; a edição marcada como removida é o que a
+// esconde. Na restauração, a edição que já existia volta a valer, e a criada só para a exclusão
+// (deletionOnly) é apagada para que a parcela volte a ser calculada pela transação principal
+func installmentTrashItem(edit *models.Transaction, mainId primitive.ObjectID, mainCount int, deletionOnly bool, deletedAt time.Time) models.TrashItem {
+	return models.TrashItem{
+		WorkspaceId:  edit.WorkspaceId,
+		Entity:       "TRANSACTION",
+		EntityId:     edit.Id,
+		Name:         edit.Name,
+		MainId:       &mainId,
+		MainCount:    &mainCount,
+		DeletionOnly: deletionOnly,
+		DeletedAt:    deletedAt,
+	}
 }
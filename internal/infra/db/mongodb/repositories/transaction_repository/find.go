@@ -23,49 +23,69 @@ func NewTransactionRepository(db *mongo.Database, findByIdEditTransactionReposit
 }
 
 func (r *TransactionRepository) Find(filters *usecase.FindTransactionsByWorkspaceIdInputRepository) ([]models.Transaction, error) {
-	collection := r.db.Collection("transaction")
+	startOfMonth, endOfMonth, err := findPeriod(filters)
+	if err != nil {
+		return nil, err
+	}
 
-	var startOfMonth, endOfMonth time.Time
+	search, err := r.searchFilter(filters)
+	if err != nil {
+		return nil, err
+	}
 
-	if filters.InitialDate != "" && filters.FinalDate != "" {
-		startDate, err := time.Parse("2006-01-02", filters.InitialDate)
-		if err != nil {
-			return nil, err
-		}
-		endDate, err := time.Parse("2006-01-02", filters.FinalDate)
-		if err != nil {
-			return nil, err
-		}
-		startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
-		endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 23, 59, 59, 0, endDate.Location())
+	return r.findExpanded(filters, search, nil, startOfMonth, endOfMonth)
+}
 
-		startOfMonth = startDate
-		endOfMonth = endDate
-	} else {
+// findPeriod devolve o período pedido, do início do dia inicial ao fim do dia final. Sem período, o
+// início fica zerado e o fim vai até 2050.
+func findPeriod(filters *usecase.FindTransactionsByWorkspaceIdInputRepository) (time.Time, time.Time, error) {
+	if filters.InitialDate == "" || filters.FinalDate == "" {
 		// for default use a high end month
-		endOfMonth = time.Date(2050, 12, 31, 23, 59, 59, 0, time.Local)
+		return time.Time{}, time.Date(2050, 12, 31, 23, 59, 59, 0, time.Local), nil
+	}
+
+	startDate, err := time.Parse("2006-01-02", filters.InitialDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
 	}
+	endDate, err := time.Parse("2006-01-02", filters.FinalDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, startDate.Location())
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 23, 59, 59, 0, endDate.Location())
+
+	return startDate, endDate, nil
+}
+
+// findExpanded busca as transações que atendem aos filtros e expande as parcelas e recorrências no período,
+// já com as edições aplicadas. search é o filtro de searchFilter e frequency, quando informado, restringe
+// a consulta a algumas frequências.
+func (r *TransactionRepository) findExpanded(filters *usecase.FindTransactionsByWorkspaceIdInputRepository, search bson.M, frequency any, startOfMonth, endOfMonth time.Time) ([]models.Transaction, error) {
+	collection := r.db.Collection("transaction")
+
+	content := contentFilter(filters, search)
+	hasContentFilter := len(content) > 0
 
 	filter := bson.M{
 		"workspace_id": filters.WorkspaceId,
+		"is_deleted":   bson.M{"$ne": true},
 	}
-
-	if filters.Type != "" {
-		filter["type"] = filters.Type
+	for key, value := range content {
+		filter[key] = value
+	}
+	if frequency != nil {
+		filter["frequency"] = frequency
 	}
 
-	if len(filters.AccountIds) > 0 {
-		filter["account_id"] = bson.M{"$in": filters.AccountIds}
+	if dateFilter := doNotRepeatDateFilter(filters.DateType, startOfMonth, endOfMonth); dateFilter != nil {
+		filter["$or"] = dateFilter["$or"]
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
 	findOptions := options.Find()
-
-	if filters.Limit > 0 && !filters.IsSearching {
-		findOptions.SetLimit(int64(filters.Offset + 300))
-	}
 	// put reverse order
 	findOptions.SetSort(bson.D{{Key: "_id", Value: -1}})
 	cursor, err := collection.Find(ctx, filter, findOptions)
@@ -79,21 +99,47 @@ func (r *TransactionRepository) Find(filters *usecase.FindTransactionsByWorkspac
 		return nil, err
 	}
 
+	matchedMains := make(map[primitive.ObjectID]bool, len(transactions))
+	matchedEdits := make(map[string]bool)
+	if hasContentFilter {
+		for _, tx := range transactions {
+			matchedMains[tx.Id] = true
+		}
+
+		// a parcela editada pode atender aos filtros mesmo quando a transação principal não atende
+		edits, err := r.findMatchingEdits(filters.WorkspaceId, content)
+		if err != nil {
+			return nil, err
+		}
+		for _, edit := range edits {
+			if edit.MainId != nil && edit.MainCount != nil {
+				matchedEdits[instanceKey(*edit.MainId, *edit.MainCount)] = true
+			}
+		}
+
+		transactions, err = r.appendMainsOfEdits(transactions, edits, filters.WorkspaceId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	transactions = r.applyRepeatAndRecurringLogicTransactions(transactions, startOfMonth, endOfMonth)
-	transactions, err = r.replaceTransactionIfEditRepeat(transactions)
+	transactions, editedInstances, err := r.replaceTransactionIfEditRepeat(transactions)
 	if err != nil {
 		return nil, err
 	}
 
-	return transactions, nil
+	return filterInstances(transactions, filters, hasContentFilter, matchedMains, matchedEdits, editedInstances), nil
 }
 
 func (r *TransactionRepository) computeInstallmentDueDate(initial time.Time, interval string, offset int, customDay ...int) time.Time {
 	switch interval {
-	// case "DAILY":
-	// 	return initial.AddDate(0, 0, offset)
-	// case "WEEKLY":
-	// 	return initial.AddDate(0, 0, 7*offset)
+	case "DAILY":
+		return initial.AddDate(0, 0, offset)
+	case "WEEKLY":
+		return initial.AddDate(0, 0, 7*offset)
+	case "BIWEEKLY":
+		return initial.AddDate(0, 0, 14*offset)
 	case "MONTHLY":
 		return initial.AddDate(0, offset, 0)
 	case "QUARTERLY":
@@ -187,10 +233,12 @@ func (r *TransactionRepository) applyRepeatAndRecurringLogicTransactions(transac
 					txCopy.RepeatSettings.CurrentCount = installmentCounter
 					installmentCounter++
 
-					// Update the balance (divide by total installments)
+					// Update the balance (divide by total installments, the last one absorbs the remainder)
 					balance := tx.Balance.Value
-					txCopy.Balance.Value = balance / float64(tx.RepeatSettings.Count)
+					txCopy.Balance.Value = balance.Installment(txCopy.RepeatSettings.CurrentCount, tx.RepeatSettings.Count)
 					txCopy.TotalBalance = balance
+					// o líquido é o da compra inteira dividido nas parcelas, a mesma divisão dos saldos
+					txCopy.Balance.NetBalance = netBalance(&tx).Installment(txCopy.RepeatSettings.CurrentCount, tx.RepeatSettings.Count)
 
 					// Sync the registration date
 					originalRegHour, originalRegMin, originalRegSec := tx.RegistrationDate.Clock()
@@ -241,9 +289,24 @@ func (r *TransactionRepository) applyRepeatAndRecurringLogicTransactions(transac
 				continue
 			}
 
+			// Intervalos em dias (diário, semanal, quinzenal): uma instância para cada ocorrência no intervalo
+			if step := helpers.IntervalDays(tx.RepeatSettings.Interval, tx.RepeatSettings.CustomDay); step > 0 {
+				txInstances := r.expandRecurringByDays(tx, dateRef, step, startOfMonth, endOfMonth)
+				if len(txInstances) > 0 {
+					helpers.SortTransactionsByDueDate(txInstances)
+					filtered = append(filtered, txInstances...)
+				}
+				continue
+			}
+
 			// Para transações recorrentes, precisamos criar uma instância para cada mês no intervalo
 			// Calculamos o intervalo entre os meses de início e fim
-			startYear, startMonth, _ := startOfMonth.Date()
+			// Sem data inicial, começamos a partir da data de referência para não percorrer meses inexistentes
+			rangeStart := startOfMonth
+			if rangeStart.IsZero() {
+				rangeStart = dateRef
+			}
+			startYear, startMonth, _ := rangeStart.Date()
 			endYear, endMonth, _ := endOfMonth.Date()
 
 			// Número total de meses no intervalo
@@ -258,9 +321,6 @@ func (r *TransactionRepository) applyRepeatAndRecurringLogicTransactions(transac
 			// Para cada mês no intervalo, crie uma cópia da transação
 			var txInstances []models.Transaction
 
-			// Contador para manter o controle da exibição sequencial (1, 2, 3, ...)
-			installmentCounter := 1
-
 			for monthOffset := 0; monthOffset <= totalMonths; monthOffset++ {
 				// Calcula a data para esta instância
 				currentDate := time.Date(startYear, startMonth, 1, 0, 0, 0, 0, time.UTC)
@@ -297,9 +357,8 @@ func (r *TransactionRepository) applyRepeatAndRecurringLogicTransactions(transac
 				if (!newDueDate.Before(startOfMonth) && newDueDate.Before(endOfMonth)) || startOfMonth.IsZero() || endOfMonth.IsZero() {
 					txCopy.DueDate = newDueDate
 
-					// Atualiza a contagem atual
-					txCopy.RepeatSettings.CurrentCount = installmentCounter
-					installmentCounter++ // Incrementa para a próxima parcela
+					// A contagem é a posição da ocorrência desde a data de referência, a mesma das edições (main_count)
+					txCopy.RepeatSettings.CurrentCount = monthsSinceOriginal + 1
 					// Atualiza o RegistrationDate
 					originalRegHour, originalRegMin, originalRegSec := tx.RegistrationDate.Clock()
 					originalRegDay := tx.RegistrationDate.Day()
@@ -344,6 +403,46 @@ func (r *TransactionRepository) applyRepeatAndRecurringLogicTransactions(transac
 	return filtered
 }
 
+// expandRecurringByDays cria as ocorrências de uma transação recorrente com intervalo em dias dentro do período.
+// O CurrentCount de cada ocorrência é a sua posição desde a data de referência (1, 2, 3, ...),
+// o mesmo número usado por helpers.CalculateCurrentCount e pelas edições (main_count).
+func (r *TransactionRepository) expandRecurringByDays(tx models.Transaction, dateRef time.Time, step int, startOfMonth, endOfMonth time.Time) []models.Transaction {
+	var txInstances []models.Transaction
+
+	firstOffset := 0
+	if !startOfMonth.IsZero() && startOfMonth.After(dateRef) {
+		daysUntilStart := int(startOfMonth.Sub(dateRef).Hours() / 24)
+		firstOffset = daysUntilStart / step
+	}
+
+	for offset := firstOffset; ; offset++ {
+		dueDate := dateRef.AddDate(0, 0, offset*step)
+		if !endOfMonth.IsZero() && !dueDate.Before(endOfMonth) {
+			break
+		}
+
+		if !startOfMonth.IsZero() && dueDate.Before(startOfMonth) {
+			continue
+		}
+
+		txCopy := tx
+		repeatSettingsCopy := *tx.RepeatSettings
+		txCopy.RepeatSettings = &repeatSettingsCopy
+		txCopy.RepeatSettings.CurrentCount = offset + 1
+		txCopy.DueDate = dueDate
+		txCopy.RegistrationDate = tx.RegistrationDate.AddDate(0, 0, offset*step)
+
+		if txCopy.IsConfirmed && txCopy.ConfirmationDate != nil {
+			newConfDate := txCopy.ConfirmationDate.AddDate(0, 0, offset*step)
+			txCopy.ConfirmationDate = &newConfDate
+		}
+
+		txInstances = append(txInstances, txCopy)
+	}
+
+	return txInstances
+}
+
 // Função auxiliar para obter o número de dias em um mês
 func daysInMonth(date time.Time) int {
 	year, month, _ := date.Date()
@@ -351,10 +450,13 @@ func daysInMonth(date time.Time) int {
 	return time.Date(year, month+1, 0, 0, 0, 0, 0, date.Location()).Day()
 }
 
-func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []models.Transaction) ([]models.Transaction, error) {
+// replaceTransactionIfEditRepeat also returns the instances that were replaced by an edit, keyed by instanceKey
+func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []models.Transaction) ([]models.Transaction, map[string]bool, error) {
+	editedInstances := make(map[string]bool)
+
 	// Skip processing if there are no transactions
 	if len(transactions) == 0 {
-		return transactions, nil
+		return transactions, editedInstances, nil
 	}
 
 	// Prepare params for batch query
@@ -394,7 +496,7 @@ func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []mo
 	// Fetch all edited transactions with a single database call
 	editedTransactions, err := r.FindByIdEditTransactionRepository.FindMany(queryParams)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Apply edited transactions
@@ -408,12 +510,15 @@ func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []mo
 		idx, exists := transactionMap[key]
 
 		if exists && *editTx.MainCount == transactions[idx].RepeatSettings.CurrentCount {
+			editedInstances[instanceKey(*editTx.MainId, *editTx.MainCount)] = true
+
 			// Store original values we need to preserve
 			repeatSettings := *transactions[idx].RepeatSettings
 			frequency := transactions[idx].Frequency
 			totalBalance := transactions[idx].TotalBalance
 			balance := transactions[idx].Balance
 			id := transactions[idx].Id
+			creditCardId := transactions[idx].CreditCardId
 
 			// Preserve the installment number/current count
 			installmentNumber := repeatSettings.CurrentCount
@@ -430,21 +535,20 @@ func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []mo
 			transactions[idx].MainCount = nil
 			transactions[idx].MainId = nil
 
+			if transactions[idx].CreditCardId == nil {
+				transactions[idx].CreditCardId = creditCardId
+			}
+
 			if transactions[idx].Frequency == "DO_NOT_REPEAT" {
 				transactions[idx].Balance = balance
 			}
 
 			transactions[idx].TotalBalance = totalBalance
+			transactions[idx].Balance.NetBalance = netBalance(&transactions[idx])
 		}
 	}
 
-	// Calculate balances for all transactions
-	for i := range transactions {
-		transactionCopy := transactions[i]
-		transactionCopy.Type = "RECIPE"
-		calc := helpers.CalculateOneTransactionBalance(&transactionCopy)
-		transactions[i].Balance.NetBalance = calc
-	}
+	setNetBalances(transactions)
 
 	// Filter out deleted transactions
 	filteredTransactions := transactions[:0]
@@ -454,5 +558,23 @@ func (r *TransactionRepository) replaceTransactionIfEditRepeat(transactions []mo
 		}
 	}
 
-	return filteredTransactions, nil
+	return filteredTransactions, editedInstances, nil
+}
+
+// setNetBalances calcula o valor líquido de cada transação. As parcelas ficam de fora: o líquido delas já
+// foi dividido na expansão, ou calculado pela edição que substituiu a parcela.
+func setNetBalances(transactions []models.Transaction) {
+	for i := range transactions {
+		if transactions[i].Frequency == "REPEAT" {
+			continue
+		}
+		transactions[i].Balance.NetBalance = netBalance(&transactions[i])
+	}
+}
+
+// netBalance é o valor líquido (com juros e descontos) da transação, sem o sinal do tipo
+func netBalance(transaction *models.Transaction) models.Money {
+	transactionCopy := *transaction
+	transactionCopy.Type = "RECIPE"
+	return helpers.CalculateOneTransactionBalance(&transactionCopy)
 }
@@ -28,7 +28,7 @@ func (r *GetTransactionByIdRepository) Find(transactionId primitive.ObjectID, wo
 
 	var transaction models.Transaction
 
-	err := collection.FindOne(ctx, bson.M{"_id": transactionId, "workspace_id": workspaceId}).Decode(&transaction)
+	err := collection.FindOne(ctx, bson.M{"_id": transactionId, "workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}}).Decode(&transaction)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
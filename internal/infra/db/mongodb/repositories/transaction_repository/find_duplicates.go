@@ -0,0 +1,159 @@
+package transaction_repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindDuplicateTransactionsRepository handles finding transactions with the same fingerprint
+type FindDuplicateTransactionsRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindDuplicateTransactionsRepository creates a new FindDuplicateTransactionsRepository
+func NewFindDuplicateTransactionsRepository(db *mongo.Database) *FindDuplicateTransactionsRepository {
+	return &FindDuplicateTransactionsRepository{Db: db}
+}
+
+// FindByFingerprint searches by type, amount and due date, which the database can filter, and
+// compares the remaining fields of the fingerprint here. Transactions that already have an id are
+// excluded from the candidates, so a batch resumed after being saved does not match itself.
+func (r *FindDuplicateTransactionsRepository) FindByFingerprint(workspaceId primitive.ObjectID, transactions []*models.Transaction) (map[string][]models.Transaction, error) {
+	duplicates := map[string][]models.Transaction{}
+	if len(transactions) == 0 {
+		return duplicates, nil
+	}
+
+	fingerprints := map[string]bool{}
+	values := []models.Money{}
+	types := []string{}
+	ids := []primitive.ObjectID{}
+	var firstDueDate, lastDueDate time.Time
+	for i, transaction := range transactions {
+		fingerprints[models.TransactionFingerprint(transaction)] = true
+		values = append(values, transaction.Balance.Value)
+		types = append(types, transaction.Type)
+		if !transaction.Id.IsZero() {
+			ids = append(ids, transaction.Id)
+		}
+
+		dueDate := transaction.DueDate.UTC()
+		if i == 0 || dueDate.Before(firstDueDate) {
+			firstDueDate = dueDate
+		}
+		if i == 0 || dueDate.After(lastDueDate) {
+			lastDueDate = dueDate
+		}
+	}
+
+	filter := bson.M{
+		"_id":           bson.M{"$nin": ids},
+		"workspace_id":  workspaceId,
+		"is_deleted":    bson.M{"$ne": true},
+		"type":          bson.M{"$in": types},
+		"balance.value": bson.M{"$in": values},
+		"due_date": bson.M{
+			"$gte": time.Date(firstDueDate.Year(), firstDueDate.Month(), firstDueDate.Day(), 0, 0, 0, 0, time.UTC),
+			"$lt":  time.Date(lastDueDate.Year(), lastDueDate.Month(), lastDueDate.Day()+1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transaction").Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []models.Transaction
+	if err := cursor.All(ctx, &candidates); err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		fingerprint := models.TransactionFingerprint(&candidate)
+		if fingerprints[fingerprint] {
+			duplicates[fingerprint] = append(duplicates[fingerprint], candidate)
+		}
+	}
+
+	return duplicates, nil
+}
+
+// FindGroups groups in the database by type, amount, due date and account or credit card, then splits
+// the groups by the full fingerprint. Groups are ordered by due date, most recent first.
+func (r *FindDuplicateTransactionsRepository) FindGroups(workspaceId primitive.ObjectID) ([]models.DuplicateTransactionGroup, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"workspace_id": workspaceId,
+			"is_deleted":   bson.M{"$ne": true},
+		}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{
+				"type":           "$type",
+				"value":          "$balance.value",
+				"due_date":       bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$due_date"}},
+				"account_id":     "$account_id",
+				"credit_card_id": "$credit_card_id",
+			},
+			"transactions": bson.M{"$push": "$$ROOT"},
+			"count":        bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transaction").Aggregate(ctx, pipeline, options.Aggregate().SetAllowDiskUse(true))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []struct {
+		Transactions []models.Transaction `bson:"transactions"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	groups := []models.DuplicateTransactionGroup{}
+	for _, result := range results {
+		byFingerprint := map[string][]models.Transaction{}
+		fingerprints := []string{}
+		for _, transaction := range result.Transactions {
+			fingerprint := models.TransactionFingerprint(&transaction)
+			if _, ok := byFingerprint[fingerprint]; !ok {
+				fingerprints = append(fingerprints, fingerprint)
+			}
+			byFingerprint[fingerprint] = append(byFingerprint[fingerprint], transaction)
+		}
+
+		for _, fingerprint := range fingerprints {
+			transactions := byFingerprint[fingerprint]
+			if len(transactions) < 2 {
+				continue
+			}
+
+			sort.Slice(transactions, func(i, j int) bool {
+				return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
+			})
+			groups = append(groups, models.DuplicateTransactionGroup{Fingerprint: fingerprint, Transactions: transactions})
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Transactions[0].DueDate.After(groups[j].Transactions[0].DueDate)
+	})
+
+	return groups, nil
+}
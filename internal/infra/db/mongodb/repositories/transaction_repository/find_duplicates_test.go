@@ -0,0 +1,35 @@
+package transaction_repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFindDuplicateTransactionsRepositoryFindByFingerprint(t *testing.T) {
+	db := mongotest.Database(t)
+	workspaceId := primitive.NewObjectID()
+
+	saved := transaction(workspaceId, "Aluguel", 150000, "DO_NOT_REPEAT", "", 0, date(2025, time.January, 10))
+	// a row of an import batch inserted before the server restarted
+	resumed := transaction(workspaceId, "Condomínio", 50000, "DO_NOT_REPEAT", "", 0, date(2025, time.January, 10))
+	mongotest.Insert(t, db, "transaction", saved, resumed)
+
+	repeated := saved
+	repeated.Id = primitive.NewObjectID()
+
+	duplicates, err := NewFindDuplicateTransactionsRepository(db).FindByFingerprint(workspaceId, []*models.Transaction{&repeated, &resumed})
+	if err != nil {
+		t.Fatalf("FindByFingerprint() error = %v", err)
+	}
+
+	if got := duplicates[models.TransactionFingerprint(&repeated)]; len(got) != 1 || got[0].Id != saved.Id {
+		t.Errorf("duplicates of the repeated row = %v, want the saved transaction", got)
+	}
+	if got := duplicates[models.TransactionFingerprint(&resumed)]; len(got) != 0 {
+		t.Errorf("duplicates of the resumed row = %v, want none", got)
+	}
+}
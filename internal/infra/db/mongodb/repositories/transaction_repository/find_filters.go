@@ -0,0 +1,340 @@
+package transaction_repository
+
+import (
+	"context"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	searchIndexMu    sync.Mutex
+	searchIndexReady bool
+)
+
+// searchFields são os campos pesquisados, os mesmos do índice de texto
+var searchFields = []string{"name", "description", "supplier", "invoice"}
+
+// ensureSearchIndex cria o índice de texto usado pela pesquisa nas transações e nas edições de parcelas.
+// Se a criação falhar, ela é tentada de novo na próxima pesquisa.
+func (r *TransactionRepository) ensureSearchIndex() bool {
+	searchIndexMu.Lock()
+	defer searchIndexMu.Unlock()
+
+	if searchIndexReady {
+		return true
+	}
+
+	keys := bson.D{}
+	for _, field := range searchFields {
+		keys = append(keys, bson.E{Key: field, Value: "text"})
+	}
+	index := mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName("transaction_search").SetDefaultLanguage("portuguese"),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	ready := true
+	for _, collection := range []string{"transaction", "edit_transaction"} {
+		if _, err := r.db.Collection(collection).Indexes().CreateOne(ctx, index); err != nil {
+			log.Printf("error creating search index on %s: %v", collection, err)
+			ready = false
+		}
+	}
+	searchIndexReady = ready
+
+	return ready
+}
+
+// searchFilter monta o filtro da pesquisa. O índice de texto encontra palavras inteiras, inclusive com
+// outras flexões em português, mas não partes de palavras, como o começo de um nome ainda sendo digitado.
+// Quando ele não encontra nada no workspace, ou o índice não pôde ser criado, a pesquisa usa uma
+// expressão regular sem diferenciar maiúsculas sobre os mesmos campos.
+func (r *TransactionRepository) searchFilter(filters *usecase.FindTransactionsByWorkspaceIdInputRepository) (bson.M, error) {
+	if filters.Search == "" {
+		return nil, nil
+	}
+
+	text := bson.M{"$text": bson.M{"$search": filters.Search}}
+	if r.ensureSearchIndex() {
+		found, err := r.anyMatches(filters.WorkspaceId, text)
+		if err != nil {
+			return nil, err
+		}
+		if found {
+			return text, nil
+		}
+	}
+
+	pattern := primitive.Regex{Pattern: regexp.QuoteMeta(filters.Search), Options: "i"}
+	fields := make([]bson.M, 0, len(searchFields))
+	for _, field := range searchFields {
+		fields = append(fields, bson.M{field: pattern})
+	}
+
+	return bson.M{"$or": fields}, nil
+}
+
+// anyMatches informa se alguma transação ou edição de parcela do workspace atende ao filtro
+func (r *TransactionRepository) anyMatches(workspaceId primitive.ObjectID, condition bson.M) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	filter := bson.M{"workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}}
+	for key, value := range condition {
+		filter[key] = value
+	}
+
+	for _, collection := range []string{"transaction", "edit_transaction"} {
+		err := r.db.Collection(collection).FindOne(ctx, filter, options.FindOne().SetProjection(bson.M{"_id": 1})).Err()
+		if err == nil {
+			return true, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// contentFilter monta os filtros que dependem do conteúdo da transação. Eles valem tanto para a transação
+// principal quanto para as edições de parcelas, já que uma edição pode mudar qualquer um desses campos.
+// search é o filtro montado por searchFilter.
+func contentFilter(filters *usecase.FindTransactionsByWorkspaceIdInputRepository, search bson.M) bson.M {
+	filter := bson.M{}
+	var conditions []bson.M
+
+	if filters.Type != "" {
+		filter["type"] = filters.Type
+	}
+
+	if len(filters.AccountIds) > 0 {
+		filter["account_id"] = bson.M{"$in": filters.AccountIds}
+	}
+
+	if len(filters.CreditCardIds) > 0 {
+		filter["credit_card_id"] = bson.M{"$in": filters.CreditCardIds}
+	}
+
+	// o $text precisa ficar no primeiro nível da consulta; a expressão regular entra junto dos demais $and
+	if text, ok := search["$text"]; ok {
+		filter["$text"] = text
+	} else if search != nil {
+		conditions = append(conditions, search)
+	}
+
+	if len(filters.CategoryIds) > 0 {
+		filter["category_id"] = bson.M{"$in": filters.CategoryIds}
+	}
+
+	if len(filters.SubCategoryIds) > 0 {
+		filter["sub_category_id"] = bson.M{"$in": filters.SubCategoryIds}
+	}
+
+	if len(filters.TagIds) > 0 {
+		filter["tags"] = bson.M{"$elemMatch": bson.M{"$or": []bson.M{
+			{"tag_id": bson.M{"$in": filters.TagIds}},
+			{"sub_tag_id": bson.M{"$in": filters.TagIds}},
+		}}}
+	}
+
+	if len(filters.AssignedTo) > 0 {
+		filter["assigned_to"] = bson.M{"$in": filters.AssignedTo}
+	}
+
+	if len(filters.CustomFields) > 0 {
+		for _, customField := range filters.CustomFields {
+			conditions = append(conditions, bson.M{"custom_fields": bson.M{"$elemMatch": bson.M{
+				"custom_field_id": customField.CustomFieldId,
+				"value":           customField.Value,
+			}}})
+		}
+	}
+
+	if len(conditions) > 0 {
+		filter["$and"] = conditions
+	}
+
+	return filter
+}
+
+// doNotRepeatDateFilter limita as transações avulsas ao período já no banco. Parcelas e recorrências
+// continuam sendo buscadas inteiras, pois as datas de cada ocorrência só existem depois da expansão.
+// DUE_OR_CONFIRMATION traz as que vencem ou foram confirmadas no período.
+func doNotRepeatDateFilter(dateType string, startDate, endDate time.Time) bson.M {
+	var fields []string
+	switch dateType {
+	case "DUE":
+		fields = []string{"due_date"}
+	case "CONFIRMATION":
+		fields = []string{"confirmation_date"}
+	case "REGISTRATION":
+		fields = []string{"registration_date"}
+	case "DUE_OR_CONFIRMATION":
+		fields = []string{"due_date", "confirmation_date"}
+	default:
+		return nil
+	}
+
+	if startDate.IsZero() {
+		return nil
+	}
+
+	conditions := []bson.M{{"frequency": bson.M{"$ne": "DO_NOT_REPEAT"}}}
+	for _, field := range fields {
+		conditions = append(conditions, bson.M{field: bson.M{"$gte": startDate, "$lte": endDate}})
+	}
+
+	return bson.M{"$or": conditions}
+}
+
+// findMatchingEdits busca as edições de parcelas que atendem aos filtros de conteúdo
+func (r *TransactionRepository) findMatchingEdits(workspaceId primitive.ObjectID, content bson.M) ([]models.Transaction, error) {
+	filter := bson.M{"workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}}
+	for key, value := range content {
+		filter[key] = value
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.db.Collection("edit_transaction").Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var edits []models.Transaction
+	if err := cursor.All(ctx, &edits); err != nil {
+		return nil, err
+	}
+
+	return edits, nil
+}
+
+// appendMainsOfEdits adiciona as transações principais das edições encontradas que ainda não estão na lista,
+// mantendo a ordem do banco (mais recentes primeiro)
+func (r *TransactionRepository) appendMainsOfEdits(transactions []models.Transaction, edits []models.Transaction, workspaceId primitive.ObjectID) ([]models.Transaction, error) {
+	loaded := make(map[primitive.ObjectID]bool, len(transactions))
+	for _, tx := range transactions {
+		loaded[tx.Id] = true
+	}
+
+	var missing []primitive.ObjectID
+	for _, edit := range edits {
+		if edit.MainId == nil || loaded[*edit.MainId] {
+			continue
+		}
+		loaded[*edit.MainId] = true
+		missing = append(missing, *edit.MainId)
+	}
+
+	if len(missing) == 0 {
+		return transactions, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.db.Collection("transaction").Find(ctx, bson.M{"_id": bson.M{"$in": missing}, "workspace_id": workspaceId, "is_deleted": bson.M{"$ne": true}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var mains []models.Transaction
+	if err := cursor.All(ctx, &mains); err != nil {
+		return nil, err
+	}
+
+	transactions = append(transactions, mains...)
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Id.Hex() > transactions[j].Id.Hex()
+	})
+
+	return transactions, nil
+}
+
+func instanceKey(id primitive.ObjectID, count int) string {
+	return id.Hex() + "_" + strconv.Itoa(count)
+}
+
+func currentCount(tx *models.Transaction) int {
+	if tx.RepeatSettings == nil {
+		return 0
+	}
+	return tx.RepeatSettings.CurrentCount
+}
+
+// filterInstances aplica os filtros sobre as ocorrências já expandidas. Uma ocorrência editada só é mantida
+// se a própria edição atendeu aos filtros de conteúdo; as demais dependem da transação principal.
+// Valor e status são calculados por ocorrência e por isso só podem ser filtrados aqui.
+func filterInstances(
+	transactions []models.Transaction,
+	filters *usecase.FindTransactionsByWorkspaceIdInputRepository,
+	hasContentFilter bool,
+	matchedMains map[primitive.ObjectID]bool,
+	matchedEdits map[string]bool,
+	editedInstances map[string]bool,
+) []models.Transaction {
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	filtered := transactions[:0]
+	for _, tx := range transactions {
+		tx.IsOverdue = !tx.IsConfirmed && tx.DueDate.Before(today)
+
+		if hasContentFilter {
+			key := instanceKey(tx.Id, currentCount(&tx))
+			switch {
+			case matchedEdits[key]:
+			case editedInstances[key]:
+				continue
+			case !matchedMains[tx.Id]:
+				continue
+			}
+		}
+
+		// o valor considerado é o líquido da ocorrência (com juros e descontos)
+		if filters.MinAmount != nil && tx.Balance.NetBalance < *filters.MinAmount {
+			continue
+		}
+		if filters.MaxAmount != nil && tx.Balance.NetBalance > *filters.MaxAmount {
+			continue
+		}
+
+		switch filters.Status {
+		case "CONFIRMED":
+			if !tx.IsConfirmed {
+				continue
+			}
+		case "PENDING":
+			if tx.IsConfirmed {
+				continue
+			}
+		case "OVERDUE":
+			if !tx.IsOverdue {
+				continue
+			}
+		}
+
+		filtered = append(filtered, tx)
+	}
+
+	return filtered
+}
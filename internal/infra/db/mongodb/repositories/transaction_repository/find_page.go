@@ -0,0 +1,400 @@
+package transaction_repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// repeatingFrequencies são as frequências que precisam ser expandidas em ocorrências
+var repeatingFrequencies = []string{"REPEAT", "RECURRING"}
+
+// pageOrder é a ordem da listagem paginada: pela data do DateType, com o id e o número da parcela
+// desempatando, ou, sem ordenação, pelo id da transação principal (mais recentes primeiro)
+type pageOrder struct {
+	byDate    bool
+	ascending bool
+	dateType  string
+}
+
+func newPageOrder(input *usecase.FindTransactionsPageInputRepository) pageOrder {
+	return pageOrder{
+		byDate:    input.UseCursor || (input.Sort != "" && input.DateType != ""),
+		ascending: input.Sort == "DESC",
+		dateType:  input.DateType,
+	}
+}
+
+func (o pageOrder) key(tx *models.Transaction) usecase.TransactionCursor {
+	cursor := usecase.TransactionCursor{Date: tx.DueDate, MainId: tx.Id.Hex()}
+
+	switch o.dateType {
+	case "CONFIRMATION":
+		if tx.ConfirmationDate != nil {
+			cursor.Date = *tx.ConfirmationDate
+		}
+	case "REGISTRATION":
+		cursor.Date = tx.RegistrationDate
+	}
+
+	if tx.RepeatSettings != nil {
+		cursor.MainCount = tx.RepeatSettings.CurrentCount
+	}
+
+	return cursor
+}
+
+// before informa se a vem antes de b na página. Na ordem pelo id, as ocorrências de uma mesma transação
+// empatam e mantêm a ordem em que foram expandidas.
+func (o pageOrder) before(a, b usecase.TransactionCursor) bool {
+	if !o.byDate {
+		return a.MainId > b.MainId
+	}
+
+	if !a.Date.Equal(b.Date) {
+		return a.Date.Before(b.Date) == o.ascending
+	}
+
+	if a.MainId != b.MainId {
+		return (a.MainId < b.MainId) == o.ascending
+	}
+
+	if a.MainCount != b.MainCount {
+		return (a.MainCount < b.MainCount) == o.ascending
+	}
+
+	return false
+}
+
+func (o pageOrder) dateField() string {
+	switch o.dateType {
+	case "CONFIRMATION":
+		return "confirmation_date"
+	case "REGISTRATION":
+		return "registration_date"
+	default:
+		return "due_date"
+	}
+}
+
+func (o pageOrder) sort() bson.D {
+	if !o.byDate {
+		return bson.D{{Key: "_id", Value: -1}}
+	}
+
+	direction := -1
+	if o.ascending {
+		direction = 1
+	}
+	return bson.D{{Key: o.dateField(), Value: direction}, {Key: "_id", Value: direction}}
+}
+
+// afterFilter limita as transações avulsas às que vêm depois do cursor. Elas não têm número de parcela,
+// então a data e o id bastam.
+func (o pageOrder) afterFilter(after usecase.TransactionCursor) (bson.M, error) {
+	id, err := primitive.ObjectIDFromHex(after.MainId)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+
+	operator := "$lt"
+	if o.ascending {
+		operator = "$gt"
+	}
+	return bson.M{"$or": []bson.M{
+		{o.dateField(): bson.M{operator: after.Date}},
+		{o.dateField(): after.Date, "_id": bson.M{operator: id}},
+	}}, nil
+}
+
+// FindPage monta uma página da listagem sem carregar todas as transações avulsas: elas são filtradas,
+// ordenadas, contadas e limitadas no banco. Só as parcelas e recorrências, que precisam ser expandidas,
+// são carregadas inteiras e depois intercaladas com as avulsas na ordem da página.
+func (r *TransactionRepository) FindPage(input *usecase.FindTransactionsPageInputRepository) (*usecase.TransactionsPage, error) {
+	filters := &input.FindTransactionsByWorkspaceIdInputRepository
+	order := newPageOrder(input)
+
+	startOfMonth, endOfMonth, err := findPeriod(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	// o filtro pelo tipo de data só vale com o período completo; sem ele nenhuma transação é mantida
+	rangeStart, rangeEnd := startOfMonth, endOfMonth
+	if filters.InitialDate == "" || filters.FinalDate == "" {
+		rangeStart, rangeEnd = time.Time{}, time.Time{}
+	}
+
+	search, err := r.searchFilter(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	repeating, err := r.findExpanded(filters, search, bson.M{"$in": repeatingFrequencies}, startOfMonth, endOfMonth)
+	if err != nil {
+		return nil, err
+	}
+	if filters.DateType != "" {
+		filtered := repeating[:0]
+		for _, tx := range repeating {
+			if inDateTypeRange(&tx, filters.DateType, rangeStart, rangeEnd) {
+				filtered = append(filtered, tx)
+			}
+		}
+		repeating = filtered
+	}
+	if order.byDate {
+		sort.SliceStable(repeating, func(i, j int) bool {
+			return order.before(order.key(&repeating[i]), order.key(&repeating[j]))
+		})
+	}
+
+	singles, singlesCount, err := r.findSinglesOfPage(input, search, order, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &usecase.TransactionsPage{TotalCount: len(repeating) + singlesCount}
+
+	if input.UseCursor {
+		if input.After != nil {
+			start := sort.Search(len(repeating), func(i int) bool {
+				return order.before(*input.After, order.key(&repeating[i]))
+			})
+			repeating = repeating[start:]
+		}
+
+		merged := mergePage(order, repeating, singles)
+		if input.Limit > 0 && len(merged) > input.Limit {
+			merged = merged[:input.Limit]
+			next := order.key(&merged[len(merged)-1])
+			page.NextCursor = &next
+		}
+		page.Transactions = merged
+	} else {
+		merged := mergePage(order, repeating, singles)
+		if input.Limit > 0 {
+			if input.Offset >= len(merged) {
+				merged = []models.Transaction{}
+			} else {
+				merged = merged[input.Offset:min(input.Offset+input.Limit, len(merged))]
+			}
+		}
+		page.Transactions = merged
+	}
+
+	if filters.MinAmount != nil || filters.MaxAmount != nil {
+		if err := r.loadSingles(page.Transactions, filters); err != nil {
+			return nil, err
+		}
+	}
+
+	return page, nil
+}
+
+// findSinglesOfPage busca as transações avulsas que podem entrar na página, já na ordem dela, e conta
+// todas as que atendem aos filtros. O valor líquido não pode ser filtrado no banco; com os filtros de
+// valor, todas as avulsas são lidas só com os campos usados no cálculo e na ordenação, e loadSingles
+// completa as que ficaram na página.
+func (r *TransactionRepository) findSinglesOfPage(input *usecase.FindTransactionsPageInputRepository, search bson.M, order pageOrder, rangeStart, rangeEnd time.Time) ([]models.Transaction, int, error) {
+	filters := &input.FindTransactionsByWorkspaceIdInputRepository
+	collection := r.db.Collection("transaction")
+	filter := singlesFilter(filters, search, order, rangeStart, rangeEnd)
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	if filters.MinAmount != nil || filters.MaxAmount != nil {
+		findOptions := options.Find().SetSort(order.sort()).SetProjection(bson.M{
+			"_id": 1, "workspace_id": 1, "type": 1, "frequency": 1, "balance": 1, "is_confirmed": 1,
+			"due_date": 1, "confirmation_date": 1, "registration_date": 1,
+		})
+		cursor, err := collection.Find(ctx, filter, findOptions)
+		if err != nil {
+			return nil, 0, err
+		}
+		defer cursor.Close(ctx)
+
+		var singles []models.Transaction
+		if err := cursor.All(ctx, &singles); err != nil {
+			return nil, 0, err
+		}
+		setNetBalances(singles)
+		singles = filterInstances(singles, filters, false, nil, nil, nil)
+		count := len(singles)
+
+		if input.UseCursor && input.After != nil {
+			start := sort.Search(len(singles), func(i int) bool {
+				return order.before(*input.After, order.key(&singles[i]))
+			})
+			singles = singles[start:]
+		}
+		return singles, count, nil
+	}
+
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	findOptions := options.Find().SetSort(order.sort())
+	if input.UseCursor {
+		if input.After != nil {
+			after, err := order.afterFilter(*input.After)
+			if err != nil {
+				return nil, 0, err
+			}
+			filter = bson.M{"$and": []bson.M{filter, after}}
+		}
+		// um item a mais indica que existe uma próxima página
+		if input.Limit > 0 {
+			findOptions.SetLimit(int64(input.Limit + 1))
+		}
+	} else if input.Limit > 0 {
+		// as parcelas intercaladas podem ocupar qualquer posição, então as avulsas são lidas desde o início
+		findOptions.SetLimit(int64(input.Offset + input.Limit))
+	}
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var singles []models.Transaction
+	if err := cursor.All(ctx, &singles); err != nil {
+		return nil, 0, err
+	}
+	setNetBalances(singles)
+
+	return filterInstances(singles, filters, false, nil, nil, nil), int(count), nil
+}
+
+// singlesFilter traduz para o banco os filtros aplicados às transações avulsas: conteúdo, tipo de data
+// e status. Elas não têm edições, então o filtro de conteúdo vale direto sobre o documento.
+func singlesFilter(filters *usecase.FindTransactionsByWorkspaceIdInputRepository, search bson.M, order pageOrder, rangeStart, rangeEnd time.Time) bson.M {
+	filter := bson.M{
+		"workspace_id": filters.WorkspaceId,
+		"is_deleted":   bson.M{"$ne": true},
+		"frequency":    bson.M{"$nin": repeatingFrequencies},
+	}
+	for key, value := range contentFilter(filters, search) {
+		filter[key] = value
+	}
+
+	var conditions []bson.M
+	if filters.DateType != "" {
+		conditions = append(conditions, bson.M{order.dateField(): bson.M{"$gte": rangeStart, "$lt": rangeEnd}})
+		if filters.DateType == "CONFIRMATION" {
+			conditions = append(conditions, bson.M{"is_confirmed": true})
+		}
+	}
+
+	switch filters.Status {
+	case "CONFIRMED":
+		conditions = append(conditions, bson.M{"is_confirmed": true})
+	case "PENDING":
+		conditions = append(conditions, bson.M{"is_confirmed": bson.M{"$ne": true}})
+	case "OVERDUE":
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		conditions = append(conditions, bson.M{"is_confirmed": bson.M{"$ne": true}, "due_date": bson.M{"$lt": today}})
+	}
+
+	if len(conditions) > 0 {
+		if content, ok := filter["$and"].([]bson.M); ok {
+			conditions = append(content, conditions...)
+		}
+		filter["$and"] = conditions
+	}
+
+	return filter
+}
+
+// inDateTypeRange é o filtro pelo tipo de data aplicado às ocorrências expandidas, o mesmo que
+// singlesFilter aplica no banco às transações avulsas
+func inDateTypeRange(tx *models.Transaction, dateType string, start, end time.Time) bool {
+	var date time.Time
+	switch dateType {
+	case "DUE":
+		date = tx.DueDate
+	case "CONFIRMATION":
+		if !tx.IsConfirmed || tx.ConfirmationDate == nil {
+			return false
+		}
+		date = *tx.ConfirmationDate
+	case "REGISTRATION":
+		date = tx.RegistrationDate
+	default:
+		return true
+	}
+
+	return !date.Before(start) && date.Before(end)
+}
+
+// mergePage intercala as ocorrências expandidas com as transações avulsas, as duas já na ordem da página
+func mergePage(order pageOrder, repeating, singles []models.Transaction) []models.Transaction {
+	merged := make([]models.Transaction, 0, len(repeating)+len(singles))
+
+	i, j := 0, 0
+	for i < len(repeating) && j < len(singles) {
+		if order.before(order.key(&singles[j]), order.key(&repeating[i])) {
+			merged = append(merged, singles[j])
+			j++
+		} else {
+			merged = append(merged, repeating[i])
+			i++
+		}
+	}
+	merged = append(merged, repeating[i:]...)
+	merged = append(merged, singles[j:]...)
+
+	return merged
+}
+
+// loadSingles troca as transações avulsas da página, lidas só com parte dos campos, pelos documentos inteiros
+func (r *TransactionRepository) loadSingles(transactions []models.Transaction, filters *usecase.FindTransactionsByWorkspaceIdInputRepository) error {
+	positions := make(map[primitive.ObjectID]int)
+	var ids []primitive.ObjectID
+	for i, tx := range transactions {
+		if tx.Frequency == "REPEAT" || tx.Frequency == "RECURRING" {
+			continue
+		}
+		positions[tx.Id] = i
+		ids = append(ids, tx.Id)
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.db.Collection("transaction").Find(ctx, bson.M{"_id": bson.M{"$in": ids}, "workspace_id": filters.WorkspaceId})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var singles []models.Transaction
+	if err := cursor.All(ctx, &singles); err != nil {
+		return err
+	}
+	setNetBalances(singles)
+
+	for _, single := range singles {
+		single.IsOverdue = transactions[positions[single.Id]].IsOverdue
+		transactions[positions[single.Id]] = single
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+package transaction_repository
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/edit_transaction_repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func transaction(workspaceId primitive.ObjectID, name string, value models.Money, frequency string, interval string, count int, dueDate time.Time) models.Transaction {
+	t := models.Transaction{
+		Id:               primitive.NewObjectID(),
+		WorkspaceId:      workspaceId,
+		Name:             name,
+		Type:             "EXPENSE",
+		Frequency:        frequency,
+		Balance:          models.TransactionBalance{Value: value},
+		DueDate:          dueDate,
+		RegistrationDate: dueDate,
+	}
+	if frequency != "DO_NOT_REPEAT" {
+		t.RepeatSettings = &models.TransactionRepeatSettings{InitialInstallment: 1, Count: count, Interval: interval}
+	}
+	return t
+}
+
+// edit is the edit_transaction of the monthly occurrence mainCount, saved with the occurrence due date
+func edit(main models.Transaction, mainCount int, name string, value models.Money, isDeleted bool) models.Transaction {
+	e := main
+	e.Id = primitive.NewObjectID()
+	e.DueDate = main.DueDate.AddDate(0, mainCount-1, 0)
+	e.MainId = &main.Id
+	e.MainCount = &mainCount
+	e.Name = name
+	e.Balance = models.TransactionBalance{Value: value}
+	e.IsDeleted = isDeleted
+	return e
+}
+
+// instance is what the test expects of each occurrence returned by Find, newest first
+type instance struct {
+	name    string
+	dueDate time.Time
+	count   int
+	value   models.Money
+}
+
+func TestTransactionRepositoryFind(t *testing.T) {
+	workspaceId := primitive.NewObjectID()
+
+	// 100.00 in 3 monthly installments, the first due on January 10th
+	installments := transaction(workspaceId, "Notebook", 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+	monthly := transaction(workspaceId, "Aluguel", 2000, "RECURRING", "MONTHLY", 0, date(2025, time.January, 5))
+	weekly := transaction(workspaceId, "Feira", 1500, "RECURRING", "WEEKLY", 0, date(2025, time.January, 1))
+	single := transaction(workspaceId, "Padaria", 800, "DO_NOT_REPEAT", "", 0, date(2025, time.February, 20))
+	// due in February and paid in March
+	paidLate := transaction(workspaceId, "Luz", 900, "DO_NOT_REPEAT", "", 0, date(2025, time.February, 25))
+	paidLate.IsConfirmed = true
+	paidLate.ConfirmationDate = &[]time.Time{date(2025, time.March, 3)}[0]
+
+	tests := []struct {
+		name         string
+		transactions []models.Transaction
+		edits        []models.Transaction
+		filters      usecase.FindTransactionsByWorkspaceIdInputRepository
+		want         []instance
+	}{
+		{
+			name:         "installments in the period",
+			transactions: []models.Transaction{installments},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-12-31"},
+			want: []instance{
+				{"Notebook", date(2025, time.March, 10), 3, 3334},
+				{"Notebook", date(2025, time.February, 10), 2, 3333},
+				{"Notebook", date(2025, time.January, 10), 1, 3333},
+			},
+		},
+		{
+			name:         "installment of the month",
+			transactions: []models.Transaction{installments},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-02-01", FinalDate: "2025-02-28"},
+			want:         []instance{{"Notebook", date(2025, time.February, 10), 2, 3333}},
+		},
+		{
+			name:         "installments after the last one",
+			transactions: []models.Transaction{installments},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-04-01", FinalDate: "2025-04-30"},
+			want:         nil,
+		},
+		{
+			name:         "edited installment",
+			transactions: []models.Transaction{installments},
+			edits:        []models.Transaction{edit(installments, 2, "Notebook usado", 5000, false)},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-12-31"},
+			want: []instance{
+				{"Notebook", date(2025, time.March, 10), 3, 3334},
+				{"Notebook usado", date(2025, time.February, 10), 2, 5000},
+				{"Notebook", date(2025, time.January, 10), 1, 3333},
+			},
+		},
+		{
+			name:         "deleted installment",
+			transactions: []models.Transaction{installments},
+			edits:        []models.Transaction{edit(installments, 2, "Notebook", 3333, true)},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-12-31"},
+			want: []instance{
+				{"Notebook", date(2025, time.March, 10), 3, 3334},
+				{"Notebook", date(2025, time.January, 10), 1, 3333},
+			},
+		},
+		{
+			name:         "monthly recurrence in the period",
+			transactions: []models.Transaction{monthly},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-03-31"},
+			want: []instance{
+				{"Aluguel", date(2025, time.March, 5), 3, 2000},
+				{"Aluguel", date(2025, time.February, 5), 2, 2000},
+				{"Aluguel", date(2025, time.January, 5), 1, 2000},
+			},
+		},
+		{
+			name:         "edited recurrence",
+			transactions: []models.Transaction{monthly},
+			edits:        []models.Transaction{edit(monthly, 3, "Aluguel reajustado", 2200, false)},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-03-01", FinalDate: "2025-03-31"},
+			want:         []instance{{"Aluguel reajustado", date(2025, time.March, 5), 3, 2200}},
+		},
+		{
+			name:         "weekly recurrence counted from the first occurrence",
+			transactions: []models.Transaction{weekly},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-02-01", FinalDate: "2025-02-28"},
+			want: []instance{
+				{"Feira", date(2025, time.February, 26), 9, 1500},
+				{"Feira", date(2025, time.February, 19), 8, 1500},
+				{"Feira", date(2025, time.February, 12), 7, 1500},
+				{"Feira", date(2025, time.February, 5), 6, 1500},
+			},
+		},
+		{
+			name:         "single transaction by due date",
+			transactions: []models.Transaction{single},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-01-31", DateType: "DUE"},
+			want:         nil,
+		},
+		{
+			name:         "single transaction confirmed in the period",
+			transactions: []models.Transaction{paidLate, single},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-03-01", FinalDate: "2025-03-31", DateType: "DUE_OR_CONFIRMATION"},
+			want:         []instance{{"Luz", date(2025, time.February, 25), 0, 900}},
+		},
+		{
+			name:         "search matching only an edited installment",
+			transactions: []models.Transaction{installments},
+			edits:        []models.Transaction{edit(installments, 2, "Conserto", 5000, false)},
+			filters:      usecase.FindTransactionsByWorkspaceIdInputRepository{InitialDate: "2025-01-01", FinalDate: "2025-12-31", Search: "Conserto"},
+			want:         []instance{{"Conserto", date(2025, time.February, 10), 2, 5000}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := mongotest.Database(t)
+			searchIndexReady = false
+
+			for _, tx := range tt.transactions {
+				mongotest.Insert(t, db, "transaction", tx)
+			}
+			for _, e := range tt.edits {
+				mongotest.Insert(t, db, "edit_transaction", e)
+			}
+
+			repository := NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+			filters := tt.filters
+			filters.WorkspaceId = workspaceId
+
+			got, err := repository.Find(&filters)
+			if err != nil {
+				t.Fatalf("Find() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Find() returned %d transactions, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				tx := got[i]
+				if tx.Id != tt.transactions[0].Id {
+					t.Errorf("[%d] Id = %v, want the main transaction %v", i, tx.Id, tt.transactions[0].Id)
+				}
+				if tx.Name != want.name {
+					t.Errorf("[%d] Name = %q, want %q", i, tx.Name, want.name)
+				}
+				if !tx.DueDate.Equal(want.dueDate) {
+					t.Errorf("[%d] DueDate = %v, want %v", i, tx.DueDate, want.dueDate)
+				}
+				if count := currentCount(&tx); count != want.count {
+					t.Errorf("[%d] CurrentCount = %v, want %v", i, count, want.count)
+				}
+				if tx.Balance.Value != want.value {
+					t.Errorf("[%d] Balance.Value = %v, want %v", i, tx.Balance.Value, want.value)
+				}
+			}
+		})
+	}
+}
+
+func TestTransactionRepositoryFindInstallmentNetBalance(t *testing.T) {
+	workspaceId := primitive.NewObjectID()
+
+	// 100.00 in 3 installments with 10.01 of discount on the purchase
+	installments := transaction(workspaceId, "Notebook", 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+	installments.Balance.Discount = 1001
+
+	db := mongotest.Database(t)
+	mongotest.Insert(t, db, "transaction", installments)
+	repository := NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+
+	got, err := repository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{WorkspaceId: workspaceId, InitialDate: "2025-01-01", FinalDate: "2025-12-31"})
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	// the net amount of the purchase is split like the balances split it, the last installment absorbing the remainder
+	want := []models.Money{3001, 2999, 2999}
+	if len(got) != len(want) {
+		t.Fatalf("Find() returned %d transactions, want %d", len(got), len(want))
+	}
+	var total models.Money
+	for i, tx := range got {
+		if tx.Balance.NetBalance != want[i] {
+			t.Errorf("[%d] NetBalance = %v, want %v", i, tx.Balance.NetBalance, want[i])
+		}
+		total += tx.Balance.NetBalance
+	}
+	if total != 8999 {
+		t.Errorf("installments add up to %v, want 89.99", total)
+	}
+}
+
+func TestTransactionRepositoryFindPage(t *testing.T) {
+	workspaceId := primitive.NewObjectID()
+
+	// created in this order, so the single transactions have the newest ids
+	installments := transaction(workspaceId, "Notebook", 10000, "REPEAT", "MONTHLY", 3, date(2025, time.January, 10))
+	bakery := transaction(workspaceId, "Padaria", 8000, "DO_NOT_REPEAT", "", 0, date(2025, time.February, 20))
+	market := transaction(workspaceId, "Mercado", 2000, "DO_NOT_REPEAT", "", 0, date(2025, time.January, 15))
+	gas := transaction(workspaceId, "Posto", 5000, "DO_NOT_REPEAT", "", 0, date(2025, time.March, 1))
+
+	db := mongotest.Database(t)
+	for _, tx := range []models.Transaction{installments, bakery, market, gas} {
+		mongotest.Insert(t, db, "transaction", tx)
+	}
+	repository := NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+
+	filters := usecase.FindTransactionsByWorkspaceIdInputRepository{
+		WorkspaceId: workspaceId,
+		InitialDate: "2025-01-01",
+		FinalDate:   "2025-12-31",
+		DateType:    "DUE",
+	}
+	minAmount := models.Money(4000)
+	withMinAmount := filters
+	withMinAmount.MinAmount = &minAmount
+
+	tests := []struct {
+		name      string
+		input     usecase.FindTransactionsPageInputRepository
+		wantPages [][]string
+		wantTotal int
+	}{
+		{
+			name:      "cursor pages merge singles and installments by due date",
+			input:     usecase.FindTransactionsPageInputRepository{FindTransactionsByWorkspaceIdInputRepository: filters, Limit: 2, UseCursor: true},
+			wantPages: [][]string{{"Notebook 3", "Posto"}, {"Padaria", "Notebook 2"}, {"Mercado", "Notebook 1"}},
+			wantTotal: 6,
+		},
+		{
+			name:      "offset page sorted by due date",
+			input:     usecase.FindTransactionsPageInputRepository{FindTransactionsByWorkspaceIdInputRepository: filters, Sort: "ASC", Offset: 2, Limit: 3},
+			wantPages: [][]string{{"Padaria", "Notebook 2", "Mercado"}},
+			wantTotal: 6,
+		},
+		{
+			name:      "offset page without sort keeps the newest transactions first",
+			input:     usecase.FindTransactionsPageInputRepository{FindTransactionsByWorkspaceIdInputRepository: filters, Offset: 1, Limit: 3},
+			wantPages: [][]string{{"Mercado", "Padaria", "Notebook 3"}},
+			wantTotal: 6,
+		},
+		{
+			name:      "amount filter loads the whole single transactions of the page",
+			input:     usecase.FindTransactionsPageInputRepository{FindTransactionsByWorkspaceIdInputRepository: withMinAmount, Limit: 10, UseCursor: true},
+			wantPages: [][]string{{"Posto", "Padaria"}},
+			wantTotal: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			for i, want := range tt.wantPages {
+				page, err := repository.FindPage(&input)
+				if err != nil {
+					t.Fatalf("FindPage() error = %v", err)
+				}
+				if page.TotalCount != tt.wantTotal {
+					t.Errorf("page %d: TotalCount = %d, want %d", i, page.TotalCount, tt.wantTotal)
+				}
+
+				var got []string
+				for _, tx := range page.Transactions {
+					name := tx.Name
+					if tx.RepeatSettings != nil {
+						name += " " + strconv.Itoa(tx.RepeatSettings.CurrentCount)
+					}
+					got = append(got, name)
+				}
+				if !slices.Equal(got, want) {
+					t.Fatalf("page %d = %v, want %v", i, got, want)
+				}
+
+				lastPage := i == len(tt.wantPages)-1
+				if input.UseCursor && (page.NextCursor == nil) != lastPage {
+					t.Fatalf("page %d: NextCursor = %v, want it only before the last page", i, page.NextCursor)
+				}
+				input.After = page.NextCursor
+			}
+		})
+	}
+}
@@ -33,10 +33,22 @@ func (r *UpdateTransactionRepository) Update(transactionId primitive.ObjectID, t
 	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
 	defer cancel()
 
-	_, err := collection.UpdateOne(ctx, bson.M{"_id": transactionId, "workspace_id": transaction.WorkspaceId}, update)
+	filter := bson.M{"_id": transactionId, "workspace_id": transaction.WorkspaceId}
+
+	// the previous version is needed to invalidate the ledger of the account the transaction is leaving
+	var previous models.Transaction
+	if err := collection.FindOne(ctx, filter).Decode(&previous); err != nil && err != mongo.ErrNoDocuments {
+		return nil, err
+	}
+
+	_, err := collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, &previous, transaction); err != nil {
+		return nil, err
+	}
+
 	return transaction, nil
 }
@@ -0,0 +1,39 @@
+package transaction_rule_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateTransactionRuleRepository handles creating categorization rules
+type CreateTransactionRuleRepository struct {
+	Db *mongo.Database
+}
+
+// NewCreateTransactionRuleRepository creates a new CreateTransactionRuleRepository
+func NewCreateTransactionRuleRepository(db *mongo.Database) *CreateTransactionRuleRepository {
+	return &CreateTransactionRuleRepository{Db: db}
+}
+
+// Create inserts a new categorization rule
+func (r *CreateTransactionRuleRepository) Create(rule *models.TransactionRule) (*models.TransactionRule, error) {
+	collection := r.Db.Collection("transaction_rule")
+
+	rule.Id = primitive.NewObjectID()
+	rule.CreatedAt = time.Now().UTC()
+	rule.UpdatedAt = rule.CreatedAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	if _, err := collection.InsertOne(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
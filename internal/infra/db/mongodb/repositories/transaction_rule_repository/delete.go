@@ -0,0 +1,32 @@
+package transaction_rule_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteTransactionRuleRepository handles deleting categorization rules
+type DeleteTransactionRuleRepository struct {
+	Db *mongo.Database
+}
+
+// NewDeleteTransactionRuleRepository creates a new DeleteTransactionRuleRepository
+func NewDeleteTransactionRuleRepository(db *mongo.Database) *DeleteTransactionRuleRepository {
+	return &DeleteTransactionRuleRepository{Db: db}
+}
+
+// Delete removes rules matching the given IDs and workspace
+func (r *DeleteTransactionRuleRepository) Delete(ruleIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
+	collection := r.Db.Collection("transaction_rule")
+	filter := bson.M{"_id": bson.M{"$in": ruleIds}, "workspace_id": workspaceId}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	_, err := collection.DeleteMany(ctx, filter)
+	return err
+}
@@ -0,0 +1,44 @@
+package transaction_rule_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindTransactionRulesRepository handles listing categorization rules
+type FindTransactionRulesRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindTransactionRulesRepository creates a new FindTransactionRulesRepository
+func NewFindTransactionRulesRepository(db *mongo.Database) *FindTransactionRulesRepository {
+	return &FindTransactionRulesRepository{Db: db}
+}
+
+// Find returns all rules of a workspace in the order they are evaluated: by priority, then by creation
+func (r *FindTransactionRulesRepository) Find(workspaceId primitive.ObjectID) ([]models.TransactionRule, error) {
+	collection := r.Db.Collection("transaction_rule")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	sort := bson.D{{Key: "priority", Value: 1}, {Key: "created_at", Value: 1}}
+	cursor, err := collection.Find(ctx, bson.M{"workspace_id": workspaceId}, options.Find().SetSort(sort))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	rules := []models.TransactionRule{}
+	if err := cursor.All(ctx, &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,40 @@
+package transaction_rule_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FindTransactionRuleByIdRepository handles fetching a categorization rule by its ID
+type FindTransactionRuleByIdRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindTransactionRuleByIdRepository creates a new FindTransactionRuleByIdRepository
+func NewFindTransactionRuleByIdRepository(db *mongo.Database) *FindTransactionRuleByIdRepository {
+	return &FindTransactionRuleByIdRepository{Db: db}
+}
+
+// Find returns a rule by its ID and workspace
+func (r *FindTransactionRuleByIdRepository) Find(ruleId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.TransactionRule, error) {
+	collection := r.Db.Collection("transaction_rule")
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var rule models.TransactionRule
+	err := collection.FindOne(ctx, bson.M{"_id": ruleId, "workspace_id": workspaceId}).Decode(&rule)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &rule, nil
+}
@@ -0,0 +1,52 @@
+package transaction_rule_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// UpdateTransactionRuleRepository handles updating categorization rules
+type UpdateTransactionRuleRepository struct {
+	Db *mongo.Database
+}
+
+// NewUpdateTransactionRuleRepository creates a new UpdateTransactionRuleRepository
+func NewUpdateTransactionRuleRepository(db *mongo.Database) *UpdateTransactionRuleRepository {
+	return &UpdateTransactionRuleRepository{Db: db}
+}
+
+// Update replaces the name, priority, conditions and actions of an existing rule
+func (r *UpdateTransactionRuleRepository) Update(ruleId primitive.ObjectID, rule *models.TransactionRule) (*models.TransactionRule, error) {
+	collection := r.Db.Collection("transaction_rule")
+
+	filter := bson.M{"_id": ruleId, "workspace_id": rule.WorkspaceId}
+	update := bson.M{"$set": bson.M{
+		"name":       rule.Name,
+		"priority":   rule.Priority,
+		"is_active":  rule.IsActive,
+		"conditions": rule.Conditions,
+		"actions":    rule.Actions,
+		"updated_at": time.Now().UTC(),
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var updated models.TransactionRule
+	err := collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updated)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
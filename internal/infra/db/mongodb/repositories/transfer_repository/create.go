@@ -0,0 +1,58 @@
+package transfer_repository
+
+import (
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateTransferRepository handles creating transfers
+type CreateTransferRepository struct {
+	Db *mongo.Database
+}
+
+// NewCreateTransferRepository creates a new CreateTransferRepository
+func NewCreateTransferRepository(db *mongo.Database) *CreateTransferRepository {
+	return &CreateTransferRepository{Db: db}
+}
+
+// Create saves the transfer and its two transactions in a single MongoDB transaction, linking them to each other
+func (r *CreateTransferRepository) Create(transfer *models.Transfer, expense *models.Transaction, receipt *models.Transaction) (*models.Transfer, error) {
+	now := time.Now().UTC()
+
+	transfer.Id = primitive.NewObjectID()
+	transfer.ExpenseTransactionId = primitive.NewObjectID()
+	transfer.ReceiptTransactionId = primitive.NewObjectID()
+	transfer.CreatedAt = now
+	transfer.UpdatedAt = now
+
+	expense.Id = transfer.ExpenseTransactionId
+	receipt.Id = transfer.ReceiptTransactionId
+	for _, leg := range []*models.Transaction{expense, receipt} {
+		leg.Transfer = transfer.Leg()
+		leg.CreatedAt = now
+		leg.UpdatedAt = now
+	}
+
+	err := helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		if _, err := r.Db.Collection("transfer").InsertOne(ctx, transfer); err != nil {
+			return err
+		}
+		_, err := r.Db.Collection("transaction").InsertMany(ctx, []interface{}{expense, receipt})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the transfer is already saved, a failure here only costs a recalculation of the balances
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, expense, receipt); err != nil {
+		log.Printf("error invalidating balance snapshots after creating transfer %s: %v", transfer.Id.Hex(), err)
+	}
+
+	return transfer, nil
+}
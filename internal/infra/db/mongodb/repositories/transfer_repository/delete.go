@@ -0,0 +1,110 @@
+package transfer_repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// DeleteTransfersRepository handles deleting transfers
+type DeleteTransfersRepository struct {
+	Db *mongo.Database
+}
+
+// NewDeleteTransfersRepository creates a new DeleteTransfersRepository
+func NewDeleteTransfersRepository(db *mongo.Database) *DeleteTransfersRepository {
+	return &DeleteTransfersRepository{Db: db}
+}
+
+// Delete moves the transfers to the trash with their two transactions, in a single MongoDB transaction.
+// They are restored together as well.
+func (r *DeleteTransfersRepository) Delete(transferIds []primitive.ObjectID, workspaceId primitive.ObjectID) error {
+	transfers, legs, err := r.find(transferIds, workspaceId)
+	if err != nil || len(transfers) == 0 {
+		return err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(transfers))
+	legIds := make([]primitive.ObjectID, 0, len(legs))
+	trash := make([]models.TrashItem, 0, len(transfers))
+	now := time.Now().UTC()
+	for _, transfer := range transfers {
+		ids = append(ids, transfer.Id)
+		trash = append(trash, models.TrashItem{
+			WorkspaceId: workspaceId,
+			Entity:      "TRANSFER",
+			EntityId:    transfer.Id,
+			Name:        transfer.Description,
+			DeletedAt:   now,
+		})
+	}
+	for _, leg := range legs {
+		legIds = append(legIds, leg.Id)
+	}
+
+	deleted := bson.M{"$set": bson.M{"is_deleted": true, "deleted_at": now, "updated_at": now}}
+	err = helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		if _, err := r.Db.Collection("transfer").UpdateMany(ctx, bson.M{"_id": bson.M{"$in": ids}}, deleted); err != nil {
+			return err
+		}
+		if _, err := r.Db.Collection("transaction").UpdateMany(ctx, bson.M{"_id": bson.M{"$in": legIds}}, deleted); err != nil {
+			return err
+		}
+		return helpers.MoveToTrash(ctx, r.Db, trash...)
+	})
+	if err != nil {
+		return err
+	}
+
+	// the transfers are already in the trash, a failure here only costs a recalculation of the balances
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, legs...); err != nil {
+		log.Printf("error invalidating balance snapshots after deleting transfers: %v", err)
+	}
+
+	return nil
+}
+
+func (r *DeleteTransfersRepository) find(transferIds []primitive.ObjectID, workspaceId primitive.ObjectID) ([]models.Transfer, []*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transfer").Find(ctx, bson.M{
+		"_id":          bson.M{"$in": transferIds},
+		"workspace_id": workspaceId,
+		"is_deleted":   bson.M{"$ne": true},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var transfers []models.Transfer
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, nil, err
+	}
+	if len(transfers) == 0 {
+		return nil, nil, nil
+	}
+
+	legIds := make([]primitive.ObjectID, 0, 2*len(transfers))
+	for _, transfer := range transfers {
+		legIds = append(legIds, transfer.ExpenseTransactionId, transfer.ReceiptTransactionId)
+	}
+
+	legCursor, err := r.Db.Collection("transaction").Find(ctx, bson.M{"_id": bson.M{"$in": legIds}, "workspace_id": workspaceId})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var legs []*models.Transaction
+	if err := legCursor.All(ctx, &legs); err != nil {
+		return nil, nil, err
+	}
+
+	return transfers, legs, nil
+}
@@ -0,0 +1,99 @@
+package transfer_repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// EditTransferRepository handles editing occurrences of repeating transfers
+type EditTransferRepository struct {
+	Db *mongo.Database
+}
+
+// NewEditTransferRepository creates a new EditTransferRepository
+func NewEditTransferRepository(db *mongo.Database) *EditTransferRepository {
+	return &EditTransferRepository{Db: db}
+}
+
+// Edit saves the edits of the same occurrence of both transactions of a transfer in a single MongoDB transaction.
+// Each edit must have MainId and MainCount; an edit already saved for the occurrence is replaced.
+func (r *EditTransferRepository) Edit(expense *models.Transaction, receipt *models.Transaction) error {
+	legs := []*models.Transaction{expense, receipt}
+
+	previous, err := r.findEdits(legs)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, leg := range legs {
+		leg.Id = primitive.NewObjectID()
+		leg.CreatedAt = now
+		for _, edit := range previous {
+			if *edit.MainId == *leg.MainId {
+				leg.Id = edit.Id
+				leg.CreatedAt = edit.CreatedAt
+			}
+		}
+		leg.IsDeleted = false
+		leg.UpdatedAt = now
+	}
+
+	err = helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		for _, leg := range legs {
+			_, err := r.Db.Collection("edit_transaction").ReplaceOne(ctx, bson.M{
+				"main_id":      leg.MainId,
+				"main_count":   leg.MainCount,
+				"workspace_id": leg.WorkspaceId,
+			}, leg, options.Replace().SetUpsert(true))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// a ocorrência pode ter mudado de data, então os saldos são recalculados desde a primeira ocorrência. As edições
+	// já estão salvas, então uma falha aqui só custa recalcular os saldos
+	if err := helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, expense.WorkspaceId, []primitive.ObjectID{*expense.MainId, *receipt.MainId}, append(previous, expense, receipt)...); err != nil {
+		log.Printf("error invalidating balance snapshots after editing transfer occurrence %d: %v", *expense.MainCount, err)
+	}
+
+	return nil
+}
+
+func (r *EditTransferRepository) findEdits(legs []*models.Transaction) ([]*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	conditions := bson.A{}
+	for _, leg := range legs {
+		conditions = append(conditions, bson.M{"main_id": leg.MainId, "main_count": leg.MainCount})
+	}
+
+	cursor, err := r.Db.Collection("edit_transaction").Find(ctx, bson.M{
+		"$or":          conditions,
+		"workspace_id": legs[0].WorkspaceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []*models.Transaction
+	if err := cursor.All(ctx, &edits); err != nil {
+		return nil, err
+	}
+
+	return edits, nil
+}
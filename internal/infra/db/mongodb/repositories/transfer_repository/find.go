@@ -0,0 +1,94 @@
+package transfer_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindTransfersRepository handles listing transfers
+type FindTransfersRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindTransfersRepository creates a new FindTransfersRepository
+func NewFindTransfersRepository(db *mongo.Database) *FindTransfersRepository {
+	return &FindTransfersRepository{Db: db}
+}
+
+// Find returns the transfers of a workspace matching the filter, the most recent first
+func (r *FindTransfersRepository) Find(filter *usecase.FindTransfersFilter) ([]models.Transfer, error) {
+	collection := r.Db.Collection("transfer")
+
+	query := bson.M{"workspace_id": filter.WorkspaceId, "is_deleted": bson.M{"$ne": true}}
+	if filter.AccountId != nil {
+		query["$or"] = bson.A{
+			bson.M{"source_account_id": *filter.AccountId},
+			bson.M{"destination_account_id": *filter.AccountId},
+		}
+	}
+
+	date := bson.M{}
+	if filter.InitialDate != nil {
+		date["$gte"] = *filter.InitialDate
+	}
+	if filter.FinalDate != nil {
+		date["$lte"] = *filter.FinalDate
+	}
+	if len(date) > 0 {
+		query["date"] = date
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := collection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "date", Value: -1}, {Key: "_id", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	transfers := []models.Transfer{}
+	if err := cursor.All(ctx, &transfers); err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// FindTransferByIdRepository handles finding a transfer by ID
+type FindTransferByIdRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindTransferByIdRepository creates a new FindTransferByIdRepository
+func NewFindTransferByIdRepository(db *mongo.Database) *FindTransferByIdRepository {
+	return &FindTransferByIdRepository{Db: db}
+}
+
+// Find returns the transfer, or nil when it does not exist or is in the trash
+func (r *FindTransferByIdRepository) Find(transferId primitive.ObjectID, workspaceId primitive.ObjectID) (*models.Transfer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	var transfer models.Transfer
+	err := r.Db.Collection("transfer").FindOne(ctx, bson.M{
+		"_id":          transferId,
+		"workspace_id": workspaceId,
+		"is_deleted":   bson.M{"$ne": true},
+	}).Decode(&transfer)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &transfer, nil
+}
@@ -0,0 +1,101 @@
+package transfer_repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// UpdateTransferRepository handles updating transfers
+type UpdateTransferRepository struct {
+	Db *mongo.Database
+}
+
+// NewUpdateTransferRepository creates a new UpdateTransferRepository
+func NewUpdateTransferRepository(db *mongo.Database) *UpdateTransferRepository {
+	return &UpdateTransferRepository{Db: db}
+}
+
+// Update saves the transfer and the amounts, accounts, dates and frequency of its two transactions in a single
+// MongoDB transaction. The other fields of the transactions, and the edits of their occurrences, are kept.
+func (r *UpdateTransferRepository) Update(transfer *models.Transfer, expense *models.Transaction, receipt *models.Transaction) (*models.Transfer, error) {
+	previous, err := r.findLegs(transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	transfer.UpdatedAt = now
+
+	expense.Id = transfer.ExpenseTransactionId
+	receipt.Id = transfer.ReceiptTransactionId
+
+	err = helpers.WithTransaction(r.Db, func(ctx mongo.SessionContext) error {
+		_, err := r.Db.Collection("transfer").ReplaceOne(ctx, bson.M{"_id": transfer.Id, "workspace_id": transfer.WorkspaceId}, transfer)
+		if err != nil {
+			return err
+		}
+
+		for _, leg := range []*models.Transaction{expense, receipt} {
+			leg.Transfer = transfer.Leg()
+			leg.UpdatedAt = now
+
+			_, err := r.Db.Collection("transaction").UpdateOne(ctx, bson.M{"_id": leg.Id, "workspace_id": transfer.WorkspaceId}, bson.M{
+				"$set": bson.M{
+					"name":              leg.Name,
+					"balance":           leg.Balance,
+					"currency":          leg.Currency,
+					"transfer":          leg.Transfer,
+					"account_id":        leg.AccountId,
+					"frequency":         leg.Frequency,
+					"repeat_settings":   leg.RepeatSettings,
+					"due_date":          leg.DueDate,
+					"is_confirmed":      leg.IsConfirmed,
+					"registration_date": leg.RegistrationDate,
+					"confirmation_date": leg.ConfirmationDate,
+					"updated_at":        now,
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// as contas e as datas podem ter mudado, então os saldos antigos e os novos são recalculados. A transferência
+	// já está salva, então uma falha aqui só custa recalcular os saldos
+	if err := helpers.InvalidateTransactionsBalanceSnapshots(r.Db, append(previous, expense, receipt)...); err != nil {
+		log.Printf("error invalidating balance snapshots after updating transfer %s: %v", transfer.Id.Hex(), err)
+	}
+
+	return transfer, nil
+}
+
+func (r *UpdateTransferRepository) findLegs(transfer *models.Transfer) ([]*models.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("transaction").Find(ctx, bson.M{
+		"_id":          bson.M{"$in": bson.A{transfer.ExpenseTransactionId, transfer.ReceiptTransactionId}},
+		"workspace_id": transfer.WorkspaceId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var legs []*models.Transaction
+	if err := cursor.All(ctx, &legs); err != nil {
+		return nil, err
+	}
+
+	return legs, nil
+}
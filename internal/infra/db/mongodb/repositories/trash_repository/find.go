@@ -0,0 +1,56 @@
+package trash_repository
+
+import (
+	"context"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// FindTrashRepository handles listing the trash
+type FindTrashRepository struct {
+	Db *mongo.Database
+}
+
+// NewFindTrashRepository creates a new FindTrashRepository
+func NewFindTrashRepository(db *mongo.Database) *FindTrashRepository {
+	return &FindTrashRepository{Db: db}
+}
+
+// Find returns the trash items of the workspace, optionally of a single entity
+func (r *FindTrashRepository) Find(workspaceId primitive.ObjectID, entity string) ([]models.TrashItem, error) {
+	collection := r.Db.Collection("trash")
+
+	filter := bson.M{"workspace_id": workspaceId}
+	if entity != "" {
+		filter["entity"] = entity
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	findOptions := options.Find().
+		SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetProjection(bson.M{"references": 0})
+
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []models.TrashItem{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	retention := helpers.TrashRetention()
+	for i := range items {
+		items[i].PurgeAt = items[i].DeletedAt.Add(retention)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,108 @@
+package trash_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// PurgeTrashRepository handles removing expired items from the trash for good
+type PurgeTrashRepository struct {
+	Db                                     *mongo.Database
+	DeleteTransactionAttachmentsRepository usecase.DeleteTransactionAttachmentsRepository
+}
+
+// NewPurgeTrashRepository creates a new PurgeTrashRepository
+func NewPurgeTrashRepository(db *mongo.Database, deleteTransactionAttachments usecase.DeleteTransactionAttachmentsRepository) *PurgeTrashRepository {
+	return &PurgeTrashRepository{
+		Db:                                     db,
+		DeleteTransactionAttachmentsRepository: deleteTransactionAttachments,
+	}
+}
+
+// Purge permanently deletes the items moved to the trash before the given time and returns how many
+// were removed
+func (r *PurgeTrashRepository) Purge(deletedBefore time.Time) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	trashCollection := r.Db.Collection("trash")
+
+	cursor, err := trashCollection.Find(ctx, bson.M{"deleted_at": bson.M{"$lt": deletedBefore}})
+	if err != nil {
+		return 0, err
+	}
+
+	var items []models.TrashItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, item := range items {
+		if err := r.purgeEntity(ctx, &item); err != nil {
+			return purged, err
+		}
+
+		if _, err := trashCollection.DeleteOne(ctx, bson.M{"_id": item.Id}); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (r *PurgeTrashRepository) purgeEntity(ctx context.Context, item *models.TrashItem) error {
+	// an excluded installment is the edit marked as deleted; removing it would bring the installment back
+	if item.MainId != nil {
+		return r.DeleteTransactionAttachmentsRepository.DeleteByTransaction(*item.MainId, item.MainCount, item.WorkspaceId)
+	}
+
+	filter := bson.M{"_id": item.EntityId, "workspace_id": item.WorkspaceId}
+
+	if item.Entity == "TRANSACTION" {
+		filter["is_deleted"] = true
+		result, err := r.Db.Collection("transaction").DeleteOne(ctx, filter)
+		if err != nil || result.DeletedCount == 0 {
+			return err
+		}
+
+		_, err = r.Db.Collection("edit_transaction").DeleteMany(ctx, bson.M{"main_id": item.EntityId, "workspace_id": item.WorkspaceId})
+		if err != nil {
+			return err
+		}
+
+		return r.DeleteTransactionAttachmentsRepository.DeleteByTransaction(item.EntityId, nil, item.WorkspaceId)
+	}
+
+	// the transactions of a transfer go with it
+	if item.Entity == "TRANSFER" {
+		legs, err := r.Db.Collection("transaction").Find(ctx, bson.M{"transfer.id": item.EntityId, "workspace_id": item.WorkspaceId, "is_deleted": true})
+		if err != nil {
+			return err
+		}
+
+		var transactions []models.Transaction
+		if err := legs.All(ctx, &transactions); err != nil {
+			return err
+		}
+		for _, transaction := range transactions {
+			if _, err := r.Db.Collection("transaction").DeleteOne(ctx, bson.M{"_id": transaction.Id}); err != nil {
+				return err
+			}
+			if err := r.DeleteTransactionAttachmentsRepository.DeleteByTransaction(transaction.Id, nil, item.WorkspaceId); err != nil {
+				return err
+			}
+		}
+	}
+
+	filter["deleted_at"] = bson.M{"$exists": true}
+	_, err := r.Db.Collection(trashCollections[item.Entity]).DeleteOne(ctx, filter)
+	return err
+}
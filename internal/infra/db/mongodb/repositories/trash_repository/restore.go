@@ -0,0 +1,276 @@
+package trash_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// trashCollections maps each entity of the trash to the collection where it is kept
+var trashCollections = map[string]string{
+	"TRANSACTION":  "transaction",
+	"TRANSFER":     "transfer",
+	"ACCOUNT":      "account",
+	"CATEGORY":     "category",
+	"CREDIT_CARD":  "credit_card",
+	"CUSTOM_FIELD": "custom_field",
+}
+
+// RestoreTrashRepository handles restoring items from the trash
+type RestoreTrashRepository struct {
+	Db *mongo.Database
+}
+
+// NewRestoreTrashRepository creates a new RestoreTrashRepository
+func NewRestoreTrashRepository(db *mongo.Database) *RestoreTrashRepository {
+	return &RestoreTrashRepository{Db: db}
+}
+
+// Restore puts the items back in their collections and re-links the references removed on deletion
+func (r *RestoreTrashRepository) Restore(trashIds []primitive.ObjectID, workspaceId primitive.ObjectID) (*usecase.RestoreTrashOutputRepository, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), helpers.Timeout)
+	defer cancel()
+
+	cursor, err := r.Db.Collection("trash").Find(ctx, bson.M{"_id": bson.M{"$in": trashIds}, "workspace_id": workspaceId})
+	if err != nil {
+		return nil, err
+	}
+
+	var items []models.TrashItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	output := &usecase.RestoreTrashOutputRepository{
+		Restored:  []models.TrashItem{},
+		Conflicts: []models.TrashItem{},
+	}
+
+	var mainIds, restoredMainIds []primitive.ObjectID
+	var edits []*models.Transaction
+	var accountIds []primitive.ObjectID
+
+	for _, item := range items {
+		if item.MainId != nil {
+			edit, err := r.restoreInstallment(ctx, &item)
+			if err != nil {
+				return nil, err
+			}
+			if edit != nil {
+				mainIds = append(mainIds, *item.MainId)
+				edits = append(edits, edit)
+			}
+		} else {
+			conflict, err := r.hasConflict(ctx, &item)
+			if err != nil {
+				return nil, err
+			}
+			if conflict {
+				output.Conflicts = append(output.Conflicts, item)
+				continue
+			}
+
+			if err := r.restoreEntity(ctx, &item); err != nil {
+				return nil, err
+			}
+			if err := r.restoreReferences(ctx, &item); err != nil {
+				return nil, err
+			}
+
+			switch item.Entity {
+			case "TRANSACTION":
+				mainIds = append(mainIds, item.EntityId)
+				restoredMainIds = append(restoredMainIds, item.EntityId)
+			case "TRANSFER":
+				legIds, err := r.restoreTransferLegs(ctx, &item)
+				if err != nil {
+					return nil, err
+				}
+				mainIds = append(mainIds, legIds...)
+			case "ACCOUNT":
+				accountIds = append(accountIds, item.EntityId)
+			}
+		}
+
+		if _, err := r.Db.Collection("trash").DeleteOne(ctx, bson.M{"_id": item.Id}); err != nil {
+			return nil, err
+		}
+
+		item.PurgeAt = item.DeletedAt.Add(helpers.TrashRetention())
+		output.Restored = append(output.Restored, item)
+	}
+
+	// the edited installments of a restored transaction may be in another account
+	if len(restoredMainIds) > 0 {
+		editCursor, err := r.Db.Collection("edit_transaction").Find(ctx, bson.M{"main_id": bson.M{"$in": restoredMainIds}})
+		if err != nil {
+			return nil, err
+		}
+
+		var restoredEdits []*models.Transaction
+		if err := editCursor.All(ctx, &restoredEdits); err != nil {
+			return nil, err
+		}
+		edits = append(edits, restoredEdits...)
+	}
+
+	if len(mainIds) > 0 {
+		if err := helpers.InvalidateMainTransactionsBalanceSnapshots(r.Db, workspaceId, mainIds, edits...); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(accountIds) > 0 {
+		if err := helpers.InvalidateAccountBalanceSnapshots(r.Db, workspaceId, accountIds, time.Time{}); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// restoreTransferLegs puts back the two transactions of a restored transfer and returns their ids
+func (r *RestoreTrashRepository) restoreTransferLegs(ctx context.Context, item *models.TrashItem) ([]primitive.ObjectID, error) {
+	collection := r.Db.Collection("transaction")
+	filter := bson.M{"transfer.id": item.EntityId, "workspace_id": item.WorkspaceId}
+
+	legIds, err := collection.Distinct(ctx, "_id", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = collection.UpdateMany(ctx, filter, bson.M{
+		"$unset": bson.M{"deleted_at": ""},
+		"$set":   bson.M{"is_deleted": false, "updated_at": time.Now().UTC()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(legIds))
+	for _, legId := range legIds {
+		if id, ok := legId.(primitive.ObjectID); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// hasConflict reports whether another record took the name of the item while it was in the trash.
+// Transactions and transfers have no unique name and never conflict.
+func (r *RestoreTrashRepository) hasConflict(ctx context.Context, item *models.TrashItem) (bool, error) {
+	if item.Entity == "TRANSACTION" || item.Entity == "TRANSFER" {
+		return false, nil
+	}
+
+	collection := r.Db.Collection(trashCollections[item.Entity])
+
+	var deleted bson.M
+	err := collection.FindOne(ctx, bson.M{"_id": item.EntityId}).Decode(&deleted)
+	if err == mongo.ErrNoDocuments {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	filter := bson.M{
+		"_id":          bson.M{"$ne": item.EntityId},
+		"workspace_id": item.WorkspaceId,
+		"name":         deleted["name"],
+		"deleted_at":   bson.M{"$exists": false},
+	}
+	if item.Entity == "CATEGORY" {
+		filter["type"] = deleted["type"]
+	}
+
+	count, err := collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *RestoreTrashRepository) restoreEntity(ctx context.Context, item *models.TrashItem) error {
+	filter := bson.M{"_id": item.EntityId, "workspace_id": item.WorkspaceId}
+	update := bson.M{"$unset": bson.M{"deleted_at": ""}, "$set": bson.M{"updated_at": time.Now().UTC()}}
+
+	if item.Entity == "TRANSACTION" || item.Entity == "TRANSFER" {
+		update["$set"].(bson.M)["is_deleted"] = false
+	}
+
+	_, err := r.Db.Collection(trashCollections[item.Entity]).UpdateOne(ctx, filter, update)
+	return err
+}
+
+// restoreInstallment undoes the exclusion of a single installment, which is an edit marked as deleted.
+// An edit created only to mark the deletion is removed instead.
+func (r *RestoreTrashRepository) restoreInstallment(ctx context.Context, item *models.TrashItem) (*models.Transaction, error) {
+	collection := r.Db.Collection("edit_transaction")
+
+	var edit models.Transaction
+	err := collection.FindOne(ctx, bson.M{"_id": item.EntityId, "workspace_id": item.WorkspaceId}).Decode(&edit)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if item.DeletionOnly {
+		// the edit is a copy of the main transaction, keeping it would replace the date and value of the installment
+		_, err = collection.DeleteOne(ctx, bson.M{"_id": item.EntityId})
+	} else {
+		_, err = collection.UpdateOne(ctx,
+			bson.M{"_id": item.EntityId},
+			bson.M{"$set": bson.M{"is_deleted": false, "updated_at": time.Now().UTC()}},
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	edit.IsDeleted = false
+	return &edit, nil
+}
+
+// restoreReferences re-links the documents that pointed to the item. Links changed by the user in the
+// meantime are kept, only the ones still empty are filled again.
+func (r *RestoreTrashRepository) restoreReferences(ctx context.Context, item *models.TrashItem) error {
+	for _, reference := range item.References {
+		collection := r.Db.Collection(reference.Collection)
+
+		switch reference.Field {
+		case "":
+			_, err := collection.InsertOne(ctx, reference.Value)
+			if err != nil && !mongo.IsDuplicateKeyError(err) {
+				return err
+			}
+		case "custom_fields":
+			_, err := collection.UpdateOne(ctx,
+				bson.M{"_id": reference.DocumentId, "custom_fields.custom_field_id": bson.M{"$ne": item.EntityId}},
+				bson.M{"$push": bson.M{"custom_fields": reference.Value}},
+			)
+			if err != nil {
+				return err
+			}
+		default:
+			_, err := collection.UpdateOne(ctx,
+				bson.M{"_id": reference.DocumentId, reference.Field: bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{reference.Field: item.EntityId}},
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const defaultStoragePath = "./data/attachments"
+
+// FileSystemStorage guarda os arquivos em disco, dentro de BasePath
+type FileSystemStorage struct {
+	BasePath string
+}
+
+// NewFileSystemStorage usa o diretório de STORAGE_PATH, ou ./data/attachments por padrão
+func NewFileSystemStorage() *FileSystemStorage {
+	basePath := os.Getenv("STORAGE_PATH")
+	if basePath == "" {
+		basePath = defaultStoragePath
+	}
+
+	return &FileSystemStorage{BasePath: basePath}
+}
+
+// Save grava o conteúdo em um arquivo temporário e só o renomeia no final, para que uma gravação
+// interrompida não deixe um arquivo pela metade
+func (s *FileSystemStorage) Save(key string, content io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (s *FileSystemStorage) Open(key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(path)
+}
+
+// Delete não considera erro um arquivo que já não existe
+func (s *FileSystemStorage) Delete(key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// path impede que uma chave saia do diretório base
+func (s *FileSystemStorage) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", errors.New("invalid storage key: " + key)
+	}
+
+	return filepath.Join(s.BasePath, cleaned), nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -18,6 +19,7 @@ type CreateAccountController struct {
 	FindAccountByWorkspaceIdRepository usecase.FindAccountByWorkspaceIdRepository
 	FindBankById                       usecase.FindBankByIdRepository
 	FindAccountByNameRepository        usecase.FindAccountByNameAndWorkspaceIdRepository
+	CreateAuditLogRepository           usecase.CreateAuditLogRepository
 }
 
 func NewCreateAccountController(
@@ -25,6 +27,7 @@ func NewCreateAccountController(
 	findManyByUserIdAndWorkspaceId usecase.FindAccountByWorkspaceIdRepository,
 	findBankById usecase.FindBankByIdRepository,
 	findByNameRepository usecase.FindAccountByNameAndWorkspaceIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *CreateAccountController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -34,21 +37,24 @@ func NewCreateAccountController(
 		Validate:                           validate,
 		FindBankById:                       findBankById,
 		FindAccountByNameRepository:        findByNameRepository,
+		CreateAuditLogRepository:           createAuditLog,
 	}
 }
 
 type CreateAccountControllerResponse struct {
-	Id          string  `json:"id"`
-	Name        string  `json:"name"`
-	WorkspaceId string  `json:"workspaceId"`
-	BankId      string  `json:"bankId"`
-	Balance     float64 `json:"balance"`
+	Id          string       `json:"id"`
+	Name        string       `json:"name"`
+	WorkspaceId string       `json:"workspaceId"`
+	BankId      string       `json:"bankId"`
+	Balance     models.Money `json:"balance"`
+	Currency    string       `json:"currency"`
 }
 
 type CreateAccountControllerBody struct {
-	Name    string  `validate:"required,min=3,max=255"`
-	Balance float64 `validate:"min=0,max=1000000000000000000"`
-	BankId  string  `validate:"required"`
+	Name     string       `validate:"required,min=3,max=255"`
+	Balance  models.Money `validate:"min=0,max=1000000000000000000"`
+	BankId   string       `validate:"required"`
+	Currency string       `validate:"omitempty,iso4217"` // empty is the base currency of the workspace
 }
 
 func (c *CreateAccountController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
@@ -124,6 +130,7 @@ func (c *CreateAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		Name:        body.Name,
 		BankId:      bankId,
 		Balance:     body.Balance,
+		Currency:    body.Currency,
 		WorkspaceId: workspaceId,
 	})
 
@@ -133,11 +140,17 @@ func (c *CreateAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewAuditLog(workspaceId, userId, "ACCOUNT", account.Id, "CREATE", nil, account),
+	))
+
 	return helpers.CreateResponse(&CreateAccountControllerResponse{
 		Id:          account.Id.Hex(),
 		Name:        account.Name,
 		WorkspaceId: account.WorkspaceId.Hex(),
 		BankId:      account.BankId.Hex(),
 		Balance:     account.Balance,
+		Currency:    account.Currency,
 	}, http.StatusOK)
 }
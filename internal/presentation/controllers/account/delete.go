@@ -5,19 +5,25 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DeleteAccountController struct {
-	DeleteAccountRepository usecase.DeleteAccountRepository
+	DeleteAccountRepository   usecase.DeleteAccountRepository
+	FindAccountByIdRepository usecase.FindAccountByIdRepository
+	CreateAuditLogRepository  usecase.CreateAuditLogRepository
 }
 
-func NewDeleteAccountController(deleteAccount usecase.DeleteAccountRepository) *DeleteAccountController {
+func NewDeleteAccountController(deleteAccount usecase.DeleteAccountRepository, findAccountById usecase.FindAccountByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *DeleteAccountController {
 	return &DeleteAccountController{
-		DeleteAccountRepository: deleteAccount,
+		DeleteAccountRepository:   deleteAccount,
+		FindAccountByIdRepository: findAccountById,
+		CreateAuditLogRepository:  createAuditLog,
 	}
 }
 
@@ -44,6 +50,20 @@ func (c *DeleteAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		idsObjectID = append(idsObjectID, objectID)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+	for _, id := range idsObjectID {
+		account, err := c.FindAccountByIdRepository.Find(id, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when finding account: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if account != nil {
+			auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "ACCOUNT", id, "DELETE", account, nil))
+		}
+	}
+
 	err = c.DeleteAccountRepository.Delete(idsObjectID, workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -51,5 +71,7 @@ func (c *DeleteAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		}, http.StatusInternalServerError)
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(nil, http.StatusNoContent)
 }
@@ -1,9 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 	"slices"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
@@ -39,6 +41,12 @@ func (c *GetAccountsController) Handle(r presentationProtocols.HttpRequest) *pre
 	}
 
 	accounts, err := c.FindAccountByWorkspaceIdRepository.Find(globalFilters)
+	var rateErr *models.ExchangeRateNotFoundError
+	if errors.As(err, &rateErr) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "cotação de " + rateErr.From + " para " + rateErr.To + " não encontrada",
+		}, http.StatusUnprocessableEntity)
+	}
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "ocorreu um erro ao buscar as contas",
@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -17,12 +18,14 @@ type ImportAccountController struct {
 	Validate                           *validator.Validate
 	FindAccountByWorkspaceIdRepository usecase.FindAccountByWorkspaceIdRepository
 	FindAccountByNameRepository        usecase.FindAccountByNameAndWorkspaceIdRepository
+	CreateAuditLogRepository           usecase.CreateAuditLogRepository
 }
 
 func NewImportAccountController(
 	importUseCase usecase.ImportAccountsRepository,
 	findAccounts usecase.FindAccountByWorkspaceIdRepository,
 	findByNameRepository usecase.FindAccountByNameAndWorkspaceIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *ImportAccountController {
 	validate := validator.New()
 
@@ -31,13 +34,14 @@ func NewImportAccountController(
 		Validate:                           validate,
 		FindAccountByWorkspaceIdRepository: findAccounts,
 		FindAccountByNameRepository:        findByNameRepository,
+		CreateAuditLogRepository:           createAuditLog,
 	}
 }
 
 type ImportAccount struct {
-	Name    string  `json:"name" validate:"required,min=3,max=255"`
-	Balance float64 `json:"balance" validate:"required"`
-	BankId  string  `json:"bankId" validate:"required"`
+	Name    string       `json:"name" validate:"required,min=3,max=255"`
+	Balance models.Money `json:"balance" validate:"required"`
+	BankId  string       `json:"bankId" validate:"required"`
 }
 
 type ImportAccountBody struct {
@@ -130,5 +134,13 @@ func (c *ImportAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := make([]models.AuditLog, 0, len(importedAccounts))
+	for i := range importedAccounts {
+		account := &importedAccounts[i]
+		auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "ACCOUNT", account.Id, "CREATE", nil, account))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(importedAccounts, http.StatusOK)
 }
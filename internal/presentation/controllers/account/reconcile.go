@@ -0,0 +1,429 @@
+package controllers
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultReconcileDays = 3
+	maxReconcileDays     = 30
+)
+
+type ReconcileAccountController struct {
+	FindAccountByIdRepository      usecase.FindAccountByIdRepository
+	FindTransactionsRepository     usecase.FindTransactionsByWorkspaceIdRepository
+	ConfirmTransactionRepository   usecase.ConfirmTransactionRepository
+	CreateAuditLogRepository       usecase.CreateAuditLogRepository
+	FindTransactionRulesRepository usecase.FindTransactionRulesRepository
+}
+
+func NewReconcileAccountController(
+	findAccountById usecase.FindAccountByIdRepository,
+	findTransactions usecase.FindTransactionsByWorkspaceIdRepository,
+	confirmTransaction usecase.ConfirmTransactionRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	findTransactionRules usecase.FindTransactionRulesRepository,
+) *ReconcileAccountController {
+	return &ReconcileAccountController{
+		FindAccountByIdRepository:      findAccountById,
+		FindTransactionsRepository:     findTransactions,
+		ConfirmTransactionRepository:   confirmTransaction,
+		CreateAuditLogRepository:       createAuditLog,
+		FindTransactionRulesRepository: findTransactionRules,
+	}
+}
+
+type ReconcileStatementLine struct {
+	Id          string       `json:"id"`
+	Date        time.Time    `json:"date"`
+	Amount      models.Money `json:"amount"`
+	Description string       `json:"description"`
+}
+
+type ReconcileMatch struct {
+	StatementLine ReconcileStatementLine `json:"statementLine"`
+	Transaction   models.Transaction     `json:"transaction"`
+	Similarity    float64                `json:"similarity"`
+}
+
+// ReconcileSuggestion follows the transaction creation body so it can be sent back as is. The
+// classification comes from the categorization rules of the workspace, when one matches.
+type ReconcileSuggestion struct {
+	StatementLine ReconcileStatementLine `json:"statementLine"`
+	Name          string                 `json:"name"`
+	Type          string                 `json:"type"`
+	Balance       struct {
+		Value models.Money `json:"value"`
+	} `json:"balance"`
+	Frequency     string                          `json:"frequency"`
+	DueDate       string                          `json:"dueDate"`
+	IsConfirmed   bool                            `json:"isConfirmed"`
+	AccountId     string                          `json:"accountId"`
+	CategoryId    *primitive.ObjectID             `json:"categoryId,omitempty"`
+	SubCategoryId *primitive.ObjectID             `json:"subCategoryId,omitempty"`
+	Tags          []models.TransactionTags        `json:"tags,omitempty"`
+	AssignedTo    *primitive.ObjectID             `json:"assignedTo,omitempty"`
+	CustomFields  []models.TransactionCustomField `json:"customFields,omitempty"`
+}
+
+type ReconcileAccountResponse struct {
+	Matched   []ReconcileMatch      `json:"matched"`
+	Unmatched []ReconcileSuggestion `json:"unmatched"`
+}
+
+func (c *ReconcileAccountController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	accountId, err := primitive.ObjectIDFromHex(r.Req.PathValue("accountId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid account ID format",
+		}, http.StatusBadRequest)
+	}
+
+	days := defaultReconcileDays
+	if value := r.UrlParams.Get("days"); value != "" {
+		days, err = strconv.Atoi(value)
+		if err != nil || days < 0 || days > maxReconcileDays {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "days must be a number between 0 and " + strconv.Itoa(maxReconcileDays),
+			}, http.StatusBadRequest)
+		}
+	}
+
+	account, err := c.FindAccountByIdRepository.Find(accountId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving account",
+		}, http.StatusInternalServerError)
+	}
+	if account == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "account not found",
+		}, http.StatusNotFound)
+	}
+
+	lines, errResponse := parseStatement(r.Req)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	firstDate, lastDate := lines[0].Date, lines[0].Date
+	for _, line := range lines {
+		if line.Date.Before(firstDate) {
+			firstDate = line.Date
+		}
+		if line.Date.After(lastDate) {
+			lastDate = line.Date
+		}
+	}
+
+	transactions, err := c.FindTransactionsRepository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{
+		WorkspaceId: workspaceId,
+		AccountIds:  []primitive.ObjectID{accountId},
+		DateType:    "DUE",
+		InitialDate: firstDate.AddDate(0, 0, -days).Format("2006-01-02"),
+		FinalDate:   lastDate.AddDate(0, 0, days).Format("2006-01-02"),
+		Status:      "PENDING",
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving transactions",
+		}, http.StatusInternalServerError)
+	}
+
+	// expenses waiting for approval or rejected can not be confirmed, so they are never matched
+	transactions = slices.DeleteFunc(transactions, func(tx models.Transaction) bool {
+		return !models.CanBeConfirmed(&tx)
+	})
+
+	matches, unmatched := matchStatementLines(lines, transactions, days)
+
+	// the bank posting date becomes the confirmation date, so the matches are confirmed by day
+	byDate := map[time.Time][]models.Transaction{}
+	for _, match := range matches {
+		byDate[match.StatementLine.Date] = append(byDate[match.StatementLine.Date], match.Transaction)
+	}
+	for date, confirmed := range byDate {
+		if err := c.ConfirmTransactionRepository.Confirm(confirmed, date); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when confirming transactions",
+			}, http.StatusInternalServerError)
+		}
+	}
+
+	response := &ReconcileAccountResponse{
+		Matched:   make([]ReconcileMatch, 0, len(matches)),
+		Unmatched: make([]ReconcileSuggestion, 0, len(unmatched)),
+	}
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := make([]models.AuditLog, 0, len(matches))
+	for _, match := range matches {
+		previous := match.Transaction
+		match.Transaction.IsConfirmed = true
+		confirmationDate := match.StatementLine.Date
+		match.Transaction.ConfirmationDate = &confirmationDate
+		response.Matched = append(response.Matched, match)
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, &match.Transaction))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	rules, err := c.FindTransactionRulesRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving categorization rules",
+		}, http.StatusInternalServerError)
+	}
+	for _, line := range unmatched {
+		response.Unmatched = append(response.Unmatched, suggestTransaction(line, accountId, rules))
+	}
+
+	return helpers.CreateResponse(response, http.StatusOK)
+}
+
+func parseStatement(req *http.Request) ([]ReconcileStatementLine, *presentationProtocols.HttpResponse) {
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid multipart form",
+		}, http.StatusBadRequest)
+	}
+
+	file, header, err := req.FormFile("file")
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "file is required",
+		}, http.StatusBadRequest)
+	}
+	defer file.Close()
+
+	var parse func(io.Reader) ([]helpers.BankStatementLine, error)
+	switch strings.ToLower(filepath.Ext(header.Filename)) {
+	case ".ofx":
+		parse = helpers.ParseOFX
+	case ".ret", ".rem", ".txt", ".cnab":
+		parse = helpers.ParseCNAB240
+	default:
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "unsupported file format, expected OFX or CNAB 240",
+		}, http.StatusBadRequest)
+	}
+
+	parsed, err := parse(file)
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	lines := make([]ReconcileStatementLine, 0, len(parsed))
+	for _, line := range parsed {
+		if line.Amount == 0 {
+			continue
+		}
+		lines = append(lines, ReconcileStatementLine{
+			Id:          line.Id,
+			Date:        line.Date,
+			Amount:      line.Amount,
+			Description: line.Description,
+		})
+	}
+
+	if len(lines) == 0 {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "statement has no transactions",
+		}, http.StatusUnprocessableEntity)
+	}
+
+	return lines, nil
+}
+
+// matchStatementLines pairs each statement line with at most one pending transaction. Amount and date
+// window are required; among the candidates the most similar name wins, then the closest date.
+func matchStatementLines(lines []ReconcileStatementLine, transactions []models.Transaction, days int) ([]ReconcileMatch, []ReconcileStatementLine) {
+	type candidate struct {
+		line        int
+		transaction int
+		similarity  float64
+		distance    float64
+	}
+
+	window := float64(days) * 24
+	var candidates []candidate
+	for i, line := range lines {
+		for j := range transactions {
+			tx := &transactions[j]
+			if tx.IsConfirmed || tx.CreditCardId != nil {
+				continue
+			}
+
+			amount := tx.Balance.NetBalance
+			if tx.Type == "EXPENSE" {
+				amount = -amount
+			}
+			if amount != line.Amount {
+				continue
+			}
+
+			distance := math.Abs(dateOnly(tx.DueDate).Sub(dateOnly(line.Date)).Hours())
+			if distance > window {
+				continue
+			}
+
+			candidates = append(candidates, candidate{
+				line:        i,
+				transaction: j,
+				similarity:  nameSimilarity(line.Description, tx.Name+" "+tx.Supplier+" "+tx.Description),
+				distance:    distance,
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].similarity != candidates[b].similarity {
+			return candidates[a].similarity > candidates[b].similarity
+		}
+		return candidates[a].distance < candidates[b].distance
+	})
+
+	usedLines := make(map[int]bool, len(lines))
+	usedTransactions := make(map[int]bool, len(candidates))
+	var matches []ReconcileMatch
+	for _, candidate := range candidates {
+		if usedLines[candidate.line] || usedTransactions[candidate.transaction] {
+			continue
+		}
+		usedLines[candidate.line] = true
+		usedTransactions[candidate.transaction] = true
+
+		matches = append(matches, ReconcileMatch{
+			StatementLine: lines[candidate.line],
+			Transaction:   transactions[candidate.transaction],
+			Similarity:    math.Round(candidate.similarity*100) / 100,
+		})
+	}
+
+	var unmatched []ReconcileStatementLine
+	for i, line := range lines {
+		if !usedLines[i] {
+			unmatched = append(unmatched, line)
+		}
+	}
+
+	return matches, unmatched
+}
+
+func suggestTransaction(line ReconcileStatementLine, accountId primitive.ObjectID, rules []models.TransactionRule) ReconcileSuggestion {
+	suggestion := ReconcileSuggestion{
+		StatementLine: line,
+		Name:          line.Description,
+		Type:          "RECIPE",
+		Frequency:     "DO_NOT_REPEAT",
+		DueDate:       line.Date.UTC().Format("2006-01-02T15:04:05Z"),
+		IsConfirmed:   true,
+		AccountId:     accountId.Hex(),
+	}
+	suggestion.Balance.Value = line.Amount.Abs()
+	if line.Amount < 0 {
+		suggestion.Type = "EXPENSE"
+	}
+
+	// rules look at the whole description, before the name is cut
+	transaction := models.Transaction{
+		Name:        line.Description,
+		Description: line.Description,
+		Type:        suggestion.Type,
+		Balance:     models.TransactionBalance{Value: suggestion.Balance.Value},
+		AccountId:   &accountId,
+	}
+	if actions := models.MatchTransactionRules(rules, &transaction); actions != nil && actions.Apply(&transaction, false) {
+		suggestion.CategoryId = transaction.CategoryId
+		suggestion.SubCategoryId = transaction.SubCategoryId
+		suggestion.Tags = transaction.Tags
+		suggestion.AssignedTo = actions.AssignedTo
+		suggestion.CustomFields = transaction.CustomFields
+	}
+
+	// the transaction name is limited to 30 characters
+	if name := []rune(suggestion.Name); len(name) > 30 {
+		suggestion.Name = strings.TrimSpace(string(name[:30]))
+	}
+
+	return suggestion
+}
+
+func dateOnly(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// nameSimilarity is the share of the statement description words found in the transaction texts
+func nameSimilarity(description, transaction string) float64 {
+	descriptionWords := similarityWords(description)
+	if len(descriptionWords) == 0 {
+		return 0
+	}
+
+	transactionWords := map[string]bool{}
+	for _, word := range similarityWords(transaction) {
+		transactionWords[word] = true
+	}
+
+	var found int
+	for _, word := range descriptionWords {
+		if transactionWords[word] {
+			found++
+		}
+	}
+
+	return float64(found) / float64(len(descriptionWords))
+}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+// similarityWords splits a text into lowercase words without accents, ignoring numbers and
+// short words such as prepositions
+func similarityWords(text string) []string {
+	text = accentReplacer.Replace(strings.ToLower(text))
+
+	var words []string
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(word) < 3 {
+			continue
+		}
+		if _, err := strconv.Atoi(word); err == nil {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return words
+}
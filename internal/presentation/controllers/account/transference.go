@@ -2,11 +2,13 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -14,28 +16,74 @@ import (
 )
 
 type TransferenceAccountController struct {
-	FindAccountByIdRepository   usecase.FindAccountByIdRepository
-	CreateTransactionRepository usecase.CreateTransactionRepository
-	Validate                    *validator.Validate
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	CreateTransferRepository        usecase.CreateTransferRepository
+	CreateAuditLogRepository        usecase.CreateAuditLogRepository
+	FindExchangeRateTableRepository usecase.FindExchangeRateTableRepository
+	Validate                        *validator.Validate
 }
 
 func NewTransferenceAccountController(
 	findAccountById usecase.FindAccountByIdRepository,
-	createTransaction usecase.CreateTransactionRepository,
+	createTransfer usecase.CreateTransferRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	findExchangeRateTable usecase.FindExchangeRateTableRepository,
 ) *TransferenceAccountController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &TransferenceAccountController{
-		FindAccountByIdRepository:   findAccountById,
-		CreateTransactionRepository: createTransaction,
-		Validate:                    validate,
+		FindAccountByIdRepository:       findAccountById,
+		CreateTransferRepository:        createTransfer,
+		CreateAuditLogRepository:        createAuditLog,
+		FindExchangeRateTableRepository: findExchangeRateTable,
+		Validate:                        validate,
 	}
 }
 
+// TransferenceAccountControllerBody holds the amount leaving the source account. Between accounts in different
+// currencies, the amount received is given by the rate or directly by destinationAmount; without them the rate
+// entered for the due date is used.
+// Without dueDate the transfer is made now and confirmed. A REPEAT transfer splits the amount in installments,
+// as transactions do, while a RECURRING one moves the whole amount on every occurrence.
 type TransferenceAccountControllerBody struct {
-	SourceAccountId      string  `json:"sourceAccountId" validate:"required"`
-	DestinationAccountId string  `json:"destinationAccountId" validate:"required"`
-	Amount               float64 `json:"amount" validate:"required,gt=0"`
+	SourceAccountId      string       `json:"sourceAccountId" validate:"required,mongodb"`
+	DestinationAccountId string       `json:"destinationAccountId" validate:"required,mongodb,nefield=SourceAccountId"`
+	Amount               models.Money `json:"amount" validate:"required,gt=0"`
+	Rate                 float64      `json:"rate" validate:"omitempty,gt=0"`
+	DestinationAmount    models.Money `json:"destinationAmount" validate:"omitempty,gt=0,excluded_with=Rate"`
+	Description          string       `json:"description" validate:"omitempty,max=255"`
+	DueDate              string       `json:"dueDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z"`
+	IsConfirmed          *bool        `json:"isConfirmed"` // omitted, a new transfer is confirmed only when it has no dueDate and an existing one is kept
+	ConfirmationDate     *string      `json:"confirmationDate" validate:"omitempty,datetime=2006-01-02T15:04:05Z"`
+	Frequency            string       `json:"frequency" validate:"oneof=DO_NOT_REPEAT RECURRING REPEAT"`
+	RepeatSettings       struct {
+		InitialInstallment time.Month `json:"initialInstallment" validate:"omitempty,min=1"`
+		Count              int        `json:"count" validate:"omitempty,min=2,max=367"`
+		Interval           string     `json:"interval" validate:"oneof=DAILY WEEKLY BIWEEKLY MONTHLY QUARTERLY YEARLY CUSTOM"`
+		CustomDay          int        `json:"customDay" validate:"required_if=Interval CUSTOM"`
+	} `json:"repeatSettings" validate:"excluded_if=Frequency DO_NOT_REPEAT,required_if=Frequency REPEAT,omitempty"` // on RECURRING only the interval is used
+}
+
+// decodeTransferBody reads and validates the body of a transfer; frequency defaults to DO_NOT_REPEAT
+func decodeTransferBody(r presentationProtocols.HttpRequest, validate *validator.Validate) (*TransferenceAccountControllerBody, *presentationProtocols.HttpResponse) {
+	var body TransferenceAccountControllerBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if body.Frequency == "" {
+		body.Frequency = "DO_NOT_REPEAT"
+	}
+
+	if err := validate.Struct(body); err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	return &body, nil
 }
 
 type TransferenceAccountControllerResponse struct {
@@ -43,20 +91,13 @@ type TransferenceAccountControllerResponse struct {
 	DestinationAccount *models.Account     `json:"destinationAccount"`
 	ExpenseTransaction *models.Transaction `json:"expenseTransaction"`
 	ReceiptTransaction *models.Transaction `json:"receiptTransaction"`
+	Transfer           *models.Transfer    `json:"transfer"`
 }
 
 func (c *TransferenceAccountController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
-	var body TransferenceAccountControllerBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "invalid body request",
-		}, http.StatusBadRequest)
-	}
-
-	if err := c.Validate.Struct(body); err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: helpers.GetErrorMessages(c.Validate, err),
-		}, http.StatusUnprocessableEntity)
+	body, errResp := decodeTransferBody(r, c.Validate)
+	if errResp != nil {
+		return errResp
 	}
 
 	// Get workspaceId from header
@@ -67,136 +108,230 @@ func (c *TransferenceAccountController) Handle(r presentationProtocols.HttpReque
 		}, http.StatusBadRequest)
 	}
 
-	// Validate and convert IDs to ObjectID
-	sourceAccountId, err := primitive.ObjectIDFromHex(body.SourceAccountId)
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "Invalid source account ID format",
+			Error: "Invalid assignedTo ID format",
 		}, http.StatusBadRequest)
 	}
 
-	destinationAccountId, err := primitive.ObjectIDFromHex(body.DestinationAccountId)
+	sourceAccount, destinationAccount, errResp := findTransferAccounts(c.FindAccountByIdRepository, body, workspaceId)
+	if errResp != nil {
+		return errResp
+	}
+
+	rates, err := c.FindExchangeRateTableRepository.Find(workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "Invalid destination account ID format",
-		}, http.StatusBadRequest)
+			Error: "An error occurred when finding exchange rates",
+		}, http.StatusInternalServerError)
 	}
 
-	// Ensure accounts are different
-	if sourceAccountId == destinationAccountId {
+	transfer := &models.Transfer{
+		WorkspaceId: workspaceId,
+		Date:        time.Now().UTC(),
+		IsConfirmed: body.DueDate == "",
+		CreatedBy:   userId,
+	}
+	if err := applyTransferBody(transfer, body, sourceAccount, destinationAccount, rates); err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "Source and destination accounts must be different",
-		}, http.StatusBadRequest)
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
 	}
 
-	// Find source account
-	sourceAccount, err := c.FindAccountByIdRepository.Find(sourceAccountId, workspaceId)
+	expenseTransaction, receiptTransaction := transferTransactions(transfer, sourceAccount, destinationAccount, userId)
+
+	transfer, err = c.CreateTransferRepository.Create(transfer, expenseTransaction, receiptTransaction)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "An error occurred when finding source account",
+			Error: "An error occurred when creating transfer",
 		}, http.StatusInternalServerError)
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, expenseTransaction),
+		infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, receiptTransaction),
+	))
+
+	return helpers.CreateResponse(&TransferenceAccountControllerResponse{
+		SourceAccount:      sourceAccount,
+		DestinationAccount: destinationAccount,
+		ExpenseTransaction: expenseTransaction,
+		ReceiptTransaction: receiptTransaction,
+		Transfer:           transfer,
+	}, http.StatusOK)
+}
+
+// findTransferAccounts loads the source and destination accounts of the body
+func findTransferAccounts(findAccountById usecase.FindAccountByIdRepository, body *TransferenceAccountControllerBody, workspaceId primitive.ObjectID) (*models.Account, *models.Account, *presentationProtocols.HttpResponse) {
+	sourceAccountId, _ := primitive.ObjectIDFromHex(body.SourceAccountId)
+	destinationAccountId, _ := primitive.ObjectIDFromHex(body.DestinationAccountId)
+
+	sourceAccount, err := findAccountById.Find(sourceAccountId, workspaceId)
+	if err != nil {
+		return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "An error occurred when finding source account",
+		}, http.StatusInternalServerError)
+	}
 	if sourceAccount == nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "Source account not found",
 		}, http.StatusNotFound)
 	}
 
-	// Find destination account
-	destinationAccount, err := c.FindAccountByIdRepository.Find(destinationAccountId, workspaceId)
+	destinationAccount, err := findAccountById.Find(destinationAccountId, workspaceId)
 	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "An error occurred when finding destination account",
 		}, http.StatusInternalServerError)
 	}
-
 	if destinationAccount == nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "Destination account not found",
 		}, http.StatusNotFound)
 	}
 
-	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
-	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "Invalid assignedTo ID format",
-		}, http.StatusBadRequest)
+	return sourceAccount, destinationAccount, nil
+}
+
+// applyTransferBody fills the accounts, schedule and amounts of the transfer
+func applyTransferBody(transfer *models.Transfer, body *TransferenceAccountControllerBody, sourceAccount, destinationAccount *models.Account, rates *models.ExchangeRates) error {
+	transfer.SourceAccountId = sourceAccount.Id
+	transfer.SourceCurrency = rates.Currency(sourceAccount.Currency)
+	transfer.DestinationAccountId = destinationAccount.Id
+	transfer.DestinationCurrency = rates.Currency(destinationAccount.Currency)
+
+	transfer.Description = body.Description
+	if transfer.Description == "" {
+		transfer.Description = "Transferência de " + sourceAccount.Name + " para " + destinationAccount.Name
 	}
 
-	// Get current time for transactions
-	now := time.Now().UTC()
-
-	// Create expense transaction for source account
-	expenseTransaction := &models.Transaction{
-		Id:         primitive.NewObjectID(),
-		Name:       "Transferência interna para " + destinationAccount.Name,
-		IsDeleted:  false,
-		CreatedBy:  workspaceId, // Using workspaceId as createdBy since we don't have user info
-		Type:       "EXPENSE",
-		Supplier:   "Transferência interna",
-		AssignedTo: userId, // Using workspaceId as assignedTo since we don't have user info
-		Balance: models.TransactionBalance{
-			Value:      body.Amount,
-			Discount:   0,
-			Interest:   0,
-			NetBalance: body.Amount,
-		},
-		Frequency:        "DO_NOT_REPEAT",
-		DueDate:          now,
-		IsConfirmed:      true,
-		AccountId:        &sourceAccountId,
-		RegistrationDate: now,
-		ConfirmationDate: &now,
-		CreatedAt:        now,
-		UpdatedAt:        now,
-		WorkspaceId:      workspaceId,
-	}
-
-	// Create receipt transaction for destination account
-	receiptTransaction := &models.Transaction{
-		Id:         primitive.NewObjectID(),
-		Name:       "Transferência interna de " + sourceAccount.Name,
-		IsDeleted:  false,
-		CreatedBy:  workspaceId, // Using workspaceId as createdBy since we don't have user info
-		Type:       "RECIPE",
-		Supplier:   "Transferência interna",
-		AssignedTo: userId, // Using workspaceId as assignedTo since we don't have user info
-		Balance: models.TransactionBalance{
-			Value:      body.Amount,
-			Discount:   0,
-			Interest:   0,
-			NetBalance: body.Amount,
-		},
-		Frequency:        "DO_NOT_REPEAT",
-		DueDate:          now,
-		IsConfirmed:      true,
-		AccountId:        &destinationAccountId,
-		RegistrationDate: now,
-		ConfirmationDate: &now,
-		CreatedAt:        now,
-		UpdatedAt:        now,
-		WorkspaceId:      workspaceId,
-	}
-
-	// Create expense transaction
-	createdExpenseTransaction, err := c.CreateTransactionRepository.Create(expenseTransaction)
-	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "An error occurred when creating expense transaction",
-		}, http.StatusInternalServerError)
+	if err := applyTransferSchedule(transfer, body); err != nil {
+		return err
 	}
 
-	// Create receipt transaction
-	createdReceiptTransaction, err := c.CreateTransactionRepository.Create(receiptTransaction)
-	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "An error occurred when creating receipt transaction",
-		}, http.StatusInternalServerError)
+	return applyTransferAmounts(transfer, body.Amount, body.Rate, body.DestinationAmount, rates)
+}
+
+// applyTransferSchedule fills the due date, confirmation and frequency of the transfer
+func applyTransferSchedule(transfer *models.Transfer, body *TransferenceAccountControllerBody) error {
+	if body.Frequency == "REPEAT" && (body.RepeatSettings.InitialInstallment < 1 || body.RepeatSettings.Count < 2) {
+		return errors.New("initialInstallment and count are required for REPEAT")
 	}
 
-	return helpers.CreateResponse(&TransferenceAccountControllerResponse{
-		ExpenseTransaction: createdExpenseTransaction,
-		ReceiptTransaction: createdReceiptTransaction,
-	}, http.StatusOK)
+	if body.Frequency == "REPEAT" && int(body.RepeatSettings.InitialInstallment) >= body.RepeatSettings.Count {
+		return errors.New("initialInstallment must be less than count")
+	}
+
+	if body.DueDate != "" {
+		dueDate, err := time.ParseInLocation("2006-01-02T15:04:05Z", body.DueDate, time.UTC)
+		if err != nil {
+			return err
+		}
+		transfer.Date = dueDate
+	}
+
+	if body.IsConfirmed != nil {
+		transfer.IsConfirmed = *body.IsConfirmed
+	}
+
+	if !transfer.IsConfirmed {
+		if body.ConfirmationDate != nil {
+			return errors.New("confirmationDate is only allowed for confirmed transfers")
+		}
+		transfer.ConfirmationDate = nil
+	} else if body.ConfirmationDate != nil {
+		confirmationDate, err := time.ParseInLocation("2006-01-02T15:04:05Z", *body.ConfirmationDate, time.UTC)
+		if err != nil {
+			return err
+		}
+		transfer.ConfirmationDate = &confirmationDate
+	} else if transfer.ConfirmationDate == nil {
+		confirmationDate := transfer.Date
+		transfer.ConfirmationDate = &confirmationDate
+	}
+
+	transfer.Frequency = body.Frequency
+	transfer.RepeatSettings = nil
+	if body.Frequency != "DO_NOT_REPEAT" {
+		transfer.RepeatSettings = &models.TransactionRepeatSettings{
+			InitialInstallment: body.RepeatSettings.InitialInstallment,
+			Count:              body.RepeatSettings.Count,
+			Interval:           body.RepeatSettings.Interval,
+			CustomDay:          body.RepeatSettings.CustomDay,
+		}
+	}
+
+	return nil
+}
+
+// applyTransferAmounts fills the amounts of the transfer. Between currencies the amount received is
+// destinationAmount, or amount converted by rate or by the rate of the transfer date.
+func applyTransferAmounts(transfer *models.Transfer, amount models.Money, rate float64, destinationAmount models.Money, rates *models.ExchangeRates) error {
+	transfer.SourceAmount = amount
+	transfer.DestinationAmount = amount
+	transfer.Rate = 1
+
+	if transfer.SourceCurrency == transfer.DestinationCurrency {
+		return nil
+	}
+
+	switch {
+	case destinationAmount > 0:
+		transfer.Rate = float64(destinationAmount) / float64(amount)
+	case rate > 0:
+		transfer.Rate = rate
+	default:
+		dayRate, err := rates.Rate(transfer.SourceCurrency, transfer.DestinationCurrency, transfer.Date)
+		if err != nil {
+			return err
+		}
+		transfer.Rate = dayRate
+	}
+
+	transfer.DestinationAmount = destinationAmount
+	if transfer.DestinationAmount == 0 {
+		transfer.DestinationAmount = amount.Mul(transfer.Rate)
+	}
+
+	return nil
+}
+
+// transferTransactions builds the legs of the transfer: an expense on the source account and a receipt on
+// the destination, both with the due date, confirmation and frequency of the transfer
+func transferTransactions(transfer *models.Transfer, sourceAccount, destinationAccount *models.Account, userId primitive.ObjectID) (*models.Transaction, *models.Transaction) {
+	leg := func(name, transactionType string, amount models.Money, account *models.Account) *models.Transaction {
+		var repeatSettings *models.TransactionRepeatSettings
+		if transfer.RepeatSettings != nil {
+			settings := *transfer.RepeatSettings
+			repeatSettings = &settings
+		}
+
+		return &models.Transaction{
+			Name:       name,
+			CreatedBy:  userId,
+			Type:       transactionType,
+			Supplier:   "Transferência interna",
+			AssignedTo: userId,
+			Balance: models.TransactionBalance{
+				Value:      amount,
+				NetBalance: amount,
+			},
+			Currency:         account.Currency,
+			Frequency:        transfer.Frequency,
+			RepeatSettings:   repeatSettings,
+			DueDate:          transfer.Date,
+			IsConfirmed:      transfer.IsConfirmed,
+			Tags:             []models.TransactionTags{},
+			AccountId:        &account.Id,
+			RegistrationDate: transfer.Date,
+			ConfirmationDate: transfer.ConfirmationDate,
+			WorkspaceId:      transfer.WorkspaceId,
+			CustomFields:     []models.TransactionCustomField{},
+		}
+	}
+
+	expense := leg("Transferência interna para "+destinationAccount.Name, "EXPENSE", transfer.SourceAmount, sourceAccount)
+	receipt := leg("Transferência interna de "+sourceAccount.Name, "RECIPE", transfer.DestinationAmount, destinationAccount)
+	return expense, receipt
 }
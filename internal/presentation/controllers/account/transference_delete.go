@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type DeleteTransfersController struct {
+	DeleteTransfersRepository  usecase.DeleteTransfersRepository
+	FindTransferByIdRepository usecase.FindTransferByIdRepository
+	CreateAuditLogRepository   usecase.CreateAuditLogRepository
+}
+
+func NewDeleteTransfersController(deleteTransfers usecase.DeleteTransfersRepository, findTransferById usecase.FindTransferByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *DeleteTransfersController {
+	return &DeleteTransfersController{
+		DeleteTransfersRepository:  deleteTransfers,
+		FindTransferByIdRepository: findTransferById,
+		CreateAuditLogRepository:   createAuditLog,
+	}
+}
+
+// Handle moves the transfers in ids, with their two transactions, to the trash
+func (c *DeleteTransfersController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	idsObjectID := []primitive.ObjectID{}
+	for _, id := range strings.Split(r.UrlParams.Get("ids"), ",") {
+		objectID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Invalid transfer ID format",
+			}, http.StatusBadRequest)
+		}
+		idsObjectID = append(idsObjectID, objectID)
+	}
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+	for _, id := range idsObjectID {
+		transfer, err := c.FindTransferByIdRepository.Find(id, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when finding transfer: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if transfer != nil {
+			auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "TRANSFER", id, "DELETE", transfer, nil))
+		}
+	}
+
+	if err := c.DeleteTransfersRepository.Delete(idsObjectID, workspaceId); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when deleting transfers: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	return helpers.CreateResponse(nil, http.StatusNoContent)
+}
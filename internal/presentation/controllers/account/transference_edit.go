@@ -0,0 +1,211 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EditTransferController struct {
+	FindTransferByIdRepository        usecase.FindTransferByIdRepository
+	FindTransactionByIdRepository     usecase.FindTransactionByIdRepository
+	FindByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository
+	EditTransferRepository            usecase.EditTransferRepository
+	CreateAuditLogRepository          usecase.CreateAuditLogRepository
+	FindExchangeRateTableRepository   usecase.FindExchangeRateTableRepository
+	Validate                          *validator.Validate
+}
+
+func NewEditTransferController(
+	findTransferById usecase.FindTransferByIdRepository,
+	findTransactionById usecase.FindTransactionByIdRepository,
+	findByIdEditTransaction usecase.FindByIdEditTransactionRepository,
+	editTransfer usecase.EditTransferRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	findExchangeRateTable usecase.FindExchangeRateTableRepository,
+) *EditTransferController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	return &EditTransferController{
+		FindTransferByIdRepository:        findTransferById,
+		FindTransactionByIdRepository:     findTransactionById,
+		FindByIdEditTransactionRepository: findByIdEditTransaction,
+		EditTransferRepository:            editTransfer,
+		CreateAuditLogRepository:          createAuditLog,
+		FindExchangeRateTableRepository:   findExchangeRateTable,
+		Validate:                          validate,
+	}
+}
+
+// EditTransferBody changes occurrence mainCount of a repeating transfer. Amount is the one of the occurrence, also
+// on REPEAT transfers, and between currencies follows the same rules as the transfer at the occurrence due date.
+type EditTransferBody struct {
+	MainCount         int          `json:"mainCount" validate:"required,min=1,max=367"`
+	Amount            models.Money `json:"amount" validate:"required,gt=0"`
+	Rate              float64      `json:"rate" validate:"omitempty,gt=0"`
+	DestinationAmount models.Money `json:"destinationAmount" validate:"omitempty,gt=0,excluded_with=Rate"`
+	DueDate           string       `json:"dueDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
+	IsConfirmed       bool         `json:"isConfirmed"`
+	ConfirmationDate  *string      `json:"confirmationDate" validate:"excluded_if=IsConfirmed false,required_if=IsConfirmed true,omitempty,datetime=2006-01-02T15:04:05Z"`
+}
+
+type EditTransferResponse struct {
+	ExpenseTransaction *models.Transaction `json:"expenseTransaction"`
+	ReceiptTransaction *models.Transaction `json:"receiptTransaction"`
+}
+
+// Handle saves an edit_transaction for the same occurrence of both transactions of the transfer
+func (c *EditTransferController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("id"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid transfer ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var body EditTransferBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transfer, err := c.FindTransferByIdRepository.Find(id, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving transfer: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	if transfer == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transfer not found",
+		}, http.StatusNotFound)
+	}
+
+	if transfer.RepeatSettings == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "only occurrences of repeating transfers can be edited",
+		}, http.StatusBadRequest)
+	}
+
+	if transfer.Frequency == "REPEAT" && transfer.RepeatSettings.Count < body.MainCount {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "mainCount is greater than the number of installments of the transfer",
+		}, http.StatusBadRequest)
+	}
+
+	rates, err := c.FindExchangeRateTableRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "An error occurred when finding exchange rates",
+		}, http.StatusInternalServerError)
+	}
+
+	occurrence := *transfer
+	occurrence.Date, _ = time.ParseInLocation("2006-01-02T15:04:05Z", body.DueDate, time.UTC)
+	occurrence.IsConfirmed = body.IsConfirmed
+	occurrence.ConfirmationDate = nil
+	if body.IsConfirmed {
+		confirmationDate, _ := time.ParseInLocation("2006-01-02T15:04:05Z", *body.ConfirmationDate, time.UTC)
+		occurrence.ConfirmationDate = &confirmationDate
+	}
+
+	if err := applyTransferAmounts(&occurrence, body.Amount, body.Rate, body.DestinationAmount, rates); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	legs := []struct {
+		id     primitive.ObjectID
+		amount models.Money
+		edit   *models.Transaction
+		before *models.Transaction
+	}{
+		{id: transfer.ExpenseTransactionId, amount: occurrence.SourceAmount},
+		{id: transfer.ReceiptTransactionId, amount: occurrence.DestinationAmount},
+	}
+
+	for i := range legs {
+		main, err := c.FindTransactionByIdRepository.Find(legs[i].id, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving transfer transactions: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if main == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "transfer transactions not found",
+			}, http.StatusNotFound)
+		}
+
+		// before the first edit the occurrence is the main transaction itself
+		legs[i].before, err = c.FindByIdEditTransactionRepository.Find(main.Id, body.MainCount, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving transfer occurrence: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if legs[i].before == nil {
+			instance := *main
+			instance.MainId = &main.Id
+			instance.MainCount = &body.MainCount
+			legs[i].before = &instance
+		}
+
+		edit := *main
+		mainId, mainCount := main.Id, body.MainCount
+		edit.MainId = &mainId
+		edit.MainCount = &mainCount
+		edit.Balance = models.TransactionBalance{Value: legs[i].amount}
+		edit.Transfer = occurrence.Leg()
+		edit.DueDate = occurrence.Date
+		edit.IsConfirmed = occurrence.IsConfirmed
+		edit.ConfirmationDate = occurrence.ConfirmationDate
+		legs[i].edit = &edit
+	}
+
+	if err := c.EditTransferRepository.Edit(legs[0].edit, legs[1].edit); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "An error occurred when editing transfer occurrence",
+		}, http.StatusInternalServerError)
+	}
+
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewTransactionAuditLog(userId, "UPDATE", legs[0].before, legs[0].edit),
+		infraHelpers.NewTransactionAuditLog(userId, "UPDATE", legs[1].before, legs[1].edit),
+	))
+
+	return helpers.CreateResponse(&EditTransferResponse{
+		ExpenseTransaction: legs[0].edit,
+		ReceiptTransaction: legs[1].edit,
+	}, http.StatusCreated)
+}
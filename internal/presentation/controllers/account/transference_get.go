@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GetTransfersController struct {
+	FindTransfersRepository usecase.FindTransfersRepository
+}
+
+func NewGetTransfersController(findTransfers usecase.FindTransfersRepository) *GetTransfersController {
+	return &GetTransfersController{
+		FindTransfersRepository: findTransfers,
+	}
+}
+
+// Handle lists the transfers of the workspace, optionally of one account (as source or destination) and
+// between initialDate and finalDate (YYYY-MM-DD)
+func (c *GetTransfersController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	filter := &usecase.FindTransfersFilter{WorkspaceId: workspaceId}
+
+	if accountIdParam := r.UrlParams.Get("accountId"); accountIdParam != "" {
+		accountId, err := primitive.ObjectIDFromHex(accountIdParam)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Invalid account ID format",
+			}, http.StatusBadRequest)
+		}
+		filter.AccountId = &accountId
+	}
+
+	if initialDateParam := r.UrlParams.Get("initialDate"); initialDateParam != "" {
+		initialDate, err := time.Parse(time.DateOnly, initialDateParam)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Invalid initialDate format, expected YYYY-MM-DD",
+			}, http.StatusBadRequest)
+		}
+		filter.InitialDate = &initialDate
+	}
+
+	if finalDateParam := r.UrlParams.Get("finalDate"); finalDateParam != "" {
+		finalDate, err := time.Parse(time.DateOnly, finalDateParam)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Invalid finalDate format, expected YYYY-MM-DD",
+			}, http.StatusBadRequest)
+		}
+		// the final day is included
+		finalDate = finalDate.Add(24*time.Hour - time.Nanosecond)
+		filter.FinalDate = &finalDate
+	}
+
+	transfers, err := c.FindTransfersRepository.Find(filter)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving transfers: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(transfers, http.StatusOK)
+}
+
+type GetTransferByIdController struct {
+	FindTransferByIdRepository usecase.FindTransferByIdRepository
+}
+
+func NewGetTransferByIdController(findTransferById usecase.FindTransferByIdRepository) *GetTransferByIdController {
+	return &GetTransferByIdController{
+		FindTransferByIdRepository: findTransferById,
+	}
+}
+
+func (c *GetTransferByIdController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("id"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid transfer ID format",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transfer, err := c.FindTransferByIdRepository.Find(id, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving transfer: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if transfer == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transfer not found",
+		}, http.StatusNotFound)
+	}
+
+	return helpers.CreateResponse(transfer, http.StatusOK)
+}
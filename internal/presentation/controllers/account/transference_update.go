@@ -0,0 +1,157 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UpdateTransferController struct {
+	FindTransferByIdRepository      usecase.FindTransferByIdRepository
+	UpdateTransferRepository        usecase.UpdateTransferRepository
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	FindTransactionByIdRepository   usecase.FindTransactionByIdRepository
+	CreateAuditLogRepository        usecase.CreateAuditLogRepository
+	FindExchangeRateTableRepository usecase.FindExchangeRateTableRepository
+	Validate                        *validator.Validate
+}
+
+func NewUpdateTransferController(
+	findTransferById usecase.FindTransferByIdRepository,
+	updateTransfer usecase.UpdateTransferRepository,
+	findAccountById usecase.FindAccountByIdRepository,
+	findTransactionById usecase.FindTransactionByIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	findExchangeRateTable usecase.FindExchangeRateTableRepository,
+) *UpdateTransferController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	return &UpdateTransferController{
+		FindTransferByIdRepository:      findTransferById,
+		UpdateTransferRepository:        updateTransfer,
+		FindAccountByIdRepository:       findAccountById,
+		FindTransactionByIdRepository:   findTransactionById,
+		CreateAuditLogRepository:        createAuditLog,
+		FindExchangeRateTableRepository: findExchangeRateTable,
+		Validate:                        validate,
+	}
+}
+
+// Handle replaces the accounts, amounts, description and schedule of a transfer, updating its two transactions
+// together. The body is the same as the one used to create it.
+func (c *UpdateTransferController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("id"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid transfer ID format",
+		}, http.StatusBadRequest)
+	}
+
+	body, errResp := decodeTransferBody(r, c.Validate)
+	if errResp != nil {
+		return errResp
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transfer, err := c.FindTransferByIdRepository.Find(id, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving transfer: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	if transfer == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transfer not found",
+		}, http.StatusNotFound)
+	}
+
+	previousExpense, err := c.FindTransactionByIdRepository.Find(transfer.ExpenseTransactionId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving expense transaction: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	previousReceipt, err := c.FindTransactionByIdRepository.Find(transfer.ReceiptTransactionId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving receipt transaction: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	sourceAccount, destinationAccount, errResp := findTransferAccounts(c.FindAccountByIdRepository, body, workspaceId)
+	if errResp != nil {
+		return errResp
+	}
+
+	rates, err := c.FindExchangeRateTableRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "An error occurred when finding exchange rates",
+		}, http.StatusInternalServerError)
+	}
+
+	if err := applyTransferBody(transfer, body, sourceAccount, destinationAccount, rates); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	expenseTransaction, receiptTransaction := transferTransactions(transfer, sourceAccount, destinationAccount, userId)
+
+	transfer, err = c.UpdateTransferRepository.Update(transfer, expenseTransaction, receiptTransaction)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "An error occurred when updating transfer",
+		}, http.StatusInternalServerError)
+	}
+
+	auditLogs := []models.AuditLog{}
+	for _, leg := range []struct{ previous, current *models.Transaction }{
+		{previousExpense, expenseTransaction},
+		{previousReceipt, receiptTransaction},
+	} {
+		if leg.previous != nil {
+			auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", leg.previous, updatedTransferLeg(leg.previous, leg.current)))
+		}
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	return helpers.CreateResponse(transfer, http.StatusOK)
+}
+
+// updatedTransferLeg returns the stored transaction with the fields the transfer update replaces
+func updatedTransferLeg(previous, current *models.Transaction) *models.Transaction {
+	updated := *previous
+	updated.Name = current.Name
+	updated.Balance = current.Balance
+	updated.Currency = current.Currency
+	updated.Transfer = current.Transfer
+	updated.AccountId = current.AccountId
+	updated.Frequency = current.Frequency
+	updated.RepeatSettings = current.RepeatSettings
+	updated.DueDate = current.DueDate
+	updated.IsConfirmed = current.IsConfirmed
+	updated.RegistrationDate = current.RegistrationDate
+	updated.ConfirmationDate = current.ConfirmationDate
+	updated.UpdatedAt = current.UpdatedAt
+	return &updated
+}
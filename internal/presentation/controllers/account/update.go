@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -18,6 +19,7 @@ type UpdateAccountController struct {
 	FindBankById                usecase.FindBankByIdRepository
 	FindAccountById             usecase.FindAccountByIdRepository
 	FindAccountByNameRepository usecase.FindAccountByNameAndWorkspaceIdRepository
+	CreateAuditLogRepository    usecase.CreateAuditLogRepository
 }
 
 func NewUpdateAccountController(
@@ -25,6 +27,7 @@ func NewUpdateAccountController(
 	findBankById usecase.FindBankByIdRepository,
 	findAccountById usecase.FindAccountByIdRepository,
 	findByNameRepository usecase.FindAccountByNameAndWorkspaceIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *UpdateAccountController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -34,13 +37,15 @@ func NewUpdateAccountController(
 		FindBankById:                findBankById,
 		FindAccountById:             findAccountById,
 		FindAccountByNameRepository: findByNameRepository,
+		CreateAuditLogRepository:    createAuditLog,
 	}
 }
 
 type UpdateAccountControllerBody struct {
-	Name    string  `validate:"required"`
-	BankId  string  `validate:"required"`
-	Balance float64 `validate:"min=0,max=1000000000000000000"`
+	Name     string       `validate:"required"`
+	BankId   string       `validate:"required"`
+	Balance  models.Money `validate:"min=0,max=1000000000000000000"`
+	Currency string       `validate:"omitempty,iso4217"` // empty keeps the current currency
 }
 
 func (c *UpdateAccountController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
@@ -120,10 +125,16 @@ func (c *UpdateAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		}, http.StatusNotFound)
 	}
 
+	currency := accountToVerify.Currency
+	if body.Currency != "" {
+		currency = body.Currency
+	}
+
 	account, err := c.UpdateAccountRepository.Update(id, &models.Account{
-		Name:    body.Name,
-		Balance: body.Balance,
-		BankId:  bank.Id,
+		Name:     body.Name,
+		Balance:  body.Balance,
+		BankId:   bank.Id,
+		Currency: currency,
 	})
 
 	if err != nil {
@@ -132,5 +143,16 @@ func (c *UpdateAccountController) Handle(r presentationProtocols.HttpRequest) *p
 		}, http.StatusInternalServerError)
 	}
 
+	updated := *accountToVerify
+	updated.Name = body.Name
+	updated.Balance = body.Balance
+	updated.BankId = bank.Id
+	updated.Currency = currency
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewAuditLog(workspaceId, userId, "ACCOUNT", id, "UPDATE", accountToVerify, &updated),
+	))
+
 	return helpers.CreateResponse(account, http.StatusOK)
 }
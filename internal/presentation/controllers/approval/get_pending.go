@@ -0,0 +1,41 @@
+package approval
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetPendingApprovalController handles listing the expenses waiting for approval
+type GetPendingApprovalController struct {
+	FindPendingApprovalRepository usecase.FindPendingApprovalRepository
+}
+
+// NewGetPendingApprovalController creates a new instance of GetPendingApprovalController
+func NewGetPendingApprovalController(findPendingApproval usecase.FindPendingApprovalRepository) *GetPendingApprovalController {
+	return &GetPendingApprovalController{
+		FindPendingApprovalRepository: findPendingApproval,
+	}
+}
+
+// Handle processes the HTTP request to list the pending expenses
+func (c *GetPendingApprovalController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transactions, err := c.FindPendingApprovalRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding pending expenses: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(transactions, http.StatusOK)
+}
@@ -0,0 +1,47 @@
+package approval
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetApprovalSettingsController handles reading the approval settings of a workspace
+type GetApprovalSettingsController struct {
+	FindApprovalSettingsRepository usecase.FindApprovalSettingsRepository
+}
+
+// NewGetApprovalSettingsController creates a new instance of GetApprovalSettingsController
+func NewGetApprovalSettingsController(findApprovalSettings usecase.FindApprovalSettingsRepository) *GetApprovalSettingsController {
+	return &GetApprovalSettingsController{
+		FindApprovalSettingsRepository: findApprovalSettings,
+	}
+}
+
+// Handle processes the HTTP request to get the approval settings
+func (c *GetApprovalSettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	settings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding approval settings: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	// a workspace that never configured approval has it disabled
+	if settings == nil {
+		settings = &models.ApprovalSettings{WorkspaceId: workspaceId, CategoryIds: []primitive.ObjectID{}}
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
@@ -0,0 +1,125 @@
+package approval
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ReviewTransactionController handles approving or rejecting a pending expense, depending on Status
+type ReviewTransactionController struct {
+	Validate                      *validator.Validate
+	Status                        string
+	FindTransactionByIdRepository usecase.FindTransactionByIdRepository
+	ReviewTransactionRepository   usecase.ReviewTransactionRepository
+	CreateAuditLogRepository      usecase.CreateAuditLogRepository
+}
+
+// NewReviewTransactionController creates a new instance of ReviewTransactionController
+func NewReviewTransactionController(status string, findTransactionById usecase.FindTransactionByIdRepository, reviewTransaction usecase.ReviewTransactionRepository, createAuditLog usecase.CreateAuditLogRepository) *ReviewTransactionController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &ReviewTransactionController{
+		Validate:                      validate,
+		Status:                        status,
+		FindTransactionByIdRepository: findTransactionById,
+		ReviewTransactionRepository:   reviewTransaction,
+		CreateAuditLogRepository:      createAuditLog,
+	}
+}
+
+// ReviewTransactionBody holds the comment of the reviewer, required when rejecting
+type ReviewTransactionBody struct {
+	Comment string `json:"comment" validate:"max=255"`
+}
+
+// Handle processes the HTTP request to review an expense
+func (c *ReviewTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body ReviewTransactionBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	if c.Status == models.ApprovalRejected && body.Comment == "" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "a comment is required to reject an expense",
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transactionId, err := primitive.ObjectIDFromHex(r.Req.PathValue("id"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid transaction ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transaction, err := c.FindTransactionByIdRepository.Find(transactionId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding transaction",
+		}, http.StatusInternalServerError)
+	}
+
+	if transaction == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transaction not found",
+		}, http.StatusNotFound)
+	}
+
+	// approvers review the expenses of others; only admins may review their own
+	if transaction.CreatedBy == userId && r.Header.Get("financeRole") != models.RoleAdmin {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "you can not review an expense you created",
+		}, http.StatusForbidden)
+	}
+
+	reviewed, err := c.ReviewTransactionRepository.Review(transactionId, workspaceId, c.Status, &models.TransactionApproval{
+		ReviewedBy: userId,
+		ReviewedAt: time.Now().UTC(),
+		Comment:    body.Comment,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when reviewing transaction: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if reviewed == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transaction is not waiting for approval",
+		}, http.StatusConflict)
+	}
+
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userId, "UPDATE", transaction, reviewed)))
+
+	return helpers.CreateResponse(reviewed, http.StatusOK)
+}
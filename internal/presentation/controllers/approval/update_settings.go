@@ -0,0 +1,99 @@
+package approval
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateApprovalSettingsController handles changing which expenses require approval
+type UpdateApprovalSettingsController struct {
+	Validate                       *validator.Validate
+	SaveApprovalSettingsRepository usecase.SaveApprovalSettingsRepository
+	FindCategoryByIdRepository     usecase.FindCategoryByIdRepository
+}
+
+// NewUpdateApprovalSettingsController creates a new instance of UpdateApprovalSettingsController
+func NewUpdateApprovalSettingsController(saveApprovalSettings usecase.SaveApprovalSettingsRepository, findCategoryById usecase.FindCategoryByIdRepository) *UpdateApprovalSettingsController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &UpdateApprovalSettingsController{
+		Validate:                       validate,
+		SaveApprovalSettingsRepository: saveApprovalSettings,
+		FindCategoryByIdRepository:     findCategoryById,
+	}
+}
+
+// UpdateApprovalSettingsBody holds the amount above which expenses require approval, zero to disable
+// it, and the expense categories that always require approval
+type UpdateApprovalSettingsBody struct {
+	Threshold   models.Money `json:"threshold" validate:"min=0,max=1000000000000000000"`
+	CategoryIds []string     `json:"categoryIds" validate:"omitempty,unique,dive,mongodb"`
+}
+
+// Handle processes the HTTP request to update the approval settings
+func (c *UpdateApprovalSettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body UpdateApprovalSettingsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	categoryIds := make([]primitive.ObjectID, 0, len(body.CategoryIds))
+	for _, id := range body.CategoryIds {
+		categoryId, _ := primitive.ObjectIDFromHex(id)
+
+		category, err := c.FindCategoryByIdRepository.Find(categoryId, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when finding category: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+
+		if category == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "category not found: " + id,
+			}, http.StatusNotFound)
+		}
+
+		if category.Type != "EXPENSE" {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "only expense categories can require approval: " + category.Name,
+			}, http.StatusBadRequest)
+		}
+
+		categoryIds = append(categoryIds, categoryId)
+	}
+
+	settings, err := c.SaveApprovalSettingsRepository.Save(&models.ApprovalSettings{
+		WorkspaceId: workspaceId,
+		Threshold:   body.Threshold,
+		CategoryIds: categoryIds,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when saving approval settings: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
@@ -0,0 +1,62 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteAttachmentController handles removing an attachment
+type DeleteAttachmentController struct {
+	FindAttachmentByIdRepository usecase.FindAttachmentByIdRepository
+	DeleteAttachmentRepository   usecase.DeleteAttachmentRepository
+}
+
+// NewDeleteAttachmentController creates a new instance of DeleteAttachmentController
+func NewDeleteAttachmentController(findAttachmentById usecase.FindAttachmentByIdRepository, deleteAttachment usecase.DeleteAttachmentRepository) *DeleteAttachmentController {
+	return &DeleteAttachmentController{
+		FindAttachmentByIdRepository: findAttachmentById,
+		DeleteAttachmentRepository:   deleteAttachment,
+	}
+}
+
+// Handle processes the HTTP request to delete an attachment
+func (c *DeleteAttachmentController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	attachmentId, err := primitive.ObjectIDFromHex(r.Req.PathValue("attachmentId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid attachment ID format",
+		}, http.StatusBadRequest)
+	}
+
+	attachment, err := c.FindAttachmentByIdRepository.Find(attachmentId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding attachment",
+		}, http.StatusInternalServerError)
+	}
+
+	if attachment == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "attachment not found",
+		}, http.StatusNotFound)
+	}
+
+	if err := c.DeleteAttachmentRepository.Delete(attachmentId, workspaceId); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when deleting attachment: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(nil, http.StatusNoContent)
+}
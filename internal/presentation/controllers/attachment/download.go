@@ -0,0 +1,63 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DownloadAttachmentController handles returning the content of an attachment
+type DownloadAttachmentController struct {
+	Storage                      usecase.FileStorage
+	FindAttachmentByIdRepository usecase.FindAttachmentByIdRepository
+}
+
+// NewDownloadAttachmentController creates a new instance of DownloadAttachmentController
+func NewDownloadAttachmentController(storage usecase.FileStorage, findAttachmentById usecase.FindAttachmentByIdRepository) *DownloadAttachmentController {
+	return &DownloadAttachmentController{
+		Storage:                      storage,
+		FindAttachmentByIdRepository: findAttachmentById,
+	}
+}
+
+// Handle processes the HTTP request to download an attachment
+func (c *DownloadAttachmentController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	attachmentId, err := primitive.ObjectIDFromHex(r.Req.PathValue("attachmentId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid attachment ID format",
+		}, http.StatusBadRequest)
+	}
+
+	attachment, err := c.FindAttachmentByIdRepository.Find(attachmentId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding attachment",
+		}, http.StatusInternalServerError)
+	}
+
+	if attachment == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "attachment not found",
+		}, http.StatusNotFound)
+	}
+
+	content, err := c.Storage.Open(attachment.StorageKey)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when reading the file: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateFileResponse(content, attachment.ContentType, attachment.FileName)
+}
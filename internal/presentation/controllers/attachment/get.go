@@ -0,0 +1,53 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetAttachmentsController handles listing the attachments of a transaction or installment
+type GetAttachmentsController struct {
+	FindTransactionByIdRepository usecase.FindTransactionByIdRepository
+	FindAttachmentsRepository     usecase.FindAttachmentsRepository
+}
+
+// NewGetAttachmentsController creates a new instance of GetAttachmentsController
+func NewGetAttachmentsController(findTransactionById usecase.FindTransactionByIdRepository, findAttachments usecase.FindAttachmentsRepository) *GetAttachmentsController {
+	return &GetAttachmentsController{
+		FindTransactionByIdRepository: findTransactionById,
+		FindAttachmentsRepository:     findAttachments,
+	}
+}
+
+// Handle processes the HTTP request to list attachments
+func (c *GetAttachmentsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transaction, response := findTransaction(c.FindTransactionByIdRepository, r, workspaceId)
+	if response != nil {
+		return response
+	}
+
+	mainCount, response := parseInstallment(r, transaction)
+	if response != nil {
+		return response
+	}
+
+	attachments, err := c.FindAttachmentsRepository.Find(transaction.Id, mainCount, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding attachments: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(attachments, http.StatusOK)
+}
@@ -0,0 +1,195 @@
+package attachment
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxAttachmentSize = 10 << 20
+
+// allowedContentTypes maps the type detected from the content of the file to the one stored
+var allowedContentTypes = map[string]string{
+	"application/pdf": "application/pdf",
+	"image/jpeg":      "image/jpeg",
+	"image/png":       "image/png",
+	"image/webp":      "image/webp",
+	"text/xml":        "application/xml",
+	"application/xml": "application/xml",
+}
+
+// UploadAttachmentController handles attaching a file to a transaction or to one of its installments
+type UploadAttachmentController struct {
+	Storage                       usecase.FileStorage
+	FindTransactionByIdRepository usecase.FindTransactionByIdRepository
+	CreateAttachmentRepository    usecase.CreateAttachmentRepository
+}
+
+// NewUploadAttachmentController creates a new instance of UploadAttachmentController
+func NewUploadAttachmentController(storage usecase.FileStorage, findTransactionById usecase.FindTransactionByIdRepository, createAttachment usecase.CreateAttachmentRepository) *UploadAttachmentController {
+	return &UploadAttachmentController{
+		Storage:                       storage,
+		FindTransactionByIdRepository: findTransactionById,
+		CreateAttachmentRepository:    createAttachment,
+	}
+}
+
+// Handle processes the HTTP request to upload an attachment, sent as the "file" field of a multipart form
+func (c *UploadAttachmentController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+
+	transaction, response := findTransaction(c.FindTransactionByIdRepository, r, workspaceId)
+	if response != nil {
+		return response
+	}
+
+	mainCount, response := parseInstallment(r, transaction)
+	if response != nil {
+		return response
+	}
+
+	r.Req.Body = http.MaxBytesReader(nil, r.Req.Body, maxAttachmentSize+1<<20)
+	if err := r.Req.ParseMultipartForm(maxAttachmentSize); err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "file exceeds the maximum size of " + strconv.Itoa(maxAttachmentSize>>20) + " MB",
+			}, http.StatusRequestEntityTooLarge)
+		}
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid multipart form",
+		}, http.StatusBadRequest)
+	}
+	defer r.Req.MultipartForm.RemoveAll()
+
+	file, header, err := r.Req.FormFile("file")
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "file is required",
+		}, http.StatusBadRequest)
+	}
+	defer file.Close()
+
+	if header.Size > maxAttachmentSize {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "file exceeds the maximum size of " + strconv.Itoa(maxAttachmentSize>>20) + " MB",
+		}, http.StatusRequestEntityTooLarge)
+	}
+
+	// the type is detected from the content, the one sent by the client is not trusted
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "file is empty or could not be read",
+		}, http.StatusBadRequest)
+	}
+	head = head[:n]
+
+	detected, _, _ := strings.Cut(http.DetectContentType(head), ";")
+	contentType, allowed := allowedContentTypes[detected]
+	if !allowed {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "unsupported file type " + detected + ", expected PDF, JPEG, PNG, WEBP or XML",
+		}, http.StatusUnsupportedMediaType)
+	}
+
+	attachment := &models.Attachment{
+		Id:            primitive.NewObjectID(),
+		WorkspaceId:   workspaceId,
+		TransactionId: transaction.Id,
+		MainCount:     mainCount,
+		FileName:      filepath.Base(header.Filename),
+		ContentType:   contentType,
+		Size:          header.Size,
+		CreatedBy:     userId,
+	}
+	attachment.StorageKey = workspaceId.Hex() + "/" + attachment.Id.Hex()
+
+	if err := c.Storage.Save(attachment.StorageKey, io.MultiReader(bytes.NewReader(head), file)); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when storing the file: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	created, err := c.CreateAttachmentRepository.Create(attachment)
+	if err != nil {
+		c.Storage.Delete(attachment.StorageKey)
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when creating attachment: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(created, http.StatusCreated)
+}
+
+func findTransaction(findTransactionById usecase.FindTransactionByIdRepository, r presentationProtocols.HttpRequest, workspaceId primitive.ObjectID) (*models.Transaction, *presentationProtocols.HttpResponse) {
+	transactionId, err := primitive.ObjectIDFromHex(r.Req.PathValue("id"))
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid transaction ID format",
+		}, http.StatusBadRequest)
+	}
+
+	transaction, err := findTransactionById.Find(transactionId, workspaceId)
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding transaction",
+		}, http.StatusInternalServerError)
+	}
+
+	if transaction == nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transaction not found",
+		}, http.StatusNotFound)
+	}
+
+	return transaction, nil
+}
+
+// parseInstallment reads the "installment" query param, the number of the installment of a repeated
+// transaction, as in edit_transaction
+func parseInstallment(r presentationProtocols.HttpRequest, transaction *models.Transaction) (*int, *presentationProtocols.HttpResponse) {
+	value := r.UrlParams.Get("installment")
+	if value == "" {
+		return nil, nil
+	}
+
+	installment, err := strconv.Atoi(value)
+	if err != nil || installment < 1 {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "installment must be a positive number",
+		}, http.StatusBadRequest)
+	}
+
+	if transaction.Frequency == "DO_NOT_REPEAT" {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transaction has no installments",
+		}, http.StatusBadRequest)
+	}
+
+	if transaction.Frequency == "REPEAT" && transaction.RepeatSettings != nil && installment > transaction.RepeatSettings.Count {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "installment is greater than the number of installments of the transaction",
+		}, http.StatusBadRequest)
+	}
+
+	return &installment, nil
+}
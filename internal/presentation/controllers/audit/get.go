@@ -0,0 +1,142 @@
+package audit
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultAuditLogsLimit = 100
+	maxAuditLogsLimit     = 500
+)
+
+type GetAuditLogsController struct {
+	FindAuditLogsRepository usecase.FindAuditLogsRepository
+	Validate                *validator.Validate
+}
+
+func NewGetAuditLogsController(findAuditLogsRepository usecase.FindAuditLogsRepository) *GetAuditLogsController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetAuditLogsController{
+		FindAuditLogsRepository: findAuditLogsRepository,
+		Validate:                validate,
+	}
+}
+
+type GetAuditLogsParams struct {
+	Entity   string `validate:"omitempty,oneof=TRANSACTION ACCOUNT CATEGORY SUB_CATEGORY"`
+	EntityId string `validate:"omitempty,mongodb"`
+	Limit    int    `validate:"min=1,max=500"`
+	Offset   int    `validate:"min=0"`
+}
+
+type GetAuditLogsResponse struct {
+	Logs  []models.AuditLog `json:"logs"`
+	Total int64             `json:"total"`
+}
+
+func (c *GetAuditLogsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	params := &GetAuditLogsParams{
+		Entity:   strings.ToUpper(r.UrlParams.Get("entity")),
+		EntityId: r.UrlParams.Get("entityId"),
+		Limit:    defaultAuditLogsLimit,
+	}
+
+	if value := r.UrlParams.Get("limit"); value != "" {
+		if params.Limit, err = strconv.Atoi(value); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "limit must be a number between 1 and " + strconv.Itoa(maxAuditLogsLimit),
+			}, http.StatusBadRequest)
+		}
+	}
+	if value := r.UrlParams.Get("offset"); value != "" {
+		if params.Offset, err = strconv.Atoi(value); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "offset must be a number",
+			}, http.StatusBadRequest)
+		}
+	}
+
+	if err := c.Validate.Struct(params); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusBadRequest)
+	}
+
+	filters := &usecase.FindAuditLogsInputRepository{
+		WorkspaceId: workspaceId,
+		Entity:      params.Entity,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	}
+
+	if params.EntityId != "" {
+		entityId, _ := primitive.ObjectIDFromHex(params.EntityId)
+		filters.EntityId = &entityId
+	}
+
+	if value := r.UrlParams.Get("from"); value != "" {
+		if filters.From, err = parseAuditDate(value, false); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "invalid from date, use YYYY-MM-DD or RFC 3339",
+			}, http.StatusBadRequest)
+		}
+	}
+	if value := r.UrlParams.Get("to"); value != "" {
+		if filters.To, err = parseAuditDate(value, true); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "invalid to date, use YYYY-MM-DD or RFC 3339",
+			}, http.StatusBadRequest)
+		}
+	}
+
+	if !filters.From.IsZero() && !filters.To.IsZero() && filters.To.Before(filters.From) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "to date must be after from date",
+		}, http.StatusBadRequest)
+	}
+
+	logs, total, err := c.FindAuditLogsRepository.Find(filters)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving audit logs",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(&GetAuditLogsResponse{
+		Logs:  logs,
+		Total: total,
+	}, http.StatusOK)
+}
+
+// parseAuditDate accepts a plain date, which covers the whole day when it is the end of the range
+func parseAuditDate(value string, endOfDay bool) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		if endOfDay {
+			return date.Add(24*time.Hour - time.Nanosecond), nil
+		}
+		return date, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.UTC(), nil
+}
@@ -0,0 +1,145 @@
+package budget
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateBudgetController handles creating category and sub-category budgets
+type CreateBudgetController struct {
+	Validate                       *validator.Validate
+	CreateBudgetRepository         usecase.CreateBudgetRepository
+	FindBudgetByCategoryRepository usecase.FindBudgetByCategoryRepository
+	FindCategoryByIdRepository     usecase.FindCategoryByIdRepository
+}
+
+// NewCreateBudgetController creates a new instance of CreateBudgetController
+func NewCreateBudgetController(
+	createBudget usecase.CreateBudgetRepository,
+	findBudgetByCategory usecase.FindBudgetByCategoryRepository,
+	findCategoryById usecase.FindCategoryByIdRepository,
+) *CreateBudgetController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &CreateBudgetController{
+		Validate:                       validate,
+		CreateBudgetRepository:         createBudget,
+		FindBudgetByCategoryRepository: findBudgetByCategory,
+		FindCategoryByIdRepository:     findCategoryById,
+	}
+}
+
+// CreateBudgetBody represents the limit of a category, or of one of its sub-categories
+type CreateBudgetBody struct {
+	CategoryId    string       `json:"categoryId" validate:"required,mongodb"`
+	SubCategoryId string       `json:"subCategoryId" validate:"omitempty,mongodb"`
+	Interval      string       `json:"interval" validate:"required,oneof=MONTHLY YEARLY"`
+	Amount        models.Money `json:"amount" validate:"required,gt=0,max=1000000000000000000"`
+	CarryOver     bool         `json:"carryOver"`
+}
+
+// Handle processes the HTTP request to create a budget
+func (c *CreateBudgetController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body CreateBudgetBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	categoryId, _ := primitive.ObjectIDFromHex(body.CategoryId)
+
+	var subCategoryId *primitive.ObjectID
+	if body.SubCategoryId != "" {
+		id, _ := primitive.ObjectIDFromHex(body.SubCategoryId)
+		subCategoryId = &id
+	}
+
+	if errResponse := validateBudgetCategory(c.FindCategoryByIdRepository, categoryId, subCategoryId, workspaceId); errResponse != nil {
+		return errResponse
+	}
+
+	existing, err := c.FindBudgetByCategoryRepository.Find(categoryId, subCategoryId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when checking for existing budget",
+		}, http.StatusInternalServerError)
+	}
+	if existing != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "a budget for this category already exists",
+		}, http.StatusConflict)
+	}
+
+	budget, err := c.CreateBudgetRepository.Create(&models.Budget{
+		WorkspaceId:   workspaceId,
+		CategoryId:    categoryId,
+		SubCategoryId: subCategoryId,
+		Interval:      body.Interval,
+		Amount:        body.Amount,
+		CarryOver:     body.CarryOver,
+		CreatedBy:     userId,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when creating budget",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(budget, http.StatusCreated)
+}
+
+// validateBudgetCategory checks that the category exists in the workspace and owns the sub-category
+func validateBudgetCategory(findCategoryById usecase.FindCategoryByIdRepository, categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID, workspaceId primitive.ObjectID) *presentationProtocols.HttpResponse {
+	category, err := findCategoryById.Find(categoryId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving category",
+		}, http.StatusInternalServerError)
+	}
+	if category == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "category not found",
+		}, http.StatusNotFound)
+	}
+
+	if subCategoryId == nil {
+		return nil
+	}
+
+	for _, subCategory := range category.SubCategories {
+		if subCategory.Id == *subCategoryId {
+			return nil
+		}
+	}
+
+	return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		Error: "sub category not found",
+	}, http.StatusNotFound)
+}
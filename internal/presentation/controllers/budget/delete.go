@@ -0,0 +1,50 @@
+package budget
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteBudgetController handles deleting budgets
+type DeleteBudgetController struct {
+	DeleteBudgetRepository usecase.DeleteBudgetRepository
+}
+
+// NewDeleteBudgetController creates a new instance of DeleteBudgetController
+func NewDeleteBudgetController(deleteBudget usecase.DeleteBudgetRepository) *DeleteBudgetController {
+	return &DeleteBudgetController{DeleteBudgetRepository: deleteBudget}
+}
+
+// Handle processes the HTTP request to delete budgets
+func (c *DeleteBudgetController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var ids []primitive.ObjectID
+	for _, id := range strings.Split(r.UrlParams.Get("ids"), ",") {
+		objectId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "invalid budget ID format",
+			}, http.StatusBadRequest)
+		}
+		ids = append(ids, objectId)
+	}
+
+	if err := c.DeleteBudgetRepository.Delete(ids, workspaceId); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when deleting budgets",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(nil, http.StatusNoContent)
+}
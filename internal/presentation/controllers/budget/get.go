@@ -0,0 +1,64 @@
+package budget
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetBudgetsController handles listing budgets and tracking them against the actual amounts of a month
+type GetBudgetsController struct {
+	Validate                     *validator.Validate
+	FindBudgetsRepository        usecase.FindBudgetsRepository
+	FindBudgetTrackingRepository usecase.FindBudgetTrackingRepository
+}
+
+// NewGetBudgetsController creates a new instance of GetBudgetsController
+func NewGetBudgetsController(findBudgets usecase.FindBudgetsRepository, findBudgetTracking usecase.FindBudgetTrackingRepository) *GetBudgetsController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetBudgetsController{
+		Validate:                     validate,
+		FindBudgetsRepository:        findBudgets,
+		FindBudgetTrackingRepository: findBudgetTracking,
+	}
+}
+
+// Handle processes the HTTP request to retrieve budgets. With month and year, it returns the
+// planned, forecast and realized amounts of each budget for that period.
+func (c *GetBudgetsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	globalFilters, httpResponse := helpers.GetGlobalFilterByQueries(&r.UrlParams, workspaceId, c.Validate)
+	if httpResponse != nil {
+		return httpResponse
+	}
+
+	if globalFilters.Month == 0 {
+		budgets, err := c.FindBudgetsRepository.Find(workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving budgets",
+			}, http.StatusInternalServerError)
+		}
+
+		return helpers.CreateResponse(budgets, http.StatusOK)
+	}
+
+	trackings, err := c.FindBudgetTrackingRepository.Find(workspaceId, globalFilters.Year, globalFilters.Month)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when tracking budgets",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(trackings, http.StatusOK)
+}
@@ -0,0 +1,84 @@
+package budget
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateBudgetController handles updating the limit of a budget
+type UpdateBudgetController struct {
+	Validate               *validator.Validate
+	UpdateBudgetRepository usecase.UpdateBudgetRepository
+}
+
+// NewUpdateBudgetController creates a new instance of UpdateBudgetController
+func NewUpdateBudgetController(updateBudget usecase.UpdateBudgetRepository) *UpdateBudgetController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &UpdateBudgetController{
+		Validate:               validate,
+		UpdateBudgetRepository: updateBudget,
+	}
+}
+
+// UpdateBudgetBody represents the new limit of a budget
+type UpdateBudgetBody struct {
+	Interval  string       `json:"interval" validate:"required,oneof=MONTHLY YEARLY"`
+	Amount    models.Money `json:"amount" validate:"required,gt=0,max=1000000000000000000"`
+	CarryOver bool         `json:"carryOver"`
+}
+
+// Handle processes the HTTP request to update a budget
+func (c *UpdateBudgetController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("budgetId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid budget ID format",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var body UpdateBudgetBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	budget, err := c.UpdateBudgetRepository.Update(id, &models.Budget{
+		WorkspaceId: workspaceId,
+		Interval:    body.Interval,
+		Amount:      body.Amount,
+		CarryOver:   body.CarryOver,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when updating budget",
+		}, http.StatusInternalServerError)
+	}
+	if budget == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "budget not found",
+		}, http.StatusNotFound)
+	}
+
+	return helpers.CreateResponse(budget, http.StatusOK)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -18,6 +19,7 @@ type CreateCategoryController struct {
 	FindAccountById              usecase.FindAccountByIdRepository
 	FindCategoriesRepository     usecase.FindCategoriesRepository
 	FindCategoryByNameRepository usecase.FindCategoryByNameAndTypeRepository
+	CreateAuditLogRepository     usecase.CreateAuditLogRepository
 }
 
 func NewCreateCategoryController(
@@ -25,6 +27,7 @@ func NewCreateCategoryController(
 	findAccountById usecase.FindAccountByIdRepository,
 	findCategorysByWorkspaceId usecase.FindCategoriesRepository,
 	findCategoryByName usecase.FindCategoryByNameAndTypeRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *CreateCategoryController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -34,6 +37,7 @@ func NewCreateCategoryController(
 		FindAccountById:              findAccountById,
 		FindCategoriesRepository:     findCategorysByWorkspaceId,
 		FindCategoryByNameRepository: findCategoryByName,
+		CreateAuditLogRepository:     createAuditLog,
 	}
 }
 
@@ -137,5 +141,10 @@ func (c *CreateCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewAuditLog(workspaceId, userId, "CATEGORY", category.Id, "CREATE", nil, category),
+	))
+
 	return helpers.CreateResponse(category, http.StatusOK)
 }
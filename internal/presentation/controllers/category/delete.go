@@ -4,19 +4,25 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DeleteCategoryController struct {
-	DeleteCategoryRepository usecase.DeleteCategoryRepository
+	DeleteCategoryRepository   usecase.DeleteCategoryRepository
+	FindCategoryByIdRepository usecase.FindCategoryByIdRepository
+	CreateAuditLogRepository   usecase.CreateAuditLogRepository
 }
 
-func NewDeleteCategoryController(deleteCategory usecase.DeleteCategoryRepository) *DeleteCategoryController {
+func NewDeleteCategoryController(deleteCategory usecase.DeleteCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *DeleteCategoryController {
 	return &DeleteCategoryController{
-		DeleteCategoryRepository: deleteCategory,
+		DeleteCategoryRepository:   deleteCategory,
+		FindCategoryByIdRepository: findCategoryById,
+		CreateAuditLogRepository:   createAuditLog,
 	}
 }
 
@@ -42,6 +48,20 @@ func (c *DeleteCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		idsObjectID = append(idsObjectID, objectID)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+	for _, id := range idsObjectID {
+		category, err := c.FindCategoryByIdRepository.Find(id, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Ocorreu um erro ao buscar a categoria: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if category != nil {
+			auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "CATEGORY", id, "DELETE", category, nil))
+		}
+	}
+
 	err = c.DeleteCategoryRepository.Delete(idsObjectID, workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -49,5 +69,7 @@ func (c *DeleteCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		}, http.StatusInternalServerError)
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(nil, http.StatusNoContent)
 }
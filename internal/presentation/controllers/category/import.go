@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -17,12 +18,14 @@ type ImportCategoryController struct {
 	Validate                     *validator.Validate
 	FindCategoriesRepository     usecase.FindCategoriesRepository
 	FindCategoryByNameRepository usecase.FindCategoryByNameAndTypeRepository
+	CreateAuditLogRepository     usecase.CreateAuditLogRepository
 }
 
 func NewImportCategoryController(
 	importUseCase usecase.ImportCategoriesRepository,
 	findCategorys usecase.FindCategoriesRepository,
 	findCategoryByName usecase.FindCategoryByNameAndTypeRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *ImportCategoryController {
 	validate := validator.New()
 
@@ -31,6 +34,7 @@ func NewImportCategoryController(
 		Validate:                     validate,
 		FindCategoriesRepository:     findCategorys,
 		FindCategoryByNameRepository: findCategoryByName,
+		CreateAuditLogRepository:     createAuditLog,
 	}
 }
 
@@ -146,5 +150,13 @@ func (c *ImportCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := make([]models.AuditLog, 0, len(importedCategories))
+	for i := range importedCategories {
+		category := &importedCategories[i]
+		auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "CATEGORY", category.Id, "CREATE", nil, category))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(importedCategories, http.StatusOK)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -17,15 +18,17 @@ type CreateSubCategoryController struct {
 	UpdateCategoryRepository usecase.UpdateCategoryRepository
 	Validate                 *validator.Validate
 	FindCategoryById         usecase.FindCategoryByIdRepository
+	CreateAuditLogRepository usecase.CreateAuditLogRepository
 }
 
-func NewCreateSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository) *CreateSubCategoryController {
+func NewCreateSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *CreateSubCategoryController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &CreateSubCategoryController{
 		UpdateCategoryRepository: updateCategory,
 		Validate:                 validate,
 		FindCategoryById:         findCategoryById,
+		CreateAuditLogRepository: createAuditLog,
 	}
 }
 
@@ -100,5 +103,10 @@ func (c *CreateSubCategoryController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewSubCategoryAuditLog(workspaceId, userId, categoryId, "CREATE", nil, subCategory),
+	))
+
 	return helpers.CreateResponse(subCategory, http.StatusOK)
 }
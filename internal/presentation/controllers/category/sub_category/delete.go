@@ -2,9 +2,12 @@ package sub_category
 
 import (
 	"net/http"
+	"slices"
 	"strings"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,11 +15,15 @@ import (
 
 type DeleteSubCategoryController struct {
 	UpdateCategoryRepository usecase.UpdateCategoryRepository
+	FindCategoryById         usecase.FindCategoryByIdRepository
+	CreateAuditLogRepository usecase.CreateAuditLogRepository
 }
 
-func NewDeleteSubCategoryController(updateCategory usecase.UpdateCategoryRepository) *DeleteSubCategoryController {
+func NewDeleteSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *DeleteSubCategoryController {
 	return &DeleteSubCategoryController{
 		UpdateCategoryRepository: updateCategory,
+		FindCategoryById:         findCategoryById,
+		CreateAuditLogRepository: createAuditLog,
 	}
 }
 
@@ -49,6 +56,23 @@ func (c *DeleteSubCategoryController) Handle(r presentationProtocols.HttpRequest
 		idsObjectID = append(idsObjectID, objectID)
 	}
 
+	category, err := c.FindCategoryById.Find(categoryId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Ocorreu um erro ao buscar a categoria.",
+		}, http.StatusInternalServerError)
+	}
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+	if category != nil {
+		for i := range category.SubCategories {
+			if slices.Contains(idsObjectID, category.SubCategories[i].Id) {
+				auditLogs = append(auditLogs, infraHelpers.NewSubCategoryAuditLog(workspaceId, userId, categoryId, "DELETE", &category.SubCategories[i], nil))
+			}
+		}
+	}
+
 	err = c.UpdateCategoryRepository.DeleteSubCategory(idsObjectID, categoryId, workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -56,5 +80,7 @@ func (c *DeleteSubCategoryController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusInternalServerError)
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(nil, http.StatusNoContent)
 }
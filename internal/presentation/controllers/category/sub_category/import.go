@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -16,15 +17,17 @@ type ImportSubCategoryController struct {
 	UpdateCategoryRepository   usecase.UpdateCategoryRepository
 	Validate                   *validator.Validate
 	FindCategoryByIdRepository usecase.FindCategoryByIdRepository
+	CreateAuditLogRepository   usecase.CreateAuditLogRepository
 }
 
-func NewImportSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository) *ImportSubCategoryController {
+func NewImportSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *ImportSubCategoryController {
 	validate := validator.New()
 
 	return &ImportSubCategoryController{
 		UpdateCategoryRepository:   updateCategory,
 		Validate:                   validate,
 		FindCategoryByIdRepository: findCategoryById,
+		CreateAuditLogRepository:   createAuditLog,
 	}
 }
 
@@ -119,12 +122,19 @@ func (c *ImportSubCategoryController) Handle(r presentationProtocols.HttpRequest
 		})
 	}
 
-	_, err = c.UpdateCategoryRepository.CreateSubCategories(subCategoryInputs, categoryId, workspaceId)
+	importedSubCategories, err := c.UpdateCategoryRepository.CreateSubCategories(subCategoryInputs, categoryId, workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "Erro ao importar subcategorias: " + err.Error(),
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := make([]models.AuditLog, 0, len(importedSubCategories))
+	for i := range importedSubCategories {
+		auditLogs = append(auditLogs, infraHelpers.NewSubCategoryAuditLog(workspaceId, userId, categoryId, "CREATE", nil, &importedSubCategories[i]))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(subCategoryInputs, http.StatusOK)
 }
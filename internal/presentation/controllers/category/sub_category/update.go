@@ -6,6 +6,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -16,15 +17,17 @@ type UpdateSubCategoryController struct {
 	UpdateCategoryRepository usecase.UpdateCategoryRepository
 	Validate                 *validator.Validate
 	FindCategoryById         usecase.FindCategoryByIdRepository
+	CreateAuditLogRepository usecase.CreateAuditLogRepository
 }
 
-func NewUpdateSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository) *UpdateSubCategoryController {
+func NewUpdateSubCategoryController(updateCategory usecase.UpdateCategoryRepository, findCategoryById usecase.FindCategoryByIdRepository, createAuditLog usecase.CreateAuditLogRepository) *UpdateSubCategoryController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &UpdateSubCategoryController{
 		UpdateCategoryRepository: updateCategory,
 		Validate:                 validate,
 		FindCategoryById:         findCategoryById,
+		CreateAuditLogRepository: createAuditLog,
 	}
 }
 
@@ -130,5 +133,14 @@ func (c *UpdateSubCategoryController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusInternalServerError)
 	}
 
+	updated := *currentSubCategory
+	updated.Name = body.Name
+	updated.Icon = body.Icon
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewSubCategoryAuditLog(workspaceId, userId, categoryId, "UPDATE", currentSubCategory, &updated),
+	))
+
 	return helpers.CreateResponse(nil, http.StatusOK)
 }
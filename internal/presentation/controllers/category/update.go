@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -16,12 +17,14 @@ type UpdateCategoryController struct {
 	Validate                     *validator.Validate
 	FindCategoryById             usecase.FindCategoryByIdRepository
 	FindCategoryByNameRepository usecase.FindCategoryByNameAndTypeRepository
+	CreateAuditLogRepository     usecase.CreateAuditLogRepository
 }
 
 func NewUpdateCategoryController(
 	updateCategory usecase.UpdateCategoryRepository,
 	findCategoryById usecase.FindCategoryByIdRepository,
 	findCategoryByName usecase.FindCategoryByNameAndTypeRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *UpdateCategoryController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -30,6 +33,7 @@ func NewUpdateCategoryController(
 		Validate:                     validate,
 		FindCategoryById:             findCategoryById,
 		FindCategoryByNameRepository: findCategoryByName,
+		CreateAuditLogRepository:     createAuditLog,
 	}
 }
 
@@ -90,6 +94,7 @@ func (c *UpdateCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		}
 	}
 
+	previous := *category
 	category.Name = body.Name
 	category.Type = body.Type
 	category.Icon = body.Icon
@@ -101,5 +106,10 @@ func (c *UpdateCategoryController) Handle(r presentationProtocols.HttpRequest) *
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(
+		infraHelpers.NewAuditLog(workspaceId, userId, "CATEGORY", categoryId, "UPDATE", &previous, category),
+	))
+
 	return helpers.CreateResponse(category, http.StatusOK)
 }
@@ -34,11 +34,11 @@ func NewCreateCreditCardController(
 
 // CreateCreditCardControllerBody defines the expected body for creating a credit card
 type CreateCreditCardControllerBody struct {
-	Name      string  `json:"name" validate:"required,min=3,max=255"`
-	DueDate   int     `json:"dueDate" validate:"required,min=1,max=31"`
-	CloseDate int     `json:"closeDate" validate:"required,min=1,max=31"`
-	Limit     float64 `json:"limit" validate:"min=0"`
-	Flag      string  `json:"flag" validate:"required,oneof=MASTERCARD VISA ELO HIPERCARD UNIONPAY AURA"`
+	Name      string       `json:"name" validate:"required,min=3,max=255"`
+	DueDate   int          `json:"dueDate" validate:"required,min=1,max=31"`
+	CloseDate int          `json:"closeDate" validate:"required,min=1,max=31"`
+	Limit     models.Money `json:"limit" validate:"min=0"`
+	Flag      string       `json:"flag" validate:"required,oneof=MASTERCARD VISA ELO HIPERCARD UNIONPAY AURA"`
 }
 
 // Handle processes the HTTP request for creating a credit card
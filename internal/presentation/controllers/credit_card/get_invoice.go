@@ -0,0 +1,82 @@
+package credit_card
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetCreditCardInvoiceController handles retrieving the invoice of a credit card statement
+type GetCreditCardInvoiceController struct {
+	FindCreditCardByIdRepository    usecase.FindCreditCardByIdRepository
+	FindCreditCardInvoiceRepository usecase.FindCreditCardInvoiceRepository
+	Validate                        *validator.Validate
+}
+
+// NewGetCreditCardInvoiceController creates a new instance of GetCreditCardInvoiceController
+func NewGetCreditCardInvoiceController(findCreditCardByIdRepository usecase.FindCreditCardByIdRepository, findCreditCardInvoiceRepository usecase.FindCreditCardInvoiceRepository) *GetCreditCardInvoiceController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetCreditCardInvoiceController{
+		FindCreditCardByIdRepository:    findCreditCardByIdRepository,
+		FindCreditCardInvoiceRepository: findCreditCardInvoiceRepository,
+		Validate:                        validate,
+	}
+}
+
+// GetCreditCardInvoiceParams holds the statement the invoice is requested for
+type GetCreditCardInvoiceParams struct {
+	Month int `validate:"required,min=1,max=12"`
+	Year  int `validate:"required,min=1,max=9999"`
+}
+
+// Handle processes the HTTP request to retrieve a credit card invoice
+func (c *GetCreditCardInvoiceController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("creditCardId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid credit card ID format",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	month, _ := strconv.Atoi(r.UrlParams.Get("month"))
+	year, _ := strconv.Atoi(r.UrlParams.Get("year"))
+	params := &GetCreditCardInvoiceParams{Month: month, Year: year}
+	if err := c.Validate.Struct(params); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid month or year: " + err.Error(),
+		}, http.StatusBadRequest)
+	}
+
+	card, err := c.FindCreditCardByIdRepository.Find(id, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving credit card",
+		}, http.StatusInternalServerError)
+	}
+	if card == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "credit card not found",
+		}, http.StatusNotFound)
+	}
+
+	invoice, err := c.FindCreditCardInvoiceRepository.Find(card, params.Year, params.Month)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving credit card invoice",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(invoice, http.StatusOK)
+}
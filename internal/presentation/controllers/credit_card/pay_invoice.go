@@ -0,0 +1,286 @@
+package credit_card
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PayCreditCardInvoiceController handles settling a credit card statement from an account
+type PayCreditCardInvoiceController struct {
+	FindCreditCardByIdRepository    usecase.FindCreditCardByIdRepository
+	FindCreditCardInvoiceRepository usecase.FindCreditCardInvoiceRepository
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	PayCreditCardInvoiceRepository  usecase.PayCreditCardInvoiceRepository
+	CreateAuditLogRepository        usecase.CreateAuditLogRepository
+	Validate                        *validator.Validate
+}
+
+// NewPayCreditCardInvoiceController creates a new instance of PayCreditCardInvoiceController
+func NewPayCreditCardInvoiceController(
+	findCreditCardById usecase.FindCreditCardByIdRepository,
+	findCreditCardInvoice usecase.FindCreditCardInvoiceRepository,
+	findAccountById usecase.FindAccountByIdRepository,
+	payCreditCardInvoice usecase.PayCreditCardInvoiceRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+) *PayCreditCardInvoiceController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &PayCreditCardInvoiceController{
+		FindCreditCardByIdRepository:    findCreditCardById,
+		FindCreditCardInvoiceRepository: findCreditCardInvoice,
+		FindAccountByIdRepository:       findAccountById,
+		PayCreditCardInvoiceRepository:  payCreditCardInvoice,
+		CreateAuditLogRepository:        createAuditLog,
+		Validate:                        validate,
+	}
+}
+
+// PayCreditCardInvoiceBody represents the payment of a statement. When Amount is lower than the
+// invoice total, the remainder is carried to the next statement with the optional interest.
+type PayCreditCardInvoiceBody struct {
+	AccountId          string       `json:"accountId" validate:"required,mongodb"`
+	Amount             models.Money `json:"amount" validate:"omitempty,gt=0"`
+	PaymentDate        string       `json:"paymentDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
+	Interest           models.Money `json:"interest" validate:"omitempty,gt=0"`
+	InterestPercentage float64      `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
+}
+
+// PayCreditCardInvoiceResponse is returned after a statement is paid
+type PayCreditCardInvoiceResponse struct {
+	Payment              *models.CreditCardInvoicePayment `json:"payment"`
+	PaymentTransaction   *models.Transaction              `json:"paymentTransaction"`
+	RemainderTransaction *models.Transaction              `json:"remainderTransaction,omitempty"`
+	Invoice              *models.CreditCardInvoice        `json:"invoice"`
+}
+
+// Handle processes the HTTP request to pay a credit card invoice
+func (c *PayCreditCardInvoiceController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body PayCreditCardInvoiceBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	creditCardId, err := primitive.ObjectIDFromHex(r.Req.PathValue("creditCardId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid credit card ID format",
+		}, http.StatusBadRequest)
+	}
+
+	period, err := time.Parse("2006-01", r.Req.PathValue("period"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid period format, expected YYYY-MM",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	accountId, err := primitive.ObjectIDFromHex(body.AccountId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid account ID format",
+		}, http.StatusBadRequest)
+	}
+
+	paymentDate, err := time.ParseInLocation("2006-01-02T15:04:05Z", body.PaymentDate, time.UTC)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid payment date format",
+		}, http.StatusBadRequest)
+	}
+
+	card, err := c.FindCreditCardByIdRepository.Find(creditCardId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving credit card",
+		}, http.StatusInternalServerError)
+	}
+	if card == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "credit card not found",
+		}, http.StatusNotFound)
+	}
+
+	account, err := c.FindAccountByIdRepository.Find(accountId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving account",
+		}, http.StatusInternalServerError)
+	}
+	if account == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "account not found",
+		}, http.StatusNotFound)
+	}
+
+	invoice, err := c.FindCreditCardInvoiceRepository.Find(card, period.Year(), int(period.Month()))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving credit card invoice",
+		}, http.StatusInternalServerError)
+	}
+
+	if invoice.Status == "PAID" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invoice is already paid",
+		}, http.StatusConflict)
+	}
+
+	// only what is still open is paid, purchases already settled by a previous payment are skipped
+	var pending []models.Transaction
+	var total models.Money
+	for _, tx := range invoice.Transactions {
+		if tx.IsConfirmed {
+			continue
+		}
+		pending = append(pending, tx)
+		total -= infraHelpers.CalculateOneTransactionBalance(&tx)
+	}
+
+	if len(pending) == 0 || total <= 0 {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invoice has no amount to be paid",
+		}, http.StatusBadRequest)
+	}
+
+	amount := total
+	if body.Amount > 0 {
+		amount = body.Amount
+	}
+
+	if amount > total {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "payment amount exceeds the invoice total",
+		}, http.StatusBadRequest)
+	}
+
+	paymentTransaction := &models.Transaction{
+		Name:             "Fatura " + card.Name,
+		Description:      "Pagamento da fatura " + invoice.Period + " do cartão " + card.Name,
+		CreatedBy:        userId,
+		Type:             "EXPENSE",
+		Supplier:         card.Name,
+		AssignedTo:       userId,
+		Balance:          models.TransactionBalance{Value: amount},
+		Frequency:        "DO_NOT_REPEAT",
+		RepeatSettings:   &models.TransactionRepeatSettings{},
+		DueDate:          invoice.DueDate,
+		IsConfirmed:      true,
+		Tags:             []models.TransactionTags{},
+		AccountId:        &accountId,
+		RegistrationDate: paymentDate,
+		ConfirmationDate: &paymentDate,
+		WorkspaceId:      workspaceId,
+		CustomFields:     []models.TransactionCustomField{},
+	}
+
+	payment := &models.CreditCardInvoicePayment{
+		WorkspaceId:  workspaceId,
+		CreditCardId: creditCardId,
+		Period:       invoice.Period,
+		AccountId:    accountId,
+		Amount:       amount,
+		InvoiceTotal: total,
+		PaymentDate:  paymentDate,
+		CreatedBy:    userId,
+	}
+
+	var remainderTransaction *models.Transaction
+	if remainder := total - amount; remainder > 0 {
+		// the remainder becomes a purchase on the first day of the next statement
+		nextOpenDate := time.Date(invoice.CloseDate.Year(), invoice.CloseDate.Month(), invoice.CloseDate.Day()+1, 0, 0, 0, 0, time.UTC)
+
+		remainderTransaction = &models.Transaction{
+			Name:        "Saldo da fatura " + invoice.Period,
+			Description: "Saldo restante da fatura " + invoice.Period + " do cartão " + card.Name,
+			CreatedBy:   userId,
+			Type:        "EXPENSE",
+			Supplier:    card.Name,
+			AssignedTo:  userId,
+			Balance: models.TransactionBalance{
+				Value:              remainder,
+				Interest:           body.Interest,
+				InterestPercentage: body.InterestPercentage,
+			},
+			Frequency:        "DO_NOT_REPEAT",
+			RepeatSettings:   &models.TransactionRepeatSettings{},
+			DueDate:          nextOpenDate,
+			Tags:             []models.TransactionTags{},
+			CreditCardId:     &creditCardId,
+			RegistrationDate: paymentDate,
+			WorkspaceId:      workspaceId,
+			CustomFields:     []models.TransactionCustomField{},
+		}
+
+		payment.RemainderAmount = remainder
+	}
+
+	payment, err = c.PayCreditCardInvoiceRepository.Pay(pending, paymentDate, paymentTransaction, remainderTransaction, payment)
+	if errors.Is(err, usecase.ErrInvoiceAlreadyPaid) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invoice is already paid",
+		}, http.StatusConflict)
+	}
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when paying invoice",
+		}, http.StatusInternalServerError)
+	}
+
+	auditLogs := make([]models.AuditLog, 0, len(pending)+2)
+	for i := range pending {
+		confirmed := pending[i]
+		confirmed.IsConfirmed = true
+		confirmed.ConfirmationDate = &paymentDate
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &pending[i], &confirmed))
+	}
+	auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, paymentTransaction))
+	if remainderTransaction != nil {
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, remainderTransaction))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	updatedInvoice, err := c.FindCreditCardInvoiceRepository.Find(card, period.Year(), int(period.Month()))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving credit card invoice",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(&PayCreditCardInvoiceResponse{
+		Payment:              payment,
+		PaymentTransaction:   paymentTransaction,
+		RemainderTransaction: remainderTransaction,
+		Invoice:              updatedInvoice,
+	}, http.StatusCreated)
+}
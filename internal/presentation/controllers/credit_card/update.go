@@ -36,12 +36,12 @@ func NewUpdateCreditCardController(
 }
 
 type UpdateCreditCardControllerBody struct {
-	Name      string  `json:"name" validate:"required,min=3,max=255"`
-	DueDate   int     `json:"dueDate" validate:"required,min=1,max=31"`
-	CloseDate int     `json:"closeDate" validate:"required,min=1,max=31"`
-	Limit     float64 `json:"limit" validate:"min=0"`
-	Balance   float64 `json:"balance" validate:"min=0"`
-	Flag      string  `json:"flag" validate:"required,oneof=MASTERCARD VISA ELO HIPERCARD UNIONPAY AURA"`
+	Name      string       `json:"name" validate:"required,min=3,max=255"`
+	DueDate   int          `json:"dueDate" validate:"required,min=1,max=31"`
+	CloseDate int          `json:"closeDate" validate:"required,min=1,max=31"`
+	Limit     models.Money `json:"limit" validate:"min=0"`
+	Balance   models.Money `json:"balance" validate:"min=0"`
+	Flag      string       `json:"flag" validate:"required,oneof=MASTERCARD VISA ELO HIPERCARD UNIONPAY AURA"`
 }
 
 // Handle processes the HTTP request to update a credit card
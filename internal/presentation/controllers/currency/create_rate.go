@@ -0,0 +1,88 @@
+package currency
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CreateExchangeRateController handles entering an exchange rate manually
+type CreateExchangeRateController struct {
+	Validate                    *validator.Validate
+	SaveExchangeRatesRepository usecase.SaveExchangeRatesRepository
+}
+
+// NewCreateExchangeRateController creates a new instance of CreateExchangeRateController
+func NewCreateExchangeRateController(saveExchangeRates usecase.SaveExchangeRatesRepository) *CreateExchangeRateController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &CreateExchangeRateController{
+		Validate:                    validate,
+		SaveExchangeRatesRepository: saveExchangeRates,
+	}
+}
+
+// CreateExchangeRateBody holds how much one unit of from is worth in to on the date. A rate already
+// entered for the same pair and day is replaced.
+type CreateExchangeRateBody struct {
+	From string  `json:"from" validate:"required,iso4217"`
+	To   string  `json:"to" validate:"required,iso4217,nefield=From"`
+	Rate float64 `json:"rate" validate:"required,gt=0"`
+	Date string  `json:"date" validate:"required,datetime=2006-01-02"`
+}
+
+// Handle processes the HTTP request to create an exchange rate
+func (c *CreateExchangeRateController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body CreateExchangeRateBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	body.From = strings.ToUpper(body.From)
+	body.To = strings.ToUpper(body.To)
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	date, _ := time.Parse("2006-01-02", body.Date)
+	rates, err := c.SaveExchangeRatesRepository.Save([]models.ExchangeRate{{
+		WorkspaceId: workspaceId,
+		From:        body.From,
+		To:          body.To,
+		Rate:        body.Rate,
+		Date:        date,
+		CreatedBy:   userId,
+	}})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when saving exchange rate",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(&rates[0], http.StatusCreated)
+}
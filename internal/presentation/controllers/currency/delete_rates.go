@@ -0,0 +1,52 @@
+package currency
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteExchangeRatesController handles deleting exchange rates
+type DeleteExchangeRatesController struct {
+	DeleteExchangeRatesRepository usecase.DeleteExchangeRatesRepository
+}
+
+// NewDeleteExchangeRatesController creates a new instance of DeleteExchangeRatesController
+func NewDeleteExchangeRatesController(deleteExchangeRates usecase.DeleteExchangeRatesRepository) *DeleteExchangeRatesController {
+	return &DeleteExchangeRatesController{
+		DeleteExchangeRatesRepository: deleteExchangeRates,
+	}
+}
+
+// Handle processes the HTTP request to delete the exchange rates given in the ids query parameter
+func (c *DeleteExchangeRatesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var ids []primitive.ObjectID
+	for _, id := range strings.Split(r.UrlParams.Get("ids"), ",") {
+		objectId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "invalid exchange rate ID format",
+			}, http.StatusBadRequest)
+		}
+		ids = append(ids, objectId)
+	}
+
+	if err := c.DeleteExchangeRatesRepository.Delete(ids, workspaceId); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when deleting exchange rates",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(nil, http.StatusNoContent)
+}
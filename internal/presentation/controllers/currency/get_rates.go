@@ -0,0 +1,82 @@
+package currency
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetExchangeRatesController handles listing the exchange rates of a workspace
+type GetExchangeRatesController struct {
+	Validate                    *validator.Validate
+	FindExchangeRatesRepository usecase.FindExchangeRatesRepository
+}
+
+// NewGetExchangeRatesController creates a new instance of GetExchangeRatesController
+func NewGetExchangeRatesController(findExchangeRates usecase.FindExchangeRatesRepository) *GetExchangeRatesController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetExchangeRatesController{
+		Validate:                    validate,
+		FindExchangeRatesRepository: findExchangeRates,
+	}
+}
+
+// GetExchangeRatesParams narrows the rates by pair and date range
+type GetExchangeRatesParams struct {
+	From        string `validate:"omitempty,iso4217"`
+	To          string `validate:"omitempty,iso4217"`
+	InitialDate string `validate:"omitempty,datetime=2006-01-02"`
+	FinalDate   string `validate:"omitempty,datetime=2006-01-02"`
+}
+
+// Handle processes the HTTP request to list exchange rates
+func (c *GetExchangeRatesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	params := &GetExchangeRatesParams{
+		From:        strings.ToUpper(r.UrlParams.Get("from")),
+		To:          strings.ToUpper(r.UrlParams.Get("to")),
+		InitialDate: r.UrlParams.Get("initialDate"),
+		FinalDate:   r.UrlParams.Get("finalDate"),
+	}
+
+	if err := c.Validate.Struct(params); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusBadRequest)
+	}
+
+	filter := &usecase.FindExchangeRatesFilter{
+		WorkspaceId: workspaceId,
+		From:        params.From,
+		To:          params.To,
+	}
+	if params.InitialDate != "" {
+		initialDate, _ := time.Parse("2006-01-02", params.InitialDate)
+		filter.InitialDate = &initialDate
+	}
+	if params.FinalDate != "" {
+		finalDate, _ := time.Parse("2006-01-02", params.FinalDate)
+		filter.FinalDate = &finalDate
+	}
+
+	rates, err := c.FindExchangeRatesRepository.Find(filter)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding exchange rates",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(rates, http.StatusOK)
+}
@@ -0,0 +1,47 @@
+package currency
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetCurrencySettingsController handles reading the base currency of a workspace
+type GetCurrencySettingsController struct {
+	FindCurrencySettingsRepository usecase.FindCurrencySettingsRepository
+}
+
+// NewGetCurrencySettingsController creates a new instance of GetCurrencySettingsController
+func NewGetCurrencySettingsController(findCurrencySettings usecase.FindCurrencySettingsRepository) *GetCurrencySettingsController {
+	return &GetCurrencySettingsController{
+		FindCurrencySettingsRepository: findCurrencySettings,
+	}
+}
+
+// Handle processes the HTTP request to get the currency settings
+func (c *GetCurrencySettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	settings, err := c.FindCurrencySettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding currency settings: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	// a workspace that never chose a base currency keeps the default one
+	if settings == nil {
+		settings = &models.CurrencySettings{WorkspaceId: workspaceId, BaseCurrency: models.DefaultCurrency}
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
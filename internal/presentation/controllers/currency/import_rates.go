@@ -0,0 +1,122 @@
+package currency
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxImportedExchangeRates = 10000
+
+// ImportExchangeRatesController handles importing exchange rates from a CSV file
+type ImportExchangeRatesController struct {
+	Validate                    *validator.Validate
+	SaveExchangeRatesRepository usecase.SaveExchangeRatesRepository
+}
+
+// NewImportExchangeRatesController creates a new instance of ImportExchangeRatesController
+func NewImportExchangeRatesController(saveExchangeRates usecase.SaveExchangeRatesRepository) *ImportExchangeRatesController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &ImportExchangeRatesController{
+		Validate:                    validate,
+		SaveExchangeRatesRepository: saveExchangeRates,
+	}
+}
+
+// ImportExchangeRatesResponse holds the rates saved from the file
+type ImportExchangeRatesResponse struct {
+	Imported int                   `json:"imported"`
+	Rates    []models.ExchangeRate `json:"rates"`
+}
+
+// Handle processes the HTTP request to import a CSV file with the columns date, from, to and rate. Rates
+// already entered for the same pair and day are replaced.
+func (c *ImportExchangeRatesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	if err := r.Req.ParseMultipartForm(32 << 20); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid multipart form",
+		}, http.StatusBadRequest)
+	}
+
+	file, _, err := r.Req.FormFile("file")
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "file is required",
+		}, http.StatusBadRequest)
+	}
+	defer file.Close()
+
+	lines, err := helpers.ParseExchangeRatesCSV(file)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	if len(lines) > maxImportedExchangeRates {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: fmt.Sprintf("the file must have at most %d exchange rates", maxImportedExchangeRates),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	rates := make([]models.ExchangeRate, 0, len(lines))
+	for i, line := range lines {
+		if err := c.Validate.Var(line.From, "iso4217"); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: fmt.Sprintf("invalid currency on rate %d: %s", i+1, line.From),
+			}, http.StatusUnprocessableEntity)
+		}
+		if err := c.Validate.Var(line.To, "iso4217"); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: fmt.Sprintf("invalid currency on rate %d: %s", i+1, line.To),
+			}, http.StatusUnprocessableEntity)
+		}
+		if strings.EqualFold(line.From, line.To) {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: fmt.Sprintf("rate %d converts %s to itself", i+1, line.From),
+			}, http.StatusUnprocessableEntity)
+		}
+
+		rates = append(rates, models.ExchangeRate{
+			WorkspaceId: workspaceId,
+			From:        line.From,
+			To:          line.To,
+			Rate:        line.Rate,
+			Date:        line.Date,
+			CreatedBy:   userId,
+		})
+	}
+
+	saved, err := c.SaveExchangeRatesRepository.Save(rates)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when saving exchange rates",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(&ImportExchangeRatesResponse{
+		Imported: len(saved),
+		Rates:    saved,
+	}, http.StatusOK)
+}
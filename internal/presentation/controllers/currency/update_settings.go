@@ -0,0 +1,68 @@
+package currency
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateCurrencySettingsController handles changing the base currency of a workspace
+type UpdateCurrencySettingsController struct {
+	Validate                       *validator.Validate
+	SaveCurrencySettingsRepository usecase.SaveCurrencySettingsRepository
+}
+
+// NewUpdateCurrencySettingsController creates a new instance of UpdateCurrencySettingsController
+func NewUpdateCurrencySettingsController(saveCurrencySettings usecase.SaveCurrencySettingsRepository) *UpdateCurrencySettingsController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &UpdateCurrencySettingsController{
+		Validate:                       validate,
+		SaveCurrencySettingsRepository: saveCurrencySettings,
+	}
+}
+
+// UpdateCurrencySettingsBody holds the currency, ISO 4217, that reports and category amounts are converted to
+type UpdateCurrencySettingsBody struct {
+	BaseCurrency string `json:"baseCurrency" validate:"required,iso4217"`
+}
+
+// Handle processes the HTTP request to update the currency settings
+func (c *UpdateCurrencySettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body UpdateCurrencySettingsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	settings, err := c.SaveCurrencySettingsRepository.Save(&models.CurrencySettings{
+		WorkspaceId:  workspaceId,
+		BaseCurrency: body.BaseCurrency,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when saving currency settings: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/member_repository"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
@@ -29,9 +30,10 @@ type CreateEditTransactionController struct {
 	FindByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository
 	UpdateEditTransactionRepository   usecase.UpdateEditTransactionRepository
 	FindCustomFieldByIdRepository     usecase.FindCustomFieldByIdRepository
+	CreateAuditLogRepository          usecase.CreateAuditLogRepository
 }
 
-func NewCreateEditTransactionController(findMemberByIdRepository *member_repository.FindMemberByIdRepository, createEditTransactionRepository usecase.CreateEditTransactionRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findTransactionById usecase.FindTransactionByIdRepository, findByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository, updateEditTransactionRepository usecase.UpdateEditTransactionRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository) *CreateEditTransactionController {
+func NewCreateEditTransactionController(findMemberByIdRepository *member_repository.FindMemberByIdRepository, createEditTransactionRepository usecase.CreateEditTransactionRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findTransactionById usecase.FindTransactionByIdRepository, findByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository, updateEditTransactionRepository usecase.UpdateEditTransactionRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository, createAuditLogRepository usecase.CreateAuditLogRepository) *CreateEditTransactionController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &CreateEditTransactionController{
@@ -44,6 +46,7 @@ func NewCreateEditTransactionController(findMemberByIdRepository *member_reposit
 		FindByIdEditTransactionRepository: findByIdEditTransactionRepository,
 		UpdateEditTransactionRepository:   updateEditTransactionRepository,
 		FindCustomFieldByIdRepository:     findCustomFieldByIdRepository,
+		CreateAuditLogRepository:          createAuditLogRepository,
 	}
 }
 
@@ -57,13 +60,13 @@ type EditTransactionBody struct {
 	Supplier    string `json:"supplier" validate:"omitempty,min=3,max=30"`
 	AssignedTo  string `json:"assignedTo" validate:"required,min=3,max=30,mongodb"`
 	Balance     struct {
-		Value              float64 `json:"value" validate:"required,min=0.01"`
-		Parts              float64 `json:"parts" validate:"omitempty,min=0.01"`
-		Labor              float64 `json:"labor" validate:"omitempty,min=0.01"`
-		Discount           float64 `json:"discount" validate:"omitempty,min=0.01"`
-		Interest           float64 `json:"interest" validate:"omitempty,min=0.01"`
-		DiscountPercentage float64 `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
-		InterestPercentage float64 `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
+		Value              models.Money `json:"value" validate:"required,gt=0"`
+		Parts              models.Money `json:"parts" validate:"omitempty,gt=0"`
+		Labor              models.Money `json:"labor" validate:"omitempty,gt=0"`
+		Discount           models.Money `json:"discount" validate:"omitempty,gt=0"`
+		Interest           models.Money `json:"interest" validate:"omitempty,gt=0"`
+		DiscountPercentage float64      `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
+		InterestPercentage float64      `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
 	} `json:"balance" validate:"required"`
 	DueDate       string  `json:"dueDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
 	IsConfirmed   bool    `json:"isConfirmed"`
@@ -73,7 +76,7 @@ type EditTransactionBody struct {
 		TagId    string `json:"tagId" validate:"omitempty,mongodb"`
 		SubTagId string `json:"subTagId" validate:"excluded_if=TagId '',omitempty,mongodb"`
 	} `json:"tags" validate:"omitempty"`
-	AccountId        *string `json:"accountId" validate:"required,mongodb"`
+	AccountId        *string `json:"accountId" validate:"omitempty,mongodb"` // obrigatório quando a transação principal não é de cartão de crédito
 	RegistrationDate string  `json:"registrationDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
 	ConfirmationDate *string `json:"confirmationDate" validate:"excluded_if=IsConfirmed false,required_if=IsConfirmed true,omitempty,datetime=2006-01-02T15:04:05Z"`
 	CustomFields     []struct {
@@ -146,6 +149,9 @@ func (c *CreateEditTransactionController) Handle(r presentationProtocols.HttpReq
 		defer wg.Done()
 		defer utils.Recovery(&wg)
 
+		if transactionParsed.AccountId == nil {
+			return
+		}
 		if err := c.validateAccount(workspaceId, *transactionParsed.AccountId); err != nil {
 			errChan <- err
 		}
@@ -241,6 +247,20 @@ func (c *CreateEditTransactionController) Handle(r presentationProtocols.HttpReq
 		}, http.StatusNotFound)
 	}
 
+	if _, isTransfer := models.TransactionTransferId(transaction); isTransfer {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "As ocorrências de transferências entre contas são editadas pela transferência.",
+		}, http.StatusConflict)
+	}
+
+	if transactionParsed.IsConfirmed && !models.CanBeConfirmed(transaction) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "A despesa precisa ser aprovada antes de ser confirmada.",
+		}, http.StatusBadRequest)
+	}
+	transactionParsed.ApprovalStatus = transaction.ApprovalStatus
+	transactionParsed.Approval = transaction.Approval
+
 	if transaction.Frequency == "REPEAT" && transaction.RepeatSettings.Count < *transactionParsed.MainCount {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "O número da parcela é maior que o total de parcelas da transação.",
@@ -253,6 +273,15 @@ func (c *CreateEditTransactionController) Handle(r presentationProtocols.HttpReq
 		}, http.StatusBadRequest)
 	}
 
+	if transactionParsed.AccountId == nil && transaction.CreditCardId == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "A conta é obrigatória.",
+		}, http.StatusBadRequest)
+	}
+
+	// a parcela editada continua na fatura do cartão da transação principal
+	transactionParsed.CreditCardId = transaction.CreditCardId
+
 	editTransaction, err := c.FindByIdEditTransactionRepository.Find(*transactionParsed.MainId, *transactionParsed.MainCount, workspaceId)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -268,6 +297,8 @@ func (c *CreateEditTransactionController) Handle(r presentationProtocols.HttpReq
 			}, http.StatusInternalServerError)
 		}
 
+		helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userObjectID, "UPDATE", editTransaction, transactionParsed)))
+
 		return helpers.CreateResponse(response, http.StatusCreated)
 	}
 
@@ -278,6 +309,12 @@ func (c *CreateEditTransactionController) Handle(r presentationProtocols.HttpReq
 		}, http.StatusInternalServerError)
 	}
 
+	// before the first edition the installment is the main transaction itself
+	instance := *transaction
+	instance.MainId = transactionParsed.MainId
+	instance.MainCount = transactionParsed.MainCount
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userObjectID, "UPDATE", &instance, transactionParsed)))
+
 	return helpers.CreateResponse(response, http.StatusCreated)
 }
 
@@ -0,0 +1,41 @@
+package member_role
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetMemberRolesController handles listing the finance roles given in a workspace
+type GetMemberRolesController struct {
+	FindMemberRolesRepository usecase.FindMemberRolesRepository
+}
+
+// NewGetMemberRolesController creates a new instance of GetMemberRolesController
+func NewGetMemberRolesController(findMemberRoles usecase.FindMemberRolesRepository) *GetMemberRolesController {
+	return &GetMemberRolesController{
+		FindMemberRolesRepository: findMemberRoles,
+	}
+}
+
+// Handle processes the HTTP request to list the member roles
+func (c *GetMemberRolesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	memberRoles, err := c.FindMemberRolesRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding member roles: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(memberRoles, http.StatusOK)
+}
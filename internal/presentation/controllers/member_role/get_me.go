@@ -0,0 +1,32 @@
+package member_role
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+)
+
+// GetMyRoleController returns the finance role of the logged user, resolved by RequirePermission
+type GetMyRoleController struct{}
+
+// NewGetMyRoleController creates a new instance of GetMyRoleController
+func NewGetMyRoleController() *GetMyRoleController {
+	return &GetMyRoleController{}
+}
+
+type GetMyRoleResponse struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// Handle processes the HTTP request to get the role of the logged user
+func (c *GetMyRoleController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	role := r.Header.Get("financeRole")
+
+	return helpers.CreateResponse(&GetMyRoleResponse{
+		Role:        role,
+		Permissions: models.RolePermissions[role],
+	}, http.StatusOK)
+}
@@ -0,0 +1,97 @@
+package member_role
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/member_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateMemberRoleController handles giving a finance role to a workspace member
+type UpdateMemberRoleController struct {
+	Validate                 *validator.Validate
+	FindMemberByIdRepository *member_repository.FindMemberByIdRepository
+	SaveMemberRoleRepository usecase.SaveMemberRoleRepository
+}
+
+// NewUpdateMemberRoleController creates a new instance of UpdateMemberRoleController
+func NewUpdateMemberRoleController(findMemberById *member_repository.FindMemberByIdRepository, saveMemberRole usecase.SaveMemberRoleRepository) *UpdateMemberRoleController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &UpdateMemberRoleController{
+		Validate:                 validate,
+		FindMemberByIdRepository: findMemberById,
+		SaveMemberRoleRepository: saveMemberRole,
+	}
+}
+
+type UpdateMemberRoleBody struct {
+	Role string `json:"role" validate:"required,oneof=VIEWER BOOKKEEPER APPROVER ADMIN"`
+}
+
+// Handle processes the HTTP request to update the role of a member
+func (c *UpdateMemberRoleController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body UpdateMemberRoleBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	memberId, err := primitive.ObjectIDFromHex(r.Req.PathValue("memberId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid member ID format",
+		}, http.StatusBadRequest)
+	}
+
+	member, err := c.FindMemberByIdRepository.Find(workspaceId, memberId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding member: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if member == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "member not found",
+		}, http.StatusNotFound)
+	}
+
+	if member.Role == "owner" || member.Role == "admin" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "the workspace owner and admins always have the ADMIN role",
+		}, http.StatusBadRequest)
+	}
+
+	memberRole, err := c.SaveMemberRoleRepository.Save(&models.MemberRole{
+		WorkspaceId: workspaceId,
+		MemberId:    memberId,
+		Role:        body.Role,
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when saving member role: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(memberRole, http.StatusOK)
+}
@@ -0,0 +1,97 @@
+package report
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxCashFlowPeriods = 400
+
+type GetCashFlowReportController struct {
+	FindCashFlowReportRepository usecase.FindCashFlowReportRepository
+	Validate                     *validator.Validate
+}
+
+func NewGetCashFlowReportController(findCashFlowReportRepository usecase.FindCashFlowReportRepository) *GetCashFlowReportController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetCashFlowReportController{
+		FindCashFlowReportRepository: findCashFlowReportRepository,
+		Validate:                     validate,
+	}
+}
+
+type GetCashFlowReportParams struct {
+	InitialDate string `validate:"required,datetime=2006-01-02"`
+	FinalDate   string `validate:"required,datetime=2006-01-02"`
+	GroupBy     string `validate:"required,oneof=MONTH WEEK DAY"`
+}
+
+func (c *GetCashFlowReportController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	params := &GetCashFlowReportParams{
+		InitialDate: r.UrlParams.Get("initialDate"),
+		FinalDate:   r.UrlParams.Get("finalDate"),
+		GroupBy:     strings.ToUpper(r.UrlParams.Get("groupBy")),
+	}
+	if params.GroupBy == "" {
+		params.GroupBy = "MONTH"
+	}
+
+	if err := c.Validate.Struct(params); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusBadRequest)
+	}
+
+	initialDate, _ := time.Parse("2006-01-02", params.InitialDate)
+	finalDate, _ := time.Parse("2006-01-02", params.FinalDate)
+	finalDate = finalDate.Add(24*time.Hour - time.Second)
+
+	if finalDate.Before(initialDate) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "final date must be after initial date",
+		}, http.StatusBadRequest)
+	}
+
+	if infraHelpers.CountCashFlowPeriods(initialDate, finalDate, params.GroupBy) > maxCashFlowPeriods {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "date range is too long for the selected grouping",
+		}, http.StatusBadRequest)
+	}
+
+	report, err := c.FindCashFlowReportRepository.Find(&usecase.FindCashFlowReportInputRepository{
+		WorkspaceId: workspaceId,
+		InitialDate: initialDate,
+		FinalDate:   finalDate,
+		GroupBy:     params.GroupBy,
+	})
+	var rateErr *models.ExchangeRateNotFoundError
+	if errors.As(err, &rateErr) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: rateErr.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when building the cash flow report",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(report, http.StatusOK)
+}
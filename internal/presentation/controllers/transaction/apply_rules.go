@@ -0,0 +1,165 @@
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ApplyTransactionRulesController aplica de novo as regras de categorização às transações já cadastradas
+type ApplyTransactionRulesController struct {
+	Validate                         *validator.Validate
+	FindTransactionRulesRepository   usecase.FindTransactionRulesRepository
+	CategorizeTransactionsRepository usecase.CategorizeTransactionsRepository
+	FindApprovalSettingsRepository   usecase.FindApprovalSettingsRepository
+	CreateAuditLogRepository         usecase.CreateAuditLogRepository
+}
+
+func NewApplyTransactionRulesController(
+	findTransactionRules usecase.FindTransactionRulesRepository,
+	categorizeTransactions usecase.CategorizeTransactionsRepository,
+	findApprovalSettings usecase.FindApprovalSettingsRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+) *ApplyTransactionRulesController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	return &ApplyTransactionRulesController{
+		Validate:                         validate,
+		FindTransactionRulesRepository:   findTransactionRules,
+		CategorizeTransactionsRepository: categorizeTransactions,
+		FindApprovalSettingsRepository:   findApprovalSettings,
+		CreateAuditLogRepository:         createAuditLog,
+	}
+}
+
+// ApplyTransactionRulesBody escolhe as regras e as transações; vazios, valem todas as regras ativas e
+// todas as transações. Com overwrite a classificação existente é substituída, senão só é completada.
+type ApplyTransactionRulesBody struct {
+	RuleIds        []string `json:"ruleIds" validate:"omitempty,dive,mongodb"`
+	TransactionIds []string `json:"transactionIds" validate:"omitempty,max=10000,dive,mongodb"`
+	InitialDate    string   `json:"initialDate" validate:"omitempty,datetime=2006-01-02"`
+	FinalDate      string   `json:"finalDate" validate:"omitempty,datetime=2006-01-02"`
+	Overwrite      bool     `json:"overwrite"`
+}
+
+type ApplyTransactionRulesResponse struct {
+	Evaluated      int                  `json:"evaluated"`
+	Updated        int                  `json:"updated"`
+	TransactionIds []primitive.ObjectID `json:"transactionIds"`
+}
+
+func (c *ApplyTransactionRulesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body ApplyTransactionRulesBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato da solicitação inválido",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato do ID da área de trabalho inválido",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato do ID do usuário inválido",
+		}, http.StatusBadRequest)
+	}
+
+	filter := &usecase.CategorizeTransactionsFilter{}
+	for _, id := range body.TransactionIds {
+		objectId, _ := primitive.ObjectIDFromHex(id)
+		filter.TransactionIds = append(filter.TransactionIds, objectId)
+	}
+	if body.InitialDate != "" {
+		initialDate, _ := time.Parse("2006-01-02", body.InitialDate)
+		filter.InitialDate = &initialDate
+	}
+	if body.FinalDate != "" {
+		// o dia final é incluído por inteiro
+		finalDate, _ := time.Parse("2006-01-02", body.FinalDate)
+		finalDate = finalDate.Add(24*time.Hour - time.Nanosecond)
+		filter.FinalDate = &finalDate
+	}
+
+	rules, err := c.FindTransactionRulesRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as regras de categorização",
+		}, http.StatusInternalServerError)
+	}
+	if len(body.RuleIds) > 0 {
+		rules = slices.DeleteFunc(rules, func(rule models.TransactionRule) bool {
+			return !slices.Contains(body.RuleIds, rule.Id.Hex())
+		})
+	}
+
+	response := &ApplyTransactionRulesResponse{TransactionIds: []primitive.ObjectID{}}
+	if len(rules) == 0 {
+		return helpers.CreateResponse(response, http.StatusOK)
+	}
+
+	transactions, err := c.CategorizeTransactionsRepository.Find(workspaceId, filter)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as transações",
+		}, http.StatusInternalServerError)
+	}
+	response.Evaluated = len(transactions)
+
+	approvalSettings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as configurações de aprovação",
+		}, http.StatusInternalServerError)
+	}
+
+	var updated []models.Transaction
+	var auditLogs []models.AuditLog
+	for _, transaction := range transactions {
+		previous := transaction
+
+		actions := models.MatchTransactionRules(rules, &transaction)
+		if actions == nil || !actions.Apply(&transaction, body.Overwrite) {
+			continue
+		}
+
+		// a nova categoria pode exigir aprovação, mas uma despesa já confirmada não volta para a fila
+		if !transaction.IsConfirmed {
+			applyApproval(approvalSettings, &previous, &transaction)
+		}
+
+		updated = append(updated, transaction)
+		response.TransactionIds = append(response.TransactionIds, transaction.Id)
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, &transaction))
+	}
+
+	if err := c.CategorizeTransactionsRepository.Update(updated); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao atualizar a classificação das transações",
+		}, http.StatusInternalServerError)
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	response.Updated = len(updated)
+	return helpers.CreateResponse(response, http.StatusOK)
+}
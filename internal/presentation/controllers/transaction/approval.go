@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// applyApproval coloca a despesa em aprovação quando ela exige. Na edição, uma despesa que já passou
+// pela regra só é avaliada de novo quando o valor líquido aumenta ou o tipo, a categoria ou o cartão mudam.
+func applyApproval(settings *models.ApprovalSettings, previous *models.Transaction, transaction *models.Transaction) {
+	if previous != nil && !approvalFieldsChanged(previous, transaction) {
+		return
+	}
+
+	if !models.RequiresApproval(settings, transaction, approvalAmount(transaction)) {
+		if !models.CanBeConfirmed(transaction) {
+			transaction.ApprovalStatus = ""
+			transaction.Approval = nil
+		}
+		return
+	}
+
+	transaction.ApprovalStatus = models.ApprovalPending
+	transaction.Approval = nil
+}
+
+// unconfirmIfAwaitingApproval desfaz a confirmação de uma despesa que não pode ser confirmada. É usada
+// na importação, onde a linha entra aguardando aprovação em vez de ser recusada.
+func unconfirmIfAwaitingApproval(transaction *models.Transaction) {
+	if transaction.IsConfirmed && !models.CanBeConfirmed(transaction) {
+		transaction.IsConfirmed = false
+		transaction.ConfirmationDate = nil
+	}
+}
+
+// approvalFieldsChanged compara o valor líquido, com descontos e juros: reduzir o desconto também
+// encarece a despesa.
+func approvalFieldsChanged(previous *models.Transaction, transaction *models.Transaction) bool {
+	return approvalAmount(transaction) > approvalAmount(previous) ||
+		transaction.Type != previous.Type ||
+		!sameObjectID(previous.CategoryId, transaction.CategoryId) ||
+		!sameObjectID(previous.CreditCardId, transaction.CreditCardId)
+}
+
+// approvalAmount é o valor líquido da despesa, sem o sinal do tipo, calculado como na listagem. É o
+// mesmo valor comparado com o limite e entre as versões da despesa.
+func approvalAmount(transaction *models.Transaction) models.Money {
+	transactionCopy := *transaction
+	transactionCopy.Type = "RECIPE"
+	return infraHelpers.CalculateOneTransactionBalance(&transactionCopy)
+}
+
+func sameObjectID(a, b *primitive.ObjectID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -22,25 +22,39 @@ import (
 )
 
 type CreateTransactionController struct {
-	Validate                      *validator.Validate
-	Translator                    ut.Translator
-	CreateTransactionRepository   usecase.CreateTransactionRepository
-	FindMemberByIdRepository      *member_repository.FindMemberByIdRepository
-	FindAccountByIdRepository     usecase.FindAccountByIdRepository
-	FindCategoryByIdRepository    usecase.FindCategoryByIdRepository
-	FindCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository
+	Validate                       *validator.Validate
+	Translator                     ut.Translator
+	CreateTransactionRepository    usecase.CreateTransactionRepository
+	FindMemberByIdRepository       *member_repository.FindMemberByIdRepository
+	FindAccountByIdRepository      usecase.FindAccountByIdRepository
+	FindCategoryByIdRepository     usecase.FindCategoryByIdRepository
+	FindCustomFieldByIdRepository  usecase.FindCustomFieldByIdRepository
+	FindCreditCardByIdRepository   usecase.FindCreditCardByIdRepository
+	CreateAuditLogRepository       usecase.CreateAuditLogRepository
+	FindApprovalSettingsRepository usecase.FindApprovalSettingsRepository
+
+	FindDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository
+	FindTransactionRulesRepository      usecase.FindTransactionRulesRepository
+	FindExchangeRateTableRepository     usecase.FindExchangeRateTableRepository
 }
 
-func NewCreateTransactionController(findMemberByIdRepository *member_repository.FindMemberByIdRepository, createTransactionRepository *transaction_repository.CreateTransactionRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository) *CreateTransactionController {
+func NewCreateTransactionController(findMemberByIdRepository *member_repository.FindMemberByIdRepository, createTransactionRepository *transaction_repository.CreateTransactionRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository, findCreditCardByIdRepository usecase.FindCreditCardByIdRepository, createAuditLogRepository usecase.CreateAuditLogRepository, findApprovalSettingsRepository usecase.FindApprovalSettingsRepository, findDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository, findTransactionRulesRepository usecase.FindTransactionRulesRepository, findExchangeRateTableRepository usecase.FindExchangeRateTableRepository) *CreateTransactionController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &CreateTransactionController{
-		Validate:                      validate,
-		FindMemberByIdRepository:      findMemberByIdRepository,
-		CreateTransactionRepository:   createTransactionRepository,
-		FindAccountByIdRepository:     findAccountByIdRepository,
-		FindCategoryByIdRepository:    findCategoryByIdRepository,
-		FindCustomFieldByIdRepository: findCustomFieldByIdRepository,
+		Validate:                       validate,
+		FindMemberByIdRepository:       findMemberByIdRepository,
+		CreateTransactionRepository:    createTransactionRepository,
+		FindAccountByIdRepository:      findAccountByIdRepository,
+		FindCategoryByIdRepository:     findCategoryByIdRepository,
+		FindCustomFieldByIdRepository:  findCustomFieldByIdRepository,
+		FindCreditCardByIdRepository:   findCreditCardByIdRepository,
+		CreateAuditLogRepository:       createAuditLogRepository,
+		FindApprovalSettingsRepository: findApprovalSettingsRepository,
+
+		FindDuplicateTransactionsRepository: findDuplicateTransactionsRepository,
+		FindTransactionRulesRepository:      findTransactionRulesRepository,
+		FindExchangeRateTableRepository:     findExchangeRateTableRepository,
 	}
 }
 
@@ -54,21 +68,22 @@ type TransactionBody struct {
 	Supplier    string  `json:"supplier" validate:"omitempty,min=3,max=30"`
 	AssignedTo  string  `json:"assignedTo" validate:"required,min=3,max=30,mongodb"`
 	Balance     struct {
-		Value              float64 `json:"value" validate:"required,min=0.01"`
-		Parts              float64 `json:"parts" validate:"omitempty,min=0.01"`
-		Labor              float64 `json:"labor" validate:"omitempty,min=0.01"`
-		Discount           float64 `json:"discount" validate:"omitempty,min=0.01"`
-		Interest           float64 `json:"interest" validate:"omitempty,min=0.01"`
-		DiscountPercentage float64 `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
-		InterestPercentage float64 `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
+		Value              models.Money `json:"value" validate:"required,gt=0"`
+		Parts              models.Money `json:"parts" validate:"omitempty,gt=0"`
+		Labor              models.Money `json:"labor" validate:"omitempty,gt=0"`
+		Discount           models.Money `json:"discount" validate:"omitempty,gt=0"`
+		Interest           models.Money `json:"interest" validate:"omitempty,gt=0"`
+		DiscountPercentage float64      `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
+		InterestPercentage float64      `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
 	} `json:"balance" validate:"required"`
+	Currency       string `json:"currency" validate:"omitempty,iso4217"` // vazio usa a moeda da conta
 	Frequency      string `json:"frequency" validate:"oneof=DO_NOT_REPEAT RECURRING REPEAT"`
 	RepeatSettings struct {
-		InitialInstallment time.Month `json:"initialInstallment" validate:"min=1"`
-		Count              int        `json:"count" validate:"min=2,max=367"`
-		Interval           string     `json:"interval" validate:"oneof=DAILY WEEKLY MONTHLY QUARTERLY YEARLY CUSTOM"`
+		InitialInstallment time.Month `json:"initialInstallment" validate:"omitempty,min=1"`
+		Count              int        `json:"count" validate:"omitempty,min=2,max=367"`
+		Interval           string     `json:"interval" validate:"oneof=DAILY WEEKLY BIWEEKLY MONTHLY QUARTERLY YEARLY CUSTOM"`
 		CustomDay          int        `json:"customDay" validate:"required_if=Interval CUSTOM"`
-	} `json:"repeatSettings" validate:"excluded_if=Frequency DO_NOT_REPEAT,excluded_if=Frequency RECURRING,required_if=Frequency REPEAT,omitempty"`
+	} `json:"repeatSettings" validate:"excluded_if=Frequency DO_NOT_REPEAT,required_if=Frequency REPEAT,omitempty"` // em RECURRING apenas o intervalo é considerado
 	DueDate       string  `json:"dueDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
 	IsConfirmed   bool    `json:"isConfirmed"`
 	CategoryId    *string `json:"categoryId" validate:"required_with=SubCategoryId,omitempty,mongodb"`
@@ -81,7 +96,7 @@ type TransactionBody struct {
 		CustomFieldId string `json:"id" validate:"required,mongodb"`
 		Value         string `json:"value" validate:"required,max=100"`
 	} `json:"customFields"`
-	AccountId        *string `json:"accountId" validate:"required,mongodb"`
+	AccountId        *string `json:"accountId" validate:"required_without=CreditCardId,omitempty,mongodb"`
 	RegistrationDate string  `json:"registrationDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
 	ConfirmationDate *string `json:"confirmationDate" validate:"excluded_if=IsConfirmed false,required_if=IsConfirmed true,omitempty,datetime=2006-01-02T15:04:05Z,excluded_with=CreditCardId"`
 	CreditCardId     *string `json:"creditCardId" validate:"omitempty,mongodb"`
@@ -131,8 +146,9 @@ func (c *CreateTransactionController) Handle(r presentationProtocols.HttpRequest
 	}
 	transaction.WorkspaceId = workspaceId
 
-	errChan := make(chan *presentationProtocols.HttpResponse, 4)
+	errChan := make(chan *presentationProtocols.HttpResponse, 6)
 	var wg sync.WaitGroup
+	var accountCurrency string
 
 	wg.Add(1)
 	go func() {
@@ -161,7 +177,26 @@ func (c *CreateTransactionController) Handle(r presentationProtocols.HttpRequest
 		if transaction.AccountId == nil {
 			return
 		}
-		if err := c.validateAccount(workspaceId, *transaction.AccountId); err != nil {
+		account, err := c.validateAccount(workspaceId, *transaction.AccountId)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		accountCurrency = account.Currency
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer utils.RecoveryWithCallback(&wg, func(r interface{}) {
+			errChan <- helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "erro na validação do cartão de crédito: ocorreu um erro inesperado",
+			}, http.StatusInternalServerError)
+		})
+		defer wg.Done()
+		if transaction.CreditCardId == nil {
+			return
+		}
+		if err := c.validateCreditCard(workspaceId, *transaction.CreditCardId); err != nil {
 			errChan <- err
 		}
 	}()
@@ -283,6 +318,50 @@ func (c *CreateTransactionController) Handle(r presentationProtocols.HttpRequest
 		return <-errChan
 	}
 
+	rates, err := c.FindExchangeRateTableRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as cotações",
+		}, http.StatusInternalServerError)
+	}
+	if err := applyTransactionCurrency(rates, transaction, accountCurrency); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	// as regras de categorização completam o que não foi informado, antes da aprovação que depende da categoria
+	rules, err := c.FindTransactionRulesRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as regras de categorização",
+		}, http.StatusInternalServerError)
+	}
+	if actions := models.MatchTransactionRules(rules, transaction); actions != nil {
+		actions.Apply(transaction, false)
+	}
+
+	// o mesmo lançamento cadastrado duas vezes só é aceito com allowDuplicate=true
+	if r.UrlParams.Get("allowDuplicate") != "true" {
+		if response := findDuplicateTransactions(c.FindDuplicateTransactionsRepository, workspaceId, transaction); response != nil {
+			return response
+		}
+	}
+
+	approvalSettings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as configurações de aprovação",
+		}, http.StatusInternalServerError)
+	}
+
+	applyApproval(approvalSettings, nil, transaction)
+	if transaction.IsConfirmed && !models.CanBeConfirmed(transaction) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "a despesa precisa ser aprovada antes de ser confirmada",
+		}, http.StatusBadRequest)
+	}
+
 	transaction, err = c.CreateTransactionRepository.Create(transaction)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -290,6 +369,8 @@ func (c *CreateTransactionController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusInternalServerError)
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userObjectID, "CREATE", nil, transaction)))
+
 	recipeTx := *transaction
 	recipeTx.Type = "RECIPE"
 	recipeNetBalance := infraHelpers.CalculateOneTransactionBalance(&recipeTx)
@@ -299,6 +380,10 @@ func (c *CreateTransactionController) Handle(r presentationProtocols.HttpRequest
 }
 
 func createTransaction(body *TransactionBody) (*models.Transaction, error) {
+	if body.Frequency == "REPEAT" && (body.RepeatSettings.InitialInstallment < 1 || body.RepeatSettings.Count < 2) {
+		return nil, errors.New("initialInstallment and count are required for REPEAT")
+	}
+
 	if body.Frequency == "REPEAT" && int(body.RepeatSettings.InitialInstallment) >= body.RepeatSettings.Count {
 		return nil, errors.New("initialInstallment must be less than count")
 	}
@@ -376,6 +461,16 @@ func createTransaction(body *TransactionBody) (*models.Transaction, error) {
 		accountId = &accountIdParsed
 	}
 
+	var creditCardId *primitive.ObjectID
+	if body.CreditCardId != nil {
+		creditCardIdParsed, err := convertID(*body.CreditCardId)
+		if err != nil {
+			return nil, err
+		}
+
+		creditCardId = &creditCardIdParsed
+	}
+
 	registrationDate, err := parseDate(body.RegistrationDate)
 	if err != nil {
 		return nil, err
@@ -426,6 +521,7 @@ func createTransaction(body *TransactionBody) (*models.Transaction, error) {
 			DiscountPercentage: body.Balance.DiscountPercentage,
 			InterestPercentage: body.Balance.InterestPercentage,
 		},
+		Currency:  body.Currency,
 		Frequency: body.Frequency,
 		RepeatSettings: &models.TransactionRepeatSettings{
 			InitialInstallment: body.RepeatSettings.InitialInstallment,
@@ -439,6 +535,7 @@ func createTransaction(body *TransactionBody) (*models.Transaction, error) {
 		SubCategoryId:    subCategoryId,
 		Tags:             tags,
 		AccountId:        accountId,
+		CreditCardId:     creditCardId,
 		RegistrationDate: registrationDate,
 		ConfirmationDate: confirmationDate,
 		DueDate:          dueDate,
@@ -462,20 +559,37 @@ func (c *CreateTransactionController) validateAssignedMember(workspaceId primiti
 	return nil
 }
 
-func (c *CreateTransactionController) validateAccount(workspaceId primitive.ObjectID, accountId primitive.ObjectID) *presentationProtocols.HttpResponse {
+func (c *CreateTransactionController) validateAccount(workspaceId primitive.ObjectID, accountId primitive.ObjectID) (*models.Account, *presentationProtocols.HttpResponse) {
 	account, err := c.FindAccountByIdRepository.Find(accountId, workspaceId)
 	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "erro ao buscar conta",
 		}, http.StatusInternalServerError)
 	}
 
 	if account == nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "conta não encontrada",
 		}, http.StatusNotFound)
 	}
 
+	return account, nil
+}
+
+func (c *CreateTransactionController) validateCreditCard(workspaceId primitive.ObjectID, creditCardId primitive.ObjectID) *presentationProtocols.HttpResponse {
+	creditCard, err := c.FindCreditCardByIdRepository.Find(creditCardId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar cartão de crédito",
+		}, http.StatusInternalServerError)
+	}
+
+	if creditCard == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "cartão de crédito não encontrado",
+		}, http.StatusNotFound)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,28 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+// applyTransactionCurrency usa a moeda da conta quando a transação não informa uma e confere se há cotação para
+// converter a transação para a moeda da conta e para a moeda base, sem a qual os saldos não podem ser calculados
+func applyTransactionCurrency(rates *models.ExchangeRates, transaction *models.Transaction, accountCurrency string) error {
+	if transaction.Currency == "" {
+		transaction.Currency = accountCurrency
+	}
+
+	date := models.TransactionReferenceDate(transaction)
+	for _, currency := range []string{accountCurrency, rates.BaseCurrency} {
+		if _, err := rates.Rate(transaction.Currency, currency, date); err != nil {
+			var rateErr *models.ExchangeRateNotFoundError
+			if errors.As(err, &rateErr) {
+				return errors.New("não há cotação de " + rateErr.From + " para " + rateErr.To)
+			}
+			return err
+		}
+	}
+
+	return nil
+}
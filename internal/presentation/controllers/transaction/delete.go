@@ -5,24 +5,35 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type DeleteTransactionController struct {
-	DeleteTransactionRepository   usecase.DeleteTransactionRepository
-	FindTransactionByIdRepository usecase.FindTransactionByIdRepository
+	DeleteTransactionRepository       usecase.DeleteTransactionRepository
+	FindTransactionByIdRepository     usecase.FindTransactionByIdRepository
+	FindByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository
+	CreateAuditLogRepository          usecase.CreateAuditLogRepository
+	DeleteTransfersRepository         usecase.DeleteTransfersRepository
 }
 
 func NewDeleteTransactionController(
 	deleteTransaction usecase.DeleteTransactionRepository,
 	findTransactionById usecase.FindTransactionByIdRepository,
+	findByIdEditTransaction usecase.FindByIdEditTransactionRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	deleteTransfers usecase.DeleteTransfersRepository,
 ) *DeleteTransactionController {
 	return &DeleteTransactionController{
-		DeleteTransactionRepository:   deleteTransaction,
-		FindTransactionByIdRepository: findTransactionById,
+		DeleteTransactionRepository:       deleteTransaction,
+		FindTransactionByIdRepository:     findTransactionById,
+		FindByIdEditTransactionRepository: findByIdEditTransaction,
+		CreateAuditLogRepository:          createAuditLog,
+		DeleteTransfersRepository:         deleteTransfers,
 	}
 }
 
@@ -92,6 +103,20 @@ func (c *DeleteTransactionController) Handle(r presentationProtocols.HttpRequest
 		}
 	}
 
+	editTransactionParams = c.addTransferOccurrences(editTransactionParams, workspaceId)
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := c.deletionAuditLogs(userId, workspaceId, idsObjectID, editTransactionParams)
+
+	idsObjectID, transferIds := c.splitTransferLegs(idsObjectID, workspaceId)
+	if len(transferIds) > 0 {
+		if err := c.DeleteTransfersRepository.Delete(transferIds, workspaceId); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Ocorreu um erro ao excluir as transferências: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+	}
+
 	// Process regular transaction deletions
 	if len(idsObjectID) > 0 {
 		err = c.DeleteTransactionRepository.Delete(idsObjectID, workspaceId)
@@ -112,5 +137,116 @@ func (c *DeleteTransactionController) Handle(r presentationProtocols.HttpRequest
 		}
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(nil, http.StatusNoContent)
 }
+
+// splitTransferLegs separates the legs of transfers between accounts, which are deleted with the whole
+// transfer, from the other transactions
+func (c *DeleteTransactionController) splitTransferLegs(ids []primitive.ObjectID, workspaceId primitive.ObjectID) ([]primitive.ObjectID, []primitive.ObjectID) {
+	var transactionIds, transferIds []primitive.ObjectID
+
+	for _, id := range ids {
+		transaction, err := c.FindTransactionByIdRepository.Find(id, workspaceId)
+		if err == nil && transaction != nil {
+			if transferId, isTransfer := models.TransactionTransferId(transaction); isTransfer {
+				transferIds = append(transferIds, transferId)
+				continue
+			}
+		}
+		transactionIds = append(transactionIds, id)
+	}
+
+	return transactionIds, transferIds
+}
+
+// addTransferOccurrences adds, for an occurrence of a leg of a transfer between accounts, the same occurrence
+// of the other leg, so both are deleted together and the two accounts keep matching
+func (c *DeleteTransactionController) addTransferOccurrences(editTransactionParams []struct {
+	MainId      primitive.ObjectID
+	MainCount   int
+	WorkspaceId primitive.ObjectID
+}, workspaceId primitive.ObjectID) []struct {
+	MainId      primitive.ObjectID
+	MainCount   int
+	WorkspaceId primitive.ObjectID
+} {
+	type occurrence struct {
+		mainId    primitive.ObjectID
+		mainCount int
+	}
+	requested := make(map[occurrence]bool, len(editTransactionParams))
+	for _, param := range editTransactionParams {
+		requested[occurrence{param.MainId, param.MainCount}] = true
+	}
+
+	params := editTransactionParams
+	for _, param := range editTransactionParams {
+		transaction, err := c.FindTransactionByIdRepository.Find(param.MainId, workspaceId)
+		if err != nil || transaction == nil {
+			continue
+		}
+		if _, isTransfer := models.TransactionTransferId(transaction); !isTransfer {
+			continue
+		}
+
+		otherLeg := transaction.Transfer.ExpenseTransactionId
+		if otherLeg == param.MainId {
+			otherLeg = transaction.Transfer.ReceiptTransactionId
+		}
+		if requested[occurrence{otherLeg, param.MainCount}] {
+			continue
+		}
+		requested[occurrence{otherLeg, param.MainCount}] = true
+
+		params = append(params, struct {
+			MainId      primitive.ObjectID
+			MainCount   int
+			WorkspaceId primitive.ObjectID
+		}{
+			MainId:      otherLeg,
+			MainCount:   param.MainCount,
+			WorkspaceId: workspaceId,
+		})
+	}
+
+	return params
+}
+
+// deletionAuditLogs captures the transactions before they are removed. An installment is audited with
+// its edition when there is one, otherwise with the main transaction it was generated from.
+func (c *DeleteTransactionController) deletionAuditLogs(userId, workspaceId primitive.ObjectID, ids []primitive.ObjectID, editTransactionParams []struct {
+	MainId      primitive.ObjectID
+	MainCount   int
+	WorkspaceId primitive.ObjectID
+}) []models.AuditLog {
+	auditLogs := []models.AuditLog{}
+
+	for _, id := range ids {
+		transaction, err := c.FindTransactionByIdRepository.Find(id, workspaceId)
+		if err != nil || transaction == nil {
+			continue
+		}
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "DELETE", transaction, nil))
+	}
+
+	for _, param := range editTransactionParams {
+		transaction, err := c.FindByIdEditTransactionRepository.Find(param.MainId, param.MainCount, workspaceId)
+		if err != nil {
+			continue
+		}
+		if transaction == nil {
+			transaction, err = c.FindTransactionByIdRepository.Find(param.MainId, workspaceId)
+			if err != nil || transaction == nil {
+				continue
+			}
+			mainId, mainCount := param.MainId, param.MainCount
+			transaction.MainId = &mainId
+			transaction.MainCount = &mainCount
+		}
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "DELETE", transaction, nil))
+	}
+
+	return auditLogs
+}
@@ -0,0 +1,94 @@
+package transaction
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DuplicateTransactionResponse is the conflict returned when a transaction repeats saved ones
+type DuplicateTransactionResponse struct {
+	Error      string               `json:"error"`
+	Duplicates []models.Transaction `json:"duplicates"`
+}
+
+// findDuplicateTransactions returns the conflict response when the transaction has the fingerprint of
+// transactions already saved in the workspace
+func findDuplicateTransactions(repository usecase.FindDuplicateTransactionsRepository, workspaceId primitive.ObjectID, transaction *models.Transaction) *presentationProtocols.HttpResponse {
+	duplicates, err := repository.FindByFingerprint(workspaceId, []*models.Transaction{transaction})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar transações duplicadas",
+		}, http.StatusInternalServerError)
+	}
+
+	candidates := duplicates[models.TransactionFingerprint(transaction)]
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return helpers.CreateResponse(&DuplicateTransactionResponse{
+		Error:      "já existe uma transação com o mesmo valor, vencimento, conta, nota fiscal, fornecedor e nome; envie allowDuplicate=true para criá-la mesmo assim",
+		Duplicates: candidates,
+	}, http.StatusConflict)
+}
+
+// findImportDuplicates returns the lines that repeat an earlier line of the file or transactions
+// already saved in the workspace. lines holds the line of each transaction in the file.
+func (c *ImportTransactionController) findImportDuplicates(workspaceId primitive.ObjectID, transactions []*models.Transaction, lines []int) ([]ImportPreviewDuplicate, error) {
+	saved, err := c.FindDuplicateTransactionsRepository.FindByFingerprint(workspaceId, transactions)
+	if err != nil {
+		return nil, err
+	}
+
+	duplicates := []ImportPreviewDuplicate{}
+	firstLines := map[string]int{}
+	for i, transaction := range transactions {
+		fingerprint := models.TransactionFingerprint(transaction)
+
+		duplicate := ImportPreviewDuplicate{Line: lines[i], DuplicateOf: firstLines[fingerprint], Transactions: saved[fingerprint]}
+		if duplicate.DuplicateOf == 0 {
+			firstLines[fingerprint] = lines[i]
+		}
+
+		if duplicate.DuplicateOf != 0 || len(duplicate.Transactions) > 0 {
+			duplicates = append(duplicates, duplicate)
+		}
+	}
+
+	return duplicates, nil
+}
+
+// GetDuplicateTransactionsController lists the groups of likely duplicated transactions of the
+// workspace, to be reviewed and cleaned up
+type GetDuplicateTransactionsController struct {
+	FindDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository
+}
+
+func NewGetDuplicateTransactionsController(findDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository) *GetDuplicateTransactionsController {
+	return &GetDuplicateTransactionsController{
+		FindDuplicateTransactionsRepository: findDuplicateTransactionsRepository,
+	}
+}
+
+func (c *GetDuplicateTransactionsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato do ID da área de trabalho inválido",
+		}, http.StatusBadRequest)
+	}
+
+	groups, err := c.FindDuplicateTransactionsRepository.FindGroups(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar transações duplicadas: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(groups, http.StatusOK)
+}
@@ -5,7 +5,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
 	"github.com/go-playground/validator/v10"
@@ -16,11 +18,13 @@ type ExcludeInstallmentsUntilController struct {
 	UpdateTransactionRepository   usecase.UpdateTransactionRepository
 	Validate                      *validator.Validate
 	FindTransactionByIdRepository usecase.FindTransactionByIdRepository
+	CreateAuditLogRepository      usecase.CreateAuditLogRepository
 }
 
 func NewExcludeInstallmentsUntilController(
 	updateTransaction usecase.UpdateTransactionRepository,
 	findTransactionById usecase.FindTransactionByIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
 ) *ExcludeInstallmentsUntilController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -28,6 +32,7 @@ func NewExcludeInstallmentsUntilController(
 		UpdateTransactionRepository:   updateTransaction,
 		Validate:                      validate,
 		FindTransactionByIdRepository: findTransactionById,
+		CreateAuditLogRepository:      createAuditLog,
 	}
 }
 
@@ -72,6 +77,18 @@ func (c *ExcludeInstallmentsUntilController) Handle(r presentationProtocols.Http
 		}, http.StatusNotFound)
 	}
 
+	if _, isTransfer := models.TransactionTransferId(transactionFound); isTransfer {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "As ocorrências de transferências entre contas são alteradas pela transferência",
+		}, http.StatusConflict)
+	}
+
+	previous := *transactionFound
+	if transactionFound.RepeatSettings != nil {
+		repeatSettings := *transactionFound.RepeatSettings
+		previous.RepeatSettings = &repeatSettings
+	}
+
 	switch transactionFound.Frequency {
 	case "REPEAT":
 		if body.Count != nil {
@@ -109,5 +126,8 @@ func (c *ExcludeInstallmentsUntilController) Handle(r presentationProtocols.Http
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, transaction)))
+
 	return helpers.CreateResponse(transaction, http.StatusOK)
 }
@@ -0,0 +1,466 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/xuri/excelize/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FindWorkspaceUserByIdRepository interface {
+	Find(userId primitive.ObjectID) (*models.WorkspaceUser, error)
+}
+
+// ExportTransactionController exports the transactions returned by GET /transaction, with the same
+// filters, as a spreadsheet that can be imported back with the columns of ExportColumns
+type ExportTransactionController struct {
+	*GetTransactionController
+
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	FindCategoryByIdRepository      usecase.FindCategoryByIdRepository
+	FindCustomFieldsRepository      usecase.FindCustomFieldsRepository
+	FindWorkspaceUserByIdRepository FindWorkspaceUserByIdRepository
+}
+
+func NewExportTransactionController(
+	getTransactionController *GetTransactionController,
+	findAccountByIdRepository usecase.FindAccountByIdRepository,
+	findCategoryByIdRepository usecase.FindCategoryByIdRepository,
+	findCustomFieldsRepository usecase.FindCustomFieldsRepository,
+	findWorkspaceUserByIdRepository FindWorkspaceUserByIdRepository,
+) *ExportTransactionController {
+	return &ExportTransactionController{
+		GetTransactionController:        getTransactionController,
+		FindAccountByIdRepository:       findAccountByIdRepository,
+		FindCategoryByIdRepository:      findCategoryByIdRepository,
+		FindCustomFieldsRepository:      findCustomFieldsRepository,
+		FindWorkspaceUserByIdRepository: findWorkspaceUserByIdRepository,
+	}
+}
+
+// exportColumns are the fixed columns of the export. The headers are the ones mapped by ExportColumns,
+// so the file goes through ApplyMapping without changes.
+var exportColumns = []ColumnDef{
+	{Key: "name", KeyToMap: "Nome"},
+	{Key: "description", KeyToMap: "Descrição"},
+	{Key: "invoice", KeyToMap: "Nota fiscal"},
+	{Key: "type", KeyToMap: "Tipo"},
+	{Key: "supplier", KeyToMap: "Fornecedor"},
+	{Key: "assignedTo", KeyToMap: "Responsável"},
+	{Key: "balance.value", KeyToMap: "Valor"},
+	{Key: "balance.discount", KeyToMap: "Desconto"},
+	{Key: "balance.interest", KeyToMap: "Juros"},
+	{Key: "balance.discountPercentage", KeyToMap: "Desconto (%)"},
+	{Key: "balance.interestPercentage", KeyToMap: "Juros (%)"},
+	{Key: "dueDate", KeyToMap: "Vencimento"},
+	{Key: "registrationDate", KeyToMap: "Competência"},
+	{Key: "confirmationDate", KeyToMap: "Confirmação"},
+	{Key: "accountId", KeyToMap: "Conta"},
+	{Key: "categoryId", KeyToMap: "Categoria"},
+	{Key: "subCategoryId", KeyToMap: "Subcategoria"},
+	{Key: "tags", KeyToMap: "Etiquetas"},
+}
+
+// the installment column is informative, each exported line is imported as a single transaction
+const exportInstallmentHeader = "Parcela"
+
+// ExportColumns returns the column definitions that import an exported file, the custom fields
+// mapped by their names
+func ExportColumns(customFields []models.CustomField) []ColumnDef {
+	columns := append([]ColumnDef{}, exportColumns...)
+	for _, customField := range customFields {
+		columns = append(columns, ColumnDef{Key: customField.Name, KeyToMap: customField.Name, IsCustomField: true})
+	}
+	return columns
+}
+
+func (c *ExportTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	format := r.UrlParams.Get("format")
+	if format == "" {
+		format = "xlsx"
+	}
+	if format != "xlsx" && format != "csv" && format != "ofx" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato inválido, use xlsx, csv ou ofx",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato do ID da área de trabalho inválido",
+		}, http.StatusBadRequest)
+	}
+
+	transactions, params, _, errHttp := c.findTransactions(r, workspaceId)
+	if errHttp != nil {
+		return errHttp
+	}
+
+	if params.Sort != "" {
+		c.sortTransactions(transactions, params)
+	} else {
+		infraHelpers.SortTransactionsByDueDate(transactions)
+	}
+
+	fileName := "transacoes-" + time.Now().UTC().Format("2006-01-02")
+
+	if format == "ofx" {
+		return c.exportOFX(transactions, params, fileName+".ofx")
+	}
+
+	customFields, err := c.FindCustomFieldsRepository.Find(&helpers.GlobalFilterParams{WorkspaceId: workspaceId})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao buscar os campos personalizados: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	rows, err := c.exportRows(transactions, customFields, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao montar a exportação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	var content bytes.Buffer
+	var contentType string
+	if format == "csv" {
+		err = writeExportCSV(&content, rows)
+		contentType = "text/csv; charset=utf-8"
+		fileName += ".csv"
+	} else {
+		err = writeExportXLSX(&content, rows)
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+		fileName += ".xlsx"
+	}
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao gerar o arquivo: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateFileResponse(io.NopCloser(&content), contentType, fileName)
+}
+
+// exportNames resolves the ids of the transactions to the names used by the import, each id only once
+type exportNames struct {
+	c           *ExportTransactionController
+	workspaceId primitive.ObjectID
+	accounts    map[primitive.ObjectID]string
+	categories  map[primitive.ObjectID]*models.Category
+	users       map[primitive.ObjectID]string
+}
+
+func (n *exportNames) account(id *primitive.ObjectID) (string, error) {
+	if id == nil {
+		return "", nil
+	}
+	if name, ok := n.accounts[*id]; ok {
+		return name, nil
+	}
+
+	account, err := n.c.FindAccountByIdRepository.Find(*id, n.workspaceId)
+	if err != nil {
+		return "", err
+	}
+	if account != nil {
+		n.accounts[*id] = account.Name
+	}
+	return n.accounts[*id], nil
+}
+
+func (n *exportNames) category(id primitive.ObjectID) (*models.Category, error) {
+	if category, ok := n.categories[id]; ok {
+		return category, nil
+	}
+
+	category, err := n.c.FindCategoryByIdRepository.Find(id, n.workspaceId)
+	if err != nil {
+		return nil, err
+	}
+	n.categories[id] = category
+	return category, nil
+}
+
+func (n *exportNames) user(id primitive.ObjectID) (string, error) {
+	if email, ok := n.users[id]; ok {
+		return email, nil
+	}
+
+	user, err := n.c.FindWorkspaceUserByIdRepository.Find(id)
+	if err != nil {
+		return "", err
+	}
+	if user != nil {
+		n.users[id] = user.Email
+	}
+	return n.users[id], nil
+}
+
+func subCategoryName(category *models.Category, id primitive.ObjectID) string {
+	if category == nil {
+		return ""
+	}
+	for _, subCategory := range category.SubCategories {
+		if subCategory.Id == id {
+			return subCategory.Name
+		}
+	}
+	return ""
+}
+
+func (c *ExportTransactionController) exportRows(transactions []models.Transaction, customFields []models.CustomField, workspaceId primitive.ObjectID) ([][]any, error) {
+	const dateLayout = "02/01/2006"
+
+	names := &exportNames{
+		c:           c,
+		workspaceId: workspaceId,
+		accounts:    map[primitive.ObjectID]string{},
+		categories:  map[primitive.ObjectID]*models.Category{},
+		users:       map[primitive.ObjectID]string{},
+	}
+
+	header := []any{}
+	for _, column := range ExportColumns(customFields) {
+		header = append(header, column.KeyToMap)
+	}
+	header = append(header, exportInstallmentHeader)
+
+	rows := [][]any{header}
+	for _, tx := range transactions {
+		transactionType := "Despesa"
+		if tx.Type == "RECIPE" {
+			transactionType = "Receita"
+		}
+
+		assignedTo, err := names.user(tx.AssignedTo)
+		if err != nil {
+			return nil, err
+		}
+
+		account, err := names.account(tx.AccountId)
+		if err != nil {
+			return nil, err
+		}
+
+		var categoryName, subCategory string
+		if tx.CategoryId != nil {
+			category, err := names.category(*tx.CategoryId)
+			if err != nil {
+				return nil, err
+			}
+			if category != nil {
+				categoryName = category.Name
+			}
+			if tx.SubCategoryId != nil {
+				subCategory = subCategoryName(category, *tx.SubCategoryId)
+			}
+		}
+
+		// the import reads the tags as "tag-subtag" separated by commas
+		tags := []string{}
+		for _, tag := range tx.Tags {
+			category, err := names.category(tag.TagId)
+			if err != nil {
+				return nil, err
+			}
+			if category == nil {
+				continue
+			}
+			if name := subCategoryName(category, tag.SubTagId); name != "" {
+				tags = append(tags, category.Name+"-"+name)
+			} else {
+				tags = append(tags, category.Name)
+			}
+		}
+
+		var confirmationDate string
+		if tx.IsConfirmed && tx.ConfirmationDate != nil {
+			confirmationDate = tx.ConfirmationDate.Format(dateLayout)
+		}
+
+		row := []any{
+			tx.Name,
+			tx.Description,
+			tx.Invoice,
+			transactionType,
+			tx.Supplier,
+			assignedTo,
+			tx.Balance.Value.Float64(),
+			tx.Balance.Discount.Float64(),
+			tx.Balance.Interest.Float64(),
+			tx.Balance.DiscountPercentage,
+			tx.Balance.InterestPercentage,
+			tx.DueDate.Format(dateLayout),
+			tx.RegistrationDate.Format(dateLayout),
+			confirmationDate,
+			account,
+			categoryName,
+			subCategory,
+			strings.Join(tags, ","),
+		}
+
+		values := map[primitive.ObjectID]string{}
+		for _, customField := range tx.CustomFields {
+			values[customField.CustomFieldId] = customField.Value
+		}
+		for _, customField := range customFields {
+			id, _ := primitive.ObjectIDFromHex(customField.Id)
+			row = append(row, values[id])
+		}
+
+		var installment string
+		if tx.Frequency == "REPEAT" && tx.RepeatSettings != nil {
+			installment = strconv.Itoa(tx.RepeatSettings.CurrentCount) + "/" + strconv.Itoa(tx.RepeatSettings.Count)
+		} else if tx.Frequency == "RECURRING" && tx.RepeatSettings != nil {
+			installment = strconv.Itoa(tx.RepeatSettings.CurrentCount)
+		}
+		row = append(row, installment)
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func writeExportXLSX(writer io.Writer, rows [][]any) error {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	sheet := file.GetSheetName(0)
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := file.SetSheetRow(sheet, cell, &row); err != nil {
+			return err
+		}
+	}
+
+	return file.Write(writer)
+}
+
+func writeExportCSV(writer io.Writer, rows [][]any) error {
+	// BOM para que o Excel reconheça o arquivo como UTF-8
+	if _, err := io.WriteString(writer, "\uFEFF"); err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	for _, row := range rows {
+		record := make([]string, len(row))
+		for i, value := range row {
+			switch v := value.(type) {
+			case float64:
+				record[i] = strconv.FormatFloat(v, 'f', 2, 64)
+			case string:
+				record[i] = v
+			}
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func (c *ExportTransactionController) exportOFX(transactions []models.Transaction, params *GetTransactionParams, fileName string) *presentationProtocols.HttpResponse {
+	lines := make([]helpers.BankStatementLine, 0, len(transactions))
+	var start, end time.Time
+
+	for _, tx := range transactions {
+		date := tx.DueDate
+		if tx.IsConfirmed && tx.ConfirmationDate != nil {
+			date = *tx.ConfirmationDate
+		}
+		if start.IsZero() || date.Before(start) {
+			start = date
+		}
+		if date.After(end) {
+			end = date
+		}
+
+		amount := tx.Balance.NetBalance
+		if tx.Type == "EXPENSE" {
+			amount = -amount
+		}
+
+		// the installments of a transaction share its id
+		id := tx.Id.Hex()
+		if tx.RepeatSettings != nil && tx.RepeatSettings.CurrentCount > 0 {
+			id += "-" + strconv.Itoa(tx.RepeatSettings.CurrentCount)
+		}
+
+		lines = append(lines, helpers.BankStatementLine{
+			Id:          id,
+			Date:        date,
+			Amount:      amount,
+			Description: tx.Name,
+		})
+	}
+
+	if len(lines) == 0 {
+		start = time.Now().UTC()
+		end = start
+	}
+
+	// the statement belongs to an account only when the export is filtered by a single one
+	accountId := "0"
+	if params.AccountIds != "" && !strings.Contains(params.AccountIds, ",") {
+		accountId = strings.TrimSpace(params.AccountIds)
+	}
+
+	var content bytes.Buffer
+	if err := helpers.WriteOFX(&content, accountId, lines, start, end); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao gerar o arquivo: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateFileResponse(io.NopCloser(&content), "application/x-ofx", fileName)
+}
+
+// ExportColumnsTransactionController returns the column definitions to import a file exported by
+// ExportTransactionController
+type ExportColumnsTransactionController struct {
+	FindCustomFieldsRepository usecase.FindCustomFieldsRepository
+}
+
+func NewExportColumnsTransactionController(findCustomFieldsRepository usecase.FindCustomFieldsRepository) *ExportColumnsTransactionController {
+	return &ExportColumnsTransactionController{
+		FindCustomFieldsRepository: findCustomFieldsRepository,
+	}
+}
+
+func (c *ExportColumnsTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "formato do ID da área de trabalho inválido",
+		}, http.StatusBadRequest)
+	}
+
+	customFields, err := c.FindCustomFieldsRepository.Find(&helpers.GlobalFilterParams{WorkspaceId: workspaceId})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao buscar os campos personalizados: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(ExportColumns(customFields), http.StatusOK)
+}
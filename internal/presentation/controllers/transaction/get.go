@@ -1,8 +1,9 @@
 package transaction
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
 	"sort"
 	"strings"
@@ -11,46 +12,50 @@ import (
 
 	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/domain/usecase"
-	workspace_user_repository "github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/user_repository"
 	"github.com/anuntech/finance-backend/internal/presentation/helpers"
 	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
-	"github.com/anuntech/finance-backend/internal/utils"
 	"github.com/go-playground/validator/v10"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type GetTransactionController struct {
 	FindTransactionsByWorkspaceIdAndMonthRepository usecase.FindTransactionsByWorkspaceIdRepository
+	FindTransactionsPageRepository                  usecase.FindTransactionsPageRepository
 	Validator                                       *validator.Validate
 	FindByIdEditTransactionRepository               usecase.FindByIdEditTransactionRepository
 	FindCustomFieldByIdRepository                   usecase.FindCustomFieldByIdRepository
-	FindCategoryByIdRepository                      usecase.FindCategoryByIdRepository
-	FindWorkspaceUserByIdRepository                 workspace_user_repository.FindWorkspaceUserByIdRepository
 }
 
 func NewGetTransactionController(
 	findManyByUserIdAndWorkspaceId usecase.FindTransactionsByWorkspaceIdRepository,
+	findTransactionsPage usecase.FindTransactionsPageRepository,
 	findByIdEditTransactionRepository usecase.FindByIdEditTransactionRepository,
 	findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository,
-	findCategoryByIdRepository usecase.FindCategoryByIdRepository,
-	findWorkspaceUserByIdRepository workspace_user_repository.FindWorkspaceUserByIdRepository,
 ) *GetTransactionController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &GetTransactionController{
 		FindTransactionsByWorkspaceIdAndMonthRepository: findManyByUserIdAndWorkspaceId,
-		Validator:                         validate,
-		FindByIdEditTransactionRepository: findByIdEditTransactionRepository,
-		FindCustomFieldByIdRepository:     findCustomFieldByIdRepository,
-		FindCategoryByIdRepository:        findCategoryByIdRepository,
-		FindWorkspaceUserByIdRepository:   findWorkspaceUserByIdRepository,
+		FindTransactionsPageRepository:                  findTransactionsPage,
+		Validator:                                       validate,
+		FindByIdEditTransactionRepository:               findByIdEditTransactionRepository,
+		FindCustomFieldByIdRepository:                   findCustomFieldByIdRepository,
 	}
 }
 
 type GetTransactionParams struct {
-	DateType string `json:"dateType" validate:"omitempty,oneof=CONFIRMATION DUE REGISTRATION"`
-	Sort     string `json:"sort" validate:"omitempty,oneof=ASC DESC"`
-	Search   string `json:"search" validate:"omitempty,max=255"`
+	DateType       string `json:"dateType" validate:"omitempty,oneof=CONFIRMATION DUE REGISTRATION"`
+	Sort           string `json:"sort" validate:"omitempty,oneof=ASC DESC"`
+	Search         string `json:"search" validate:"omitempty,max=255"`
+	CategoryIds    string `json:"categoryIds" validate:"omitempty"`
+	SubCategoryIds string `json:"subCategoryIds" validate:"omitempty"`
+	TagIds         string `json:"tagIds" validate:"omitempty"`
+	AccountIds     string `json:"accountIds" validate:"omitempty"`
+	AssignedTo     string `json:"assignedTo" validate:"omitempty"`
+	CustomFields   string `json:"customFields" validate:"omitempty"` // id:valor separados por vírgula
+	MinAmount      string `json:"minAmount" validate:"omitempty,numeric"`
+	MaxAmount      string `json:"maxAmount" validate:"omitempty,numeric"`
+	Status         string `json:"status" validate:"omitempty,oneof=CONFIRMED PENDING OVERDUE"`
 }
 
 func (c *GetTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
@@ -61,59 +66,136 @@ func (c *GetTransactionController) Handle(r presentationProtocols.HttpRequest) *
 		}, http.StatusBadRequest)
 	}
 
-	globalFilters, errHttp := helpers.GetGlobalFilterByQueries(&r.UrlParams, workspaceId, c.Validator)
+	findInput, params, globalFilters, errHttp := c.parseFindInput(r, workspaceId)
 	if errHttp != nil {
 		return errHttp
 	}
 
-	params := &GetTransactionParams{
-		DateType: r.UrlParams.Get("dateType"),
-		Sort:     r.UrlParams.Get("sort"),
-		Search:   r.UrlParams.Get("search"),
+	pageInput := &usecase.FindTransactionsPageInputRepository{
+		FindTransactionsByWorkspaceIdInputRepository: *findInput,
+		Sort:   params.Sort,
+		Limit:  globalFilters.Limit,
+		Offset: globalFilters.Offset,
 	}
 
-	transactions, err := c.FindTransactionsByWorkspaceIdAndMonthRepository.Find(&usecase.FindTransactionsByWorkspaceIdInputRepository{
-		Month:       globalFilters.Month,
-		Year:        globalFilters.Year,
-		Type:        globalFilters.Type,
-		InitialDate: globalFilters.InitialDate,
-		FinalDate:   globalFilters.FinalDate,
-		WorkspaceId: workspaceId,
-		Limit:       globalFilters.Limit,
-		Offset:      globalFilters.Offset,
-		IsSearching: r.UrlParams.Get("search") != "",
-	})
+	// the cursor mode is used when the parameter is sent, even empty for the first page
+	if r.UrlParams.Has("cursor") {
+		pageInput.UseCursor = true
+		if encodedCursor := r.UrlParams.Get("cursor"); encodedCursor != "" {
+			pageInput.After, err = decodeTransactionCursor(encodedCursor)
+			if err != nil {
+				return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+					Error: "cursor inválido",
+				}, http.StatusBadRequest)
+			}
+		}
+	}
 
+	page, err := c.FindTransactionsPageRepository.FindPage(pageInput)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "ocorreu um erro ao buscar as transações " + err.Error(),
 		}, http.StatusInternalServerError)
 	}
 
-	// Ensure transactions is never nil
-	if transactions == nil {
-		transactions = []models.Transaction{}
-	}
-
 	type GetTransactionResponse struct {
 		Transactions []models.Transaction `json:"transactions"`
 		HasNextPage  bool                 `json:"hasNextPage"`
+		TotalCount   int                  `json:"totalCount"`
+		NextCursor   string               `json:"nextCursor,omitempty"`
+		PageIncome   models.Money         `json:"pageIncome"`
+		PageExpense  models.Money         `json:"pageExpense"`
 	}
 
-	if params.Search != "" {
-		transactions, err = c.filterTransactionsBySearch(transactions, params.Search)
-		length := len(transactions)
-		transactions = c.applyPagination(transactions, globalFilters.Limit, globalFilters.Offset)
-		if err != nil {
-			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-				Error: "ocorreu um erro ao filtrar as transações pela pesquisa",
-			}, http.StatusInternalServerError)
+	var nextCursor string
+	hasNextPage := globalFilters.Limit > 0 && globalFilters.Offset+globalFilters.Limit < page.TotalCount
+	if pageInput.UseCursor {
+		hasNextPage = page.NextCursor != nil
+		if page.NextCursor != nil {
+			data, err := json.Marshal(page.NextCursor)
+			if err != nil {
+				return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+					Error: "ocorreu um erro ao gerar o cursor",
+				}, http.StatusInternalServerError)
+			}
+			nextCursor = base64.RawURLEncoding.EncodeToString(data)
 		}
+	}
+
+	transactions, err := c.putTransactionCustomFieldTypes(page.Transactions)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao processar os campos personalizados",
+		}, http.StatusInternalServerError)
+	}
+
+	if transactions == nil {
+		transactions = []models.Transaction{}
+	}
+
+	pageIncome, pageExpense := pageTotals(transactions)
+
+	return helpers.CreateResponse(&GetTransactionResponse{
+		Transactions: transactions,
+		HasNextPage:  hasNextPage,
+		TotalCount:   page.TotalCount,
+		NextCursor:   nextCursor,
+		PageIncome:   pageIncome,
+		PageExpense:  pageExpense,
+	}, http.StatusOK)
+}
+
+// parseFindInput validates the query of GET /transaction and turns it into the repository filters
+func (c *GetTransactionController) parseFindInput(r presentationProtocols.HttpRequest, workspaceId primitive.ObjectID) (*usecase.FindTransactionsByWorkspaceIdInputRepository, *GetTransactionParams, *helpers.GlobalFilterParams, *presentationProtocols.HttpResponse) {
+	globalFilters, errHttp := helpers.GetGlobalFilterByQueries(&r.UrlParams, workspaceId, c.Validator)
+	if errHttp != nil {
+		return nil, nil, nil, errHttp
+	}
+
+	params := &GetTransactionParams{
+		DateType:       r.UrlParams.Get("dateType"),
+		Sort:           r.UrlParams.Get("sort"),
+		Search:         r.UrlParams.Get("search"),
+		CategoryIds:    r.UrlParams.Get("categoryIds"),
+		SubCategoryIds: r.UrlParams.Get("subCategoryIds"),
+		TagIds:         r.UrlParams.Get("tagIds"),
+		AccountIds:     r.UrlParams.Get("accountIds"),
+		AssignedTo:     r.UrlParams.Get("assignedTo"),
+		CustomFields:   r.UrlParams.Get("customFields"),
+		MinAmount:      r.UrlParams.Get("minAmount"),
+		MaxAmount:      r.UrlParams.Get("maxAmount"),
+		Status:         r.UrlParams.Get("status"),
+	}
+
+	if err := c.Validator.Struct(params); err != nil {
+		return nil, nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validator, err),
+		}, http.StatusBadRequest)
+	}
+
+	findInput, err := c.buildFindInput(globalFilters, params)
+	if err != nil {
+		return nil, nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusBadRequest)
+	}
+
+	return findInput, params, globalFilters, nil
+}
+
+// findTransactions applies the filters of the query to every transaction of the workspace, with the
+// installments expanded, for the export that is not paginated
+func (c *GetTransactionController) findTransactions(r presentationProtocols.HttpRequest, workspaceId primitive.ObjectID) ([]models.Transaction, *GetTransactionParams, *helpers.GlobalFilterParams, *presentationProtocols.HttpResponse) {
+	findInput, params, globalFilters, errHttp := c.parseFindInput(r, workspaceId)
+	if errHttp != nil {
+		return nil, nil, nil, errHttp
+	}
 
-		return helpers.CreateResponse(&GetTransactionResponse{
-			Transactions: transactions,
-			HasNextPage:  globalFilters.Limit > 0 && globalFilters.Offset+globalFilters.Limit < length,
-		}, http.StatusOK)
+	transactions, err := c.FindTransactionsByWorkspaceIdAndMonthRepository.Find(findInput)
+	if err != nil {
+		return nil, nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ocorreu um erro ao buscar as transações " + err.Error(),
+		}, http.StatusInternalServerError)
 	}
 
 	if params.DateType != "" {
@@ -121,29 +203,143 @@ func (c *GetTransactionController) Handle(r presentationProtocols.HttpRequest) *
 	}
 
 	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "ocorreu um erro ao filtrar as transações",
 		}, http.StatusInternalServerError)
 	}
 
-	if params.Sort != "" {
-		c.sortTransactions(transactions, params)
+	// Ensure transactions is never nil
+	if transactions == nil {
+		transactions = []models.Transaction{}
 	}
 
-	transactionsCount := len(transactions)
+	return transactions, params, globalFilters, nil
+}
 
-	transactions = c.applyPagination(transactions, globalFilters.Limit, globalFilters.Offset)
-	transactions, err = c.putTransactionCustomFieldTypes(transactions)
+// decodeTransactionCursor reads the cursor returned as nextCursor by the previous page
+func decodeTransactionCursor(encodedCursor string) (*usecase.TransactionCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encodedCursor)
 	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "ocorreu um erro ao processar os campos personalizados",
-		}, http.StatusInternalServerError)
+		return nil, err
 	}
 
-	return helpers.CreateResponse(&GetTransactionResponse{
-		Transactions: transactions,
-		HasNextPage:  globalFilters.Limit > 0 && globalFilters.Offset+globalFilters.Limit < transactionsCount,
-	}, http.StatusOK)
+	var cursor usecase.TransactionCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	if _, err := primitive.ObjectIDFromHex(cursor.MainId); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+func pageTotals(transactions []models.Transaction) (income models.Money, expense models.Money) {
+	for _, tx := range transactions {
+		if models.IsTransferLeg(&tx) {
+			continue
+		}
+
+		switch tx.Type {
+		case "RECIPE":
+			income += tx.Balance.NetBalance
+		case "EXPENSE":
+			expense += tx.Balance.NetBalance
+		}
+	}
+
+	return income, expense
+}
+
+func (c *GetTransactionController) buildFindInput(globalFilters *helpers.GlobalFilterParams, params *GetTransactionParams) (*usecase.FindTransactionsByWorkspaceIdInputRepository, error) {
+	input := &usecase.FindTransactionsByWorkspaceIdInputRepository{
+		Month:       globalFilters.Month,
+		Year:        globalFilters.Year,
+		Type:        globalFilters.Type,
+		InitialDate: globalFilters.InitialDate,
+		FinalDate:   globalFilters.FinalDate,
+		DateType:    params.DateType,
+		WorkspaceId: globalFilters.WorkspaceId,
+		Search:      strings.TrimSpace(params.Search),
+		Status:      params.Status,
+	}
+
+	var err error
+	if input.CategoryIds, err = parseObjectIds(params.CategoryIds); err != nil {
+		return nil, errors.New("formato do ID da categoria inválido")
+	}
+	if input.SubCategoryIds, err = parseObjectIds(params.SubCategoryIds); err != nil {
+		return nil, errors.New("formato do ID da subcategoria inválido")
+	}
+	if input.TagIds, err = parseObjectIds(params.TagIds); err != nil {
+		return nil, errors.New("formato do ID da etiqueta inválido")
+	}
+	if input.AccountIds, err = parseObjectIds(params.AccountIds); err != nil {
+		return nil, errors.New("formato do ID da conta inválido")
+	}
+	if input.AssignedTo, err = parseObjectIds(params.AssignedTo); err != nil {
+		return nil, errors.New("formato do ID do responsável inválido")
+	}
+
+	for _, customField := range splitList(params.CustomFields) {
+		id, value, found := strings.Cut(customField, ":")
+		if !found {
+			return nil, errors.New("o filtro de campo personalizado deve estar no formato id:valor")
+		}
+
+		customFieldId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, errors.New("formato do ID do campo personalizado inválido")
+		}
+
+		input.CustomFields = append(input.CustomFields, models.TransactionCustomField{
+			CustomFieldId: customFieldId,
+			Value:         value,
+		})
+	}
+
+	if params.MinAmount != "" {
+		minAmount, err := models.ParseMoney(params.MinAmount)
+		if err != nil {
+			return nil, errors.New("valor mínimo inválido")
+		}
+		input.MinAmount = &minAmount
+	}
+
+	if params.MaxAmount != "" {
+		maxAmount, err := models.ParseMoney(params.MaxAmount)
+		if err != nil {
+			return nil, errors.New("valor máximo inválido")
+		}
+		input.MaxAmount = &maxAmount
+	}
+
+	return input, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func parseObjectIds(value string) ([]primitive.ObjectID, error) {
+	var ids []primitive.ObjectID
+	for _, item := range splitList(value) {
+		id, err := primitive.ObjectIDFromHex(item)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
 }
 
 func (c *GetTransactionController) filterTransactionsByDateType(transactions []models.Transaction, globalFilters *helpers.GlobalFilterParams, params *GetTransactionParams) ([]models.Transaction, error) {
@@ -222,30 +418,6 @@ func (c *GetTransactionController) sortTransactions(transactions []models.Transa
 	}
 }
 
-func (c *GetTransactionController) applyPagination(transactions []models.Transaction, limit int, offset int) []models.Transaction {
-	if limit <= 0 {
-		return transactions
-	}
-
-	totalItems := len(transactions)
-	if totalItems == 0 {
-		return transactions
-	}
-
-	// Make sure offset is within bounds
-	if offset >= totalItems {
-		return []models.Transaction{}
-	}
-
-	// Calculate the end index with bounds checking
-	endIndex := offset + limit
-	if endIndex > totalItems {
-		endIndex = totalItems
-	}
-
-	return transactions[offset:endIndex]
-}
-
 func (c *GetTransactionController) putTransactionCustomFieldTypes(transactions []models.Transaction) ([]models.Transaction, error) {
 	wg := sync.WaitGroup{}
 	customErrors := []error{}
@@ -280,192 +452,3 @@ func (c *GetTransactionController) putTransactionCustomFieldTypes(transactions [
 
 	return transactions, nil
 }
-
-func (c *GetTransactionController) ContainsIgnoreCase(s, substr string) bool {
-	return strings.Contains(
-		strings.ToLower(s),
-		strings.ToLower(substr),
-	)
-}
-
-func (c *GetTransactionController) filterTransactionsBySearch(transactions []models.Transaction, search string) ([]models.Transaction, error) {
-	filtered := []models.Transaction{}
-
-	filterCategoryById := func(categoryId primitive.ObjectID, workspaceId primitive.ObjectID) (bool, error) {
-		category, err := c.FindCategoryByIdRepository.Find(categoryId, workspaceId)
-		if err != nil {
-			return false, err
-		}
-
-		if c.ContainsIgnoreCase(category.Name, search) {
-			return true, nil
-		}
-
-		for _, subCategory := range category.SubCategories {
-			if c.ContainsIgnoreCase(subCategory.Name, search) {
-				return true, nil
-			}
-		}
-
-		return false, nil
-	}
-
-	filterByCategory := func(tx *models.Transaction) (bool, error) {
-		if tx.CategoryId == nil {
-			return false, nil
-		}
-
-		return filterCategoryById(*tx.CategoryId, tx.WorkspaceId)
-	}
-
-	filterByTag := func(tx *models.Transaction) (bool, error) {
-		if tx.Tags == nil {
-			return false, nil
-		}
-
-		for _, tag := range tx.Tags {
-			categoryMatch, err := filterCategoryById(tag.TagId, tx.WorkspaceId)
-			if err != nil {
-				return false, err
-			}
-
-			if categoryMatch {
-				return true, nil
-			}
-		}
-
-		return false, nil
-	}
-
-	filterByAssignedTo := func(id primitive.ObjectID) bool {
-		user, err := c.FindWorkspaceUserByIdRepository.Find(id)
-		if err != nil {
-			return false
-		}
-
-		if user == nil {
-			return false
-		}
-
-		return c.ContainsIgnoreCase(user.Name, search)
-	}
-
-	wg := sync.WaitGroup{}
-	var mu sync.Mutex
-
-	wg.Add(len(transactions))
-	for i := range transactions {
-		go func(i int) {
-			defer utils.Recovery(&wg)
-			defer wg.Done()
-
-			tx := &transactions[i]
-
-			var once sync.Once
-
-			addToFiltered := func() {
-				once.Do(func() {
-					mu.Lock()
-					defer mu.Unlock()
-					filtered = append(filtered, *tx)
-				})
-			}
-
-			if c.ContainsIgnoreCase(tx.Name, search) ||
-				c.ContainsIgnoreCase(tx.Description, search) ||
-				c.ContainsIgnoreCase(tx.Supplier, search) ||
-				c.ContainsIgnoreCase(tx.Type, search) ||
-				c.ContainsIgnoreCase(tx.Frequency, search) ||
-				c.ContainsIgnoreCase(fmt.Sprintf("%.2f", tx.Balance.Value), search) ||
-				c.ContainsIgnoreCase(fmt.Sprintf("%.2f", tx.Balance.Discount), search) ||
-				c.ContainsIgnoreCase(fmt.Sprintf("%.2f", tx.Balance.Interest), search) ||
-				c.ContainsIgnoreCase(fmt.Sprintf("%.2f", tx.Balance.DiscountPercentage), search) ||
-				c.ContainsIgnoreCase(fmt.Sprintf("%.2f", tx.Balance.InterestPercentage), search) {
-				addToFiltered()
-				return
-			}
-
-			if c.isDateMatch(tx.DueDate, search) ||
-				c.isDateMatch(tx.RegistrationDate, search) ||
-				(tx.ConfirmationDate != nil && c.isDateMatch(*tx.ConfirmationDate, search)) {
-				addToFiltered()
-				return
-			}
-
-			for _, cf := range tx.CustomFields {
-				if c.ContainsIgnoreCase(cf.Value, search) {
-					addToFiltered()
-					return
-				}
-			}
-
-			wg2 := sync.WaitGroup{}
-			wg2.Add(1)
-			go func() {
-				defer wg2.Done()
-				categoryMatch, err := filterByCategory(tx)
-				if err != nil {
-					return
-				}
-
-				if categoryMatch {
-					addToFiltered()
-					return
-				}
-			}()
-
-			wg2.Add(1)
-			go func() {
-				defer wg2.Done()
-				tagMatch, err := filterByTag(tx)
-				if err != nil {
-					return
-				}
-
-				if tagMatch {
-					addToFiltered()
-					return
-				}
-			}()
-
-			wg2.Add(1)
-			go func() {
-				defer wg2.Done()
-				if filterByAssignedTo(tx.AssignedTo) {
-					addToFiltered()
-					return
-				}
-			}()
-
-			wg2.Wait()
-		}(i)
-	}
-
-	wg.Wait()
-
-	sort.Slice(filtered, func(i, j int) bool {
-		if filtered[i].Id == filtered[j].Id {
-			return filtered[i].RepeatSettings.CurrentCount > filtered[j].RepeatSettings.CurrentCount
-		}
-
-		return filtered[i].CreatedAt.After(filtered[j].CreatedAt)
-	})
-
-	return filtered, nil
-}
-
-func (c *GetTransactionController) isDateMatch(date time.Time, search string) bool {
-	formattedDate := date.Format("02/01/2006")
-	if c.ContainsIgnoreCase(formattedDate, search) {
-		return true
-	}
-
-	dayStr := ""
-	if date.Day() < 10 {
-		dayStr = date.Format("2")
-	} else {
-		dayStr = date.Format("02")
-	}
-
-	return c.ContainsIgnoreCase(dayStr, search)
-}
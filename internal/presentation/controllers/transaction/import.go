@@ -66,7 +66,16 @@ type ImportTransactionController struct {
 
 	CreateAccountRepository  CreateAccountRepository
 	CreateCategoryRepository CreateCategoryRepository
+	UpdateCategoryRepository usecase.UpdateCategoryRepository
 	FindBankByNameRepository usecase.FindBankByNameRepository
+
+	ImportPreviewRepository  usecase.ImportPreviewRepository
+	CreateAuditLogRepository usecase.CreateAuditLogRepository
+
+	FindDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository
+	FindTransactionRulesRepository      usecase.FindTransactionRulesRepository
+	FindExchangeRateTableRepository     usecase.FindExchangeRateTableRepository
+	FindApprovalSettingsRepository      usecase.FindApprovalSettingsRepository
 }
 
 // Cache structures and helper functions
@@ -101,6 +110,27 @@ type bankCache struct {
 	items map[string]*models.Bank
 }
 
+// ruleCache guarda as regras de categorização do workspace, buscadas uma única vez por importação
+type ruleCache struct {
+	once  sync.Once
+	rules []models.TransactionRule
+	err   error
+}
+
+// rateCache guarda a moeda base e as cotações do workspace, buscadas uma única vez por importação
+type rateCache struct {
+	once  sync.Once
+	rates *models.ExchangeRates
+	err   error
+}
+
+// approvalCache guarda as configurações de aprovação do workspace, buscadas uma única vez por importação
+type approvalCache struct {
+	once     sync.Once
+	settings *models.ApprovalSettings
+	err      error
+}
+
 // Create a requestCache struct to hold all caches for a single request
 type requestCache struct {
 	categoryCache    categoryCache
@@ -108,6 +138,19 @@ type requestCache struct {
 	memberCache      memberCache
 	customFieldCache customFieldCache
 	bankCache        bankCache
+	ruleCache        ruleCache
+	rateCache        rateCache
+	approvalCache    approvalCache
+
+	// preview collects the accounts and categories that would be created when the import runs as a
+	// dry-run; nothing is written to the database in that mode
+	preview   *models.ImportPreview
+	previewMu sync.Mutex
+
+	// audit logs of the accounts and categories created along the way, recorded with the transactions
+	userId    primitive.ObjectID
+	auditLogs []models.AuditLog
+	auditMu   sync.Mutex
 }
 
 // Create a new requestCache
@@ -243,6 +286,27 @@ func (c *customFieldCache) getByName(name string, workspaceId primitive.ObjectID
 	return customField, nil
 }
 
+func (c *ruleCache) get(workspaceId primitive.ObjectID, findFn func(primitive.ObjectID) ([]models.TransactionRule, error)) ([]models.TransactionRule, error) {
+	c.once.Do(func() {
+		c.rules, c.err = findFn(workspaceId)
+	})
+	return c.rules, c.err
+}
+
+func (c *rateCache) get(workspaceId primitive.ObjectID, findFn func(primitive.ObjectID) (*models.ExchangeRates, error)) (*models.ExchangeRates, error) {
+	c.once.Do(func() {
+		c.rates, c.err = findFn(workspaceId)
+	})
+	return c.rates, c.err
+}
+
+func (c *approvalCache) get(workspaceId primitive.ObjectID, findFn func(primitive.ObjectID) (*models.ApprovalSettings, error)) (*models.ApprovalSettings, error) {
+	c.once.Do(func() {
+		c.settings, c.err = findFn(workspaceId)
+	})
+	return c.settings, c.err
+}
+
 func (c *bankCache) getByName(name string, findFn func(string) (*models.Bank, error)) (*models.Bank, error) {
 	c.mu.RLock()
 	bank, ok := c.items[strings.ToLower(name)]
@@ -289,7 +353,14 @@ func NewImportTransactionController(
 	findCustomFieldByNameRepository FindCustomFieldByNameRepository,
 	createAccountRepository CreateAccountRepository,
 	createCategoryRepository CreateCategoryRepository,
+	updateCategoryRepository usecase.UpdateCategoryRepository,
 	findBankByNameRepository usecase.FindBankByNameRepository,
+	importPreviewRepository usecase.ImportPreviewRepository,
+	createAuditLogRepository usecase.CreateAuditLogRepository,
+	findDuplicateTransactionsRepository usecase.FindDuplicateTransactionsRepository,
+	findTransactionRulesRepository usecase.FindTransactionRulesRepository,
+	findExchangeRateTableRepository usecase.FindExchangeRateTableRepository,
+	findApprovalSettingsRepository usecase.FindApprovalSettingsRepository,
 ) *ImportTransactionController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
@@ -306,7 +377,14 @@ func NewImportTransactionController(
 		FindCustomFieldByNameRepository:     findCustomFieldByNameRepository,
 		CreateAccountRepository:             createAccountRepository,
 		CreateCategoryRepository:            createCategoryRepository,
+		UpdateCategoryRepository:            updateCategoryRepository,
 		FindBankByNameRepository:            findBankByNameRepository,
+		ImportPreviewRepository:             importPreviewRepository,
+		CreateAuditLogRepository:            createAuditLogRepository,
+		FindDuplicateTransactionsRepository: findDuplicateTransactionsRepository,
+		FindTransactionRulesRepository:      findTransactionRulesRepository,
+		FindExchangeRateTableRepository:     findExchangeRateTableRepository,
+		FindApprovalSettingsRepository:      findApprovalSettingsRepository,
 	}
 }
 
@@ -318,19 +396,20 @@ type TransactionImportItem struct {
 	Supplier    string `json:"supplier" validate:"omitempty,min=3,max=30"`
 	AssignedTo  string `json:"assignedTo" validate:"required,email"`
 	Balance     struct {
-		Value              float64 `json:"value" validate:"required,min=0.01"`
-		Discount           float64 `json:"discount" validate:"omitempty,min=0.01"`
-		Interest           float64 `json:"interest" validate:"omitempty,min=0.01"`
-		DiscountPercentage float64 `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
-		InterestPercentage float64 `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
+		Value              models.Money `json:"value" validate:"required,gt=0"`
+		Discount           models.Money `json:"discount" validate:"omitempty,gt=0"`
+		Interest           models.Money `json:"interest" validate:"omitempty,gt=0"`
+		DiscountPercentage float64      `json:"discountPercentage" validate:"omitempty,min=0.01,max=100"`
+		InterestPercentage float64      `json:"interestPercentage" validate:"omitempty,min=0.01,max=100"`
 	} `json:"balance" validate:"required"`
+	Currency       string `json:"currency" validate:"omitempty,iso4217"` // vazio usa a moeda da conta
 	Frequency      string `json:"frequency" validate:"oneof=DO_NOT_REPEAT RECURRING REPEAT"`
 	RepeatSettings struct {
-		InitialInstallment time.Month `json:"initialInstallment" validate:"min=1"`
-		Count              int        `json:"count" validate:"min=2,max=367"`
-		Interval           string     `json:"interval" validate:"oneof=DAILY WEEKLY MONTHLY QUARTERLY YEARLY CUSTOM"`
+		InitialInstallment time.Month `json:"initialInstallment" validate:"omitempty,min=1"`
+		Count              int        `json:"count" validate:"omitempty,min=2,max=367"`
+		Interval           string     `json:"interval" validate:"oneof=DAILY WEEKLY BIWEEKLY MONTHLY QUARTERLY YEARLY CUSTOM"`
 		CustomDay          int        `json:"customDay" validate:"omitempty,required_if=Interval CUSTOM"`
-	} `json:"repeatSettings" validate:"excluded_if=Frequency DO_NOT_REPEAT,excluded_if=Frequency RECURRING,required_if=Frequency REPEAT,omitempty"`
+	} `json:"repeatSettings" validate:"excluded_if=Frequency DO_NOT_REPEAT,required_if=Frequency REPEAT,omitempty"` // em RECURRING apenas o intervalo é considerado
 	DueDate     string  `json:"dueDate" validate:"required,datetime=2006-01-02T15:04:05Z"`
 	IsConfirmed bool    `json:"isConfirmed"`
 	Category    *string `json:"categoryId" validate:"omitempty"`
@@ -353,99 +432,21 @@ type ImportTransactionBody struct {
 }
 
 func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	// with dryRun nothing is written, the response is a preview that can be committed with its token
+	dryRun := r.UrlParams.Get("dryRun") == "true"
+
 	// Initialize request-scoped cache
 	cache := newRequestCache()
 
-	transactions, err := c.ParseMultipartAndMap(r.Req)
-	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-			Error: "error parsing multipart and mapping: " + err.Error(),
-		}, http.StatusBadRequest)
-	}
-
-	validationErrors := []map[string]any{}
-
-	transactions, err = c.ParseAllDatesAndTypes(transactions)
-	if err != nil {
-		if !strings.Contains(err.Error(), "erros na análise de datas:") {
-			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
-				Error: "error parsing all dates: " + err.Error(),
-			}, http.StatusBadRequest)
-		}
-
-		errorMsg := err.Error()
-
-		if idx := strings.Index(errorMsg, "erros na análise de datas: "); idx >= 0 {
-			errorMsg = errorMsg[idx+len("erros na análise de datas: "):]
-		}
-
-		dateErrors := strings.Split(errorMsg, "; ")
-
-		for _, dateError := range dateErrors {
-			re := regexp.MustCompile(`linha (\d+)`)
-			matches := re.FindStringSubmatch(dateError)
-			if len(matches) >= 2 {
-				idx, err := strconv.Atoi(matches[1])
-				if err == nil {
-					validationErrors = append(validationErrors, map[string]any{
-						"line":  idx,
-						"error": dateError,
-					})
-				} else {
-					validationErrors = append(validationErrors, map[string]any{
-						"line":  0,
-						"error": dateError,
-					})
-				}
-			} else {
-				validationErrors = append(validationErrors, map[string]any{
-					"line":  0,
-					"error": dateError,
-				})
-			}
-		}
+	transactions, validationErrors, errResponse := c.parseAndValidate(r.Req)
+	if errResponse != nil {
+		return errResponse
 	}
 
 	body := &ImportTransactionBody{
 		Transactions: transactions,
 	}
 
-	if err := c.Validate.Struct(body); err != nil {
-
-		if errs, ok := err.(validator.ValidationErrors); ok {
-			for _, e := range errs {
-
-				field := e.Namespace()
-				index := 0
-
-				re := regexp.MustCompile(`Transactions\[(\d+)\]`)
-				matches := re.FindStringSubmatch(field)
-				if len(matches) >= 2 {
-					if idx, err := strconv.Atoi(matches[1]); err == nil {
-						index = idx
-					}
-				}
-
-				// Skip ConfirmationDate validation errors
-				if strings.Contains(field, "ConfirmationDate") {
-					continue
-				}
-
-				errorMsg := c.translateValidationError(e)
-				validationErrors = append(validationErrors, map[string]any{
-					"line":  index + 2,
-					"error": errorMsg,
-				})
-			}
-		} else {
-
-			validationErrors = append(validationErrors, map[string]any{
-				"line":  0,
-				"error": "Erro de validação: " + err.Error(),
-			})
-		}
-	}
-
 	userID := r.Header.Get("userId")
 	userObjectID, err := primitive.ObjectIDFromHex(userID)
 	if err != nil {
@@ -468,6 +469,24 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusBadRequest)
 	}
 
+	cache.userId = userObjectID
+
+	if dryRun {
+		if c.ImportPreviewRepository == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "pré-visualização de importação indisponível",
+			}, http.StatusServiceUnavailable)
+		}
+
+		cache.preview = &models.ImportPreview{
+			WorkspaceId:   workspaceId,
+			CreatedBy:     userObjectID,
+			Accounts:      []models.Account{},
+			Categories:    []models.Category{},
+			SubCategories: []models.ImportPreviewSubCategory{},
+		}
+	}
+
 	LIMIT := 15000
 	if len(body.Transactions) > LIMIT {
 		validationErrors = append(validationErrors, map[string]any{
@@ -491,7 +510,8 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 		}
 	}
 
-	if len(missingMembers) > 0 && float64(len(missingMembers))/float64(len(memberEmails)) > 0.25 {
+	// in a preview each line gets its own member not found error
+	if !dryRun && len(missingMembers) > 0 && float64(len(missingMembers))/float64(len(memberEmails)) > 0.25 {
 		for i, tx := range body.Transactions {
 			if slices.Contains(missingMembers, tx.AssignedTo) {
 				validationErrors = append(validationErrors, map[string]any{
@@ -528,6 +548,17 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 	errorCount := 0
 	var errorCountMutex sync.Mutex
 
+	invalidLines := map[int]bool{}
+	if dryRun {
+		// the preview reports the errors of every line, up to what the channel holds
+		maxErrors = cap(errs)
+		for _, validationError := range validationErrors {
+			if line, ok := validationError["line"].(int); ok {
+				invalidLines[line] = true
+			}
+		}
+	}
+
 	if len(missingMembers) > 0 && float64(len(missingMembers))/float64(len(memberEmails)) > 0.25 {
 		for i, tx := range body.Transactions {
 			if slices.Contains(missingMembers, tx.AssignedTo) {
@@ -549,6 +580,11 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 	}
 
 	for i, txImport := range body.Transactions {
+		// invalid lines are not converted in a preview, so they do not plan accounts and categories
+		if invalidLines[i+2] {
+			continue
+		}
+
 		errorCountMutex.Lock()
 		if errorCount >= maxErrors {
 			errorCountMutex.Unlock()
@@ -597,7 +633,7 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 					errMutex.Lock()
 					errTypeCount["member_not_found"]++
 
-					if errTypeCount["member_not_found"] > len(body.Transactions)/4 {
+					if !dryRun && errTypeCount["member_not_found"] > len(body.Transactions)/4 {
 						stopMutex.Lock()
 						stopProcessing = true
 						stopMutex.Unlock()
@@ -653,6 +689,10 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 		})
 	}
 
+	if dryRun {
+		return c.previewResponse(cache.preview, body.Transactions, importedTransactions, validationErrors)
+	}
+
 	if len(validationErrors) > 0 {
 		totalErrors := len(validationErrors)
 		displayErrors := validationErrors
@@ -667,9 +707,29 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 	}
 
 	finalTransactions := make([]*models.Transaction, 0, len(importedTransactions))
-	for _, tx := range importedTransactions {
+	finalLines := make([]int, 0, len(importedTransactions))
+	for i, tx := range importedTransactions {
 		if tx != nil {
 			finalTransactions = append(finalTransactions, tx)
+			finalLines = append(finalLines, i+2)
+		}
+	}
+
+	// lines that repeat another line or a saved transaction are only imported with allowDuplicates=true
+	if r.UrlParams.Get("allowDuplicates") != "true" {
+		duplicates, err := c.findImportDuplicates(workspaceId, finalTransactions, finalLines)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao buscar transações duplicadas: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+
+		if len(duplicates) > 0 {
+			return helpers.CreateResponse(map[string]any{
+				"error":      "Possíveis transações duplicadas, envie allowDuplicates=true para importá-las mesmo assim",
+				"total":      len(duplicates),
+				"duplicates": duplicates,
+			}, http.StatusConflict)
 		}
 	}
 
@@ -685,10 +745,119 @@ func (c *ImportTransactionController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusBadRequest)
 	}
 
+	for _, tx := range finalTransactions {
+		cache.auditLogs = append(cache.auditLogs, infraHelpers.NewTransactionAuditLog(userObjectID, "CREATE", nil, tx))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(cache.auditLogs...))
+
 	return helpers.CreateResponse(nil, http.StatusCreated)
 }
 
+// parseAndValidate reads the uploaded file with its column mapping and validates every line. Errors of
+// the lines are returned to be reported together, the response is only set when the file can't be read.
+func (c *ImportTransactionController) parseAndValidate(r *http.Request) ([]TransactionImportItem, []map[string]any, *presentationProtocols.HttpResponse) {
+	transactions, err := c.ParseMultipartAndMap(r)
+	if err != nil {
+		return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "error parsing multipart and mapping: " + err.Error(),
+		}, http.StatusBadRequest)
+	}
+
+	validationErrors := []map[string]any{}
+
+	transactions, err = c.ParseAllDatesAndTypes(transactions)
+	if err != nil {
+		if !strings.Contains(err.Error(), "erros na análise de datas:") {
+			return nil, nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "error parsing all dates: " + err.Error(),
+			}, http.StatusBadRequest)
+		}
+
+		errorMsg := err.Error()
+
+		if idx := strings.Index(errorMsg, "erros na análise de datas: "); idx >= 0 {
+			errorMsg = errorMsg[idx+len("erros na análise de datas: "):]
+		}
+
+		dateErrors := strings.Split(errorMsg, "; ")
+
+		for _, dateError := range dateErrors {
+			re := regexp.MustCompile(`linha (\d+)`)
+			matches := re.FindStringSubmatch(dateError)
+			if len(matches) >= 2 {
+				idx, err := strconv.Atoi(matches[1])
+				if err == nil {
+					validationErrors = append(validationErrors, map[string]any{
+						"line":  idx,
+						"error": dateError,
+					})
+				} else {
+					validationErrors = append(validationErrors, map[string]any{
+						"line":  0,
+						"error": dateError,
+					})
+				}
+			} else {
+				validationErrors = append(validationErrors, map[string]any{
+					"line":  0,
+					"error": dateError,
+				})
+			}
+		}
+	}
+
+	body := &ImportTransactionBody{
+		Transactions: transactions,
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			for _, e := range errs {
+
+				field := e.Namespace()
+				index := 0
+
+				re := regexp.MustCompile(`Transactions\[(\d+)\]`)
+				matches := re.FindStringSubmatch(field)
+				if len(matches) >= 2 {
+					if idx, err := strconv.Atoi(matches[1]); err == nil {
+						index = idx
+					}
+				}
+
+				// Skip ConfirmationDate validation errors
+				if strings.Contains(field, "ConfirmationDate") {
+					continue
+				}
+
+				errorMsg := c.translateValidationError(e)
+				validationErrors = append(validationErrors, map[string]any{
+					"line":  index + 2,
+					"error": errorMsg,
+				})
+			}
+		} else {
+
+			validationErrors = append(validationErrors, map[string]any{
+				"line":  0,
+				"error": "Erro de validação: " + err.Error(),
+			})
+		}
+	}
+
+	return body.Transactions, validationErrors, nil
+}
+
 func (c *ImportTransactionController) convertImportedTransaction(txImport *TransactionImportItem, workspaceId, userID primitive.ObjectID, cache *requestCache) (*models.Transaction, error) {
+	if txImport.Frequency == "REPEAT" && (txImport.RepeatSettings.InitialInstallment < 1 || txImport.RepeatSettings.Count < 2) {
+		return nil, errors.New("initialInstallment and count are required for REPEAT")
+	}
+
+	if txImport.Frequency == "REPEAT" && int(txImport.RepeatSettings.InitialInstallment) >= txImport.RepeatSettings.Count {
+		return nil, errors.New("initialInstallment must be less than count")
+	}
+
 	parseDate := func(date string) (time.Time, error) {
 		location := time.UTC
 		return time.ParseInLocation("2006-01-02T15:04:05Z", date, location)
@@ -761,7 +930,7 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 				BankId:      bank.Id,
 			}
 
-			account, err = c.CreateAccountRepository.Create(newAccount)
+			account, err = c.createAccount(cache, newAccount)
 			if err != nil {
 				cache.accountCache.mu.Unlock()
 				return nil, fmt.Errorf("erro ao criar conta '%s': %w", txImport.Account, err)
@@ -790,6 +959,15 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 			if category, ok := cache.categoryCache.items[key]; ok {
 				categoryId = &category.Id
 				cache.categoryCache.mu.Unlock()
+
+				// the category was created by another line of the same import
+				if txImport.SubCategory != nil && *txImport.SubCategory != "" {
+					subCatId, err := c.getOrCreateSubCategory(cache, key, *txImport.SubCategory, "BookCopy")
+					if err != nil {
+						return nil, fmt.Errorf("error updating category with new subcategory: %w", err)
+					}
+					subCategoryId = &subCatId
+				}
 			} else {
 
 				newCategory := &models.Category{
@@ -813,7 +991,7 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 					subCategoryId = &subCatId
 				}
 
-				category, err = c.CreateCategoryRepository.Create(newCategory)
+				category, err = c.createCategory(cache, newCategory)
 				if err != nil {
 					cache.categoryCache.mu.Unlock()
 					return nil, fmt.Errorf("error creating category: %w", err)
@@ -828,36 +1006,12 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 			categoryId = &category.Id
 
 			if txImport.SubCategory != nil && *txImport.SubCategory != "" {
-				found := false
-				for _, subCat := range category.SubCategories {
-					if strings.EqualFold(subCat.Name, *txImport.SubCategory) {
-						subCategoryId = &subCat.Id
-						found = true
-						break
-					}
-				}
-
-				if !found {
-
-					updatedCategory := *category
-					subCatId := primitive.NewObjectID()
-					updatedCategory.SubCategories = append(updatedCategory.SubCategories, models.SubCategoryCategory{
-						Id:   subCatId,
-						Name: *txImport.SubCategory,
-						Icon: "BookCopy",
-					})
-
-					updatedCat, err := c.CreateCategoryRepository.Create(&updatedCategory)
-					if err != nil {
-						return nil, fmt.Errorf("error updating category with new subcategory: %w", err)
-					}
-
-					cache.categoryCache.mu.Lock()
-					cache.categoryCache.items[cacheKey{name: strings.ToLower(*txImport.Category), typ: txImport.Type, workspaceId: workspaceId}] = updatedCat
-					cache.categoryCache.mu.Unlock()
-
-					subCategoryId = &subCatId
+				key := cacheKey{name: strings.ToLower(*txImport.Category), typ: txImport.Type, workspaceId: workspaceId}
+				subCatId, err := c.getOrCreateSubCategory(cache, key, *txImport.SubCategory, "BookCopy")
+				if err != nil {
+					return nil, fmt.Errorf("error updating category with new subcategory: %w", err)
 				}
+				subCategoryId = &subCatId
 			}
 		}
 	}
@@ -921,7 +1075,7 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 					Icon: "BookCopy",
 				})
 
-				category, err = c.CreateCategoryRepository.Create(newTag)
+				category, err = c.createCategory(cache, newTag)
 				if err != nil {
 					return nil, fmt.Errorf("error creating tag: %w", err)
 				}
@@ -935,7 +1089,7 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 					SubTagId: subTagId,
 				})
 			} else {
-				category, err = c.CreateCategoryRepository.Create(newTag)
+				category, err = c.createCategory(cache, newTag)
 				if err != nil {
 					return nil, fmt.Errorf("error creating tag: %w", err)
 				}
@@ -963,35 +1117,12 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 				continue
 			}
 
-			found := false
-			for _, subCat := range category.SubCategories {
-				if strings.EqualFold(subCat.Name, tag.SubTag) {
-					tags[len(tags)-1].SubTagId = subCat.Id
-					found = true
-					break
-				}
-			}
-
-			if found {
-				continue
-			}
-
-			updatedTag := *category
-			subTagId := primitive.NewObjectID()
-			updatedTag.SubCategories = append(updatedTag.SubCategories, models.SubCategoryCategory{
-				Id:   subTagId,
-				Name: tag.SubTag,
-			})
-
-			updatedTagPtr, err := c.CreateCategoryRepository.Create(&updatedTag)
+			key := cacheKey{name: strings.ToLower(tag.Tag), typ: "TAG", workspaceId: workspaceId}
+			subTagId, err := c.getOrCreateSubCategory(cache, key, tag.SubTag, "")
 			if err != nil {
 				return nil, fmt.Errorf("error updating tag with new subtag: %w", err)
 			}
 
-			cache.categoryCache.mu.Lock()
-			cache.categoryCache.items[cacheKey{name: strings.ToLower(tag.Tag), typ: "TAG", workspaceId: workspaceId}] = updatedTagPtr
-			cache.categoryCache.mu.Unlock()
-
 			tags[len(tags)-1].SubTagId = subTagId
 		}
 	}
@@ -1010,6 +1141,14 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 		}
 	}
 
+	// sem intervalo, a recorrência é mensal
+	if txImport.Frequency == "RECURRING" && txImport.RepeatSettings.Interval != "" {
+		repeatSettings = &models.TransactionRepeatSettings{
+			Interval:  txImport.RepeatSettings.Interval,
+			CustomDay: txImport.RepeatSettings.CustomDay,
+		}
+	}
+
 	transaction := &models.Transaction{
 		Id:          primitive.NewObjectID(),
 		Name:        txImport.Name,
@@ -1027,6 +1166,7 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 			DiscountPercentage: txImport.Balance.DiscountPercentage,
 			InterestPercentage: txImport.Balance.InterestPercentage,
 		},
+		Currency:         txImport.Currency,
 		Frequency:        txImport.Frequency,
 		RepeatSettings:   repeatSettings,
 		DueDate:          dueDate,
@@ -1042,6 +1182,32 @@ func (c *ImportTransactionController) convertImportedTransaction(txImport *Trans
 		UpdatedAt:        time.Now(),
 	}
 
+	rates, err := cache.rateCache.get(workspaceId, c.FindExchangeRateTableRepository.Find)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar as cotações: %w", err)
+	}
+	if err := applyTransactionCurrency(rates, transaction, account.Currency); err != nil {
+		return nil, err
+	}
+
+	// as regras de categorização completam a classificação que a linha não trouxe
+	rules, err := cache.ruleCache.get(workspaceId, c.FindTransactionRulesRepository.Find)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar as regras de categorização: %w", err)
+	}
+	if actions := models.MatchTransactionRules(rules, transaction); actions != nil {
+		actions.Apply(transaction, false)
+	}
+
+	// a aprovação depende da categoria, por isso vem depois das regras. A despesa que fica aguardando
+	// aprovação entra sem confirmar, em vez de a linha inteira ser recusada.
+	approvalSettings, err := cache.approvalCache.get(workspaceId, c.FindApprovalSettingsRepository.Find)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar as configurações de aprovação: %w", err)
+	}
+	applyApproval(approvalSettings, nil, transaction)
+	unconfirmIfAwaitingApproval(transaction)
+
 	return transaction, nil
 }
 
@@ -1109,6 +1275,11 @@ func ParseCSV(r io.Reader) ([]map[string]any, error) {
 		return nil, fmt.Errorf("csv header: %w", err)
 	}
 
+	// planilhas salvas pelo Excel, e as exportadas pelo sistema, começam com o BOM do UTF-8
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\uFEFF")
+	}
+
 	for i, h := range headers {
 
 		headers[i] = strings.ReplaceAll(h, "\"", "")
@@ -0,0 +1,248 @@
+package transaction
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CommitImportTransactionController struct {
+	ImportPreviewRepository             usecase.ImportPreviewRepository
+	CreateTransactionRepository         usecase.CreateTransactionRepository
+	FindAccountByNameRepository         usecase.FindAccountByNameAndWorkspaceIdRepository
+	FindCategoryByNameAndTypeRepository usecase.FindCategoryByNameAndTypeRepository
+	FindCategoryByIdRepository          usecase.FindCategoryByIdRepository
+	CreateAccountRepository             CreateAccountRepository
+	CreateCategoryRepository            CreateCategoryRepository
+	UpdateCategoryRepository            usecase.UpdateCategoryRepository
+	CreateAuditLogRepository            usecase.CreateAuditLogRepository
+	FindApprovalSettingsRepository      usecase.FindApprovalSettingsRepository
+}
+
+func NewCommitImportTransactionController(
+	importPreviewRepository usecase.ImportPreviewRepository,
+	createTransactionRepository usecase.CreateTransactionRepository,
+	findAccountByNameRepository usecase.FindAccountByNameAndWorkspaceIdRepository,
+	findCategoryByNameAndTypeRepository usecase.FindCategoryByNameAndTypeRepository,
+	findCategoryByIdRepository usecase.FindCategoryByIdRepository,
+	createAccountRepository CreateAccountRepository,
+	createCategoryRepository CreateCategoryRepository,
+	updateCategoryRepository usecase.UpdateCategoryRepository,
+	createAuditLogRepository usecase.CreateAuditLogRepository,
+	findApprovalSettingsRepository usecase.FindApprovalSettingsRepository,
+) *CommitImportTransactionController {
+	return &CommitImportTransactionController{
+		ImportPreviewRepository:             importPreviewRepository,
+		CreateTransactionRepository:         createTransactionRepository,
+		FindAccountByNameRepository:         findAccountByNameRepository,
+		FindCategoryByNameAndTypeRepository: findCategoryByNameAndTypeRepository,
+		FindCategoryByIdRepository:          findCategoryByIdRepository,
+		CreateAccountRepository:             createAccountRepository,
+		CreateCategoryRepository:            createCategoryRepository,
+		UpdateCategoryRepository:            updateCategoryRepository,
+		CreateAuditLogRepository:            createAuditLogRepository,
+		FindApprovalSettingsRepository:      findApprovalSettingsRepository,
+	}
+}
+
+type CommitImportTransactionResponse struct {
+	Transactions  int `json:"transactions"`
+	Accounts      int `json:"accounts"`
+	Categories    int `json:"categories"`
+	SubCategories int `json:"subCategories"`
+}
+
+func (c *CommitImportTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	if c.ImportPreviewRepository == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "pré-visualização de importação indisponível",
+		}, http.StatusServiceUnavailable)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	token := r.Req.PathValue("token")
+	preview, err := c.ImportPreviewRepository.Find(workspaceId, token)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar a pré-visualização: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	if preview == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Pré-visualização não encontrada ou expirada",
+		}, http.StatusNotFound)
+	}
+
+	if response := c.checkOutdated(preview); response != nil {
+		return response
+	}
+
+	// the approval settings may have changed since the preview was generated
+	approvalSettings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar as configurações de aprovação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	// removing the preview first guarantees that two requests do not commit it twice
+	deleted, err := c.ImportPreviewRepository.Delete(workspaceId, token)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao confirmar a pré-visualização: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	if !deleted {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Pré-visualização já foi confirmada",
+		}, http.StatusConflict)
+	}
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+
+	// the repositories generate new ids, the provisional ones of the preview are replaced below
+	ids := map[primitive.ObjectID]primitive.ObjectID{}
+
+	for _, account := range preview.Accounts {
+		created, err := c.CreateAccountRepository.Create(&account)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao criar conta '" + account.Name + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		ids[account.Id] = created.Id
+		auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "ACCOUNT", created.Id, "CREATE", nil, created))
+	}
+
+	for _, category := range preview.Categories {
+		created, err := c.CreateCategoryRepository.Create(&category)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao criar categoria '" + category.Name + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		ids[category.Id] = created.Id
+		auditLogs = append(auditLogs, infraHelpers.NewAuditLog(workspaceId, userId, "CATEGORY", created.Id, "CREATE", nil, created))
+	}
+
+	for _, subCategory := range preview.SubCategories {
+		provisionalId := subCategory.SubCategory.Id
+		created, err := c.UpdateCategoryRepository.CreateSubCategory(&subCategory.SubCategory, subCategory.CategoryId, workspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao criar subcategoria '" + subCategory.SubCategory.Name + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		ids[provisionalId] = created.Id
+		auditLogs = append(auditLogs, infraHelpers.NewSubCategoryAuditLog(workspaceId, userId, subCategory.CategoryId, "CREATE", nil, created))
+	}
+
+	replace := func(id *primitive.ObjectID) *primitive.ObjectID {
+		if id == nil {
+			return nil
+		}
+		if replaced, ok := ids[*id]; ok {
+			return &replaced
+		}
+		return id
+	}
+
+	transactions := make([]*models.Transaction, len(preview.Transactions))
+	for i := range preview.Transactions {
+		tx := &preview.Transactions[i]
+		tx.AccountId = replace(tx.AccountId)
+		tx.CategoryId = replace(tx.CategoryId)
+		tx.SubCategoryId = replace(tx.SubCategoryId)
+		for j := range tx.Tags {
+			tx.Tags[j].TagId = *replace(&tx.Tags[j].TagId)
+			tx.Tags[j].SubTagId = *replace(&tx.Tags[j].SubTagId)
+		}
+		applyApproval(approvalSettings, nil, tx)
+		unconfirmIfAwaitingApproval(tx)
+		transactions[i] = tx
+	}
+
+	if _, err := c.CreateTransactionRepository.CreateMany(transactions); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao criar transações: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	for _, tx := range transactions {
+		auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, tx))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	return helpers.CreateResponse(&CommitImportTransactionResponse{
+		Transactions:  len(transactions),
+		Accounts:      len(preview.Accounts),
+		Categories:    len(preview.Categories),
+		SubCategories: len(preview.SubCategories),
+	}, http.StatusCreated)
+}
+
+// checkOutdated rejects the commit when an entity of the preview was created by someone else in the
+// meantime, since committing it would duplicate accounts or categories
+func (c *CommitImportTransactionController) checkOutdated(preview *models.ImportPreview) *presentationProtocols.HttpResponse {
+	outdated := func(reason string) *presentationProtocols.HttpResponse {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "A pré-visualização está desatualizada (" + reason + "), gere uma nova",
+		}, http.StatusConflict)
+	}
+
+	for _, account := range preview.Accounts {
+		existing, err := c.FindAccountByNameRepository.FindByNameAndWorkspaceId(account.Name, preview.WorkspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao buscar conta '" + account.Name + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if existing != nil {
+			return outdated("a conta '" + account.Name + "' já existe")
+		}
+	}
+
+	for _, category := range preview.Categories {
+		existing, err := c.FindCategoryByNameAndTypeRepository.Find(category.Name, category.Type, preview.WorkspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao buscar categoria '" + category.Name + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if existing != nil {
+			return outdated("a categoria '" + category.Name + "' já existe")
+		}
+	}
+
+	for _, subCategory := range preview.SubCategories {
+		category, err := c.FindCategoryByIdRepository.Find(subCategory.CategoryId, preview.WorkspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao buscar categoria '" + subCategory.CategoryName + "': " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		if category == nil {
+			return outdated("a categoria '" + subCategory.CategoryName + "' foi removida")
+		}
+		for _, existing := range category.SubCategories {
+			if strings.EqualFold(existing.Name, subCategory.SubCategory.Name) {
+				return outdated("a subcategoria '" + subCategory.SubCategory.Name + "' já existe")
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,515 @@
+package transaction
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	importJobLimit     = 100000
+	importJobBatchSize = 200
+	importJobMaxErrors = 1000
+
+	// ImportJobLockTTL is how long a job stays with a worker without progress; after it the job is
+	// considered interrupted and queued again
+	ImportJobLockTTL = 2 * time.Minute
+)
+
+// importJobItem is a valid line of the file waiting to be imported. The id of the transaction is
+// chosen when the job is created, so a batch interrupted by a restart is not imported twice.
+type importJobItem struct {
+	Line int                   `json:"line"`
+	Id   primitive.ObjectID    `json:"id"`
+	Item TransactionImportItem `json:"item"`
+}
+
+func addImportJobError(job *models.ImportJob, line int, message string) {
+	if len(job.Errors) < importJobMaxErrors {
+		job.Errors = append(job.Errors, models.ImportJobError{Line: line, Error: message})
+	}
+}
+
+// CreateImportJobController validates the file like ImportTransactionController and queues the valid
+// lines to be imported in the background
+type CreateImportJobController struct {
+	*ImportTransactionController
+
+	ImportJobRepository usecase.ImportJobRepository
+}
+
+func NewCreateImportJobController(importTransactionController *ImportTransactionController, importJobRepository usecase.ImportJobRepository) *CreateImportJobController {
+	return &CreateImportJobController{
+		ImportTransactionController: importTransactionController,
+		ImportJobRepository:         importJobRepository,
+	}
+}
+
+func (c *CreateImportJobController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	if c.ImportJobRepository == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "importação em segundo plano indisponível",
+		}, http.StatusServiceUnavailable)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de usuário inválido",
+		}, http.StatusBadRequest)
+	}
+
+	transactions, validationErrors, errResponse := c.parseAndValidate(r.Req)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if len(transactions) > importJobLimit {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: fmt.Sprintf("Máximo de %d transações por importação", importJobLimit),
+		}, http.StatusBadRequest)
+	}
+
+	now := time.Now().UTC()
+	job := &models.ImportJob{
+		Id:          primitive.NewObjectID().Hex(),
+		WorkspaceId: workspaceId,
+		CreatedBy:   userId,
+		Status:      models.ImportJobPending,
+		Total:       len(transactions),
+		Errors:      []models.ImportJobError{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+
+		AllowDuplicates: r.UrlParams.Get("allowDuplicates") == "true",
+	}
+
+	// the invalid lines are reported by the job and skipped, an error without line is about the file
+	invalidLines := map[int]bool{}
+	for _, validationError := range validationErrors {
+		line, _ := validationError["line"].(int)
+		message, _ := validationError["error"].(string)
+		if line == 0 {
+			return helpers.CreateResponse(map[string]any{
+				"total":  len(validationErrors),
+				"errors": validationErrors,
+			}, http.StatusBadRequest)
+		}
+
+		addImportJobError(job, line, message)
+		invalidLines[line] = true
+	}
+	job.Failed = len(invalidLines)
+	job.Processed = len(invalidLines)
+
+	items := make([]importJobItem, 0, len(transactions)-len(invalidLines))
+	for i, transaction := range transactions {
+		if invalidLines[i+2] {
+			continue
+		}
+		items = append(items, importJobItem{Line: i + 2, Id: primitive.NewObjectID(), Item: transaction})
+	}
+
+	if len(items) == 0 {
+		job.Status = models.ImportJobCompleted
+		job.FinishedAt = &now
+		if err := c.ImportJobRepository.Save(job); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao salvar a importação: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		return helpers.CreateResponse(job, http.StatusAccepted)
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao preparar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if err := c.ImportJobRepository.SaveItems(job.Id, data); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao salvar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if err := c.ImportJobRepository.Save(job); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao salvar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if err := c.ImportJobRepository.Enqueue(job.Id); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao enfileirar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(job, http.StatusAccepted)
+}
+
+type GetImportJobController struct {
+	ImportJobRepository usecase.ImportJobRepository
+}
+
+func NewGetImportJobController(importJobRepository usecase.ImportJobRepository) *GetImportJobController {
+	return &GetImportJobController{
+		ImportJobRepository: importJobRepository,
+	}
+}
+
+// findImportJob returns the job only to members of its workspace
+func findImportJob(repository usecase.ImportJobRepository, r presentationProtocols.HttpRequest) (*models.ImportJob, *presentationProtocols.HttpResponse) {
+	if repository == nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "importação em segundo plano indisponível",
+		}, http.StatusServiceUnavailable)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	job, err := repository.Find(r.Req.PathValue("id"))
+	if err != nil {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	if job == nil || job.WorkspaceId != workspaceId {
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Importação não encontrada",
+		}, http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+func (c *GetImportJobController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	job, errResponse := findImportJob(c.ImportJobRepository, r)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	return helpers.CreateResponse(job, http.StatusOK)
+}
+
+type CancelImportJobController struct {
+	ImportJobRepository usecase.ImportJobRepository
+}
+
+func NewCancelImportJobController(importJobRepository usecase.ImportJobRepository) *CancelImportJobController {
+	return &CancelImportJobController{
+		ImportJobRepository: importJobRepository,
+	}
+}
+
+// Handle stops the job before its next batch, the transactions of the batches already processed are kept
+func (c *CancelImportJobController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	job, errResponse := findImportJob(c.ImportJobRepository, r)
+	if errResponse != nil {
+		return errResponse
+	}
+
+	if job.Finished() {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "A importação já foi finalizada",
+		}, http.StatusConflict)
+	}
+
+	if err := c.ImportJobRepository.Cancel(job.Id); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao cancelar a importação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	// a job still in the queue is finished here, the worker only drops it when dequeued
+	if job.Status == models.ImportJobPending {
+		now := time.Now().UTC()
+		job.Status = models.ImportJobCanceled
+		job.UpdatedAt = now
+		job.FinishedAt = &now
+		if err := c.ImportJobRepository.Save(job); err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao cancelar a importação: " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+	}
+
+	return helpers.CreateResponse(job, http.StatusOK)
+}
+
+// ImportJobRunner processes the queued import jobs in batches, saving the progress after each one
+type ImportJobRunner struct {
+	*ImportTransactionController
+
+	ImportJobRepository usecase.ImportJobRepository
+}
+
+func NewImportJobRunner(importTransactionController *ImportTransactionController, importJobRepository usecase.ImportJobRepository) *ImportJobRunner {
+	return &ImportJobRunner{
+		ImportTransactionController: importTransactionController,
+		ImportJobRepository:         importJobRepository,
+	}
+}
+
+// Run processes the job from where it stopped. It returns without doing anything when another worker
+// holds the job, and an error leaves the job active to be resumed later.
+func (c *ImportJobRunner) Run(id string) error {
+	locked, err := c.ImportJobRepository.Lock(id, ImportJobLockTTL)
+	if err != nil {
+		return err
+	}
+	if !locked {
+		return nil
+	}
+	defer func() {
+		if err := c.ImportJobRepository.Unlock(id); err != nil {
+			log.Println("Error unlocking import job:", err)
+		}
+	}()
+
+	job, err := c.ImportJobRepository.Find(id)
+	if err != nil {
+		return err
+	}
+	if job == nil || job.Finished() {
+		return c.ImportJobRepository.Finish(id)
+	}
+
+	data, err := c.ImportJobRepository.FindItems(id)
+	if err != nil {
+		return err
+	}
+	if data == nil {
+		return c.finish(job, models.ImportJobFailed, "As linhas da importação expiraram")
+	}
+
+	var items []importJobItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		return c.finish(job, models.ImportJobFailed, "Erro ao ler as linhas da importação: "+err.Error())
+	}
+
+	job.Status = models.ImportJobRunning
+	job.UpdatedAt = time.Now().UTC()
+	if err := c.ImportJobRepository.Save(job); err != nil {
+		return err
+	}
+
+	cache := newRequestCache()
+	cache.userId = job.CreatedBy
+
+	for job.Offset < len(items) {
+		canceled, err := c.ImportJobRepository.IsCanceled(id)
+		if err != nil {
+			return err
+		}
+		if canceled {
+			return c.finish(job, models.ImportJobCanceled, "")
+		}
+
+		if err := c.ImportJobRepository.ExtendLock(id, ImportJobLockTTL); err != nil {
+			return err
+		}
+
+		end := min(job.Offset+importJobBatchSize, len(items))
+		if err := c.runBatch(job, items[job.Offset:end], cache); err != nil {
+			return c.finish(job, models.ImportJobFailed, "Erro ao criar transações: "+err.Error())
+		}
+
+		job.Offset = end
+		job.UpdatedAt = time.Now().UTC()
+		if err := c.ImportJobRepository.Save(job); err != nil {
+			return err
+		}
+	}
+
+	return c.finish(job, models.ImportJobCompleted, "")
+}
+
+func (c *ImportJobRunner) finish(job *models.ImportJob, status string, reason string) error {
+	now := time.Now().UTC()
+	job.Status = status
+	job.Error = reason
+	job.UpdatedAt = now
+	job.FinishedAt = &now
+
+	if err := c.ImportJobRepository.Save(job); err != nil {
+		return err
+	}
+
+	return c.ImportJobRepository.Finish(job.Id)
+}
+
+func (c *ImportJobRunner) runBatch(job *models.ImportJob, items []importJobItem, cache *requestCache) error {
+	transactions := make([]*models.Transaction, 0, len(items))
+	lines := make([]int, 0, len(items))
+	for _, item := range items {
+		transaction, err := c.convertImportJobItem(item, job, cache)
+		if err != nil {
+			addImportJobError(job, item.Line, c.translateErrorMessage(err.Error()))
+			job.Failed++
+			continue
+		}
+		transactions = append(transactions, transaction)
+		lines = append(lines, item.Line)
+	}
+
+	// the earlier batches are already saved, so a line repeating one of them is found in the database
+	if !job.AllowDuplicates {
+		duplicates, err := c.findImportDuplicates(job.WorkspaceId, transactions, lines)
+		if err != nil {
+			return err
+		}
+
+		duplicatedLines := map[int]bool{}
+		for _, duplicate := range duplicates {
+			duplicatedLines[duplicate.Line] = true
+			if duplicate.DuplicateOf != 0 {
+				addImportJobError(job, duplicate.Line, "Possível duplicidade da linha "+strconv.Itoa(duplicate.DuplicateOf))
+			} else {
+				addImportJobError(job, duplicate.Line, "Possível duplicidade de uma transação já cadastrada")
+			}
+			job.Failed++
+		}
+
+		unique := make([]*models.Transaction, 0, len(transactions))
+		for i, transaction := range transactions {
+			if !duplicatedLines[lines[i]] {
+				unique = append(unique, transaction)
+			}
+		}
+		transactions = unique
+	}
+
+	created, existing, err := c.createImportJobTransactions(transactions)
+	if err != nil {
+		return err
+	}
+
+	for _, transaction := range created {
+		cache.auditLogs = append(cache.auditLogs, infraHelpers.NewTransactionAuditLog(job.CreatedBy, "CREATE", nil, transaction))
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(cache.auditLogs...))
+	cache.auditLogs = nil
+
+	job.Processed += len(items)
+	// the rows inserted before the restart were not counted, since the batch was not finished
+	job.Created += len(created) + existing
+	return nil
+}
+
+func (c *ImportJobRunner) convertImportJobItem(item importJobItem, job *models.ImportJob, cache *requestCache) (transaction *models.Transaction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic recovered: %v", r)
+		}
+	}()
+
+	transaction, err = c.convertImportedTransaction(&item.Item, job.WorkspaceId, job.CreatedBy, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction.Id = item.Id
+	return transaction, nil
+}
+
+// createImportJobTransactions returns the transactions actually inserted and how many already
+// existed. A batch resumed after a restart may have been inserted already, so on duplicated ids the
+// transactions are inserted one by one and the existing ones skipped.
+func (c *ImportJobRunner) createImportJobTransactions(transactions []*models.Transaction) ([]*models.Transaction, int, error) {
+	_, err := c.CreateTransactionRepository.CreateMany(transactions)
+	if err == nil {
+		return transactions, 0, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return nil, 0, err
+	}
+
+	created := []*models.Transaction{}
+	existing := 0
+	for _, transaction := range transactions {
+		if _, err := c.CreateTransactionRepository.CreateMany([]*models.Transaction{transaction}); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				existing++
+				continue
+			}
+			return nil, 0, err
+		}
+		created = append(created, transaction)
+	}
+
+	return created, existing, nil
+}
+
+// Resume queues again the active jobs that no worker holds, like the ones interrupted by a restart of
+// the server, and drops the ones already finished
+func (c *ImportJobRunner) Resume() error {
+	ids, err := c.ImportJobRepository.FindActive()
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		job, err := c.ImportJobRepository.Find(id)
+		if err != nil {
+			return err
+		}
+		if job == nil || job.Finished() {
+			if err := c.ImportJobRepository.Finish(id); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// a recent job is still waiting in the queue or being processed
+		if time.Since(job.UpdatedAt) < ImportJobLockTTL {
+			continue
+		}
+
+		locked, err := c.ImportJobRepository.Lock(id, ImportJobLockTTL)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			continue
+		}
+
+		job.UpdatedAt = time.Now().UTC()
+		if err := c.ImportJobRepository.Save(job); err != nil {
+			return err
+		}
+		if err := c.ImportJobRepository.Unlock(id); err != nil {
+			return err
+		}
+		if err := c.ImportJobRepository.Enqueue(id); err != nil {
+			return err
+		}
+		log.Printf("Import job %s queued again", id)
+	}
+
+	return nil
+}
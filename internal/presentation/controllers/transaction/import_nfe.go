@@ -0,0 +1,312 @@
+package transaction
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const maxNfeFileSize = 5 << 20
+
+// ImportNfeTransactionController creates an expense from an NF-e XML file. The account, category and
+// member resolution is the same of the spreadsheet import.
+type ImportNfeTransactionController struct {
+	*ImportTransactionController
+
+	FindNfeImportSettingsRepository usecase.FindNfeImportSettingsRepository
+	CreateEditTransactionRepository usecase.CreateEditTransactionRepository
+}
+
+func NewImportNfeTransactionController(
+	importTransactionController *ImportTransactionController,
+	findNfeImportSettingsRepository usecase.FindNfeImportSettingsRepository,
+	createEditTransactionRepository usecase.CreateEditTransactionRepository,
+) *ImportNfeTransactionController {
+	return &ImportNfeTransactionController{
+		ImportTransactionController:     importTransactionController,
+		FindNfeImportSettingsRepository: findNfeImportSettingsRepository,
+		CreateEditTransactionRepository: createEditTransactionRepository,
+	}
+}
+
+type ImportNfeTransactionResponse struct {
+	Transaction  *models.Transaction   `json:"transaction"`
+	Installments []*models.Transaction `json:"installments"`
+}
+
+// Handle recebe o XML no campo "file" e o nome da conta no campo "account". O responsável é o membro
+// informado em "assignedTo" (email) ou, sem ele, quem enviou a nota.
+func (c *ImportNfeTransactionController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de usuário inválido",
+		}, http.StatusBadRequest)
+	}
+
+	r.Req.Body = http.MaxBytesReader(nil, r.Req.Body, maxNfeFileSize+1<<20)
+	if err := r.Req.ParseMultipartForm(maxNfeFileSize); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Formulário inválido ou arquivo maior que 5 MB",
+		}, http.StatusBadRequest)
+	}
+	defer r.Req.MultipartForm.RemoveAll()
+
+	account := strings.TrimSpace(r.Req.FormValue("account"))
+	if account == "" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "O campo 'account' com o nome da conta é obrigatório",
+		}, http.StatusBadRequest)
+	}
+
+	file, _, err := r.Req.FormFile("file")
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "O campo 'file' com o XML da NF-e é obrigatório",
+		}, http.StatusBadRequest)
+	}
+	defer file.Close()
+
+	nfe, err := helpers.ParseNFe(file)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao ler a NF-e: " + err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	settings, err := c.FindNfeImportSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar as configurações de importação de NF-e: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	cache := newRequestCache()
+	cache.userId = userId
+
+	assignedTo := strings.TrimSpace(r.Req.FormValue("assignedTo"))
+	if assignedTo == "" {
+		member, err := c.FindMemberByIdRepository.Find(workspaceId, userId)
+		if err != nil || member == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Membro não encontrado no workspace",
+			}, http.StatusNotFound)
+		}
+
+		// o membro já é conhecido, então entra no cache no lugar da busca por email
+		assignedTo = userId.Hex()
+		cache.memberCache.items[cacheKey{name: assignedTo, workspaceId: workspaceId}] = member
+	}
+
+	item := nfeImportItem(nfe, account, assignedTo)
+	item.Category, item.SubCategory = matchNfeCategoryRule(settings, nfe)
+
+	transaction, err := c.convertImportedTransaction(item, workspaceId, userId, cache)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: c.translateErrorMessage(err.Error()),
+		}, http.StatusBadRequest)
+	}
+
+	// a nota importada de novo é recusada, a não ser com allowDuplicate=true
+	if r.UrlParams.Get("allowDuplicate") != "true" {
+		if response := findDuplicateTransactions(c.FindDuplicateTransactionsRepository, workspaceId, transaction); response != nil {
+			return response
+		}
+	}
+
+	transaction, err = c.CreateTransactionRepository.Create(transaction)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao criar a transação: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	cache.auditLogs = append(cache.auditLogs, infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, transaction))
+
+	installments := []*models.Transaction{}
+	for _, edit := range nfeInstallmentEdits(transaction, nfe.Installments) {
+		created, err := c.CreateEditTransactionRepository.Create(edit)
+		if err != nil {
+			helpers.LogAuditError(c.CreateAuditLogRepository.Create(cache.auditLogs...))
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "Erro ao criar a parcela " + strconv.Itoa(*edit.MainCount) + ": " + err.Error(),
+			}, http.StatusInternalServerError)
+		}
+		installments = append(installments, created)
+		cache.auditLogs = append(cache.auditLogs, infraHelpers.NewTransactionAuditLog(userId, "CREATE", nil, created))
+	}
+
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(cache.auditLogs...))
+
+	return helpers.CreateResponse(&ImportNfeTransactionResponse{
+		Transaction:  transaction,
+		Installments: installments,
+	}, http.StatusCreated)
+}
+
+// nfeImportItem monta a linha de importação da nota. Com mais de uma duplicata a despesa vira uma
+// série REPEAT mensal que começa no primeiro vencimento; o valor é o total das duplicatas.
+func nfeImportItem(nfe *helpers.NFe, account, assignedTo string) *TransactionImportItem {
+	const dateLayout = "2006-01-02T15:04:05Z"
+
+	name := nfe.Issuer.TradeName
+	if name == "" {
+		name = nfe.Issuer.Name
+	}
+
+	item := &TransactionImportItem{
+		Name:             truncateRunes(name, 30),
+		Description:      nfeDescription(nfe),
+		Invoice:          nfe.Number,
+		Type:             "EXPENSE",
+		Supplier:         truncateRunes(nfe.Issuer.Name, 30),
+		AssignedTo:       assignedTo,
+		Frequency:        "DO_NOT_REPEAT",
+		DueDate:          nfe.IssueDate.Format(dateLayout),
+		Account:          account,
+		RegistrationDate: nfe.IssueDate.Format(dateLayout),
+	}
+
+	payable := nfe.Total
+	if len(nfe.Installments) > 0 {
+		payable = 0
+		for _, installment := range nfe.Installments {
+			payable += installment.Value
+		}
+		item.DueDate = nfe.Installments[0].DueDate.Format(dateLayout)
+	}
+
+	// o valor é o bruto e o desconto fica separado, assim o líquido é o que será pago
+	item.Balance.Value = payable + nfe.Discount
+	item.Balance.Discount = nfe.Discount
+
+	if len(nfe.Installments) > 1 {
+		item.Frequency = "REPEAT"
+		item.RepeatSettings.InitialInstallment = 1
+		item.RepeatSettings.Count = len(nfe.Installments)
+		item.RepeatSettings.Interval = "MONTHLY"
+	}
+
+	return item
+}
+
+// nfeInstallmentEdits cria uma edição (edit_transaction) para cada duplicata cujo vencimento ou valor
+// difere da parcela calculada pela série mensal
+func nfeInstallmentEdits(transaction *models.Transaction, installments []helpers.NFeInstallment) []*models.Transaction {
+	if transaction.Frequency != "REPEAT" {
+		return nil
+	}
+
+	count := len(installments)
+	payable := transaction.Balance.Value - transaction.Balance.Discount
+
+	edits := []*models.Transaction{}
+	for i, installment := range installments {
+		dueDate := transaction.DueDate.AddDate(0, i, 0)
+		if dueDate.Equal(installment.DueDate) && installment.Value == payable.Installment(i+1, count) {
+			continue
+		}
+
+		mainCount := i + 1
+		edit := *transaction
+		edit.MainId = &transaction.Id
+		edit.MainCount = &mainCount
+		edit.Frequency = ""
+		edit.RepeatSettings = nil
+		edit.DueDate = installment.DueDate
+		edit.Balance = models.TransactionBalance{Value: installment.Value}
+		edits = append(edits, &edit)
+	}
+
+	return edits
+}
+
+// matchNfeCategoryRule devolve a categoria e a subcategoria da primeira regra que combina com a nota,
+// ou as padrão das configurações
+func matchNfeCategoryRule(settings *models.NfeImportSettings, nfe *helpers.NFe) (*string, *string) {
+	if settings == nil {
+		return nil, nil
+	}
+
+	for _, rule := range settings.CategoryRules {
+		if nfeRuleMatches(&rule, nfe) {
+			return &rule.Category, &rule.SubCategory
+		}
+	}
+
+	if settings.DefaultCategory == "" {
+		return nil, nil
+	}
+	return &settings.DefaultCategory, &settings.DefaultSubCategory
+}
+
+func nfeRuleMatches(rule *models.NfeCategoryRule, nfe *helpers.NFe) bool {
+	if rule.IssuerDocument != "" && rule.IssuerDocument != nfe.Issuer.Document {
+		return false
+	}
+
+	keyword := strings.ToLower(rule.Keyword)
+	if keyword != "" && strings.Contains(strings.ToLower(nfe.Issuer.Name), keyword) {
+		keyword = ""
+	}
+
+	if rule.Cfop == "" && rule.Ncm == "" && keyword == "" {
+		return true
+	}
+
+	for _, item := range nfe.Items {
+		if strings.HasPrefix(item.Cfop, rule.Cfop) &&
+			strings.HasPrefix(item.Ncm, rule.Ncm) &&
+			strings.Contains(strings.ToLower(item.Description), keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func nfeDescription(nfe *helpers.NFe) string {
+	description := "NF-e " + nfe.Number
+	if nfe.Series != "" {
+		description += " série " + nfe.Series
+	}
+	description += " - " + nfe.Issuer.Name + " (" + formatDocument(nfe.Issuer.Document) + ")"
+	if nfe.AccessKey != "" {
+		description += " - chave " + nfe.AccessKey
+	}
+	return truncateRunes(description, 255)
+}
+
+// formatDocument aplica a máscara do CNPJ ou do CPF
+func formatDocument(document string) string {
+	switch len(document) {
+	case 14:
+		return document[0:2] + "." + document[2:5] + "." + document[5:8] + "/" + document[8:12] + "-" + document[12:14]
+	case 11:
+		return document[0:3] + "." + document[3:6] + "." + document[6:9] + "-" + document[9:11]
+	default:
+		return document
+	}
+}
+
+func truncateRunes(value string, size int) string {
+	runes := []rune(strings.TrimSpace(value))
+	if len(runes) <= size {
+		return string(runes)
+	}
+	return strings.TrimSpace(string(runes[:size]))
+}
@@ -0,0 +1,130 @@
+package transaction
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type GetNfeImportSettingsController struct {
+	FindNfeImportSettingsRepository usecase.FindNfeImportSettingsRepository
+}
+
+func NewGetNfeImportSettingsController(findNfeImportSettingsRepository usecase.FindNfeImportSettingsRepository) *GetNfeImportSettingsController {
+	return &GetNfeImportSettingsController{
+		FindNfeImportSettingsRepository: findNfeImportSettingsRepository,
+	}
+}
+
+func (c *GetNfeImportSettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	settings, err := c.FindNfeImportSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar as configurações de importação de NF-e: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	if settings == nil {
+		settings = &models.NfeImportSettings{WorkspaceId: workspaceId, CategoryRules: []models.NfeCategoryRule{}}
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
+
+type UpdateNfeImportSettingsController struct {
+	Validate                        *validator.Validate
+	SaveNfeImportSettingsRepository usecase.SaveNfeImportSettingsRepository
+}
+
+func NewUpdateNfeImportSettingsController(saveNfeImportSettingsRepository usecase.SaveNfeImportSettingsRepository) *UpdateNfeImportSettingsController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+
+	return &UpdateNfeImportSettingsController{
+		Validate:                        validate,
+		SaveNfeImportSettingsRepository: saveNfeImportSettingsRepository,
+	}
+}
+
+// UpdateNfeImportSettingsBody holds the category rules, in the order they are evaluated, and the
+// category used when no rule matches. Categories are referenced by name and created on the first import.
+type UpdateNfeImportSettingsBody struct {
+	CategoryRules []struct {
+		IssuerDocument string `json:"issuerDocument" validate:"omitempty,numeric,min=11,max=14"`
+		Cfop           string `json:"cfop" validate:"omitempty,numeric,max=4"`
+		Ncm            string `json:"ncm" validate:"omitempty,numeric,max=8"`
+		Keyword        string `json:"keyword" validate:"omitempty,min=2,max=50"`
+		Category       string `json:"category" validate:"required,min=2,max=30"`
+		SubCategory    string `json:"subCategory" validate:"omitempty,min=2,max=30"`
+	} `json:"categoryRules" validate:"max=100,dive"`
+	DefaultCategory    string `json:"defaultCategory" validate:"omitempty,min=2,max=30"`
+	DefaultSubCategory string `json:"defaultSubCategory" validate:"excluded_without=DefaultCategory,omitempty,min=2,max=30"`
+}
+
+func (c *UpdateNfeImportSettingsController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body UpdateNfeImportSettingsBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Requisição inválida. Por favor, verifique os dados enviados.",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "ID de workspace inválido",
+		}, http.StatusBadRequest)
+	}
+
+	rules := make([]models.NfeCategoryRule, 0, len(body.CategoryRules))
+	for i, rule := range body.CategoryRules {
+		if rule.IssuerDocument == "" && rule.Cfop == "" && rule.Ncm == "" && strings.TrimSpace(rule.Keyword) == "" {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "A regra " + strconv.Itoa(i+1) + " precisa de ao menos uma condição (CNPJ/CPF, CFOP, NCM ou palavra-chave)",
+			}, http.StatusUnprocessableEntity)
+		}
+
+		rules = append(rules, models.NfeCategoryRule{
+			IssuerDocument: rule.IssuerDocument,
+			Cfop:           rule.Cfop,
+			Ncm:            rule.Ncm,
+			Keyword:        strings.TrimSpace(rule.Keyword),
+			Category:       strings.TrimSpace(rule.Category),
+			SubCategory:    strings.TrimSpace(rule.SubCategory),
+		})
+	}
+
+	settings, err := c.SaveNfeImportSettingsRepository.Save(&models.NfeImportSettings{
+		WorkspaceId:        workspaceId,
+		CategoryRules:      rules,
+		DefaultCategory:    strings.TrimSpace(body.DefaultCategory),
+		DefaultSubCategory: strings.TrimSpace(body.DefaultSubCategory),
+	})
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao salvar as configurações de importação de NF-e: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(settings, http.StatusOK)
+}
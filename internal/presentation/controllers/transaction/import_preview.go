@@ -0,0 +1,246 @@
+package transaction
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	infraHelpers "github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const importPreviewExpiration = 30 * time.Minute
+
+type ImportPreviewRow struct {
+	Line        int                 `json:"line"`
+	Transaction *models.Transaction `json:"transaction,omitempty"`
+	Errors      []string            `json:"errors,omitempty"`
+	Warnings    []string            `json:"warnings,omitempty"`
+}
+
+// ImportPreviewDuplicate is a line that repeats an earlier line of the file, DuplicateOf, or
+// transactions already saved in the workspace
+type ImportPreviewDuplicate struct {
+	Line         int                  `json:"line"`
+	DuplicateOf  int                  `json:"duplicateOf,omitempty"`
+	Transactions []models.Transaction `json:"transactions,omitempty"`
+}
+
+// ImportPreviewResponse is returned by a dry-run import. The token is only issued when no line has
+// errors, since the commit writes every transaction of the preview.
+type ImportPreviewResponse struct {
+	Token         string                            `json:"token,omitempty"`
+	ExpiresAt     *time.Time                        `json:"expiresAt,omitempty"`
+	Total         int                               `json:"total"`
+	Valid         int                               `json:"valid"`
+	Rows          []ImportPreviewRow                `json:"rows"`
+	Accounts      []models.Account                  `json:"accounts"`
+	Categories    []models.Category                 `json:"categories"`
+	SubCategories []models.ImportPreviewSubCategory `json:"subCategories"`
+	Duplicates    []ImportPreviewDuplicate          `json:"duplicates"`
+	Errors        []map[string]any                  `json:"errors"`
+}
+
+func (c *ImportTransactionController) createAccount(cache *requestCache, account *models.Account) (*models.Account, error) {
+	if cache.preview == nil {
+		created, err := c.CreateAccountRepository.Create(account)
+		if err != nil {
+			return nil, err
+		}
+		cache.audit(infraHelpers.NewAuditLog(created.WorkspaceId, cache.userId, "ACCOUNT", created.Id, "CREATE", nil, created))
+		return created, nil
+	}
+
+	cache.previewMu.Lock()
+	defer cache.previewMu.Unlock()
+
+	cache.preview.Accounts = append(cache.preview.Accounts, *account)
+	return account, nil
+}
+
+func (c *ImportTransactionController) createCategory(cache *requestCache, category *models.Category) (*models.Category, error) {
+	if cache.preview == nil {
+		created, err := c.CreateCategoryRepository.Create(category)
+		if err != nil {
+			return nil, err
+		}
+		cache.audit(infraHelpers.NewAuditLog(created.WorkspaceId, cache.userId, "CATEGORY", created.Id, "CREATE", nil, created))
+		return created, nil
+	}
+
+	cache.previewMu.Lock()
+	defer cache.previewMu.Unlock()
+
+	cache.preview.Categories = append(cache.preview.Categories, *category)
+	return category, nil
+}
+
+func (cache *requestCache) audit(log models.AuditLog) {
+	cache.auditMu.Lock()
+	defer cache.auditMu.Unlock()
+
+	cache.auditLogs = append(cache.auditLogs, log)
+}
+
+// getOrCreateSubCategory returns the id of the sub category with the given name inside the cached
+// category, adding it to the category when it does not exist yet
+func (c *ImportTransactionController) getOrCreateSubCategory(cache *requestCache, key cacheKey, name, icon string) (primitive.ObjectID, error) {
+	cache.categoryCache.mu.Lock()
+	defer cache.categoryCache.mu.Unlock()
+
+	category, ok := cache.categoryCache.items[key]
+	if !ok {
+		return primitive.NilObjectID, errors.New("category not found: " + key.name)
+	}
+
+	for _, subCategory := range category.SubCategories {
+		if strings.EqualFold(subCategory.Name, name) {
+			return subCategory.Id, nil
+		}
+	}
+
+	subCategory := &models.SubCategoryCategory{Id: primitive.NewObjectID(), Name: name, Icon: icon}
+	if cache.preview == nil {
+		created, err := c.UpdateCategoryRepository.CreateSubCategory(subCategory, category.Id, category.WorkspaceId)
+		if err != nil {
+			return primitive.NilObjectID, err
+		}
+		subCategory = created
+		cache.audit(infraHelpers.NewSubCategoryAuditLog(category.WorkspaceId, cache.userId, category.Id, "CREATE", nil, subCategory))
+	} else {
+		cache.previewMu.Lock()
+		planned := false
+		for i := range cache.preview.Categories {
+			if cache.preview.Categories[i].Id == category.Id {
+				cache.preview.Categories[i].SubCategories = append(cache.preview.Categories[i].SubCategories, *subCategory)
+				planned = true
+				break
+			}
+		}
+		if !planned {
+			cache.preview.SubCategories = append(cache.preview.SubCategories, models.ImportPreviewSubCategory{
+				CategoryId:   category.Id,
+				CategoryName: category.Name,
+				CategoryType: category.Type,
+				SubCategory:  *subCategory,
+			})
+		}
+		cache.previewMu.Unlock()
+	}
+
+	updated := *category
+	updated.SubCategories = append(slices.Clone(category.SubCategories), *subCategory)
+	cache.categoryCache.items[key] = &updated
+
+	return subCategory.Id, nil
+}
+
+func (c *ImportTransactionController) previewResponse(preview *models.ImportPreview, items []TransactionImportItem, transactions []*models.Transaction, validationErrors []map[string]any) *presentationProtocols.HttpResponse {
+	response := &ImportPreviewResponse{
+		Total:         len(items),
+		Rows:          make([]ImportPreviewRow, len(items)),
+		Accounts:      preview.Accounts,
+		Categories:    preview.Categories,
+		SubCategories: preview.SubCategories,
+		Duplicates:    []ImportPreviewDuplicate{},
+		Errors:        []map[string]any{},
+	}
+
+	for i := range items {
+		response.Rows[i] = ImportPreviewRow{Line: i + 2, Transaction: transactions[i]}
+	}
+
+	for _, validationError := range validationErrors {
+		line, _ := validationError["line"].(int)
+		if line < 2 || line-2 >= len(items) {
+			response.Errors = append(response.Errors, validationError)
+			continue
+		}
+		row := &response.Rows[line-2]
+		row.Errors = append(row.Errors, fmt.Sprint(validationError["error"]))
+	}
+
+	validTransactions := []*models.Transaction{}
+	validLines := []int{}
+	for i := range response.Rows {
+		row := &response.Rows[i]
+		if len(row.Errors) > 0 || row.Transaction == nil {
+			row.Transaction = nil
+			continue
+		}
+		response.Valid++
+		if items[i].IsConfirmed && !row.Transaction.IsConfirmed {
+			row.Warnings = append(row.Warnings, "Despesa aguardando aprovação, será importada sem confirmar")
+		}
+		validTransactions = append(validTransactions, row.Transaction)
+		validLines = append(validLines, row.Line)
+	}
+
+	// the same bill twice is only a warning in the preview, it may be intentional
+	duplicates, err := c.findImportDuplicates(preview.WorkspaceId, validTransactions, validLines)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar transações duplicadas: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+	for _, duplicate := range duplicates {
+		row := &response.Rows[duplicate.Line-2]
+		if duplicate.DuplicateOf != 0 {
+			row.Warnings = append(row.Warnings, "Possível duplicidade da linha "+strconv.Itoa(duplicate.DuplicateOf))
+		}
+		if len(duplicate.Transactions) > 0 {
+			row.Warnings = append(row.Warnings, "Possível duplicidade de uma transação já cadastrada")
+		}
+	}
+	response.Duplicates = append(response.Duplicates, duplicates...)
+
+	if len(validationErrors) > 0 || response.Valid == 0 {
+		return helpers.CreateResponse(response, http.StatusOK)
+	}
+
+	token, err := newImportPreviewToken()
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao gerar a pré-visualização: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	now := time.Now().UTC()
+	preview.Token = token
+	preview.CreatedAt = now
+	preview.ExpiresAt = now.Add(importPreviewExpiration)
+	preview.Transactions = make([]models.Transaction, 0, response.Valid)
+	for _, row := range response.Rows {
+		if row.Transaction != nil {
+			preview.Transactions = append(preview.Transactions, *row.Transaction)
+		}
+	}
+
+	if err := c.ImportPreviewRepository.Save(preview); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao salvar a pré-visualização: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	response.Token = token
+	response.ExpiresAt = &preview.ExpiresAt
+
+	return helpers.CreateResponse(response, http.StatusOK)
+}
+
+func newImportPreviewToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
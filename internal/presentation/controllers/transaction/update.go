@@ -18,26 +18,34 @@ import (
 )
 
 type UpdateTransactionController struct {
-	UpdateTransactionRepository   usecase.UpdateTransactionRepository
-	Validate                      *validator.Validate
-	FindTransactionById           usecase.FindTransactionByIdRepository
-	FindMemberByIdRepository      *member_repository.FindMemberByIdRepository
-	FindAccountByIdRepository     usecase.FindAccountByIdRepository
-	FindCategoryByIdRepository    usecase.FindCategoryByIdRepository
-	FindCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository
+	UpdateTransactionRepository     usecase.UpdateTransactionRepository
+	Validate                        *validator.Validate
+	FindTransactionById             usecase.FindTransactionByIdRepository
+	FindMemberByIdRepository        *member_repository.FindMemberByIdRepository
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	FindCategoryByIdRepository      usecase.FindCategoryByIdRepository
+	FindCustomFieldByIdRepository   usecase.FindCustomFieldByIdRepository
+	FindCreditCardByIdRepository    usecase.FindCreditCardByIdRepository
+	CreateAuditLogRepository        usecase.CreateAuditLogRepository
+	FindApprovalSettingsRepository  usecase.FindApprovalSettingsRepository
+	FindExchangeRateTableRepository usecase.FindExchangeRateTableRepository
 }
 
-func NewUpdateTransactionController(updateTransaction usecase.UpdateTransactionRepository, findTransactionById usecase.FindTransactionByIdRepository, findMemberByIdRepository *member_repository.FindMemberByIdRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository) *UpdateTransactionController {
+func NewUpdateTransactionController(updateTransaction usecase.UpdateTransactionRepository, findTransactionById usecase.FindTransactionByIdRepository, findMemberByIdRepository *member_repository.FindMemberByIdRepository, findAccountByIdRepository usecase.FindAccountByIdRepository, findCategoryByIdRepository usecase.FindCategoryByIdRepository, findCustomFieldByIdRepository usecase.FindCustomFieldByIdRepository, findCreditCardByIdRepository usecase.FindCreditCardByIdRepository, createAuditLogRepository usecase.CreateAuditLogRepository, findApprovalSettingsRepository usecase.FindApprovalSettingsRepository, findExchangeRateTableRepository usecase.FindExchangeRateTableRepository) *UpdateTransactionController {
 	validate := validator.New(validator.WithRequiredStructEnabled())
 
 	return &UpdateTransactionController{
-		UpdateTransactionRepository:   updateTransaction,
-		Validate:                      validate,
-		FindTransactionById:           findTransactionById,
-		FindMemberByIdRepository:      findMemberByIdRepository,
-		FindAccountByIdRepository:     findAccountByIdRepository,
-		FindCategoryByIdRepository:    findCategoryByIdRepository,
-		FindCustomFieldByIdRepository: findCustomFieldByIdRepository,
+		UpdateTransactionRepository:     updateTransaction,
+		Validate:                        validate,
+		FindTransactionById:             findTransactionById,
+		FindMemberByIdRepository:        findMemberByIdRepository,
+		FindAccountByIdRepository:       findAccountByIdRepository,
+		FindCategoryByIdRepository:      findCategoryByIdRepository,
+		FindCustomFieldByIdRepository:   findCustomFieldByIdRepository,
+		FindCreditCardByIdRepository:    findCreditCardByIdRepository,
+		CreateAuditLogRepository:        createAuditLogRepository,
+		FindApprovalSettingsRepository:  findApprovalSettingsRepository,
+		FindExchangeRateTableRepository: findExchangeRateTableRepository,
 	}
 }
 
@@ -80,6 +88,12 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 			Error: "erro ao buscar a transação",
 		}, http.StatusInternalServerError)
 	}
+	if _, isTransfer := models.TransactionTransferId(transaction); isTransfer {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "transações de transferência entre contas são editadas pela transferência",
+		}, http.StatusConflict)
+	}
+	previous := *transaction
 
 	transaction.Name = body.Name
 	transaction.Description = body.Description
@@ -97,6 +111,7 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 		InitialInstallment: body.RepeatSettings.InitialInstallment,
 		Count:              body.RepeatSettings.Count,
 		Interval:           body.RepeatSettings.Interval,
+		CustomDay:          body.RepeatSettings.CustomDay,
 	}
 
 	transactionIdsParsed, err := createTransaction(&body)
@@ -108,6 +123,7 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 
 	transaction.Tags = transactionIdsParsed.Tags
 	transaction.AccountId = transactionIdsParsed.AccountId
+	transaction.CreditCardId = transactionIdsParsed.CreditCardId
 	transaction.RegistrationDate = transactionIdsParsed.RegistrationDate
 	transaction.DueDate = transactionIdsParsed.DueDate
 	transaction.ConfirmationDate = transactionIdsParsed.ConfirmationDate
@@ -115,8 +131,15 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 	transaction.CustomFields = transactionIdsParsed.CustomFields
 	transaction.CategoryId = transactionIdsParsed.CategoryId
 	transaction.SubCategoryId = transactionIdsParsed.SubCategoryId
-	errChan := make(chan *presentationProtocols.HttpResponse, 4)
+	// sem moeda informada a transação mantém a sua
+	transaction.Currency = previous.Currency
+	if transactionIdsParsed.Currency != "" {
+		transaction.Currency = transactionIdsParsed.Currency
+	}
+
+	errChan := make(chan *presentationProtocols.HttpResponse, 6)
 	var wg sync.WaitGroup
+	var accountCurrency string
 
 	wg.Add(1)
 	go func() {
@@ -140,7 +163,27 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 		if transaction.AccountId == nil {
 			return
 		}
-		if err := c.validateAccount(workspaceId, *transaction.AccountId); err != nil {
+		account, err := c.validateAccount(workspaceId, *transaction.AccountId)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		accountCurrency = account.Currency
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer utils.RecoveryWithCallback(&wg, func(r interface{}) {
+			errChan <- helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "erro na validação do cartão de crédito: ocorreu um erro inesperado",
+			}, http.StatusInternalServerError)
+		})
+
+		if transaction.CreditCardId == nil {
+			return
+		}
+		if err := c.validateCreditCard(workspaceId, *transaction.CreditCardId); err != nil {
 			errChan <- err
 		}
 	}()
@@ -249,6 +292,32 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 		return <-errChan
 	}
 
+	rates, err := c.FindExchangeRateTableRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as cotações",
+		}, http.StatusInternalServerError)
+	}
+	if err := applyTransactionCurrency(rates, transaction, accountCurrency); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: err.Error(),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	approvalSettings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar as configurações de aprovação",
+		}, http.StatusInternalServerError)
+	}
+
+	applyApproval(approvalSettings, &previous, transaction)
+	if transaction.IsConfirmed && !models.CanBeConfirmed(transaction) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "a despesa precisa ser aprovada antes de ser confirmada",
+		}, http.StatusBadRequest)
+	}
+
 	transactionUpdated, err := c.UpdateTransactionRepository.Update(transactionId, transaction)
 	if err != nil {
 		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
@@ -256,6 +325,9 @@ func (c *UpdateTransactionController) Handle(r presentationProtocols.HttpRequest
 		}, http.StatusInternalServerError)
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, transactionUpdated)))
+
 	recipeTx := *transactionUpdated
 	recipeTx.Type = "RECIPE"
 	recipeNetBalance := infraHelpers.CalculateOneTransactionBalance(&recipeTx)
@@ -281,20 +353,37 @@ func (c *UpdateTransactionController) validateAssignedMember(workspaceId primiti
 	return nil
 }
 
-func (c *UpdateTransactionController) validateAccount(workspaceId primitive.ObjectID, accountId primitive.ObjectID) *presentationProtocols.HttpResponse {
+func (c *UpdateTransactionController) validateAccount(workspaceId primitive.ObjectID, accountId primitive.ObjectID) (*models.Account, *presentationProtocols.HttpResponse) {
 	account, err := c.FindAccountByIdRepository.Find(accountId, workspaceId)
 	if err != nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "erro ao buscar conta",
 		}, http.StatusInternalServerError)
 	}
 
 	if account == nil {
-		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		return nil, helpers.CreateResponse(&presentationProtocols.ErrorResponse{
 			Error: "conta não encontrada",
 		}, http.StatusNotFound)
 	}
 
+	return account, nil
+}
+
+func (c *UpdateTransactionController) validateCreditCard(workspaceId primitive.ObjectID, creditCardId primitive.ObjectID) *presentationProtocols.HttpResponse {
+	creditCard, err := c.FindCreditCardByIdRepository.Find(creditCardId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "erro ao buscar cartão de crédito",
+		}, http.StatusInternalServerError)
+	}
+
+	if creditCard == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "cartão de crédito não encontrado",
+		}, http.StatusNotFound)
+	}
+
 	return nil
 }
 
@@ -3,6 +3,7 @@ package transaction
 import (
 	"encoding/json"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -36,11 +37,11 @@ type UpdateManyRequest struct {
 
 // UpdateManyBalanceRequest is the request body for updating balance fields in multiple transactions
 type UpdateManyBalanceRequest struct {
-	Value              *float64 `json:"value,omitempty"`
-	Discount           *float64 `json:"discount,omitempty"`
-	Interest           *float64 `json:"interest,omitempty"`
-	DiscountPercentage *float64 `json:"discountPercentage,omitempty"`
-	InterestPercentage *float64 `json:"interestPercentage,omitempty"`
+	Value              *models.Money `json:"value,omitempty"`
+	Discount           *models.Money `json:"discount,omitempty"`
+	Interest           *models.Money `json:"interest,omitempty"`
+	DiscountPercentage *float64      `json:"discountPercentage,omitempty"`
+	InterestPercentage *float64      `json:"interestPercentage,omitempty"`
 }
 
 // CustomFieldUpdate is the request body for updating custom fields
@@ -55,6 +56,8 @@ type UpdateManyTransactionController struct {
 	UpdateTransactionRepository       usecase.UpdateTransactionRepository
 	CreateEditTransactionRepository   usecase.CreateEditTransactionRepository
 	FindCustomFieldByIdRepository     usecase.FindCustomFieldByIdRepository
+	CreateAuditLogRepository          usecase.CreateAuditLogRepository
+	FindApprovalSettingsRepository    usecase.FindApprovalSettingsRepository
 }
 
 func NewUpdateManyTransactionController(
@@ -63,6 +66,8 @@ func NewUpdateManyTransactionController(
 	updateTransaction usecase.UpdateTransactionRepository,
 	createEditTransaction usecase.CreateEditTransactionRepository,
 	findCustomFieldById usecase.FindCustomFieldByIdRepository,
+	createAuditLog usecase.CreateAuditLogRepository,
+	findApprovalSettings usecase.FindApprovalSettingsRepository,
 ) *UpdateManyTransactionController {
 	return &UpdateManyTransactionController{
 		FindTransactionByIdRepository:     findTransactionById,
@@ -70,6 +75,8 @@ func NewUpdateManyTransactionController(
 		UpdateTransactionRepository:       updateTransaction,
 		CreateEditTransactionRepository:   createEditTransaction,
 		FindCustomFieldByIdRepository:     findCustomFieldById,
+		CreateAuditLogRepository:          createAuditLog,
+		FindApprovalSettingsRepository:    findApprovalSettings,
 	}
 }
 
@@ -145,9 +152,19 @@ func (c *UpdateManyTransactionController) Handle(r presentationProtocols.HttpReq
 		}
 	}
 
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+
 	successCount := 0
 	failedCount := 0
 	updatedTransactions := []any{}
+	auditLogs := []models.AuditLog{}
+
+	approvalSettings, err := c.FindApprovalSettingsRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "Erro ao buscar as configurações de aprovação",
+		}, http.StatusInternalServerError)
+	}
 
 	for _, identifier := range transactionIdentifiers {
 		var transaction *models.Transaction
@@ -181,6 +198,15 @@ func (c *UpdateManyTransactionController) Handle(r presentationProtocols.HttpReq
 			}
 		}
 
+		// as duas pontas de uma transferência são editadas juntas, pela transferência
+		if _, isTransfer := models.TransactionTransferId(transaction); isTransfer {
+			failedCount++
+			continue
+		}
+
+		previous := *transaction
+		previous.CustomFields = slices.Clone(transaction.CustomFields)
+
 		// Update only non-nil fields
 		if body.Name != nil {
 			transaction.Name = *body.Name
@@ -279,6 +305,16 @@ func (c *UpdateManyTransactionController) Handle(r presentationProtocols.HttpReq
 			}
 		}
 
+		// as parcelas seguem a aprovação da transação principal
+		if !identifier.IsInstallment {
+			applyApproval(approvalSettings, &previous, transaction)
+		}
+		if transaction.IsConfirmed && !models.CanBeConfirmed(transaction) {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "A despesa precisa ser aprovada antes de ser confirmada! ID: " + identifier.ID.Hex(),
+			}, http.StatusBadRequest)
+		}
+
 		recipeNetBalance := infraHelpers.CalculateOneTransactionBalance(transaction)
 		transaction.Balance.NetBalance = recipeNetBalance
 
@@ -327,6 +363,7 @@ func (c *UpdateManyTransactionController) Handle(r presentationProtocols.HttpReq
 
 			successCount++
 			updatedTransactions = append(updatedTransactions, response)
+			auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, transaction))
 			continue
 		}
 
@@ -336,9 +373,12 @@ func (c *UpdateManyTransactionController) Handle(r presentationProtocols.HttpReq
 		} else {
 			successCount++
 			updatedTransactions = append(updatedTransactions, updatedTransaction)
+			auditLogs = append(auditLogs, infraHelpers.NewTransactionAuditLog(userId, "UPDATE", &previous, updatedTransaction))
 		}
 	}
 
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
 	return helpers.CreateResponse(map[string]any{
 		"success":      successCount,
 		"failed":       failedCount,
@@ -0,0 +1,330 @@
+package transaction_rule
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FindMemberByIdRepository finds a member of the workspace, used to check the assignee set by a rule
+type FindMemberByIdRepository interface {
+	Find(workspaceId primitive.ObjectID, memberId primitive.ObjectID) (*models.Member, error)
+}
+
+// CreateTransactionRuleController handles creating categorization rules
+type CreateTransactionRuleController struct {
+	Validate                        *validator.Validate
+	CreateTransactionRuleRepository usecase.CreateTransactionRuleRepository
+	FindCategoryByIdRepository      usecase.FindCategoryByIdRepository
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	FindCustomFieldByIdRepository   usecase.FindCustomFieldByIdRepository
+	FindMemberByIdRepository        FindMemberByIdRepository
+}
+
+// NewCreateTransactionRuleController creates a new instance of CreateTransactionRuleController
+func NewCreateTransactionRuleController(
+	createTransactionRule usecase.CreateTransactionRuleRepository,
+	findCategoryById usecase.FindCategoryByIdRepository,
+	findAccountById usecase.FindAccountByIdRepository,
+	findCustomFieldById usecase.FindCustomFieldByIdRepository,
+	findMemberById FindMemberByIdRepository,
+) *CreateTransactionRuleController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &CreateTransactionRuleController{
+		Validate:                        validate,
+		CreateTransactionRuleRepository: createTransactionRule,
+		FindCategoryByIdRepository:      findCategoryById,
+		FindAccountByIdRepository:       findAccountById,
+		FindCustomFieldByIdRepository:   findCustomFieldById,
+		FindMemberByIdRepository:        findMemberById,
+	}
+}
+
+// TransactionRuleBody represents a rule: the conditions a transaction must meet and how it is classified.
+// A rule without isActive is created active.
+type TransactionRuleBody struct {
+	Name       string `json:"name" validate:"required,min=2,max=50"`
+	Priority   int    `json:"priority" validate:"min=0,max=10000"`
+	IsActive   *bool  `json:"isActive"`
+	Conditions struct {
+		Name        string        `json:"name" validate:"omitempty,max=100"`
+		Supplier    string        `json:"supplier" validate:"omitempty,max=100"`
+		Description string        `json:"description" validate:"omitempty,max=255"`
+		MinAmount   *models.Money `json:"minAmount" validate:"omitempty,min=0"`
+		MaxAmount   *models.Money `json:"maxAmount" validate:"omitempty,min=0"`
+		AccountIds  []string      `json:"accountIds" validate:"omitempty,dive,mongodb"`
+		Type        string        `json:"type" validate:"omitempty,oneof=EXPENSE RECIPE"`
+	} `json:"conditions"`
+	Actions struct {
+		CategoryId    string `json:"categoryId" validate:"required_with=SubCategoryId,omitempty,mongodb"`
+		SubCategoryId string `json:"subCategoryId" validate:"omitempty,mongodb"`
+		Tags          []struct {
+			TagId    string `json:"tagId" validate:"required,mongodb"`
+			SubTagId string `json:"subTagId" validate:"omitempty,mongodb"`
+		} `json:"tags" validate:"omitempty,dive"`
+		AssignedTo   string `json:"assignedTo" validate:"omitempty,mongodb"`
+		CustomFields []struct {
+			Id    string `json:"id" validate:"required,mongodb"`
+			Value string `json:"value" validate:"required,max=100"`
+		} `json:"customFields" validate:"omitempty,dive"`
+	} `json:"actions"`
+}
+
+// Handle processes the HTTP request to create a categorization rule
+func (c *CreateTransactionRuleController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body TransactionRuleBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	userId, err := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid user ID format",
+		}, http.StatusBadRequest)
+	}
+
+	rule := body.toTransactionRule(workspaceId)
+	rule.CreatedBy = userId
+
+	if errResponse := validateTransactionRule(rule, c.FindCategoryByIdRepository, c.FindAccountByIdRepository, c.FindCustomFieldByIdRepository, c.FindMemberByIdRepository); errResponse != nil {
+		return errResponse
+	}
+
+	rule, err = c.CreateTransactionRuleRepository.Create(rule)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when creating rule",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(rule, http.StatusCreated)
+}
+
+// toTransactionRule converts the body, whose IDs were already checked by the validator
+func (body *TransactionRuleBody) toTransactionRule(workspaceId primitive.ObjectID) *models.TransactionRule {
+	objectId := func(id string) *primitive.ObjectID {
+		if id == "" {
+			return nil
+		}
+		objectId, _ := primitive.ObjectIDFromHex(id)
+		return &objectId
+	}
+
+	rule := &models.TransactionRule{
+		WorkspaceId: workspaceId,
+		Name:        strings.TrimSpace(body.Name),
+		Priority:    body.Priority,
+		IsActive:    body.IsActive == nil || *body.IsActive,
+		Conditions: models.TransactionRuleConditions{
+			Name:        strings.TrimSpace(body.Conditions.Name),
+			Supplier:    strings.TrimSpace(body.Conditions.Supplier),
+			Description: strings.TrimSpace(body.Conditions.Description),
+			MinAmount:   body.Conditions.MinAmount,
+			MaxAmount:   body.Conditions.MaxAmount,
+			Type:        body.Conditions.Type,
+		},
+		Actions: models.TransactionRuleActions{
+			CategoryId:    objectId(body.Actions.CategoryId),
+			SubCategoryId: objectId(body.Actions.SubCategoryId),
+			AssignedTo:    objectId(body.Actions.AssignedTo),
+		},
+	}
+
+	for _, accountId := range body.Conditions.AccountIds {
+		rule.Conditions.AccountIds = append(rule.Conditions.AccountIds, *objectId(accountId))
+	}
+
+	for _, tag := range body.Actions.Tags {
+		transactionTag := models.TransactionTags{TagId: *objectId(tag.TagId)}
+		if subTagId := objectId(tag.SubTagId); subTagId != nil {
+			transactionTag.SubTagId = *subTagId
+		}
+		rule.Actions.Tags = append(rule.Actions.Tags, transactionTag)
+	}
+
+	for _, customField := range body.Actions.CustomFields {
+		rule.Actions.CustomFields = append(rule.Actions.CustomFields, models.TransactionCustomField{
+			CustomFieldId: *objectId(customField.Id),
+			Value:         customField.Value,
+		})
+	}
+
+	return rule
+}
+
+// validateTransactionRule checks that the rule can match something and that everything its actions set
+// belongs to the workspace. A category or a type specific custom field needs the type condition, so the
+// rule never gives an expense category to a recipe.
+func validateTransactionRule(
+	rule *models.TransactionRule,
+	findCategoryById usecase.FindCategoryByIdRepository,
+	findAccountById usecase.FindAccountByIdRepository,
+	findCustomFieldById usecase.FindCustomFieldByIdRepository,
+	findMemberById FindMemberByIdRepository,
+) *presentationProtocols.HttpResponse {
+	conditions, actions := rule.Conditions, rule.Actions
+
+	if conditions.Name == "" && conditions.Supplier == "" && conditions.Description == "" &&
+		conditions.MinAmount == nil && conditions.MaxAmount == nil && len(conditions.AccountIds) == 0 && conditions.Type == "" {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "the rule needs at least one condition",
+		}, http.StatusUnprocessableEntity)
+	}
+
+	if actions.CategoryId == nil && len(actions.Tags) == 0 && actions.AssignedTo == nil && len(actions.CustomFields) == 0 {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "the rule needs at least one action",
+		}, http.StatusUnprocessableEntity)
+	}
+
+	if conditions.MinAmount != nil && conditions.MaxAmount != nil && *conditions.MinAmount > *conditions.MaxAmount {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "minimum amount cannot be greater than maximum amount",
+		}, http.StatusUnprocessableEntity)
+	}
+
+	for _, accountId := range conditions.AccountIds {
+		account, err := findAccountById.Find(accountId, rule.WorkspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving account",
+			}, http.StatusInternalServerError)
+		}
+		if account == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "account not found",
+			}, http.StatusNotFound)
+		}
+	}
+
+	if actions.CategoryId != nil {
+		if conditions.Type == "" {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "a rule that sets a category needs the transaction type condition",
+			}, http.StatusUnprocessableEntity)
+		}
+
+		if errResponse := validateRuleCategory(findCategoryById, rule.WorkspaceId, *actions.CategoryId, actions.SubCategoryId, conditions.Type); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	seenTags := make(map[models.TransactionTags]bool)
+	for _, tag := range actions.Tags {
+		if seenTags[tag] {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "duplicate tag: " + tag.TagId.Hex(),
+			}, http.StatusUnprocessableEntity)
+		}
+		seenTags[tag] = true
+
+		subTagId := &tag.SubTagId
+		if tag.SubTagId.IsZero() {
+			subTagId = nil
+		}
+		if errResponse := validateRuleCategory(findCategoryById, rule.WorkspaceId, tag.TagId, subTagId, "TAG"); errResponse != nil {
+			return errResponse
+		}
+	}
+
+	if actions.AssignedTo != nil {
+		member, err := findMemberById.Find(rule.WorkspaceId, *actions.AssignedTo)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving member",
+			}, http.StatusInternalServerError)
+		}
+		if member == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "the assignee is not a member of the workspace",
+			}, http.StatusNotFound)
+		}
+	}
+
+	seenCustomFields := make(map[primitive.ObjectID]bool)
+	for _, field := range actions.CustomFields {
+		if seenCustomFields[field.CustomFieldId] {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "duplicate custom field: " + field.CustomFieldId.Hex(),
+			}, http.StatusUnprocessableEntity)
+		}
+		seenCustomFields[field.CustomFieldId] = true
+
+		customField, err := findCustomFieldById.Find(field.CustomFieldId, rule.WorkspaceId)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "an error occurred when retrieving custom field",
+			}, http.StatusInternalServerError)
+		}
+		if customField == nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "custom field not found",
+			}, http.StatusNotFound)
+		}
+		if customField.TransactionType != "" && customField.TransactionType != "ALL" && customField.TransactionType != conditions.Type {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "the custom field " + customField.Name + " does not apply to the transaction type of the rule",
+			}, http.StatusUnprocessableEntity)
+		}
+	}
+
+	return nil
+}
+
+// validateRuleCategory checks that the category, or tag, exists with the expected type and owns the sub-category
+func validateRuleCategory(findCategoryById usecase.FindCategoryByIdRepository, workspaceId primitive.ObjectID, categoryId primitive.ObjectID, subCategoryId *primitive.ObjectID, categoryType string) *presentationProtocols.HttpResponse {
+	category, err := findCategoryById.Find(categoryId, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving category",
+		}, http.StatusInternalServerError)
+	}
+	if category == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "category not found",
+		}, http.StatusNotFound)
+	}
+
+	if !strings.EqualFold(category.Type, categoryType) {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "the type of the category " + category.Name + " must be " + categoryType,
+		}, http.StatusUnprocessableEntity)
+	}
+
+	if subCategoryId == nil {
+		return nil
+	}
+
+	for _, subCategory := range category.SubCategories {
+		if subCategory.Id == *subCategoryId {
+			return nil
+		}
+	}
+
+	return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+		Error: "sub category not found",
+	}, http.StatusNotFound)
+}
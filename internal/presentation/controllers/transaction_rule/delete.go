@@ -0,0 +1,50 @@
+package transaction_rule
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeleteTransactionRuleController handles deleting categorization rules
+type DeleteTransactionRuleController struct {
+	DeleteTransactionRuleRepository usecase.DeleteTransactionRuleRepository
+}
+
+// NewDeleteTransactionRuleController creates a new instance of DeleteTransactionRuleController
+func NewDeleteTransactionRuleController(deleteTransactionRule usecase.DeleteTransactionRuleRepository) *DeleteTransactionRuleController {
+	return &DeleteTransactionRuleController{DeleteTransactionRuleRepository: deleteTransactionRule}
+}
+
+// Handle processes the HTTP request to delete rules. Transactions already classified keep their classification.
+func (c *DeleteTransactionRuleController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var ids []primitive.ObjectID
+	for _, id := range strings.Split(r.UrlParams.Get("ids"), ",") {
+		objectId, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+				Error: "invalid rule ID format",
+			}, http.StatusBadRequest)
+		}
+		ids = append(ids, objectId)
+	}
+
+	if err := c.DeleteTransactionRuleRepository.Delete(ids, workspaceId); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when deleting rules",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(nil, http.StatusNoContent)
+}
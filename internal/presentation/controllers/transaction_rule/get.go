@@ -0,0 +1,80 @@
+package transaction_rule
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetTransactionRulesController handles listing categorization rules
+type GetTransactionRulesController struct {
+	FindTransactionRulesRepository usecase.FindTransactionRulesRepository
+}
+
+// NewGetTransactionRulesController creates a new instance of GetTransactionRulesController
+func NewGetTransactionRulesController(findTransactionRules usecase.FindTransactionRulesRepository) *GetTransactionRulesController {
+	return &GetTransactionRulesController{FindTransactionRulesRepository: findTransactionRules}
+}
+
+// Handle processes the HTTP request to retrieve the rules in the order they are evaluated
+func (c *GetTransactionRulesController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	rules, err := c.FindTransactionRulesRepository.Find(workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving rules",
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(rules, http.StatusOK)
+}
+
+// GetTransactionRuleByIdController handles fetching a categorization rule
+type GetTransactionRuleByIdController struct {
+	FindTransactionRuleByIdRepository usecase.FindTransactionRuleByIdRepository
+}
+
+// NewGetTransactionRuleByIdController creates a new instance of GetTransactionRuleByIdController
+func NewGetTransactionRuleByIdController(findTransactionRuleById usecase.FindTransactionRuleByIdRepository) *GetTransactionRuleByIdController {
+	return &GetTransactionRuleByIdController{FindTransactionRuleByIdRepository: findTransactionRuleById}
+}
+
+// Handle processes the HTTP request to retrieve a rule
+func (c *GetTransactionRuleByIdController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("ruleId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid rule ID format",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	rule, err := c.FindTransactionRuleByIdRepository.Find(id, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when retrieving rule",
+		}, http.StatusInternalServerError)
+	}
+	if rule == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "rule not found",
+		}, http.StatusNotFound)
+	}
+
+	return helpers.CreateResponse(rule, http.StatusOK)
+}
@@ -0,0 +1,90 @@
+package transaction_rule
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// UpdateTransactionRuleController handles updating categorization rules
+type UpdateTransactionRuleController struct {
+	Validate                        *validator.Validate
+	UpdateTransactionRuleRepository usecase.UpdateTransactionRuleRepository
+	FindCategoryByIdRepository      usecase.FindCategoryByIdRepository
+	FindAccountByIdRepository       usecase.FindAccountByIdRepository
+	FindCustomFieldByIdRepository   usecase.FindCustomFieldByIdRepository
+	FindMemberByIdRepository        FindMemberByIdRepository
+}
+
+// NewUpdateTransactionRuleController creates a new instance of UpdateTransactionRuleController
+func NewUpdateTransactionRuleController(
+	updateTransactionRule usecase.UpdateTransactionRuleRepository,
+	findCategoryById usecase.FindCategoryByIdRepository,
+	findAccountById usecase.FindAccountByIdRepository,
+	findCustomFieldById usecase.FindCustomFieldByIdRepository,
+	findMemberById FindMemberByIdRepository,
+) *UpdateTransactionRuleController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &UpdateTransactionRuleController{
+		Validate:                        validate,
+		UpdateTransactionRuleRepository: updateTransactionRule,
+		FindCategoryByIdRepository:      findCategoryById,
+		FindAccountByIdRepository:       findAccountById,
+		FindCustomFieldByIdRepository:   findCustomFieldById,
+		FindMemberByIdRepository:        findMemberById,
+	}
+}
+
+// Handle processes the HTTP request to update a rule. The body replaces the whole rule.
+func (c *UpdateTransactionRuleController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	id, err := primitive.ObjectIDFromHex(r.Req.PathValue("ruleId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid rule ID format",
+		}, http.StatusBadRequest)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	var body TransactionRuleBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	rule := body.toTransactionRule(workspaceId)
+	if errResponse := validateTransactionRule(rule, c.FindCategoryByIdRepository, c.FindAccountByIdRepository, c.FindCustomFieldByIdRepository, c.FindMemberByIdRepository); errResponse != nil {
+		return errResponse
+	}
+
+	rule, err = c.UpdateTransactionRuleRepository.Update(id, rule)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when updating rule",
+		}, http.StatusInternalServerError)
+	}
+	if rule == nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "rule not found",
+		}, http.StatusNotFound)
+	}
+
+	return helpers.CreateResponse(rule, http.StatusOK)
+}
@@ -0,0 +1,57 @@
+package trash
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GetTrashController handles listing the deleted items of a workspace
+type GetTrashController struct {
+	FindTrashRepository usecase.FindTrashRepository
+	Validate            *validator.Validate
+}
+
+// NewGetTrashController creates a new instance of GetTrashController
+func NewGetTrashController(findTrashRepository usecase.FindTrashRepository) *GetTrashController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &GetTrashController{
+		FindTrashRepository: findTrashRepository,
+		Validate:            validate,
+	}
+}
+
+type GetTrashParams struct {
+	Entity string `validate:"omitempty,oneof=TRANSACTION ACCOUNT CATEGORY CREDIT_CARD CUSTOM_FIELD"`
+}
+
+// Handle processes the HTTP request to list the trash
+func (c *GetTrashController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	params := &GetTrashParams{Entity: strings.ToUpper(r.UrlParams.Get("entity"))}
+	if err := c.Validate.Struct(params); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusBadRequest)
+	}
+
+	items, err := c.FindTrashRepository.Find(workspaceId, params.Entity)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when finding the trash: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	return helpers.CreateResponse(items, http.StatusOK)
+}
@@ -0,0 +1,119 @@
+package trash
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/presentation/helpers"
+	presentationProtocols "github.com/anuntech/finance-backend/internal/presentation/protocols"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RestoreTrashController handles restoring deleted items
+type RestoreTrashController struct {
+	Validate                 *validator.Validate
+	RestoreTrashRepository   usecase.RestoreTrashRepository
+	CreateAuditLogRepository usecase.CreateAuditLogRepository
+}
+
+// NewRestoreTrashController creates a new instance of RestoreTrashController
+func NewRestoreTrashController(restoreTrash usecase.RestoreTrashRepository, createAuditLog usecase.CreateAuditLogRepository) *RestoreTrashController {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	return &RestoreTrashController{
+		Validate:                 validate,
+		RestoreTrashRepository:   restoreTrash,
+		CreateAuditLogRepository: createAuditLog,
+	}
+}
+
+// RestoreTrashBody holds the ids of the trash items, as returned by GET /trash
+type RestoreTrashBody struct {
+	Ids []string `json:"ids" validate:"required,min=1,dive,mongodb"`
+}
+
+// RestoreTrashResponse lists the restored items and the ones kept in the trash because their name is
+// now used by another record
+type RestoreTrashResponse struct {
+	Restored  []models.TrashItem `json:"restored"`
+	Conflicts []models.TrashItem `json:"conflicts"`
+}
+
+// Handle processes the HTTP request to restore items from the trash
+func (c *RestoreTrashController) Handle(r presentationProtocols.HttpRequest) *presentationProtocols.HttpResponse {
+	var body RestoreTrashBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid body request",
+		}, http.StatusBadRequest)
+	}
+
+	if err := c.Validate.Struct(body); err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: helpers.GetErrorMessages(c.Validate, err),
+		}, http.StatusUnprocessableEntity)
+	}
+
+	workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "invalid workspace ID format",
+		}, http.StatusBadRequest)
+	}
+
+	ids := make([]primitive.ObjectID, len(body.Ids))
+	for i, id := range body.Ids {
+		ids[i], _ = primitive.ObjectIDFromHex(id)
+	}
+
+	result, err := c.RestoreTrashRepository.Restore(ids, workspaceId)
+	if err != nil {
+		return helpers.CreateResponse(&presentationProtocols.ErrorResponse{
+			Error: "an error occurred when restoring the trash: " + err.Error(),
+		}, http.StatusInternalServerError)
+	}
+
+	userId, _ := primitive.ObjectIDFromHex(r.Header.Get("userId"))
+	auditLogs := []models.AuditLog{}
+	for _, item := range result.Restored {
+		if log, ok := restoreAuditLog(userId, &item); ok {
+			auditLogs = append(auditLogs, log)
+		}
+	}
+	helpers.LogAuditError(c.CreateAuditLogRepository.Create(auditLogs...))
+
+	return helpers.CreateResponse(&RestoreTrashResponse{
+		Restored:  result.Restored,
+		Conflicts: result.Conflicts,
+	}, http.StatusOK)
+}
+
+// restoreAuditLog records the restore of the audited entities. Installments are logged on the main
+// transaction, like their other changes.
+func restoreAuditLog(userId primitive.ObjectID, item *models.TrashItem) (models.AuditLog, bool) {
+	switch item.Entity {
+	case "TRANSACTION", "ACCOUNT", "CATEGORY":
+	default:
+		return models.AuditLog{}, false
+	}
+
+	entityId := item.EntityId
+	if item.MainId != nil {
+		entityId = *item.MainId
+	}
+
+	return models.AuditLog{
+		Id:          primitive.NewObjectID(),
+		WorkspaceId: item.WorkspaceId,
+		UserId:      userId,
+		Entity:      item.Entity,
+		EntityId:    entityId,
+		MainCount:   item.MainCount,
+		Action:      "RESTORE",
+		Changes:     []models.AuditLogChange{{Field: "deleted_at", Before: item.DeletedAt}},
+		CreatedAt:   time.Now().UTC(),
+	}, true
+}
@@ -0,0 +1,11 @@
+package helpers
+
+import "log"
+
+// LogAuditError registra a falha ao gravar a auditoria sem interromper a requisição, já que a
+// alteração auditada já foi feita
+func LogAuditError(err error) {
+	if err != nil {
+		log.Printf("error recording audit log: %v", err)
+	}
+}
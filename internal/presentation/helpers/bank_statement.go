@@ -0,0 +1,225 @@
+package helpers
+
+import (
+	"bufio"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+// BankStatementLine representa um lançamento do extrato bancário. O valor é positivo para créditos
+// e negativo para débitos.
+type BankStatementLine struct {
+	Id          string
+	Date        time.Time
+	Amount      models.Money
+	Description string
+}
+
+var (
+	ofxTransactionBlock = regexp.MustCompile(`(?is)<STMTTRN>(.*?)(?:</STMTTRN>|</BANKTRANLIST>)`)
+	ofxTag              = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+// ParseOFX lê os lançamentos (STMTTRN) de um arquivo OFX. Funciona tanto para o OFX 1.x (SGML, sem
+// as tags de fechamento) quanto para o OFX 2.x (XML).
+func ParseOFX(reader io.Reader) ([]BankStatementLine, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	content := decodeStatement(data)
+
+	blocks := ofxTransactionBlock.FindAllStringSubmatch(content, -1)
+	if len(blocks) == 0 {
+		return nil, errors.New("no transactions found in OFX file")
+	}
+
+	lines := make([]BankStatementLine, 0, len(blocks))
+	for _, block := range blocks {
+		fields := map[string]string{}
+		for _, tag := range ofxTag.FindAllStringSubmatch(block[1], -1) {
+			// o texto vem com as entidades (&amp;, &lt;...) que o próprio WriteOFX grava
+			fields[strings.ToUpper(tag[1])] = html.UnescapeString(strings.TrimSpace(tag[2]))
+		}
+
+		date, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, errors.New("invalid DTPOSTED in OFX file: " + fields["DTPOSTED"])
+		}
+
+		amount, err := parseOFXAmount(fields["TRNAMT"])
+		if err != nil {
+			return nil, errors.New("invalid TRNAMT in OFX file: " + fields["TRNAMT"])
+		}
+
+		// o NAME tem no máximo 32 caracteres, e o MEMO que começa por ele é a descrição completa
+		description := fields["NAME"]
+		if memo := fields["MEMO"]; strings.HasPrefix(strings.ToLower(memo), strings.ToLower(description)) {
+			description = memo
+		} else if memo != "" {
+			description = strings.TrimSpace(description + " " + memo)
+		}
+
+		lines = append(lines, BankStatementLine{
+			Id:          fields["FITID"],
+			Date:        date,
+			Amount:      amount,
+			Description: description,
+		})
+	}
+
+	return lines, nil
+}
+
+// parseOFXAmount aceita a vírgula ou o ponto como separador decimal. Alguns bancos gravam também o
+// separador de milhar (1.234,56): o último separador é o decimal e os anteriores são descartados, a
+// menos que ele se repita (1.234.567), quando todos são de milhar.
+func parseOFXAmount(value string) (models.Money, error) {
+	decimal := strings.LastIndexAny(value, ".,")
+	if decimal >= 0 {
+		integer := strings.NewReplacer(".", "", ",", "").Replace(value[:decimal])
+		if strings.Contains(value[:decimal], value[decimal:decimal+1]) {
+			value = integer + value[decimal+1:]
+		} else {
+			value = integer + "." + value[decimal+1:]
+		}
+	}
+	return models.ParseMoney(value)
+}
+
+// parseOFXDate considera apenas a data (AAAAMMDD); hora e fuso, quando presentes, são ignorados
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("invalid date")
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// ParseCNAB240 lê os lançamentos do segmento E (extrato para conciliação bancária) de um arquivo
+// CNAB 240 da FEBRABAN. As posições seguem o layout padrão, contadas a partir de 1.
+func ParseCNAB240(reader io.Reader) ([]BankStatementLine, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []BankStatementLine
+	scanner := bufio.NewScanner(strings.NewReader(decodeStatement(data)))
+	for scanner.Scan() {
+		// as posições contam caracteres e não bytes: um acento antes de um campo não pode deslocar os seguintes
+		record := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		if len(record) < 169 {
+			continue
+		}
+		// registro de detalhe (posição 8) do segmento E (posição 14)
+		if record[7] != '3' || record[13] != 'E' {
+			continue
+		}
+		for len(record) < 240 {
+			record = append(record, ' ')
+		}
+		field := func(start, end int) string {
+			return string(record[start:end])
+		}
+
+		date, err := time.Parse("02012006", field(142, 150))
+		if err != nil {
+			return nil, errors.New("invalid posting date in CNAB 240 file: " + field(142, 150))
+		}
+
+		cents, err := strconv.ParseInt(field(150, 168), 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid amount in CNAB 240 file: " + field(150, 168))
+		}
+		amount := models.Money(cents)
+		if record[168] == 'D' {
+			amount = -amount
+		}
+
+		lines = append(lines, BankStatementLine{
+			Id:          strings.TrimSpace(field(201, 240)),
+			Date:        date,
+			Amount:      amount,
+			Description: strings.TrimSpace(field(176, 201)),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("no statement records (segment E) found in CNAB 240 file")
+	}
+
+	return lines, nil
+}
+
+// decodeStatement converte arquivos em ISO-8859-1/Windows-1252, comuns nos bancos brasileiros, para UTF-8
+func decodeStatement(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+var ofxEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", " ", "\n", " ")
+
+// WriteOFX grava os lançamentos como um extrato OFX 1.02 (SGML), com as tags de fechamento para que
+// também seja lido pelos programas que esperam o OFX 2.x. O arquivo volta a ser lido por ParseOFX.
+func WriteOFX(writer io.Writer, accountId string, lines []BankStatementLine, start, end time.Time) error {
+	const dateLayout = "20060102"
+	now := time.Now().UTC().Format("20060102150405")
+
+	output := bufio.NewWriter(writer)
+	output.WriteString("OFXHEADER:100\r\nDATA:OFXSGML\r\nVERSION:102\r\nSECURITY:NONE\r\nENCODING:UTF-8\r\nCHARSET:NONE\r\nCOMPRESSION:NONE\r\nOLDFILEUID:NONE\r\nNEWFILEUID:NONE\r\n\r\n")
+	output.WriteString("<OFX>\r\n<SIGNONMSGSRSV1>\r\n<SONRS>\r\n<STATUS>\r\n<CODE>0</CODE>\r\n<SEVERITY>INFO</SEVERITY>\r\n</STATUS>\r\n")
+	output.WriteString("<DTSERVER>" + now + "</DTSERVER>\r\n<LANGUAGE>POR</LANGUAGE>\r\n</SONRS>\r\n</SIGNONMSGSRSV1>\r\n")
+	output.WriteString("<BANKMSGSRSV1>\r\n<STMTTRNRS>\r\n<TRNUID>1</TRNUID>\r\n<STATUS>\r\n<CODE>0</CODE>\r\n<SEVERITY>INFO</SEVERITY>\r\n</STATUS>\r\n")
+	output.WriteString("<STMTRS>\r\n<CURDEF>BRL</CURDEF>\r\n<BANKACCTFROM>\r\n<BANKID>0</BANKID>\r\n<ACCTID>" + ofxEscaper.Replace(accountId) + "</ACCTID>\r\n<ACCTTYPE>CHECKING</ACCTTYPE>\r\n</BANKACCTFROM>\r\n")
+	output.WriteString("<BANKTRANLIST>\r\n<DTSTART>" + start.Format(dateLayout) + "</DTSTART>\r\n<DTEND>" + end.Format(dateLayout) + "</DTEND>\r\n")
+
+	var balance models.Money
+	for _, line := range lines {
+		transactionType := "CREDIT"
+		if line.Amount < 0 {
+			transactionType = "DEBIT"
+		}
+		balance += line.Amount
+
+		output.WriteString("<STMTTRN>\r\n<TRNTYPE>" + transactionType + "</TRNTYPE>\r\n")
+		output.WriteString("<DTPOSTED>" + line.Date.Format(dateLayout) + "</DTPOSTED>\r\n")
+		output.WriteString("<TRNAMT>" + line.Amount.String() + "</TRNAMT>\r\n")
+		output.WriteString("<FITID>" + ofxEscaper.Replace(line.Id) + "</FITID>\r\n")
+		output.WriteString("<NAME>" + ofxEscaper.Replace(truncateOFX(line.Description, 32)) + "</NAME>\r\n")
+		if utf8.RuneCountInString(line.Description) > 32 {
+			output.WriteString("<MEMO>" + ofxEscaper.Replace(truncateOFX(line.Description, 255)) + "</MEMO>\r\n")
+		}
+		output.WriteString("</STMTTRN>\r\n")
+	}
+
+	output.WriteString("</BANKTRANLIST>\r\n<LEDGERBAL>\r\n<BALAMT>" + balance.String() + "</BALAMT>\r\n")
+	output.WriteString("<DTASOF>" + end.Format(dateLayout) + "</DTASOF>\r\n</LEDGERBAL>\r\n</STMTRS>\r\n</STMTTRNRS>\r\n</BANKMSGSRSV1>\r\n</OFX>\r\n")
+
+	return output.Flush()
+}
+
+func truncateOFX(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) <= size {
+		return value
+	}
+	return string(runes[:size])
+}
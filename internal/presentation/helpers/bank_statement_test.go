@@ -0,0 +1,62 @@
+package helpers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+func TestWriteOFXParseOFX(t *testing.T) {
+	date := time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC)
+	lines := []BankStatementLine{
+		{Id: "1", Date: date, Amount: -123456, Description: "Padaria Pão & Cia"},
+		{Id: "2", Date: date, Amount: 5000, Description: "Transferência recebida de <João> & Maria Comércio Ltda"},
+	}
+
+	var buffer bytes.Buffer
+	if err := WriteOFX(&buffer, "12345-6", lines, date, date); err != nil {
+		t.Fatalf("WriteOFX() error = %v", err)
+	}
+
+	parsed, err := ParseOFX(&buffer)
+	if err != nil {
+		t.Fatalf("ParseOFX() error = %v", err)
+	}
+	if len(parsed) != len(lines) {
+		t.Fatalf("ParseOFX() returned %d lines, want %d", len(parsed), len(lines))
+	}
+	for i, line := range lines {
+		if parsed[i] != line {
+			t.Errorf("line %d = %+v, want %+v", i, parsed[i], line)
+		}
+	}
+}
+
+func TestParseOFXAmount(t *testing.T) {
+	tests := []struct {
+		value string
+		want  models.Money
+	}{
+		{value: "-1234.56", want: -123456},
+		{value: "-1234,56", want: -123456},
+		{value: "1.234,56", want: 123456},
+		{value: "1,234.56", want: 123456},
+		{value: "1.234.567", want: 123456700},
+		{value: "50", want: 5000},
+	}
+
+	for _, tt := range tests {
+		statement := "<OFX><BANKTRANLIST><STMTTRN><DTPOSTED>20250110<TRNAMT>" + tt.value + "<FITID>1<NAME>Teste</STMTTRN></BANKTRANLIST></OFX>"
+		lines, err := ParseOFX(strings.NewReader(statement))
+		if err != nil {
+			t.Errorf("ParseOFX(TRNAMT %s) error = %v", tt.value, err)
+			continue
+		}
+		if lines[0].Amount != tt.want {
+			t.Errorf("ParseOFX(TRNAMT %s) amount = %v, want %v", tt.value, lines[0].Amount, tt.want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"mime"
+	"net/http"
 
 	"github.com/anuntech/finance-backend/internal/presentation/protocols"
 )
@@ -27,3 +29,16 @@ func CreateResponse(body interface{}, statusCode int) *protocols.HttpResponse {
 		StatusCode: statusCode,
 	}
 }
+
+// CreateFileResponse devolve o conteúdo de um arquivo para download, com o nome original
+func CreateFileResponse(body io.ReadCloser, contentType string, fileName string) *protocols.HttpResponse {
+	header := http.Header{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+
+	return &protocols.HttpResponse{
+		Body:       body,
+		StatusCode: http.StatusOK,
+		Header:     header,
+	}
+}
@@ -0,0 +1,109 @@
+package helpers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExchangeRateLine representa uma cotação lida do arquivo: quanto uma unidade de From vale em To na data
+type ExchangeRateLine struct {
+	Date time.Time
+	From string
+	To   string
+	Rate float64
+}
+
+var exchangeRateColumns = []string{"date", "from", "to", "rate"}
+
+// ParseExchangeRatesCSV lê as cotações de um CSV com as colunas date, from, to e rate, em qualquer ordem.
+// Aceita vírgula ou ponto e vírgula como separador, datas em AAAA-MM-DD ou DD/MM/AAAA e vírgula decimal.
+func ParseExchangeRatesCSV(reader io.Reader) ([]ExchangeRateLine, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimPrefix(decodeStatement(data), "\ufeff")
+
+	csvReader := csv.NewReader(strings.NewReader(content))
+	csvReader.TrimLeadingSpace = true
+	if firstLine, _, _ := strings.Cut(content, "\n"); strings.Count(firstLine, ";") > strings.Count(firstLine, ",") {
+		csvReader.Comma = ';'
+	}
+
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.New("invalid CSV file: " + err.Error())
+	}
+	if len(records) < 2 {
+		return nil, errors.New("no exchange rates found in CSV file")
+	}
+
+	index := make(map[string]int)
+	for i, column := range records[0] {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+	for _, column := range exchangeRateColumns {
+		if _, exists := index[column]; !exists {
+			return nil, errors.New("missing column in CSV file: " + column)
+		}
+	}
+
+	lines := make([]ExchangeRateLine, 0, len(records)-1)
+	for i, record := range records[1:] {
+		lineNumber := i + 2
+		if isBlankRecord(record) {
+			continue
+		}
+
+		field := func(column string) string {
+			if index[column] >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[index[column]])
+		}
+
+		date, err := parseExchangeRateDate(field("date"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid date on line %d: %s", lineNumber, field("date"))
+		}
+
+		rate, err := strconv.ParseFloat(strings.ReplaceAll(field("rate"), ",", "."), 64)
+		if err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid rate on line %d: %s", lineNumber, field("rate"))
+		}
+
+		lines = append(lines, ExchangeRateLine{
+			Date: date,
+			From: strings.ToUpper(field("from")),
+			To:   strings.ToUpper(field("to")),
+			Rate: rate,
+		})
+	}
+
+	if len(lines) == 0 {
+		return nil, errors.New("no exchange rates found in CSV file")
+	}
+
+	return lines, nil
+}
+
+func parseExchangeRateDate(value string) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+	return time.Parse("02/01/2006", value)
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,228 @@
+package helpers
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+// NFe reúne os dados de uma nota fiscal eletrônica usados para lançar a despesa
+type NFe struct {
+	AccessKey     string
+	Number        string
+	Series        string
+	IssueDate     time.Time
+	Issuer        NFeIssuer
+	Items         []NFeItem
+	ProductsTotal models.Money
+	Discount      models.Money // desconto dos produtos somado ao desconto da fatura
+	Total         models.Money // valor total da nota (vNF)
+	Installments  []NFeInstallment
+}
+
+// NFeIssuer é o emitente da nota, o fornecedor. Document é o CNPJ, ou o CPF, apenas com os dígitos.
+type NFeIssuer struct {
+	Document  string
+	Name      string
+	TradeName string
+}
+
+type NFeItem struct {
+	Description string
+	Ncm         string
+	Cfop        string
+	Value       models.Money
+}
+
+// NFeInstallment é uma duplicata da cobrança
+type NFeInstallment struct {
+	Number  string
+	DueDate time.Time
+	Value   models.Money
+}
+
+type nfeInfXML struct {
+	Id  string `xml:"Id,attr"`
+	Ide struct {
+		Number string `xml:"nNF"`
+		Series string `xml:"serie"`
+		DhEmi  string `xml:"dhEmi"`
+		DEmi   string `xml:"dEmi"`
+	} `xml:"ide"`
+	Emit struct {
+		CNPJ  string `xml:"CNPJ"`
+		CPF   string `xml:"CPF"`
+		XNome string `xml:"xNome"`
+		XFant string `xml:"xFant"`
+	} `xml:"emit"`
+	Det []struct {
+		Prod struct {
+			XProd string `xml:"xProd"`
+			NCM   string `xml:"NCM"`
+			CFOP  string `xml:"CFOP"`
+			VProd string `xml:"vProd"`
+		} `xml:"prod"`
+	} `xml:"det"`
+	Total struct {
+		ICMSTot struct {
+			VProd string `xml:"vProd"`
+			VDesc string `xml:"vDesc"`
+			VNF   string `xml:"vNF"`
+		} `xml:"ICMSTot"`
+	} `xml:"total"`
+	Cobr struct {
+		Fat struct {
+			VDesc string `xml:"vDesc"`
+		} `xml:"fat"`
+		Dup []struct {
+			NDup  string `xml:"nDup"`
+			DVenc string `xml:"dVenc"`
+			VDup  string `xml:"vDup"`
+		} `xml:"dup"`
+	} `xml:"cobr"`
+}
+
+// ParseNFe lê o XML de uma NF-e (modelo 55), tanto o nfeProc autorizado quanto apenas o elemento NFe.
+// Somente o grupo infNFe é considerado; a assinatura e o protocolo são ignorados.
+func ParseNFe(reader io.Reader) (*NFe, error) {
+	decoder := xml.NewDecoder(reader)
+	decoder.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(decodeStatement(data)), nil
+	}
+
+	var inf *nfeInfXML
+	for inf == nil {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.New("infNFe element not found, the file is not an NF-e")
+		}
+		if err != nil {
+			return nil, errors.New("invalid XML: " + err.Error())
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "infNFe" {
+			continue
+		}
+
+		inf = &nfeInfXML{}
+		if err := decoder.DecodeElement(inf, &start); err != nil {
+			return nil, errors.New("invalid infNFe element: " + err.Error())
+		}
+	}
+
+	return buildNFe(inf)
+}
+
+func buildNFe(inf *nfeInfXML) (*NFe, error) {
+	nfe := &NFe{
+		AccessKey: strings.TrimPrefix(strings.TrimSpace(inf.Id), "NFe"),
+		Number:    strings.TrimSpace(inf.Ide.Number),
+		Series:    strings.TrimSpace(inf.Ide.Series),
+		Issuer: NFeIssuer{
+			Document:  onlyDigits(inf.Emit.CNPJ + inf.Emit.CPF),
+			Name:      strings.TrimSpace(inf.Emit.XNome),
+			TradeName: strings.TrimSpace(inf.Emit.XFant),
+		},
+	}
+
+	if nfe.Number == "" {
+		return nil, errors.New("NF-e without number (nNF)")
+	}
+	if nfe.Issuer.Name == "" || nfe.Issuer.Document == "" {
+		return nil, errors.New("NF-e without issuer name or CNPJ/CPF")
+	}
+
+	// dhEmi a partir da versão 3.10, dEmi nas versões anteriores
+	issueDate, err := parseNFeDate(inf.Ide.DhEmi + inf.Ide.DEmi)
+	if err != nil {
+		return nil, errors.New("invalid issue date in NF-e: " + inf.Ide.DhEmi + inf.Ide.DEmi)
+	}
+	nfe.IssueDate = issueDate
+
+	if nfe.Total, err = parseNFeValue(inf.Total.ICMSTot.VNF); err != nil || nfe.Total <= 0 {
+		return nil, errors.New("invalid total value (vNF) in NF-e: " + inf.Total.ICMSTot.VNF)
+	}
+	if nfe.ProductsTotal, err = parseNFeValue(inf.Total.ICMSTot.VProd); err != nil {
+		return nil, errors.New("invalid products value (vProd) in NF-e: " + inf.Total.ICMSTot.VProd)
+	}
+
+	productsDiscount, err := parseNFeValue(inf.Total.ICMSTot.VDesc)
+	if err != nil {
+		return nil, errors.New("invalid discount (vDesc) in NF-e: " + inf.Total.ICMSTot.VDesc)
+	}
+	invoiceDiscount, err := parseNFeValue(inf.Cobr.Fat.VDesc)
+	if err != nil {
+		return nil, errors.New("invalid invoice discount (fat/vDesc) in NF-e: " + inf.Cobr.Fat.VDesc)
+	}
+	nfe.Discount = productsDiscount + invoiceDiscount
+
+	for _, det := range inf.Det {
+		value, err := parseNFeValue(det.Prod.VProd)
+		if err != nil {
+			return nil, errors.New("invalid item value (vProd) in NF-e: " + det.Prod.VProd)
+		}
+
+		nfe.Items = append(nfe.Items, NFeItem{
+			Description: strings.TrimSpace(det.Prod.XProd),
+			Ncm:         strings.TrimSpace(det.Prod.NCM),
+			Cfop:        strings.TrimSpace(det.Prod.CFOP),
+			Value:       value,
+		})
+	}
+
+	for _, dup := range inf.Cobr.Dup {
+		dueDate, err := parseNFeDate(dup.DVenc)
+		if err != nil {
+			return nil, errors.New("invalid due date (dVenc) in NF-e: " + dup.DVenc)
+		}
+
+		value, err := parseNFeValue(dup.VDup)
+		if err != nil || value <= 0 {
+			return nil, errors.New("invalid installment value (vDup) in NF-e: " + dup.VDup)
+		}
+
+		nfe.Installments = append(nfe.Installments, NFeInstallment{
+			Number:  strings.TrimSpace(dup.NDup),
+			DueDate: dueDate,
+			Value:   value,
+		})
+	}
+
+	return nfe, nil
+}
+
+// parseNFeDate considera apenas a data (AAAA-MM-DD), no fuso UTC como as demais datas das transações
+func parseNFeDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) < 10 {
+		return time.Time{}, errors.New("invalid date")
+	}
+	return time.Parse("2006-01-02", value[:10])
+}
+
+// parseNFeValue lê os valores da nota, sempre com ponto decimal; campos ausentes valem zero
+func parseNFeValue(value string) (models.Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	return models.ParseMoney(value)
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, value)
+}
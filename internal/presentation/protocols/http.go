@@ -16,4 +16,5 @@ type HttpRequest struct {
 type HttpResponse struct {
 	Body       io.ReadCloser
 	StatusCode int
+	Header     http.Header
 }
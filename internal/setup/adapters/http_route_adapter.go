@@ -17,6 +17,11 @@ func AdaptRoute(controller protocols.Controller) http.Handler {
 		}
 
 		res := controller.Handle(*httpRequest)
+		defer res.Body.Close()
+
+		for key, values := range res.Header {
+			w.Header()[key] = values
+		}
 
 		w.WriteHeader(res.StatusCode)
 		_, err := io.Copy(w, res.Body)
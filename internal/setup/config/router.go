@@ -15,6 +15,16 @@ func SetupRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.D
 	routes.TransactionRoutes(apiServer, db, workspaceDb)
 	routes.CustomFieldRoutes(apiServer, db, workspaceDb)
 	routes.CreditCardRoutes(apiServer, db, workspaceDb)
+	routes.BudgetRoutes(apiServer, db, workspaceDb)
+	routes.ReportRoutes(apiServer, db, workspaceDb)
+	routes.AuditRoutes(apiServer, db, workspaceDb)
+	routes.TrashRoutes(apiServer, db, workspaceDb)
+	routes.MemberRoleRoutes(apiServer, db, workspaceDb)
+	routes.ApprovalRoutes(apiServer, db, workspaceDb)
+	routes.AttachmentRoutes(apiServer, db, workspaceDb)
+	routes.ImportJobRoutes(apiServer, db, workspaceDb)
+	routes.TransactionRuleRoutes(apiServer, db, workspaceDb)
+	routes.CurrencyRoutes(apiServer, db, workspaceDb)
 
 	server.Handle("/api/", http.StripPrefix("/api", apiServer))
 }
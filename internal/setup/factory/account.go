@@ -2,8 +2,13 @@ package factory
 
 import (
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/bank_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/currency_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/edit_transaction_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_rule_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transfer_repository"
 	controllers "github.com/anuntech/finance-backend/internal/presentation/controllers/account"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -13,7 +18,8 @@ func MakeCreateAccountController(db *mongo.Database) *controllers.CreateAccountC
 	findManyByUserIdAndWorkspaceId := account_repository.NewFindAccountsRepository(db)
 	findBankById := bank_repository.NewFindByIdMongoRepository(db)
 	findByNameRepository := account_repository.NewFindByNameMongoRepository(db)
-	return controllers.NewCreateAccountController(accountRepository, findManyByUserIdAndWorkspaceId, findBankById, findByNameRepository)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewCreateAccountController(accountRepository, findManyByUserIdAndWorkspaceId, findBankById, findByNameRepository, createAuditLog)
 }
 
 func MakeGetAccountsController(db *mongo.Database) *controllers.GetAccountsController {
@@ -28,7 +34,9 @@ func MakeGetAccountByIdController(db *mongo.Database) *controllers.GetAccountByI
 
 func MakeDeleteAccountController(db *mongo.Database) *controllers.DeleteAccountController {
 	deleteAccount := account_repository.NewDeleteAccountMongoRepository(db)
-	return controllers.NewDeleteAccountController(deleteAccount)
+	findAccountById := account_repository.NewFindByIdMongoRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewDeleteAccountController(deleteAccount, findAccountById, createAuditLog)
 }
 
 func MakeUpdateAccountController(db *mongo.Database) *controllers.UpdateAccountController {
@@ -36,18 +44,68 @@ func MakeUpdateAccountController(db *mongo.Database) *controllers.UpdateAccountC
 	findBankById := bank_repository.NewFindByIdMongoRepository(db)
 	findAccountById := account_repository.NewFindByIdMongoRepository(db)
 	findByNameRepository := account_repository.NewFindByNameMongoRepository(db)
-	return controllers.NewUpdateAccountController(updateAccount, findBankById, findAccountById, findByNameRepository)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewUpdateAccountController(updateAccount, findBankById, findAccountById, findByNameRepository, createAuditLog)
 }
 
 func MakeImportAccountController(db *mongo.Database) *controllers.ImportAccountController {
 	importAccounts := account_repository.NewImportAccountsMongoRepository(db)
 	findAccountByWorkspaceId := account_repository.NewFindAccountsRepository(db)
 	findByNameRepository := account_repository.NewFindByNameMongoRepository(db)
-	return controllers.NewImportAccountController(importAccounts, findAccountByWorkspaceId, findByNameRepository)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewImportAccountController(importAccounts, findAccountByWorkspaceId, findByNameRepository, createAuditLog)
 }
 
 func MakeTransferenceAccountController(db *mongo.Database) *controllers.TransferenceAccountController {
 	findAccountById := account_repository.NewFindByIdMongoRepository(db)
-	createTransaction := transaction_repository.NewCreateTransactionRepository(db)
-	return controllers.NewTransferenceAccountController(findAccountById, createTransaction)
+	createTransfer := transfer_repository.NewCreateTransferRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	findExchangeRateTable := currency_repository.NewFindExchangeRateTableRepository(db)
+	return controllers.NewTransferenceAccountController(findAccountById, createTransfer, createAuditLog, findExchangeRateTable)
+}
+
+func MakeGetTransfersController(db *mongo.Database) *controllers.GetTransfersController {
+	findTransfers := transfer_repository.NewFindTransfersRepository(db)
+	return controllers.NewGetTransfersController(findTransfers)
+}
+
+func MakeGetTransferByIdController(db *mongo.Database) *controllers.GetTransferByIdController {
+	findTransferById := transfer_repository.NewFindTransferByIdRepository(db)
+	return controllers.NewGetTransferByIdController(findTransferById)
+}
+
+func MakeUpdateTransferController(db *mongo.Database) *controllers.UpdateTransferController {
+	findTransferById := transfer_repository.NewFindTransferByIdRepository(db)
+	updateTransfer := transfer_repository.NewUpdateTransferRepository(db)
+	findAccountById := account_repository.NewFindByIdMongoRepository(db)
+	findTransactionById := transaction_repository.NewGetTransactionByIdRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	findExchangeRateTable := currency_repository.NewFindExchangeRateTableRepository(db)
+	return controllers.NewUpdateTransferController(findTransferById, updateTransfer, findAccountById, findTransactionById, createAuditLog, findExchangeRateTable)
+}
+
+func MakeEditTransferController(db *mongo.Database) *controllers.EditTransferController {
+	findTransferById := transfer_repository.NewFindTransferByIdRepository(db)
+	findTransactionById := transaction_repository.NewGetTransactionByIdRepository(db)
+	findByIdEditTransaction := edit_transaction_repository.NewFindByIdEditTransactionRepository(db)
+	editTransfer := transfer_repository.NewEditTransferRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	findExchangeRateTable := currency_repository.NewFindExchangeRateTableRepository(db)
+	return controllers.NewEditTransferController(findTransferById, findTransactionById, findByIdEditTransaction, editTransfer, createAuditLog, findExchangeRateTable)
+}
+
+func MakeDeleteTransfersController(db *mongo.Database) *controllers.DeleteTransfersController {
+	deleteTransfers := transfer_repository.NewDeleteTransfersRepository(db)
+	findTransferById := transfer_repository.NewFindTransferByIdRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewDeleteTransfersController(deleteTransfers, findTransferById, createAuditLog)
+}
+
+func MakeReconcileAccountController(db *mongo.Database) *controllers.ReconcileAccountController {
+	findAccountById := account_repository.NewFindByIdMongoRepository(db)
+	findTransactions := transaction_repository.NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+	confirmTransaction := transaction_repository.NewConfirmTransactionRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	findTransactionRules := transaction_rule_repository.NewFindTransactionRulesRepository(db)
+	return controllers.NewReconcileAccountController(findAccountById, findTransactions, confirmTransaction, createAuditLog, findTransactionRules)
 }
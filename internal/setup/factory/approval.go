@@ -0,0 +1,46 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/approval_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/category_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/approval"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetApprovalSettingsController(db *mongo.Database) *approval.GetApprovalSettingsController {
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
+
+	return approval.NewGetApprovalSettingsController(findApprovalSettingsRepository)
+}
+
+func MakeUpdateApprovalSettingsController(db *mongo.Database) *approval.UpdateApprovalSettingsController {
+	saveApprovalSettingsRepository := approval_repository.NewSaveApprovalSettingsRepository(db)
+	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
+
+	return approval.NewUpdateApprovalSettingsController(saveApprovalSettingsRepository, findCategoryByIdRepository)
+}
+
+func MakeGetPendingApprovalController(db *mongo.Database) *approval.GetPendingApprovalController {
+	findPendingApprovalRepository := approval_repository.NewFindPendingApprovalRepository(db)
+
+	return approval.NewGetPendingApprovalController(findPendingApprovalRepository)
+}
+
+func MakeApproveTransactionController(db *mongo.Database) *approval.ReviewTransactionController {
+	return makeReviewTransactionController(db, models.ApprovalApproved)
+}
+
+func MakeRejectTransactionController(db *mongo.Database) *approval.ReviewTransactionController {
+	return makeReviewTransactionController(db, models.ApprovalRejected)
+}
+
+func makeReviewTransactionController(db *mongo.Database, status string) *approval.ReviewTransactionController {
+	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
+	reviewTransactionRepository := approval_repository.NewReviewTransactionRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+
+	return approval.NewReviewTransactionController(status, findTransactionByIdRepository, reviewTransactionRepository, createAuditLogRepository)
+}
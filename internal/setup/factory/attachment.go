@@ -0,0 +1,38 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/attachment_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/storage"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/attachment"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeUploadAttachmentController(db *mongo.Database) *attachment.UploadAttachmentController {
+	fileStorage := storage.NewFileSystemStorage()
+	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
+	createAttachmentRepository := attachment_repository.NewCreateAttachmentRepository(db)
+
+	return attachment.NewUploadAttachmentController(fileStorage, findTransactionByIdRepository, createAttachmentRepository)
+}
+
+func MakeGetAttachmentsController(db *mongo.Database) *attachment.GetAttachmentsController {
+	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
+	findAttachmentsRepository := attachment_repository.NewFindAttachmentsRepository(db)
+
+	return attachment.NewGetAttachmentsController(findTransactionByIdRepository, findAttachmentsRepository)
+}
+
+func MakeDownloadAttachmentController(db *mongo.Database) *attachment.DownloadAttachmentController {
+	fileStorage := storage.NewFileSystemStorage()
+	findAttachmentByIdRepository := attachment_repository.NewFindAttachmentByIdRepository(db)
+
+	return attachment.NewDownloadAttachmentController(fileStorage, findAttachmentByIdRepository)
+}
+
+func MakeDeleteAttachmentController(db *mongo.Database) *attachment.DeleteAttachmentController {
+	findAttachmentByIdRepository := attachment_repository.NewFindAttachmentByIdRepository(db)
+	deleteAttachmentRepository := attachment_repository.NewDeleteAttachmentRepository(db, storage.NewFileSystemStorage())
+
+	return attachment.NewDeleteAttachmentController(findAttachmentByIdRepository, deleteAttachmentRepository)
+}
@@ -0,0 +1,13 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/audit"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetAuditLogsController(db *mongo.Database) *audit.GetAuditLogsController {
+	findAuditLogsRepository := audit_log_repository.NewFindAuditLogsRepository(db)
+
+	return audit.NewGetAuditLogsController(findAuditLogsRepository)
+}
@@ -0,0 +1,35 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/budget_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/category_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/budget"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MakeCreateBudgetController creates the controller for creating budgets
+func MakeCreateBudgetController(db *mongo.Database) *budget.CreateBudgetController {
+	createRepo := budget_repository.NewCreateBudgetRepository(db)
+	findByCategoryRepo := budget_repository.NewFindBudgetByCategoryRepository(db)
+	findCategoryByIdRepo := category_repository.NewFindCategoryByIdRepository(db)
+	return budget.NewCreateBudgetController(createRepo, findByCategoryRepo, findCategoryByIdRepo)
+}
+
+// MakeGetBudgetsController creates the controller for listing and tracking budgets
+func MakeGetBudgetsController(db *mongo.Database) *budget.GetBudgetsController {
+	findRepo := budget_repository.NewFindBudgetsRepository(db)
+	findTrackingRepo := budget_repository.NewFindBudgetTrackingRepository(db, findRepo, category_repository.NewFindCategoriesRepository(db))
+	return budget.NewGetBudgetsController(findRepo, findTrackingRepo)
+}
+
+// MakeUpdateBudgetController creates the controller for updating budgets
+func MakeUpdateBudgetController(db *mongo.Database) *budget.UpdateBudgetController {
+	updateRepo := budget_repository.NewUpdateBudgetRepository(db)
+	return budget.NewUpdateBudgetController(updateRepo)
+}
+
+// MakeDeleteBudgetController creates the controller for deleting budgets
+func MakeDeleteBudgetController(db *mongo.Database) *budget.DeleteBudgetController {
+	deleteRepo := budget_repository.NewDeleteBudgetRepository(db)
+	return budget.NewDeleteBudgetController(deleteRepo)
+}
@@ -2,6 +2,7 @@ package factory
 
 import (
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/category_repository"
 	controllers "github.com/anuntech/finance-backend/internal/presentation/controllers/category"
 	controllers_category "github.com/anuntech/finance-backend/internal/presentation/controllers/category/sub_category"
@@ -13,7 +14,8 @@ func MakeCreateCategoryController(db *mongo.Database) *controllers.CreateCategor
 	findAccountById := account_repository.NewFindByIdMongoRepository(db)
 	findCategorysByWorkspaceId := category_repository.NewFindCategoriesRepository(db)
 	findCategoryByNameAndType := category_repository.NewFindByNameAndTypeMongoRepository(db)
-	return controllers.NewCreateCategoryController(createCategory, findAccountById, findCategorysByWorkspaceId, findCategoryByNameAndType)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewCreateCategoryController(createCategory, findAccountById, findCategorysByWorkspaceId, findCategoryByNameAndType, createAuditLog)
 }
 
 func MakeGetCategorysController(db *mongo.Database) *controllers.GetCategoriesController {
@@ -24,17 +26,22 @@ func MakeGetCategorysController(db *mongo.Database) *controllers.GetCategoriesCo
 func MakeCreateSubCategoryController(db *mongo.Database) *controllers_category.CreateSubCategoryController {
 	updateCategory := category_repository.NewUpdateCategoryRepository(db)
 	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
-	return controllers_category.NewCreateSubCategoryController(updateCategory, findCategoryById)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers_category.NewCreateSubCategoryController(updateCategory, findCategoryById, createAuditLog)
 }
 
 func MakeDeleteSubCategoryController(db *mongo.Database) *controllers_category.DeleteSubCategoryController {
 	updateCategory := category_repository.NewUpdateCategoryRepository(db)
-	return controllers_category.NewDeleteSubCategoryController(updateCategory)
+	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers_category.NewDeleteSubCategoryController(updateCategory, findCategoryById, createAuditLog)
 }
 
 func MakeDeleteCategoryController(db *mongo.Database) *controllers.DeleteCategoryController {
 	deleteCategory := category_repository.NewDeleteCategoryRepository(db)
-	return controllers.NewDeleteCategoryController(deleteCategory)
+	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewDeleteCategoryController(deleteCategory, findCategoryById, createAuditLog)
 }
 
 func MakeGetCategoryByIdController(db *mongo.Database) *controllers.GetCategoryByIdController {
@@ -46,24 +53,28 @@ func MakeUpdateCategoryController(db *mongo.Database) *controllers.UpdateCategor
 	updateCategory := category_repository.NewUpdateCategoryRepository(db)
 	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
 	findCategoryByNameAndType := category_repository.NewFindByNameAndTypeMongoRepository(db)
-	return controllers.NewUpdateCategoryController(updateCategory, findCategoryById, findCategoryByNameAndType)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewUpdateCategoryController(updateCategory, findCategoryById, findCategoryByNameAndType, createAuditLog)
 }
 
 func MakeUpdateSubCategoryController(db *mongo.Database) *controllers_category.UpdateSubCategoryController {
 	updateCategory := category_repository.NewUpdateCategoryRepository(db)
 	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
-	return controllers_category.NewUpdateSubCategoryController(updateCategory, findCategoryById)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers_category.NewUpdateSubCategoryController(updateCategory, findCategoryById, createAuditLog)
 }
 
 func MakeImportCategoryController(db *mongo.Database) *controllers.ImportCategoryController {
 	importCategory := category_repository.NewImportCategoriesRepository(db)
 	findCategorysByWorkspaceId := category_repository.NewFindCategoriesRepository(db)
 	findCategoryByNameAndType := category_repository.NewFindByNameAndTypeMongoRepository(db)
-	return controllers.NewImportCategoryController(importCategory, findCategorysByWorkspaceId, findCategoryByNameAndType)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewImportCategoryController(importCategory, findCategorysByWorkspaceId, findCategoryByNameAndType, createAuditLog)
 }
 
 func MakeImportSubCategoryController(db *mongo.Database) *controllers_category.ImportSubCategoryController {
 	updateCategory := category_repository.NewUpdateCategoryRepository(db)
 	findCategoryById := category_repository.NewFindCategoryByIdRepository(db)
-	return controllers_category.NewImportSubCategoryController(updateCategory, findCategoryById)
+	createAuditLog := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers_category.NewImportSubCategoryController(updateCategory, findCategoryById, createAuditLog)
 }
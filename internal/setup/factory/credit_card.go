@@ -1,7 +1,11 @@
 package factory
 
 import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/credit_card_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/edit_transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
 	controllers "github.com/anuntech/finance-backend/internal/presentation/controllers/credit_card"
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -15,7 +19,8 @@ func MakeCreateCreditCardController(db *mongo.Database) *controllers.CreateCredi
 
 // MakeGetCreditCardsController creates the controller for retrieving credit cards
 func MakeGetCreditCardsController(db *mongo.Database) *controllers.GetCreditCardsController {
-	findRepo := credit_card_repository.NewFindCreditCardsRepository(db)
+	findTransactionsRepo := transaction_repository.NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+	findRepo := credit_card_repository.NewFindCreditCardsRepository(db, findTransactionsRepo)
 	return controllers.NewGetCreditCardsController(findRepo)
 }
 
@@ -38,3 +43,28 @@ func MakeDeleteCreditCardController(db *mongo.Database) *controllers.DeleteCredi
 	deleteRepo := credit_card_repository.NewDeleteCreditCardRepository(db)
 	return controllers.NewDeleteCreditCardController(deleteRepo)
 }
+
+// MakeGetCreditCardInvoiceController creates the controller for retrieving a credit card invoice
+func MakeGetCreditCardInvoiceController(db *mongo.Database) *controllers.GetCreditCardInvoiceController {
+	findByIdRepo := credit_card_repository.NewFindCreditCardByIdRepository(db)
+	findTransactionsRepo := transaction_repository.NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+	findInvoiceRepo := credit_card_repository.NewFindCreditCardInvoiceRepository(db, findTransactionsRepo)
+	return controllers.NewGetCreditCardInvoiceController(findByIdRepo, findInvoiceRepo)
+}
+
+// MakePayCreditCardInvoiceController creates the controller for paying a credit card invoice
+func MakePayCreditCardInvoiceController(db *mongo.Database) *controllers.PayCreditCardInvoiceController {
+	findByIdRepo := credit_card_repository.NewFindCreditCardByIdRepository(db)
+	findTransactionsRepo := transaction_repository.NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+	findInvoiceRepo := credit_card_repository.NewFindCreditCardInvoiceRepository(db, findTransactionsRepo)
+	findAccountByIdRepo := account_repository.NewFindByIdMongoRepository(db)
+	payInvoiceRepo := credit_card_repository.NewPayCreditCardInvoiceRepository(db)
+	createAuditLogRepo := audit_log_repository.NewCreateAuditLogRepository(db)
+	return controllers.NewPayCreditCardInvoiceController(
+		findByIdRepo,
+		findInvoiceRepo,
+		findAccountByIdRepo,
+		payInvoiceRepo,
+		createAuditLogRepo,
+	)
+}
@@ -0,0 +1,43 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/currency_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/currency"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetCurrencySettingsController(db *mongo.Database) *currency.GetCurrencySettingsController {
+	findCurrencySettingsRepository := currency_repository.NewFindCurrencySettingsRepository(db)
+
+	return currency.NewGetCurrencySettingsController(findCurrencySettingsRepository)
+}
+
+func MakeUpdateCurrencySettingsController(db *mongo.Database) *currency.UpdateCurrencySettingsController {
+	saveCurrencySettingsRepository := currency_repository.NewSaveCurrencySettingsRepository(db)
+
+	return currency.NewUpdateCurrencySettingsController(saveCurrencySettingsRepository)
+}
+
+func MakeGetExchangeRatesController(db *mongo.Database) *currency.GetExchangeRatesController {
+	findExchangeRatesRepository := currency_repository.NewFindExchangeRatesRepository(db)
+
+	return currency.NewGetExchangeRatesController(findExchangeRatesRepository)
+}
+
+func MakeCreateExchangeRateController(db *mongo.Database) *currency.CreateExchangeRateController {
+	saveExchangeRatesRepository := currency_repository.NewSaveExchangeRatesRepository(db)
+
+	return currency.NewCreateExchangeRateController(saveExchangeRatesRepository)
+}
+
+func MakeImportExchangeRatesController(db *mongo.Database) *currency.ImportExchangeRatesController {
+	saveExchangeRatesRepository := currency_repository.NewSaveExchangeRatesRepository(db)
+
+	return currency.NewImportExchangeRatesController(saveExchangeRatesRepository)
+}
+
+func MakeDeleteExchangeRatesController(db *mongo.Database) *currency.DeleteExchangeRatesController {
+	deleteExchangeRatesRepository := currency_repository.NewDeleteExchangeRatesRepository(db)
+
+	return currency.NewDeleteExchangeRatesController(deleteExchangeRatesRepository)
+}
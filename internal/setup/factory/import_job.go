@@ -0,0 +1,41 @@
+package factory
+
+import (
+	"os"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/redis_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/transaction"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// makeImportJobRepository returns nil when Redis is not configured, which disables background imports
+func makeImportJobRepository() usecase.ImportJobRepository {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		return nil
+	}
+	return redis_repository.NewImportJobRepository(redisURL)
+}
+
+func MakeCreateImportJobController(workspaceDb *mongo.Database, db *mongo.Database) *transaction.CreateImportJobController {
+	return transaction.NewCreateImportJobController(
+		MakeImportTransactionController(workspaceDb, db),
+		makeImportJobRepository(),
+	)
+}
+
+func MakeGetImportJobController() *transaction.GetImportJobController {
+	return transaction.NewGetImportJobController(makeImportJobRepository())
+}
+
+func MakeCancelImportJobController() *transaction.CancelImportJobController {
+	return transaction.NewCancelImportJobController(makeImportJobRepository())
+}
+
+func MakeImportJobRunner(workspaceDb *mongo.Database, db *mongo.Database) *transaction.ImportJobRunner {
+	return transaction.NewImportJobRunner(
+		MakeImportTransactionController(workspaceDb, db),
+		makeImportJobRepository(),
+	)
+}
@@ -0,0 +1,25 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/member_role_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/member_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/member_role"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetMemberRolesController(db *mongo.Database) *member_role.GetMemberRolesController {
+	findMemberRolesRepository := member_role_repository.NewFindMemberRolesRepository(db)
+
+	return member_role.NewGetMemberRolesController(findMemberRolesRepository)
+}
+
+func MakeGetMyRoleController() *member_role.GetMyRoleController {
+	return member_role.NewGetMyRoleController()
+}
+
+func MakeUpdateMemberRoleController(workspaceDb *mongo.Database, db *mongo.Database) *member_role.UpdateMemberRoleController {
+	findMemberByIdRepository := member_repository.NewFindMemberByIdRepository(workspaceDb)
+	saveMemberRoleRepository := member_role_repository.NewSaveMemberRoleRepository(db)
+
+	return member_role.NewUpdateMemberRoleController(findMemberByIdRepository, saveMemberRoleRepository)
+}
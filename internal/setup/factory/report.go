@@ -0,0 +1,16 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/edit_transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/report_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/report"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetCashFlowReportController(db *mongo.Database) *report.GetCashFlowReportController {
+	findTransactionsRepository := transaction_repository.NewTransactionRepository(db, edit_transaction_repository.NewFindByIdEditTransactionRepository(db))
+	findCashFlowReportRepository := report_repository.NewFindCashFlowReportRepository(db, findTransactionsRepository)
+
+	return report.NewGetCashFlowReportController(findCashFlowReportRepository)
+}
@@ -1,12 +1,23 @@
 package factory
 
 import (
+	"os"
+
+	"github.com/anuntech/finance-backend/internal/domain/usecase"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/approval_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/bank_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/category_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/credit_card_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/currency_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/custom_field_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/edit_transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/nfe_import_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/redis_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_rule_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transfer_repository"
 	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/member_repository"
 	workspace_user_repository "github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/user_repository"
 	"github.com/anuntech/finance-backend/internal/presentation/controllers/edit_transaction"
@@ -20,6 +31,12 @@ func MakeCreateTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 	findAccountByIdRepository := account_repository.NewFindByIdMongoRepository(db)
 	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
 	findCustomFieldByIdRepository := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	findCreditCardByIdRepository := credit_card_repository.NewFindCreditCardByIdRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
+	findDuplicateTransactionsRepository := transaction_repository.NewFindDuplicateTransactionsRepository(db)
+	findTransactionRulesRepository := transaction_rule_repository.NewFindTransactionRulesRepository(db)
+	findExchangeRateTableRepository := currency_repository.NewFindExchangeRateTableRepository(db)
 
 	return transaction.NewCreateTransactionController(
 		findMemberByIdRepository,
@@ -27,6 +44,12 @@ func MakeCreateTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 		findAccountByIdRepository,
 		findCategoryByIdRepository,
 		findCustomFieldByIdRepository,
+		findCreditCardByIdRepository,
+		createAuditLogRepository,
+		findApprovalSettingsRepository,
+		findDuplicateTransactionsRepository,
+		findTransactionRulesRepository,
+		findExchangeRateTableRepository,
 	)
 }
 
@@ -37,18 +60,51 @@ func MakeGetTransactionController(workspaceDb *mongo.Database, db *mongo.Databas
 	)
 	findByIdEditTransactionRepository := edit_transaction_repository.NewFindByIdEditTransactionRepository(db)
 	findCustomFieldByIdRepository := custom_field_repository.NewFindCustomFieldByIdRepository(db)
-	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
-	findWorkspaceUserByIdRepository := workspace_user_repository.NewFindWorkspaceUserByIdRepository(workspaceDb)
 
 	return transaction.NewGetTransactionController(
+		findTransactionsByWorkspaceIdAndMonthRepository,
 		findTransactionsByWorkspaceIdAndMonthRepository,
 		findByIdEditTransactionRepository,
 		findCustomFieldByIdRepository,
+	)
+}
+
+func MakeExportTransactionController(workspaceDb *mongo.Database, db *mongo.Database) *transaction.ExportTransactionController {
+	findAccountByIdRepository := account_repository.NewFindByIdMongoRepository(db)
+	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
+	findCustomFieldsRepository := custom_field_repository.NewFindCustomFieldsRepository(db)
+	findWorkspaceUserByIdRepository := workspace_user_repository.NewFindWorkspaceUserByIdRepository(workspaceDb)
+
+	return transaction.NewExportTransactionController(
+		MakeGetTransactionController(workspaceDb, db),
+		findAccountByIdRepository,
 		findCategoryByIdRepository,
-		*findWorkspaceUserByIdRepository,
+		findCustomFieldsRepository,
+		findWorkspaceUserByIdRepository,
 	)
 }
 
+func MakeGetDuplicateTransactionsController(db *mongo.Database) *transaction.GetDuplicateTransactionsController {
+	findDuplicateTransactionsRepository := transaction_repository.NewFindDuplicateTransactionsRepository(db)
+
+	return transaction.NewGetDuplicateTransactionsController(findDuplicateTransactionsRepository)
+}
+
+func MakeApplyTransactionRulesController(db *mongo.Database) *transaction.ApplyTransactionRulesController {
+	findTransactionRulesRepository := transaction_rule_repository.NewFindTransactionRulesRepository(db)
+	categorizeTransactionsRepository := transaction_repository.NewCategorizeTransactionsRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+
+	return transaction.NewApplyTransactionRulesController(findTransactionRulesRepository, categorizeTransactionsRepository, findApprovalSettingsRepository, createAuditLogRepository)
+}
+
+func MakeExportColumnsTransactionController(db *mongo.Database) *transaction.ExportColumnsTransactionController {
+	findCustomFieldsRepository := custom_field_repository.NewFindCustomFieldsRepository(db)
+
+	return transaction.NewExportColumnsTransactionController(findCustomFieldsRepository)
+}
+
 func MakeUpdateTransactionController(workspaceDb *mongo.Database, db *mongo.Database) *transaction.UpdateTransactionController {
 	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
 	updateTransactionRepository := transaction_repository.NewUpdateTransactionRepository(db)
@@ -56,6 +112,10 @@ func MakeUpdateTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 	findAccountByIdRepository := account_repository.NewFindByIdMongoRepository(db)
 	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
 	findCustomFieldByIdRepository := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	findCreditCardByIdRepository := credit_card_repository.NewFindCreditCardByIdRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
+	findExchangeRateTableRepository := currency_repository.NewFindExchangeRateTableRepository(db)
 
 	return transaction.NewUpdateTransactionController(
 		updateTransactionRepository,
@@ -64,6 +124,10 @@ func MakeUpdateTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 		findAccountByIdRepository,
 		findCategoryByIdRepository,
 		findCustomFieldByIdRepository,
+		findCreditCardByIdRepository,
+		createAuditLogRepository,
+		findApprovalSettingsRepository,
+		findExchangeRateTableRepository,
 	)
 }
 
@@ -76,10 +140,16 @@ func MakeGetTransactionByIdController(workspaceDb *mongo.Database) *transaction.
 func MakeDeleteTransactionController(db *mongo.Database) *transaction.DeleteTransactionController {
 	deleteTransactionRepository := transaction_repository.NewDeleteTransactionRepository(db)
 	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
+	findByIdEditTransactionRepository := edit_transaction_repository.NewFindByIdEditTransactionRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	deleteTransfersRepository := transfer_repository.NewDeleteTransfersRepository(db)
 
 	return transaction.NewDeleteTransactionController(
 		deleteTransactionRepository,
 		findTransactionByIdRepository,
+		findByIdEditTransactionRepository,
+		createAuditLogRepository,
+		deleteTransfersRepository,
 	)
 }
 
@@ -92,6 +162,7 @@ func MakeCreateEditTransactionController(workspaceDb *mongo.Database, db *mongo.
 	findByIdEditTransactionRepository := edit_transaction_repository.NewFindByIdEditTransactionRepository(db)
 	updateEditTransactionRepository := edit_transaction_repository.NewUpdateEditTransactionRepository(db)
 	findCustomFieldByIdRepository := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
 
 	return edit_transaction.NewCreateEditTransactionController(
 		findMemberByIdRepository,
@@ -102,6 +173,7 @@ func MakeCreateEditTransactionController(workspaceDb *mongo.Database, db *mongo.
 		findByIdEditTransactionRepository,
 		updateEditTransactionRepository,
 		findCustomFieldByIdRepository,
+		createAuditLogRepository,
 	)
 }
 
@@ -119,7 +191,13 @@ func MakeImportTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 
 	createAccountRepository := account_repository.NewCreateAccountMongoRepository(db)
 	createCategoryRepository := category_repository.NewCreateCategoryRepository(db)
+	updateCategoryRepository := category_repository.NewUpdateCategoryRepository(db)
 	findBankByNameRepository := bank_repository.NewFindByNameMongoRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	findDuplicateTransactionsRepository := transaction_repository.NewFindDuplicateTransactionsRepository(db)
+	findTransactionRulesRepository := transaction_rule_repository.NewFindTransactionRulesRepository(db)
+	findExchangeRateTableRepository := currency_repository.NewFindExchangeRateTableRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
 	return transaction.NewImportTransactionController(
 		findMemberByIdRepository,
 		createTransactionRepository,
@@ -132,16 +210,82 @@ func MakeImportTransactionController(workspaceDb *mongo.Database, db *mongo.Data
 		findCustomFieldByNameRepository,
 		createAccountRepository,
 		createCategoryRepository,
+		updateCategoryRepository,
 		findBankByNameRepository,
+		makeImportPreviewRepository(),
+		createAuditLogRepository,
+		findDuplicateTransactionsRepository,
+		findTransactionRulesRepository,
+		findExchangeRateTableRepository,
+		findApprovalSettingsRepository,
 	)
 }
 
+func MakeCommitImportTransactionController(db *mongo.Database) *transaction.CommitImportTransactionController {
+	createTransactionRepository := transaction_repository.NewCreateTransactionRepository(db)
+	findAccountByNameRepository := account_repository.NewFindByNameMongoRepository(db)
+	findCategoryByNameAndTypeRepository := category_repository.NewFindByNameAndTypeMongoRepository(db)
+	findCategoryByIdRepository := category_repository.NewFindCategoryByIdRepository(db)
+	createAccountRepository := account_repository.NewCreateAccountMongoRepository(db)
+	createCategoryRepository := category_repository.NewCreateCategoryRepository(db)
+	updateCategoryRepository := category_repository.NewUpdateCategoryRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
+
+	return transaction.NewCommitImportTransactionController(
+		makeImportPreviewRepository(),
+		createTransactionRepository,
+		findAccountByNameRepository,
+		findCategoryByNameAndTypeRepository,
+		findCategoryByIdRepository,
+		createAccountRepository,
+		createCategoryRepository,
+		updateCategoryRepository,
+		createAuditLogRepository,
+		findApprovalSettingsRepository,
+	)
+}
+
+func MakeImportNfeTransactionController(workspaceDb *mongo.Database, db *mongo.Database) *transaction.ImportNfeTransactionController {
+	findNfeImportSettingsRepository := nfe_import_repository.NewFindNfeImportSettingsRepository(db)
+	createEditTransactionRepository := edit_transaction_repository.NewCreateEditTransactionRepository(db)
+
+	return transaction.NewImportNfeTransactionController(
+		MakeImportTransactionController(workspaceDb, db),
+		findNfeImportSettingsRepository,
+		createEditTransactionRepository,
+	)
+}
+
+func MakeGetNfeImportSettingsController(db *mongo.Database) *transaction.GetNfeImportSettingsController {
+	findNfeImportSettingsRepository := nfe_import_repository.NewFindNfeImportSettingsRepository(db)
+
+	return transaction.NewGetNfeImportSettingsController(findNfeImportSettingsRepository)
+}
+
+func MakeUpdateNfeImportSettingsController(db *mongo.Database) *transaction.UpdateNfeImportSettingsController {
+	saveNfeImportSettingsRepository := nfe_import_repository.NewSaveNfeImportSettingsRepository(db)
+
+	return transaction.NewUpdateNfeImportSettingsController(saveNfeImportSettingsRepository)
+}
+
+// makeImportPreviewRepository returns nil when Redis is not configured, which disables import previews
+func makeImportPreviewRepository() usecase.ImportPreviewRepository {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		return nil
+	}
+	return redis_repository.NewImportPreviewRepository(redisURL)
+}
+
 func MakeUpdateManyTransactionController(db *mongo.Database) *transaction.UpdateManyTransactionController {
 	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
 	findByIdEditTransactionRepository := edit_transaction_repository.NewFindByIdEditTransactionRepository(db)
 	updateTransactionRepository := transaction_repository.NewUpdateTransactionRepository(db)
 	createEditTransactionRepository := edit_transaction_repository.NewCreateEditTransactionRepository(db)
 	findCustomFieldByIdRepository := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+	findApprovalSettingsRepository := approval_repository.NewFindApprovalSettingsRepository(db)
 
 	return transaction.NewUpdateManyTransactionController(
 		findTransactionByIdRepository,
@@ -149,12 +293,15 @@ func MakeUpdateManyTransactionController(db *mongo.Database) *transaction.Update
 		updateTransactionRepository,
 		createEditTransactionRepository,
 		findCustomFieldByIdRepository,
+		createAuditLogRepository,
+		findApprovalSettingsRepository,
 	)
 }
 
 func MakeExcludeInstallmentsUntilController(db *mongo.Database) *transaction.ExcludeInstallmentsUntilController {
 	findTransactionByIdRepository := transaction_repository.NewGetTransactionByIdRepository(db)
 	updateTransactionRepository := transaction_repository.NewUpdateTransactionRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
 
-	return transaction.NewExcludeInstallmentsUntilController(updateTransactionRepository, findTransactionByIdRepository)
+	return transaction.NewExcludeInstallmentsUntilController(updateTransactionRepository, findTransactionByIdRepository, createAuditLogRepository)
 }
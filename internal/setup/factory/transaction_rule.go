@@ -0,0 +1,49 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/account_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/category_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/custom_field_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/transaction_rule_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/workspace_repository/member_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/transaction_rule"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MakeCreateTransactionRuleController creates the controller for creating categorization rules
+func MakeCreateTransactionRuleController(workspaceDb *mongo.Database, db *mongo.Database) *transaction_rule.CreateTransactionRuleController {
+	createRepo := transaction_rule_repository.NewCreateTransactionRuleRepository(db)
+	findCategoryByIdRepo := category_repository.NewFindCategoryByIdRepository(db)
+	findAccountByIdRepo := account_repository.NewFindByIdMongoRepository(db)
+	findCustomFieldByIdRepo := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	findMemberByIdRepo := member_repository.NewFindMemberByIdRepository(workspaceDb)
+	return transaction_rule.NewCreateTransactionRuleController(createRepo, findCategoryByIdRepo, findAccountByIdRepo, findCustomFieldByIdRepo, findMemberByIdRepo)
+}
+
+// MakeGetTransactionRulesController creates the controller for listing categorization rules
+func MakeGetTransactionRulesController(db *mongo.Database) *transaction_rule.GetTransactionRulesController {
+	findRepo := transaction_rule_repository.NewFindTransactionRulesRepository(db)
+	return transaction_rule.NewGetTransactionRulesController(findRepo)
+}
+
+// MakeGetTransactionRuleByIdController creates the controller for fetching a categorization rule
+func MakeGetTransactionRuleByIdController(db *mongo.Database) *transaction_rule.GetTransactionRuleByIdController {
+	findByIdRepo := transaction_rule_repository.NewFindTransactionRuleByIdRepository(db)
+	return transaction_rule.NewGetTransactionRuleByIdController(findByIdRepo)
+}
+
+// MakeUpdateTransactionRuleController creates the controller for updating categorization rules
+func MakeUpdateTransactionRuleController(workspaceDb *mongo.Database, db *mongo.Database) *transaction_rule.UpdateTransactionRuleController {
+	updateRepo := transaction_rule_repository.NewUpdateTransactionRuleRepository(db)
+	findCategoryByIdRepo := category_repository.NewFindCategoryByIdRepository(db)
+	findAccountByIdRepo := account_repository.NewFindByIdMongoRepository(db)
+	findCustomFieldByIdRepo := custom_field_repository.NewFindCustomFieldByIdRepository(db)
+	findMemberByIdRepo := member_repository.NewFindMemberByIdRepository(workspaceDb)
+	return transaction_rule.NewUpdateTransactionRuleController(updateRepo, findCategoryByIdRepo, findAccountByIdRepo, findCustomFieldByIdRepo, findMemberByIdRepo)
+}
+
+// MakeDeleteTransactionRuleController creates the controller for deleting categorization rules
+func MakeDeleteTransactionRuleController(db *mongo.Database) *transaction_rule.DeleteTransactionRuleController {
+	deleteRepo := transaction_rule_repository.NewDeleteTransactionRuleRepository(db)
+	return transaction_rule.NewDeleteTransactionRuleController(deleteRepo)
+}
@@ -0,0 +1,21 @@
+package factory
+
+import (
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/audit_log_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/trash_repository"
+	"github.com/anuntech/finance-backend/internal/presentation/controllers/trash"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MakeGetTrashController(db *mongo.Database) *trash.GetTrashController {
+	findTrashRepository := trash_repository.NewFindTrashRepository(db)
+
+	return trash.NewGetTrashController(findTrashRepository)
+}
+
+func MakeRestoreTrashController(db *mongo.Database) *trash.RestoreTrashController {
+	restoreTrashRepository := trash_repository.NewRestoreTrashRepository(db)
+	createAuditLogRepository := audit_log_repository.NewCreateAuditLogRepository(db)
+
+	return trash.NewRestoreTrashController(restoreTrashRepository, createAuditLogRepository)
+}
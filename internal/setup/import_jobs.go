@@ -0,0 +1,78 @@
+package setup
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	defaultImportJobWorkers = 2
+	importJobDequeueTimeout = 5 * time.Second
+	importJobResumeInterval = time.Minute
+)
+
+// StartImportJobs starts the workers of the background imports, IMPORT_JOB_WORKERS of them, and
+// periodically queues again the jobs interrupted by a restart. Without Redis nothing is started.
+func StartImportJobs(db *mongo.Database, workspaceDb *mongo.Database) {
+	if os.Getenv("REDIS_URL") == "" {
+		log.Println("REDIS_URL not set, background imports disabled")
+		return
+	}
+
+	workers, err := strconv.Atoi(os.Getenv("IMPORT_JOB_WORKERS"))
+	if err != nil || workers <= 0 {
+		workers = defaultImportJobWorkers
+	}
+
+	runner := factory.MakeImportJobRunner(workspaceDb, db)
+
+	run := func(id string) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("Panic running import job %s: %v", id, r)
+			}
+		}()
+
+		if err := runner.Run(id); err != nil {
+			log.Printf("Error running import job %s: %v", id, err)
+		}
+	}
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				id, err := runner.ImportJobRepository.Dequeue(importJobDequeueTimeout)
+				if err != nil {
+					log.Println("Error dequeuing import job:", err)
+					time.Sleep(importJobDequeueTimeout)
+					continue
+				}
+				if id != "" {
+					run(id)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		resume := func() {
+			if err := runner.Resume(); err != nil {
+				log.Println("Error resuming import jobs:", err)
+			}
+		}
+
+		resume()
+
+		ticker := time.NewTicker(importJobResumeInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			resume()
+		}
+	}()
+}
@@ -81,13 +81,16 @@ func IsAllowed(next http.Handler, db *mongo.Database) http.Handler {
 		}
 
 		isUserAllowed := false
+		workspaceRole := "member"
 
 		if workspace.Owner == userObjectID {
 			isUserAllowed = true
+			workspaceRole = "owner"
 		} else {
 			for _, value := range workspace.Members {
 				if value.MemberId == userObjectID && value.Role == "admin" {
 					isUserAllowed = true
+					workspaceRole = "admin"
 					break
 				}
 			}
@@ -116,6 +119,9 @@ func IsAllowed(next http.Handler, db *mongo.Database) http.Handler {
 			return
 		}
 
+		// always overwritten, RequirePermission trusts it to tell the owner and admins apart
+		r.Header.Set("workspaceRole", workspaceRole)
+
 		next.ServeHTTP(w, r)
 	})
 }
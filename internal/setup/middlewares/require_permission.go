@@ -0,0 +1,59 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/member_role_repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RequirePermission must run inside IsAllowed. The workspace owner and admins are always ADMIN; the
+// other members have the finance role given to them, or DefaultMemberRole.
+func RequirePermission(next http.Handler, permission string, db *mongo.Database) http.Handler {
+	findMemberRoleRepository := member_role_repository.NewFindMemberRoleRepository(db)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := models.RoleAdmin
+
+		switch r.Header.Get("workspaceRole") {
+		case "owner", "admin":
+		case "member":
+			workspaceId, err := primitive.ObjectIDFromHex(r.Header.Get("workspaceId"))
+			if err != nil {
+				http.Error(w, "Invalid workspace ID format", http.StatusBadRequest)
+				return
+			}
+
+			userId, err := primitive.ObjectIDFromHex(r.Header.Get("UserId"))
+			if err != nil {
+				http.Error(w, "Invalid user ID", http.StatusBadRequest)
+				return
+			}
+
+			memberRole, err := findMemberRoleRepository.Find(workspaceId, userId)
+			if err != nil {
+				http.Error(w, "Error finding member role", http.StatusInternalServerError)
+				return
+			}
+
+			role = models.DefaultMemberRole
+			if memberRole != nil {
+				role = memberRole.Role
+			}
+		default:
+			http.Error(w, "User not allowed to access this application", http.StatusUnauthorized)
+			return
+		}
+
+		if !models.HasPermission(role, permission) {
+			http.Error(w, "Role "+role+" does not have the "+permission+" permission", http.StatusForbidden)
+			return
+		}
+
+		r.Header.Set("financeRole", role)
+
+		next.ServeHTTP(w, r)
+	})
+}
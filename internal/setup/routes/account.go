@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -12,49 +13,91 @@ import (
 func AccountRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
 	server.Handle("POST /account", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateAccountController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateAccountController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /account", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetAccountsController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetAccountsController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /account/{id}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetAccountByIdController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetAccountByIdController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("DELETE /account", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteAccountController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteAccountController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /account/import", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeImportAccountController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportAccountController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PUT /account/{id}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateAccountController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateAccountController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /account/transfer", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeTransferenceAccountController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeTransferenceAccountController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /account/transfer", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetTransfersController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /account/transfer/{id}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetTransferByIdController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("PUT /account/transfer/{id}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateTransferController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /account/transfer/{id}/edit", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeEditTransferController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("DELETE /account/transfer", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteTransfersController(db)), models.PermissionDelete, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /account/{accountId}/reconcile", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeReconcileAccountController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
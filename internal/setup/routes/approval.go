@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func ApprovalRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("GET /approval/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetApprovalSettingsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("PUT /approval/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateApprovalSettingsController(db)), models.PermissionSettings, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /approval/pending", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetPendingApprovalController(db)), models.PermissionApprove, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /transaction/{id}/approve", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeApproveTransactionController(db)), models.PermissionApprove, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /transaction/{id}/reject", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeRejectTransactionController(db)), models.PermissionApprove, db),
+			workspaceDb,
+		),
+	))
+}
@@ -0,0 +1,51 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/routes"
+)
+
+func TestApprovalThresholdNetAmount(t *testing.T) {
+	s := newTestServer(t)
+	routes.ApprovalRoutes(s.mux, s.db, s.workspaceDb)
+	owner := s.workspace.Owner
+
+	if code := s.do(owner, http.MethodPut, "/approval/settings", map[string]any{"threshold": "100.00"}, nil); code != http.StatusOK {
+		t.Fatalf("PUT /approval/settings = %d, want %d", code, http.StatusOK)
+	}
+
+	tests := []struct {
+		name     string
+		discount string
+		want     string
+	}{
+		// gross 120.00, but the net value is exactly the threshold
+		{name: "net at the threshold", discount: "20.00", want: ""},
+		{name: "net above the threshold", discount: "19.99", want: models.ApprovalPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := map[string]any{
+				"name":             tt.name,
+				"type":             "EXPENSE",
+				"assignedTo":       owner.Hex(),
+				"balance":          map[string]any{"value": "120.00", "discount": tt.discount},
+				"frequency":        "DO_NOT_REPEAT",
+				"dueDate":          "2025-01-10T00:00:00Z",
+				"registrationDate": "2025-01-10T00:00:00Z",
+				"accountId":        s.accountId.Hex(),
+			}
+			var created models.Transaction
+			if code := s.do(owner, http.MethodPost, "/transaction", body, &created); code != http.StatusCreated {
+				t.Fatalf("POST /transaction = %d, want %d", code, http.StatusCreated)
+			}
+			if created.ApprovalStatus != tt.want {
+				t.Errorf("ApprovalStatus = %q, want %q", created.ApprovalStatus, tt.want)
+			}
+		})
+	}
+}
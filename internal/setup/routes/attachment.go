@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AttachmentRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("POST /transaction/{id}/attachment", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUploadAttachmentController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /transaction/{id}/attachment", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetAttachmentsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /attachment/{attachmentId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDownloadAttachmentController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("DELETE /attachment/{attachmentId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteAttachmentController(db)), models.PermissionDelete, db),
+			workspaceDb,
+		),
+	))
+}
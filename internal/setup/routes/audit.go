@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func AuditRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("GET /audit", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetAuditLogsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+}
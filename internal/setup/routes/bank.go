@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -12,21 +13,21 @@ import (
 func BankRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
 	server.Handle("GET /bank", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBanksController(db))),
+			middlewares.RequirePermission(middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBanksController(db))), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /bank/{id}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBankByIdController(db))),
+			middlewares.RequirePermission(middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBankByIdController(db))), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /bank/search", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBankByNameController(db))),
+			middlewares.RequirePermission(middlewares.AllowCacheHeader(adapters.AdaptRoute(factory.MakeGetBankByNameController(db))), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
@@ -0,0 +1,46 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// BudgetRoutes registers HTTP routes for budget operations
+func BudgetRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	// Create a budget for a category or sub-category
+	server.Handle("POST /budget", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateBudgetController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	// Get all budgets, or track them against a month with ?month=&year=
+	server.Handle("GET /budget", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetBudgetsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	// Update a budget
+	server.Handle("PUT /budget/{budgetId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateBudgetController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	// Delete budgets
+	server.Handle("DELETE /budget", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteBudgetController(db)), models.PermissionDelete, db),
+			workspaceDb,
+		),
+	))
+}
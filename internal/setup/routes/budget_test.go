@@ -0,0 +1,76 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"github.com/anuntech/finance-backend/internal/setup/routes"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestBudgetTracking(t *testing.T) {
+	s := newTestServer(t)
+	routes.BudgetRoutes(s.mux, s.db, s.workspaceDb)
+	owner := s.workspace.Owner
+
+	category := models.Category{
+		Id:            primitive.NewObjectID(),
+		Name:          "Mercado",
+		Type:          "EXPENSE",
+		Icon:          "cart",
+		SubCategories: []models.SubCategoryCategory{{Id: primitive.NewObjectID(), Name: "Feira", Icon: "leaf"}},
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+		WorkspaceId:   s.workspace.ID,
+	}
+	mongotest.Insert(t, s.db, "category", category)
+
+	expense := func(name string, value string, subCategoryId *primitive.ObjectID, isConfirmed bool) {
+		t.Helper()
+		body := map[string]any{
+			"name":             name,
+			"type":             "EXPENSE",
+			"assignedTo":       owner.Hex(),
+			"balance":          map[string]any{"value": value},
+			"frequency":        "DO_NOT_REPEAT",
+			"dueDate":          "2025-01-10T00:00:00Z",
+			"registrationDate": "2025-01-10T00:00:00Z",
+			"accountId":        s.accountId.Hex(),
+			"categoryId":       category.Id.Hex(),
+			"isConfirmed":      isConfirmed,
+		}
+		if subCategoryId != nil {
+			body["subCategoryId"] = subCategoryId.Hex()
+		}
+		if isConfirmed {
+			body["confirmationDate"] = "2025-01-10T00:00:00Z"
+		}
+		if code := s.do(owner, http.MethodPost, "/transaction", body, nil); code != http.StatusCreated {
+			t.Fatalf("POST /transaction = %d, want %d", code, http.StatusCreated)
+		}
+	}
+
+	// the confirmed expense has no sub-category and must still count in the category budget
+	expense("Supermercado", "100.00", nil, true)
+	expense("Feira da semana", "30.00", &category.SubCategories[0].Id, false)
+
+	budget := map[string]any{"categoryId": category.Id.Hex(), "interval": "MONTHLY", "amount": "500.00"}
+	if code := s.do(owner, http.MethodPost, "/budget", budget, nil); code != http.StatusCreated {
+		t.Fatalf("POST /budget = %d, want %d", code, http.StatusCreated)
+	}
+
+	var trackings []models.BudgetTracking
+	if code := s.do(owner, http.MethodGet, "/budget?month=1&year=2025", nil, &trackings); code != http.StatusOK {
+		t.Fatalf("GET /budget = %d, want %d", code, http.StatusOK)
+	}
+	if len(trackings) != 1 {
+		t.Fatalf("GET /budget returned %d trackings, want 1", len(trackings))
+	}
+
+	if trackings[0].Forecast != 13000 || trackings[0].Realized != 10000 {
+		t.Errorf("forecast = %v and realized = %v, want 130.00 and 100.00", trackings[0].Forecast, trackings[0].Realized)
+	}
+}
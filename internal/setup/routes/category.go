@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -12,70 +13,70 @@ import (
 func CategoryRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
 	server.Handle("POST /category", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /category", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCategorysController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCategorysController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /category/sub-category", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateSubCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateSubCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PUT /category/sub-category/{categoryId}/{subCategoryId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateSubCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateSubCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("DELETE /category/sub-category/{categoryId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteSubCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteSubCategoryController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("DELETE /category", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteCategoryController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /category/{categoryId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCategoryByIdController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCategoryByIdController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PUT /category/{categoryId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /category/import", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeImportCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /category/sub-category/{categoryId}/import", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeImportSubCategoryController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportSubCategoryController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
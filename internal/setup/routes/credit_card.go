@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -14,7 +15,7 @@ func CreditCardRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mo
 	// Create a new credit card
 	server.Handle("POST /credit-card", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateCreditCardController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateCreditCardController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
@@ -22,7 +23,7 @@ func CreditCardRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mo
 	// Get all credit cards
 	server.Handle("GET /credit-card", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCreditCardsController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCreditCardsController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
@@ -30,7 +31,23 @@ func CreditCardRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mo
 	// Get a credit card by ID
 	server.Handle("GET /credit-card/{creditCardId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCreditCardByIdController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCreditCardByIdController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	// Get the invoice of a credit card statement
+	server.Handle("GET /credit-card/{creditCardId}/invoices", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCreditCardInvoiceController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	// Pay a credit card invoice from an account
+	server.Handle("POST /credit-card/{creditCardId}/invoices/{period}/pay", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakePayCreditCardInvoiceController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
@@ -38,7 +55,7 @@ func CreditCardRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mo
 	// Update a credit card
 	server.Handle("PUT /credit-card/{creditCardId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateCreditCardController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateCreditCardController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
@@ -46,7 +63,7 @@ func CreditCardRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mo
 	// Delete credit cards
 	server.Handle("DELETE /credit-card", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteCreditCardController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteCreditCardController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
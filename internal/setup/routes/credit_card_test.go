@@ -0,0 +1,118 @@
+package routes_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// purchase creates an expense on the credit card
+func (s *testServer) purchase(t *testing.T, card models.CreditCard, name string, value string, frequency string, repeatSettings map[string]any, dueDate string) {
+	t.Helper()
+	body := map[string]any{
+		"name":             name,
+		"type":             "EXPENSE",
+		"assignedTo":       s.workspace.Owner.Hex(),
+		"balance":          map[string]any{"value": value},
+		"frequency":        frequency,
+		"dueDate":          dueDate,
+		"registrationDate": dueDate,
+		"creditCardId":     card.Id.Hex(),
+	}
+	if repeatSettings != nil {
+		body["repeatSettings"] = repeatSettings
+	}
+	if code := s.do(s.workspace.Owner, http.MethodPost, "/transaction", body, nil); code != http.StatusCreated {
+		t.Fatalf("POST /transaction = %d, want %d", code, http.StatusCreated)
+	}
+}
+
+func TestCreditCardBalance(t *testing.T) {
+	s := newTestServer(t)
+
+	// statements close on the 10th and are due on the 20th
+	card := models.CreditCard{Id: primitive.NewObjectID(), WorkspaceId: s.workspace.ID, Name: "Visa", CloseDate: 10, DueDate: 20, Limit: 500000}
+	mongotest.Insert(t, s.db, "credit_card", card)
+
+	s.purchase(t, card, "Notebook", "60.00", "REPEAT", map[string]any{"initialInstallment": 1, "count": 3, "interval": "MONTHLY"}, "2025-01-03T00:00:00Z")
+	s.purchase(t, card, "Streaming", "10.00", "RECURRING", map[string]any{"interval": "MONTHLY"}, "2025-01-05T00:00:00Z")
+
+	var cards []models.CreditCard
+	if code := s.do(s.workspace.Owner, http.MethodGet, "/credit-card?month=1&year=2025", nil, &cards); code != http.StatusOK {
+		t.Fatalf("GET /credit-card = %d, want %d", code, http.StatusOK)
+	}
+	if len(cards) != 1 {
+		t.Fatalf("GET /credit-card returned %d cards, want 1", len(cards))
+	}
+
+	// every open installment uses the limit, the recurrence only up to the statement closing on January 10th
+	if cards[0].Balance != 7000 || cards[0].AvailableLimit != 493000 {
+		t.Errorf("balance = %v and available limit = %v, want 70.00 and 4930.00", cards[0].Balance, cards[0].AvailableLimit)
+	}
+}
+
+func TestPayCreditCardInvoice(t *testing.T) {
+	s := newTestServer(t)
+	owner := s.workspace.Owner
+
+	// statements close on the 10th and are due on the 20th
+	card := models.CreditCard{Id: primitive.NewObjectID(), WorkspaceId: s.workspace.ID, Name: "Visa", CloseDate: 10, DueDate: 20, Limit: 500000}
+	mongotest.Insert(t, s.db, "credit_card", card)
+
+	s.purchase(t, card, "Mercado", "100.00", "DO_NOT_REPEAT", nil, "2025-01-02T00:00:00Z")
+	s.purchase(t, card, "Notebook", "60.00", "REPEAT", map[string]any{"initialInstallment": 1, "count": 3, "interval": "MONTHLY"}, "2025-01-03T00:00:00Z")
+
+	payBody := map[string]any{"accountId": s.accountId.Hex(), "amount": "50.00", "paymentDate": "2025-01-20T00:00:00Z"}
+
+	var paid struct {
+		Payment              models.CreditCardInvoicePayment `json:"payment"`
+		PaymentTransaction   models.Transaction              `json:"paymentTransaction"`
+		RemainderTransaction *models.Transaction             `json:"remainderTransaction"`
+		Invoice              models.CreditCardInvoice        `json:"invoice"`
+	}
+	target := "/credit-card/" + card.Id.Hex() + "/invoices/2025-01/pay"
+	if code := s.do(owner, http.MethodPost, target, payBody, &paid); code != http.StatusCreated {
+		t.Fatalf("POST %s = %d, want %d", target, code, http.StatusCreated)
+	}
+
+	if paid.Payment.InvoiceTotal != 12000 || paid.Payment.Amount != 5000 || paid.Payment.RemainderAmount != 7000 {
+		t.Errorf("payment = total %v, amount %v, remainder %v, want 120.00, 50.00 and 70.00",
+			paid.Payment.InvoiceTotal, paid.Payment.Amount, paid.Payment.RemainderAmount)
+	}
+	if paid.Payment.TransactionId != paid.PaymentTransaction.Id {
+		t.Errorf("payment transaction = %v, want %v", paid.Payment.TransactionId, paid.PaymentTransaction.Id)
+	}
+	if paid.RemainderTransaction == nil || paid.Payment.RemainderTransactionId == nil || *paid.Payment.RemainderTransactionId != paid.RemainderTransaction.Id {
+		t.Fatalf("remainder transaction = %v, want it linked to the payment", paid.RemainderTransaction)
+	}
+	if want := time.Date(2025, time.January, 11, 0, 0, 0, 0, time.UTC); !paid.RemainderTransaction.DueDate.Equal(want) {
+		t.Errorf("remainder due date = %v, want the opening of the next statement %v", paid.RemainderTransaction.DueDate, want)
+	}
+	if paid.Invoice.Status != "PAID" {
+		t.Errorf("invoice status = %q, want PAID", paid.Invoice.Status)
+	}
+
+	if code := s.do(owner, http.MethodPost, target, payBody, nil); code != http.StatusConflict {
+		t.Errorf("paying again = %d, want %d", code, http.StatusConflict)
+	}
+
+	var next models.CreditCardInvoice
+	if code := s.do(owner, http.MethodGet, "/credit-card/"+card.Id.Hex()+"/invoices?year=2025&month=2", nil, &next); code != http.StatusOK {
+		t.Fatalf("GET invoice = %d, want %d", code, http.StatusOK)
+	}
+	if next.Total != 7000+2000 {
+		t.Errorf("next invoice total = %v, want the remainder and the second installment, 90.00", next.Total)
+	}
+
+	var accounts []models.Account
+	if code := s.do(owner, http.MethodGet, "/account?month=1&year=2025", nil, &accounts); code != http.StatusOK {
+		t.Fatalf("GET /account = %d, want %d", code, http.StatusOK)
+	}
+	if accounts[0].Balance != -5000 || accounts[0].CurrentBalance != -5000 {
+		t.Errorf("account balances = %v and %v, want the payment, -50.00", accounts[0].Balance, accounts[0].CurrentBalance)
+	}
+}
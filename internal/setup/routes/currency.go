@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func CurrencyRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("GET /currency/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCurrencySettingsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("PUT /currency/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateCurrencySettingsController(db)), models.PermissionSettings, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /exchange-rate", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetExchangeRatesController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /exchange-rate", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateExchangeRateController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /exchange-rate/import", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportExchangeRatesController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("DELETE /exchange-rate", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteExchangeRatesController(db)), models.PermissionDelete, db),
+			workspaceDb,
+		),
+	))
+}
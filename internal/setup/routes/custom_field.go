@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -13,7 +14,7 @@ func CustomFieldRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *m
 	// Create a new custom field
 	server.Handle("POST /custom-field", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateCustomFieldController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateCustomFieldController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
@@ -21,7 +22,7 @@ func CustomFieldRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *m
 	// Get all custom fields
 	server.Handle("GET /custom-field", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCustomFieldsController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCustomFieldsController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
@@ -29,7 +30,7 @@ func CustomFieldRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *m
 	// Get a specific custom field by ID
 	server.Handle("GET /custom-field/{customFieldId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetCustomFieldByIdController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetCustomFieldByIdController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
@@ -37,7 +38,7 @@ func CustomFieldRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *m
 	// Update a custom field
 	server.Handle("PUT /custom-field/{customFieldId}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateCustomFieldController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateCustomFieldController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
@@ -45,7 +46,7 @@ func CustomFieldRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *m
 	// Delete custom fields
 	server.Handle("DELETE /custom-field", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteCustomFieldController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteCustomFieldController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func ImportJobRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("POST /import/jobs", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateImportJobController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /import/jobs/{id}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetImportJobController()), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /import/jobs/{id}/cancel", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCancelImportJobController()), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+}
@@ -0,0 +1,34 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func MemberRoleRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	server.Handle("GET /member-role", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetMemberRolesController(db)), models.PermissionManageRoles, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /member-role/me", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetMyRoleController()), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("PUT /member-role/{memberId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateMemberRoleController(workspaceDb, db)), models.PermissionManageRoles, db),
+			workspaceDb,
+		),
+	))
+}
@@ -0,0 +1,193 @@
+package routes_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"github.com/anuntech/finance-backend/internal/setup/routes"
+	"github.com/square/go-jose/v3"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"golang.org/x/crypto/hkdf"
+)
+
+const secret = "finance-backend-test-secret"
+
+// testServer serves the transaction, account and credit card routes over a seeded workspace with one account
+type testServer struct {
+	t           *testing.T
+	mux         *http.ServeMux
+	db          *mongo.Database
+	workspaceDb *mongo.Database
+	workspace   *mongotest.Workspace
+	accountId   primitive.ObjectID
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	t.Setenv("SECRET_JWT", secret)
+
+	db := mongotest.Database(t)
+	workspaceDb := mongotest.Database(t)
+	workspace := mongotest.SeedWorkspace(t, workspaceDb)
+
+	account := models.Account{
+		Id:          primitive.NewObjectID(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		Name:        "Conta corrente",
+		BankId:      primitive.NewObjectID(),
+		WorkspaceId: workspace.ID,
+	}
+	mongotest.Insert(t, db, "account", account)
+
+	mux := http.NewServeMux()
+	routes.TransactionRoutes(mux, db, workspaceDb)
+	routes.AccountRoutes(mux, db, workspaceDb)
+	routes.CreditCardRoutes(mux, db, workspaceDb)
+
+	return &testServer{t: t, mux: mux, db: db, workspaceDb: workspaceDb, workspace: workspace, accountId: account.Id}
+}
+
+// do sends the request as the user, with body encoded as JSON, and decodes the response into out when given
+func (s *testServer) do(userId primitive.ObjectID, method string, target string, body any, out any) int {
+	s.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			s.t.Fatalf("encoding body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Authorization", "Bearer "+accessToken(s.t, userId))
+	req.Header.Set("workspaceId", s.workspace.ID.Hex())
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, req)
+
+	if out != nil && rec.Code < 300 {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			s.t.Fatalf("%s %s: decoding response %q: %v", method, target, rec.Body.String(), err)
+		}
+	}
+	if rec.Code >= 300 {
+		s.t.Logf("%s %s: %d %s", method, target, rec.Code, rec.Body.String())
+	}
+
+	return rec.Code
+}
+
+// accessToken encrypts a session for the user the way NextAuth does, with the key derived from SECRET_JWT
+func accessToken(t *testing.T, userId primitive.ObjectID) string {
+	t.Helper()
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, []byte(secret), nil, []byte("NextAuth.js Generated Encryption Key")), key); err != nil {
+		t.Fatalf("deriving key: %v", err)
+	}
+
+	encrypter, err := jose.NewEncrypter(jose.A256GCM, jose.Recipient{Algorithm: jose.DIRECT, Key: key}, nil)
+	if err != nil {
+		t.Fatalf("creating encrypter: %v", err)
+	}
+
+	now := time.Now().Unix()
+	payload, _ := json.Marshal(map[string]any{"sub": userId.Hex(), "iat": now, "exp": now + 3600})
+	object, err := encrypter.Encrypt(payload)
+	if err != nil {
+		t.Fatalf("encrypting token: %v", err)
+	}
+
+	token, err := object.CompactSerialize()
+	if err != nil {
+		t.Fatalf("serializing token: %v", err)
+	}
+	return token
+}
+
+func TestPermissions(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     func(s *testServer) primitive.ObjectID
+		method   string
+		target   string
+		wantCode int
+	}{
+		{
+			name:     "owner lists transactions",
+			user:     func(s *testServer) primitive.ObjectID { return s.workspace.Owner },
+			method:   http.MethodGet,
+			target:   "/transaction?month=1&year=2025",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "viewer lists transactions",
+			user: func(s *testServer) primitive.ObjectID {
+				return s.workspace.AddMember(s.t, s.workspaceDb, s.db, "member", models.RoleViewer)
+			},
+			method:   http.MethodGet,
+			target:   "/transaction?month=1&year=2025",
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "viewer cannot create transactions",
+			user: func(s *testServer) primitive.ObjectID {
+				return s.workspace.AddMember(s.t, s.workspaceDb, s.db, "member", models.RoleViewer)
+			},
+			method:   http.MethodPost,
+			target:   "/transaction",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "viewer cannot delete transactions",
+			user: func(s *testServer) primitive.ObjectID {
+				return s.workspace.AddMember(s.t, s.workspaceDb, s.db, "member", models.RoleViewer)
+			},
+			method:   http.MethodDelete,
+			target:   "/transaction?ids=" + primitive.NewObjectID().Hex(),
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name: "admin of the workspace creates transactions",
+			user: func(s *testServer) primitive.ObjectID {
+				return s.workspace.AddMember(s.t, s.workspaceDb, s.db, "admin", "")
+			},
+			method:   http.MethodPost,
+			target:   "/transaction",
+			wantCode: http.StatusBadRequest, // allowed, rejected by the validation of the empty body
+		},
+		{
+			name:     "user outside the workspace",
+			user:     func(s *testServer) primitive.ObjectID { return primitive.NewObjectID() },
+			method:   http.MethodGet,
+			target:   "/transaction?month=1&year=2025",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+
+			var body any
+			if tt.method == http.MethodPost {
+				body = map[string]any{}
+			}
+			if code := s.do(tt.user(s), tt.method, tt.target, body, nil); code != tt.wantCode {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.target, code, tt.wantCode)
+			}
+		})
+	}
+}
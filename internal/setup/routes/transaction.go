@@ -3,6 +3,7 @@ package routes
 import (
 	"net/http"
 
+	"github.com/anuntech/finance-backend/internal/domain/models"
 	"github.com/anuntech/finance-backend/internal/setup/adapters"
 	"github.com/anuntech/finance-backend/internal/setup/factory"
 	"github.com/anuntech/finance-backend/internal/setup/middlewares"
@@ -12,56 +13,112 @@ import (
 func TransactionRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
 	server.Handle("POST /transaction", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateTransactionController(workspaceDb, db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateTransactionController(workspaceDb, db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("GET /transaction", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeGetTransactionController(workspaceDb, db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetTransactionController(workspaceDb, db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PUT /transaction/{id}", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateTransactionController(workspaceDb, db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateTransactionController(workspaceDb, db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("DELETE /transaction", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeDeleteTransactionController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteTransactionController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /transaction/edit", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeCreateEditTransactionController(workspaceDb, db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateEditTransactionController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /transaction/duplicates", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetDuplicateTransactionsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /transaction/apply-rules", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeApplyTransactionRulesController(db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /transaction/export", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeExportTransactionController(workspaceDb, db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /transaction/export/columns", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeExportColumnsTransactionController(db)), models.PermissionRead, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("POST /transaction/import", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeImportTransactionController(workspaceDb, db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportTransactionController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /transaction/import/nfe", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeImportNfeTransactionController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("GET /transaction/import/nfe/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetNfeImportSettingsController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("PUT /transaction/import/nfe/settings", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateNfeImportSettingsController(db)), models.PermissionSettings, db),
+			workspaceDb,
+		),
+	))
+
+	server.Handle("POST /transaction/import/preview/{token}/commit", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCommitImportTransactionController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PATCH /transaction", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeUpdateManyTransactionController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateManyTransactionController(db)), models.PermissionWrite, db),
 			workspaceDb,
 		),
 	))
 
 	server.Handle("PATCH /transaction/exclude-installments-until", middlewares.VerifyAccessToken(
 		middlewares.IsAllowed(
-			adapters.AdaptRoute(factory.MakeExcludeInstallmentsUntilController(db)),
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeExcludeInstallmentsUntilController(db)), models.PermissionDelete, db),
 			workspaceDb,
 		),
 	))
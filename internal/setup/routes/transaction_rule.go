@@ -0,0 +1,55 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/adapters"
+	"github.com/anuntech/finance-backend/internal/setup/factory"
+	"github.com/anuntech/finance-backend/internal/setup/middlewares"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// TransactionRuleRoutes registers HTTP routes for the categorization rules. Applying them again to existing
+// transactions is POST /transaction/apply-rules.
+func TransactionRuleRoutes(server *http.ServeMux, db *mongo.Database, workspaceDb *mongo.Database) {
+	// Create a rule
+	server.Handle("POST /transaction-rule", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeCreateTransactionRuleController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	// Get all rules in the order they are evaluated
+	server.Handle("GET /transaction-rule", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetTransactionRulesController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	// Get a rule
+	server.Handle("GET /transaction-rule/{ruleId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeGetTransactionRuleByIdController(db)), models.PermissionRead, db),
+			workspaceDb,
+		),
+	))
+
+	// Update a rule
+	server.Handle("PUT /transaction-rule/{ruleId}", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeUpdateTransactionRuleController(workspaceDb, db)), models.PermissionWrite, db),
+			workspaceDb,
+		),
+	))
+
+	// Delete rules
+	server.Handle("DELETE /transaction-rule", middlewares.VerifyAccessToken(
+		middlewares.IsAllowed(
+			middlewares.RequirePermission(adapters.AdaptRoute(factory.MakeDeleteTransactionRuleController(db)), models.PermissionDelete, db),
+			workspaceDb,
+		),
+	))
+}
@@ -0,0 +1,210 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+)
+
+type occurrence struct {
+	name  string
+	value models.Money
+}
+
+// occurrenceEdit is the body of POST /transaction/edit for one occurrence of the created transaction
+type occurrenceEdit struct {
+	mainCount   int
+	name        string
+	value       string
+	dueDate     string
+	isConfirmed bool
+}
+
+func TestTransactionOccurrences(t *testing.T) {
+	tests := []struct {
+		name        string
+		frequency   string
+		interval    string
+		count       int
+		value       string
+		dueDate     string
+		isConfirmed bool
+		edits       []occurrenceEdit
+		deletes     []int
+		// occurrences listed from January to December of 2025 by their count
+		want map[int]occurrence
+		// account balances at the end of March of 2025
+		wantBalance        models.Money
+		wantCurrentBalance models.Money
+	}{
+		{
+			name:      "installments",
+			frequency: "REPEAT", interval: "MONTHLY", count: 3, value: "100.00", dueDate: "2025-01-10T00:00:00Z",
+			want: map[int]occurrence{
+				1: {"Notebook", 3333},
+				2: {"Notebook", 3333},
+				3: {"Notebook", 3334},
+			},
+			wantBalance:        -10000,
+			wantCurrentBalance: 0,
+		},
+		{
+			name:      "confirmed installments",
+			frequency: "REPEAT", interval: "MONTHLY", count: 3, value: "100.00", dueDate: "2025-01-10T00:00:00Z", isConfirmed: true,
+			want: map[int]occurrence{
+				1: {"Notebook", 3333},
+				2: {"Notebook", 3333},
+				3: {"Notebook", 3334},
+			},
+			wantBalance:        -10000,
+			wantCurrentBalance: -10000,
+		},
+		{
+			name:      "edited and deleted installments",
+			frequency: "REPEAT", interval: "MONTHLY", count: 3, value: "100.00", dueDate: "2025-01-10T00:00:00Z",
+			edits: []occurrenceEdit{
+				{mainCount: 2, name: "Notebook usado", value: "50.00", dueDate: "2025-02-10T00:00:00Z", isConfirmed: true},
+			},
+			deletes: []int{3},
+			want: map[int]occurrence{
+				1: {"Notebook", 3333},
+				2: {"Notebook usado", 5000},
+			},
+			wantBalance:        -3333 - 5000,
+			wantCurrentBalance: -5000,
+		},
+		{
+			name:      "recurrence starting after the balance month",
+			frequency: "RECURRING", interval: "MONTHLY", value: "20.00", dueDate: "2025-10-05T00:00:00Z",
+			want: map[int]occurrence{
+				1: {"Notebook", 2000},
+				2: {"Notebook", 2000},
+				3: {"Notebook", 2000},
+			},
+			wantBalance:        0,
+			wantCurrentBalance: 0,
+		},
+		{
+			name:      "edited and deleted recurrence",
+			frequency: "RECURRING", interval: "MONTHLY", value: "20.00", dueDate: "2025-01-05T00:00:00Z",
+			edits: []occurrenceEdit{
+				{mainCount: 3, name: "Notebook reajustado", value: "22.00", dueDate: "2025-03-05T00:00:00Z"},
+			},
+			deletes: []int{5},
+			want: func() map[int]occurrence {
+				want := map[int]occurrence{}
+				for count := 1; count <= 12; count++ {
+					want[count] = occurrence{"Notebook", 2000}
+				}
+				want[3] = occurrence{"Notebook reajustado", 2200}
+				delete(want, 5)
+				return want
+			}(),
+			wantBalance:        -2000 - 2000 - 2200,
+			wantCurrentBalance: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestServer(t)
+			owner := s.workspace.Owner
+
+			body := map[string]any{
+				"name":             "Notebook",
+				"type":             "EXPENSE",
+				"assignedTo":       owner.Hex(),
+				"balance":          map[string]any{"value": tt.value},
+				"frequency":        tt.frequency,
+				"repeatSettings":   map[string]any{"initialInstallment": 1, "count": tt.count, "interval": tt.interval},
+				"dueDate":          tt.dueDate,
+				"registrationDate": tt.dueDate,
+				"accountId":        s.accountId.Hex(),
+				"isConfirmed":      tt.isConfirmed,
+			}
+			if tt.frequency == "RECURRING" {
+				body["repeatSettings"] = map[string]any{"interval": tt.interval}
+			}
+			if tt.isConfirmed {
+				body["confirmationDate"] = tt.dueDate
+			}
+
+			var created models.Transaction
+			if code := s.do(owner, http.MethodPost, "/transaction", body, &created); code != http.StatusCreated {
+				t.Fatalf("POST /transaction = %d, want %d", code, http.StatusCreated)
+			}
+
+			// the balances of March are cached now, the edits and deletes below must invalidate them
+			if code := s.do(owner, http.MethodGet, "/account?month=3&year=2025", nil, nil); code != http.StatusOK {
+				t.Fatalf("GET /account = %d, want %d", code, http.StatusOK)
+			}
+
+			for _, edit := range tt.edits {
+				editBody := map[string]any{
+					"mainId":           created.Id.Hex(),
+					"mainCount":        edit.mainCount,
+					"name":             edit.name,
+					"type":             "EXPENSE",
+					"assignedTo":       owner.Hex(),
+					"balance":          map[string]any{"value": edit.value},
+					"dueDate":          edit.dueDate,
+					"registrationDate": edit.dueDate,
+					"accountId":        s.accountId.Hex(),
+					"isConfirmed":      edit.isConfirmed,
+				}
+				if edit.isConfirmed {
+					editBody["confirmationDate"] = edit.dueDate
+				}
+				if code := s.do(owner, http.MethodPost, "/transaction/edit", editBody, nil); code != http.StatusCreated {
+					t.Fatalf("POST /transaction/edit = %d, want %d", code, http.StatusCreated)
+				}
+			}
+
+			for _, count := range tt.deletes {
+				target := fmt.Sprintf("/transaction?ids=%s-%d", created.Id.Hex(), count)
+				if code := s.do(owner, http.MethodDelete, target, nil, nil); code != http.StatusNoContent {
+					t.Fatalf("DELETE %s = %d, want %d", target, code, http.StatusNoContent)
+				}
+			}
+
+			var list struct {
+				Transactions []models.Transaction `json:"transactions"`
+			}
+			if code := s.do(owner, http.MethodGet, "/transaction?initialDate=2025-01-01&finalDate=2025-12-31&limit=100", nil, &list); code != http.StatusOK {
+				t.Fatalf("GET /transaction = %d, want %d", code, http.StatusOK)
+			}
+
+			got := map[int]occurrence{}
+			for _, tx := range list.Transactions {
+				if tx.Id != created.Id {
+					t.Errorf("listed transaction %v, want only the occurrences of %v", tx.Id, created.Id)
+				}
+				got[tx.RepeatSettings.CurrentCount] = occurrence{tx.Name, tx.Balance.Value}
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("listed %d occurrences, want %d: %v", len(got), len(tt.want), got)
+			}
+			for count, want := range tt.want {
+				if got[count] != want {
+					t.Errorf("occurrence %d = %+v, want %+v", count, got[count], want)
+				}
+			}
+
+			var accounts []models.Account
+			if code := s.do(owner, http.MethodGet, "/account?month=3&year=2025", nil, &accounts); code != http.StatusOK {
+				t.Fatalf("GET /account = %d, want %d", code, http.StatusOK)
+			}
+			if len(accounts) != 1 {
+				t.Fatalf("listed %d accounts, want 1", len(accounts))
+			}
+			if accounts[0].Balance != tt.wantBalance {
+				t.Errorf("Balance = %v, want %v", accounts[0].Balance, tt.wantBalance)
+			}
+			if accounts[0].CurrentBalance != tt.wantCurrentBalance {
+				t.Errorf("CurrentBalance = %v, want %v", accounts[0].CurrentBalance, tt.wantCurrentBalance)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/mongotest"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDeleteTransferOccurrence(t *testing.T) {
+	s := newTestServer(t)
+	owner := s.workspace.Owner
+
+	savings := models.Account{
+		Id:          primitive.NewObjectID(),
+		CreatedAt:   time.Now().UTC(),
+		UpdatedAt:   time.Now().UTC(),
+		Name:        "Poupança",
+		BankId:      primitive.NewObjectID(),
+		WorkspaceId: s.workspace.ID,
+	}
+	mongotest.Insert(t, s.db, "account", savings)
+
+	body := map[string]any{
+		"sourceAccountId":      s.accountId.Hex(),
+		"destinationAccountId": savings.Id.Hex(),
+		"amount":               "50.00",
+		"dueDate":              "2025-01-10T00:00:00Z",
+		"frequency":            "RECURRING",
+		"repeatSettings":       map[string]any{"interval": "MONTHLY"},
+	}
+	var created struct {
+		ExpenseTransaction models.Transaction `json:"expenseTransaction"`
+		ReceiptTransaction models.Transaction `json:"receiptTransaction"`
+	}
+	if code := s.do(owner, http.MethodPost, "/account/transfer", body, &created); code != http.StatusOK {
+		t.Fatalf("POST /account/transfer = %d, want %d", code, http.StatusOK)
+	}
+
+	// the occurrence of February is deleted through only one of the legs
+	target := fmt.Sprintf("/transaction?ids=%s-2", created.ExpenseTransaction.Id.Hex())
+	if code := s.do(owner, http.MethodDelete, target, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("DELETE %s = %d, want %d", target, code, http.StatusNoContent)
+	}
+
+	var list struct {
+		Transactions []models.Transaction `json:"transactions"`
+	}
+	if code := s.do(owner, http.MethodGet, "/transaction?initialDate=2025-01-01&finalDate=2025-03-31&limit=100", nil, &list); code != http.StatusOK {
+		t.Fatalf("GET /transaction = %d, want %d", code, http.StatusOK)
+	}
+
+	occurrences := map[primitive.ObjectID][]int{}
+	for _, tx := range list.Transactions {
+		occurrences[tx.Id] = append(occurrences[tx.Id], tx.RepeatSettings.CurrentCount)
+	}
+	for _, leg := range []primitive.ObjectID{created.ExpenseTransaction.Id, created.ReceiptTransaction.Id} {
+		if got := occurrences[leg]; len(got) != 2 || got[0] == 2 || got[1] == 2 {
+			t.Errorf("leg %v listed occurrences %v, want 1 and 3", leg, got)
+		}
+	}
+}
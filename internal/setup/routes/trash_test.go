@@ -0,0 +1,74 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/domain/models"
+	"github.com/anuntech/finance-backend/internal/setup/routes"
+)
+
+func TestRestoreDeletedInstallment(t *testing.T) {
+	s := newTestServer(t)
+	routes.TrashRoutes(s.mux, s.db, s.workspaceDb)
+	owner := s.workspace.Owner
+
+	body := map[string]any{
+		"name":             "Notebook",
+		"type":             "EXPENSE",
+		"assignedTo":       owner.Hex(),
+		"balance":          map[string]any{"value": "100.00"},
+		"frequency":        "REPEAT",
+		"repeatSettings":   map[string]any{"initialInstallment": 1, "count": 3, "interval": "MONTHLY"},
+		"dueDate":          "2025-01-10T00:00:00Z",
+		"registrationDate": "2025-01-10T00:00:00Z",
+		"accountId":        s.accountId.Hex(),
+	}
+	var created models.Transaction
+	if code := s.do(owner, http.MethodPost, "/transaction", body, &created); code != http.StatusCreated {
+		t.Fatalf("POST /transaction = %d, want %d", code, http.StatusCreated)
+	}
+
+	target := fmt.Sprintf("/transaction?ids=%s-2", created.Id.Hex())
+	if code := s.do(owner, http.MethodDelete, target, nil, nil); code != http.StatusNoContent {
+		t.Fatalf("DELETE %s = %d, want %d", target, code, http.StatusNoContent)
+	}
+
+	var trash []models.TrashItem
+	if code := s.do(owner, http.MethodGet, "/trash", nil, &trash); code != http.StatusOK {
+		t.Fatalf("GET /trash = %d, want %d", code, http.StatusOK)
+	}
+	if len(trash) != 1 {
+		t.Fatalf("GET /trash returned %d items, want 1", len(trash))
+	}
+	if code := s.do(owner, http.MethodPost, "/trash/restore", map[string]any{"ids": []string{trash[0].Id.Hex()}}, nil); code != http.StatusOK {
+		t.Fatalf("POST /trash/restore = %d, want %d", code, http.StatusOK)
+	}
+
+	var list struct {
+		Transactions []models.Transaction `json:"transactions"`
+	}
+	if code := s.do(owner, http.MethodGet, "/transaction?initialDate=2025-01-01&finalDate=2025-12-31&limit=100", nil, &list); code != http.StatusOK {
+		t.Fatalf("GET /transaction = %d, want %d", code, http.StatusOK)
+	}
+	if len(list.Transactions) != 3 {
+		t.Fatalf("listed %d installments, want 3", len(list.Transactions))
+	}
+
+	// the restored installment is again the second one of the purchase, not a copy of the whole purchase
+	for _, tx := range list.Transactions {
+		if tx.RepeatSettings.CurrentCount != 2 {
+			continue
+		}
+		if want := time.Date(2025, time.February, 10, 0, 0, 0, 0, time.UTC); !tx.DueDate.Equal(want) {
+			t.Errorf("DueDate = %v, want %v", tx.DueDate, want)
+		}
+		if tx.Balance.Value != 3333 {
+			t.Errorf("Balance.Value = %v, want 33.33", tx.Balance.Value)
+		}
+		return
+	}
+	t.Errorf("installment 2 was not listed")
+}
@@ -32,6 +32,8 @@ func Server() *http.ServeMux {
 	log.Println("Databases loaded")
 
 	config.SetupRoutes(mux, db, workspaceDb)
+	StartTrashPurge(db)
+	StartImportJobs(db, workspaceDb)
 
 	return mux
 }
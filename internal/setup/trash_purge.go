@@ -0,0 +1,42 @@
+package setup
+
+import (
+	"log"
+	"time"
+
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/helpers"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/attachment_repository"
+	"github.com/anuntech/finance-backend/internal/infra/db/mongodb/repositories/trash_repository"
+	"github.com/anuntech/finance-backend/internal/infra/storage"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const trashPurgeInterval = time.Hour
+
+// StartTrashPurge removes for good, in the background, the items kept in the trash for longer than
+// TRASH_RETENTION_DAYS
+func StartTrashPurge(db *mongo.Database) {
+	deleteAttachmentRepository := attachment_repository.NewDeleteAttachmentRepository(db, storage.NewFileSystemStorage())
+	purgeTrashRepository := trash_repository.NewPurgeTrashRepository(db, deleteAttachmentRepository)
+
+	purge := func() {
+		purged, err := purgeTrashRepository.Purge(time.Now().UTC().Add(-helpers.TrashRetention()))
+		if err != nil {
+			log.Println("Error purging trash:", err)
+		}
+		if purged > 0 {
+			log.Printf("Purged %d items from the trash", purged)
+		}
+	}
+
+	go func() {
+		purge()
+
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			purge()
+		}
+	}()
+}